@@ -1,73 +1,179 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"iiitn-career-portal/internal/cache"
 	"iiitn-career-portal/internal/config"
 	"iiitn-career-portal/internal/database"
-	"iiitn-career-portal/internal/packages/admin"
+	"iiitn-career-portal/internal/health"
+	"iiitn-career-portal/internal/logging"
+	"iiitn-career-portal/internal/metrics"
 	"iiitn-career-portal/internal/packages/applications"
-	"iiitn-career-portal/internal/packages/auth"
-	"iiitn-career-portal/internal/packages/authorization"
-	"iiitn-career-portal/internal/packages/colleges"
 	"iiitn-career-portal/internal/packages/jobs"
+	"iiitn-career-portal/internal/packages/keycloak"
+	"iiitn-career-portal/internal/packages/notifications"
 	"iiitn-career-portal/internal/packages/profile"
-	"log"
+	"iiitn-career-portal/internal/packages/ratelimit"
+	"iiitn-career-portal/internal/packages/webhooks"
+	"iiitn-career-portal/internal/server"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
 func main() {
 	_ = godotenv.Load()
-	cfg := config.Load()
 
+	// migrate only needs DATABASE_URL, so it skips full validation
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		cfg, err := config.Read()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		logging.Setup(cfg.LogLevel, cfg.LogFormat)
+		runMigrate(cfg, os.Args[2:])
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	logging.Setup(cfg.LogLevel, cfg.LogFormat)
+	slog.Info("configuration loaded", "env", cfg.Env)
+
+	// cancelled on SIGINT/SIGTERM; workers stop on it
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Postgres is required; everything else may start degraded.
 	db := database.Connect(cfg.DB)
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("failed to open database pool", "error", err)
+	}
+
+	if cfg.MigrateOnBoot {
+		database.Migrate(db)
+	}
+
+	redisClient, err := cache.NewRedisClient(cfg.Redis)
+	if err != nil {
+		slog.Warn("starting without redis: rate limits are per instance, "+
+			"real-time notifications are skipped", "error", err)
+	}
+
+	kc := keycloak.New(cfg)
+
+	checker := health.New(
+		health.Postgres(sqlDB),
+		health.Redis(redisClient),
+		health.Check{Name: "keycloak", Probe: kc.Ping},
+		health.Check{Name: "minio", Probe: func(ctx context.Context) error {
+			if cfg.MinioEndpoint == "" {
+				return health.ErrDisabled
+			}
+			return profile.PingStorage(ctx, cfg)
+		}},
+	)
 
-	database.Migrate(db)
-	redisClient := cache.NewRedisClient(cfg.Redis)
-
-	router := gin.Default()
-
-	router.Use(cors.New(cors.Config{
-		AllowOrigins: []string{
-			"http://localhost:5173",
-		},
-		AllowMethods: []string{
-			"GET", "POST", "PUT", "DELETE", "OPTIONS",
-		},
-		AllowHeaders: []string{
-			"Origin", "Content-Type", "Authorization",
-		},
-		ExposeHeaders: []string{
-			"Content-Length",
-		},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
-
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
+	if err := metrics.RegisterDB(sqlDB); err != nil {
+		slog.Warn("db pool metrics disabled", "error", err)
+	}
+	if err := metrics.RegisterQueues(redisClient, map[string]string{
+		"notifications": notifications.WorkerQueueKey,
+	}); err != nil {
+		slog.Warn("queue metrics disabled", "error", err)
+	}
+
+	var workers sync.WaitGroup
+	workers.Add(4)
+	go func() {
+		defer workers.Done()
+		jobs.StartBookmarkReminders(ctx, db, redisClient)
+	}()
+	go func() {
+		defer workers.Done()
+		webhooks.StartDeliveryWorker(ctx, db, cfg.Webhooks)
+	}()
+	go func() {
+		defer workers.Done()
+		applications.StartIntentSweeper(ctx, db, redisClient)
+	}()
+	go func() {
+		defer workers.Done()
+		jobs.StartSavedSearchAlerts(ctx, db, redisClient)
+	}()
+
+	router := server.NewRouter(server.Deps{
+		Config:   cfg,
+		DB:       db,
+		Redis:    redisClient,
+		Keycloak: kc,
+		Limiter:  ratelimit.New(redisClient),
+		Health:   checker,
 	})
 
-	api := router.Group("/api")
-	{
-		auth.RegisterRoutes(api, cfg, db)
-		colleges.RegisterRoutes(api, db)
-		protected := api.Group("/")
-		protected.Use(authorization.RequireAuth(cfg))
-		{
-			admin.RegisterRoutes(protected, db)
-			profile.RegisterRoutes(protected, db, cfg)
-			jobs.RegisterRoutes(protected, db, redisClient)
-			applications.RegisterRoutes(protected, db, redisClient)
+	srv := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("server starting", "port", cfg.Port)
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
 		}
+		close(serveErr)
+	}()
+
+	select {
+	case err := <-serveErr:
+		stop()
+		workers.Wait()
+		logging.Fatal("server stopped", "error", err)
+	case <-ctx.Done():
 	}
 
-	log.Println("Server running on port:", cfg.Port)
+	slog.Info("shutting down", "timeout", cfg.ShutdownTimeout.String())
+	checker.Drain()
 
-	if err := router.Run(":" + cfg.Port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// stop accepting, then wait for in-flight requests
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("http shutdown incomplete", "error", err)
+	}
+
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		slog.Error("workers did not stop in time")
 	}
+
+	if redisClient != nil {
+		_ = redisClient.Close()
+	}
+	_ = sqlDB.Close()
+
+	slog.Info("shutdown complete")
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/database"
+	"iiitn-career-portal/internal/logging"
+	"os"
+	"strconv"
+)
+
+const migrateUsage = `usage: portal migrate <command>
+
+commands:
+  up [n]                  apply all (or the next n) pending migrations
+  down [n]                roll back the last n migrations (default 1)
+  status                  list migrations and whether they are applied
+  create [-dir d] <name>  write an empty up/down pair`
+
+// runMigrate implements the `migrate` subcommand.
+func runMigrate(cfg config.Config, args []string) {
+	if len(args) == 0 {
+		fmt.Println(migrateUsage)
+		os.Exit(2)
+	}
+
+	cmd, rest := args[0], args[1:]
+
+	if cmd == "create" {
+		fs := flag.NewFlagSet("create", flag.ExitOnError)
+		dir := fs.String("dir", "internal/database/migrations", "migrations source directory")
+		_ = fs.Parse(rest)
+
+		if fs.NArg() != 1 {
+			fmt.Println(migrateUsage)
+			os.Exit(2)
+		}
+
+		up, down, err := database.CreateMigration(*dir, fs.Arg(0))
+		if err != nil {
+			logging.Fatal("create migration failed", "error", err)
+		}
+		fmt.Println("created", up)
+		fmt.Println("created", down)
+		return
+	}
+
+	steps := 0
+	if len(rest) > 0 {
+		n, err := strconv.Atoi(rest[0])
+		if err != nil || n < 1 {
+			logging.Fatal("step count must be a positive integer")
+		}
+		steps = n
+	}
+
+	db := database.Connect(cfg.DB)
+
+	switch cmd {
+	case "up":
+		applied, err := database.MigrateUp(db, steps)
+		for _, m := range applied {
+			fmt.Printf("applied   %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			logging.Fatal("migrate up failed", "error", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+
+	case "down":
+		reverted, err := database.MigrateDown(db, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted  %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			logging.Fatal("migrate down failed", "error", err)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("nothing to roll back")
+		}
+
+	case "status":
+		states, err := database.MigrationStatus(db)
+		if err != nil {
+			logging.Fatal("migrate status failed", "error", err)
+		}
+		for _, s := range states {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if s.Modified {
+				state += " (modified since applied)"
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, state)
+		}
+
+	default:
+		fmt.Println(migrateUsage)
+		os.Exit(2)
+	}
+}
@@ -2,20 +2,29 @@ package cache
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-func NewRedisClient(redisURL string) *redis.Client {
+// ErrNotConfigured is returned when REDIS_URL is empty.
+var ErrNotConfigured = errors.New("REDIS_URL is not set")
+
+// NewRedisClient connects to Redis. Redis is optional, so failures are
+// returned rather than fatal:
+//   - no URL or an invalid one: nil client, features run without Redis
+//   - unreachable: the client is still returned (it reconnects on its
+//     own) together with the ping error
+func NewRedisClient(redisURL string) (*redis.Client, error) {
 	if redisURL == "" {
-		log.Fatal("REDIS_URL is not set")
+		return nil, ErrNotConfigured
 	}
 
 	opt, err := redis.ParseURL(redisURL)
 	if err != nil {
-		log.Fatalf("invalid REDIS_URL: %v", err)
+		return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
 	}
 
 	client := redis.NewClient(opt)
@@ -25,10 +34,8 @@ func NewRedisClient(redisURL string) *redis.Client {
 	defer cancel()
 
 	if err := client.Ping(ctx).Err(); err != nil {
-		log.Fatalf("failed to connect to redis: %v", err)
+		return client, fmt.Errorf("redis ping: %w", err)
 	}
 
-	log.Println("redis connected")
-
-	return client
+	return client, nil
 }
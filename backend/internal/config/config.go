@@ -1,65 +1,150 @@
 package config
 
 import (
-	"os"
-	"strconv"
+	"time"
 )
 
-// Role of this struct = only runtime settings container
+// Config is every runtime setting. It is layered (see Load): built-in
+// defaults, then an optional YAML file, then environment variables.
+// yaml tags name the file keys, env tags the variables.
 type Config struct {
-	Port string
-	DB   string
-
-	JWTSecret string
-
-	Redis          string
-	Minio          string
-	MinioEndpoint  string
-	MinioAccessKey string
-	MinioSecretKey string
-	MinioBucket    string
-	MinioUseSSL    bool
-	MinioPublicURL string
-
-	BaseURL      string
-	Realm        string
-	ClientID     string
-	ClientSecret string
-
-	FrontendURL    string
-	BackendBaseURL string
+	// APP_ENV: development (default), staging or production. Production
+	// tightens validation (secure cookies, scanner on, no localhost CORS).
+	Env string `yaml:"-" env:"APP_ENV"`
+
+	Port string `yaml:"port" env:"PORT"`
+	DB   string `yaml:"database_url" env:"DATABASE_URL"`
+
+	JWTSecret string `yaml:"jwt_secret" env:"JWT_SECRET"`
+	// lifetime of the portal JWT and its cookie
+	SessionTTL time.Duration `yaml:"session_ttl" env:"SESSION_TTL"`
+
+	Redis          string `yaml:"redis_url" env:"REDIS_URL"`
+	Minio          string `yaml:"minio_url" env:"MINIO_URL"`
+	MinioEndpoint  string `yaml:"minio_endpoint" env:"MINIO_ENDPOINT"`
+	MinioAccessKey string `yaml:"minio_access_key" env:"MINIO_ACCESS_KEY"`
+	MinioSecretKey string `yaml:"minio_secret_key" env:"MINIO_SECRET_KEY"`
+	MinioBucket    string `yaml:"minio_bucket" env:"MINIO_BUCKET"`
+	MinioUseSSL    bool   `yaml:"minio_use_ssl" env:"MINIO_USE_SSL"`
+	MinioPublicURL string `yaml:"minio_public_url" env:"MINIO_PUBLIC_URL"`
+
+	BaseURL      string `yaml:"keycloak_base_url" env:"KEYCLOAK_BASE_URL"`
+	Realm        string `yaml:"keycloak_realm" env:"KEYCLOAK_REALM"`
+	ClientID     string `yaml:"keycloak_client_id" env:"KEYCLOAK_CLIENT_ID"`
+	ClientSecret string `yaml:"keycloak_client_secret" env:"KEYCLOAK_CLIENT_SECRET"`
+
+	FrontendURL    string `yaml:"frontend_url" env:"FRONTEND_URL"`
+	BackendBaseURL string `yaml:"backend_url" env:"BACKEND_URL"`
+
+	// how long job listings and details stay in Redis; 0 disables caching
+	JobCacheTTL time.Duration `yaml:"job_cache_ttl" env:"JOB_CACHE_TTL"`
+
+	// apply pending SQL migrations at startup (advisory-locked)
+	MigrateOnBoot bool `yaml:"migrate_on_boot" env:"MIGRATE_ON_BOOT"`
+
+	// per-policy overrides, e.g. RATE_LIMITS="login_ip=20/1m,apply_user=10/1h"
+	RateLimits map[string]string `yaml:"rate_limits" env:"RATE_LIMITS"`
+
+	// reject requests that don't match /api/openapi.json before the handler runs
+	OpenAPIValidate bool `yaml:"openapi_validate" env:"OPENAPI_VALIDATE"`
+
+	// LOG_LEVEL debug|info|warn|error, LOG_FORMAT json|text
+	LogLevel  string `yaml:"log_level" env:"LOG_LEVEL"`
+	LogFormat string `yaml:"log_format" env:"LOG_FORMAT"`
+
+	// bearer token required on /metrics; empty leaves it open
+	MetricsToken string `yaml:"metrics_token" env:"METRICS_TOKEN"`
+
+	// how long SIGTERM waits for in-flight requests and workers
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+
+	CORS    CORSConfig    `yaml:"cors"`
+	Cookie  CookieConfig  `yaml:"cookie"`
+	Upload  UploadConfig  `yaml:"upload"`
+	Scanner ScannerConfig `yaml:"scanner"`
+
+	Webhooks WebhookConfig `yaml:"webhooks"`
 }
 
-func Load() Config {
+type CORSConfig struct {
+	// exact origins (scheme://host[:port]); credentials are allowed, so
+	// "*" is rejected
+	AllowedOrigins []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	MaxAge         time.Duration `yaml:"max_age" env:"CORS_MAX_AGE"`
+}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "3000"
-	}
+// CookieConfig shapes the portal_token session cookie.
+type CookieConfig struct {
+	Domain   string `yaml:"domain" env:"COOKIE_DOMAIN"`
+	Secure   bool   `yaml:"secure" env:"COOKIE_SECURE"`
+	SameSite string `yaml:"same_site" env:"COOKIE_SAME_SITE"` // lax, strict, none
+}
 
-	minioUseSSL, _ := strconv.ParseBool(os.Getenv("MINIO_USE_SSL"))
+type UploadConfig struct {
+	MaxResumeBytes int64 `yaml:"max_resume_bytes" env:"UPLOAD_MAX_RESUME_BYTES"`
+	// named resume versions a student may keep
+	MaxResumes int `yaml:"max_resumes" env:"UPLOAD_MAX_RESUMES"`
+}
+
+// ScannerConfig points at clamd. Disabling it is refused in production.
+type ScannerConfig struct {
+	Enabled bool          `yaml:"enabled" env:"SCANNER_ENABLED"`
+	Addr    string        `yaml:"addr" env:"CLAMD_ADDR"`
+	Timeout time.Duration `yaml:"timeout" env:"SCANNER_TIMEOUT"`
+}
 
+// WebhookConfig shapes outbound webhook delivery.
+type WebhookConfig struct {
+	// per-request timeout of a delivery
+	Timeout time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
+	// a delivery is given up after this many attempts
+	MaxAttempts int `yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+	// deliver to loopback and private addresses (local receivers);
+	// refused in production
+	AllowPrivate bool `yaml:"allow_private" env:"WEBHOOK_ALLOW_PRIVATE"`
+}
+
+const (
+	DefaultSessionTTL     = 24 * time.Hour
+	DefaultMaxResumeBytes = 2 << 20
+	DefaultMaxResumes     = 5
+)
+
+// Defaults suit local development against docker-compose.
+func Defaults() Config {
 	return Config{
-		Port: port,
-		DB:   os.Getenv("DATABASE_URL"),
-
-		JWTSecret: os.Getenv("JWT_SECRET"),
-
-		Redis:          os.Getenv("REDIS_URL"),
-		Minio:          os.Getenv("MINIO_URL"),
-		MinioEndpoint:  os.Getenv("MINIO_ENDPOINT"),
-		MinioAccessKey: os.Getenv("MINIO_ACCESS_KEY"),
-		MinioBucket:    os.Getenv("MINIO_BUCKET"),
-		MinioSecretKey: os.Getenv("MINIO_SECRET_KEY"),
-		MinioPublicURL: os.Getenv("MINIO_PUBLIC_URL"),
-		MinioUseSSL:    minioUseSSL,
-
-		BaseURL:      os.Getenv("KEYCLOAK_BASE_URL"),
-		Realm:        os.Getenv("KEYCLOAK_REALM"),
-		ClientID:     os.Getenv("KEYCLOAK_CLIENT_ID"),
-		ClientSecret: os.Getenv("KEYCLOAK_CLIENT_SECRET"),
-
-		FrontendURL:    os.Getenv("FRONTEND_URL"),
-		BackendBaseURL: os.Getenv("BACKEND_URL"),
+		Env:             "development",
+		Port:            "3000",
+		SessionTTL:      DefaultSessionTTL,
+		JobCacheTTL:     time.Minute,
+		MigrateOnBoot:   true,
+		RateLimits:      map[string]string{},
+		LogLevel:        "info",
+		LogFormat:       "json",
+		ShutdownTimeout: 20 * time.Second,
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"http://localhost:5173"},
+			MaxAge:         12 * time.Hour,
+		},
+		Cookie: CookieConfig{
+			SameSite: "lax",
+		},
+		Upload: UploadConfig{
+			MaxResumeBytes: DefaultMaxResumeBytes,
+			MaxResumes:     DefaultMaxResumes,
+		},
+		Scanner: ScannerConfig{
+			Enabled: true,
+			Addr:    "localhost:3310",
+			Timeout: 30 * time.Second,
+		},
+		Webhooks: WebhookConfig{
+			Timeout:     10 * time.Second,
+			MaxAttempts: 8,
+		},
 	}
 }
+
+func (c Config) IsProduction() bool {
+	return c.Env == "production"
+}
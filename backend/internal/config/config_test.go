@@ -0,0 +1,227 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// isolate runs the test in an empty directory with every config variable
+// unset, so the host environment can't leak in.
+func isolate(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	t.Setenv("APP_ENV", "")
+	t.Setenv("CONFIG_FILE", "")
+	for _, name := range envNames(reflect.TypeOf(Config{})) {
+		t.Setenv(name, "")
+	}
+}
+
+func envNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if name := f.Tag.Get("env"); name != "" {
+			names = append(names, name)
+		} else if f.Type.Kind() == reflect.Struct {
+			names = append(names, envNames(f.Type)...)
+		}
+	}
+	return names
+}
+
+func validEnv(t *testing.T) {
+	t.Helper()
+	for k, v := range map[string]string{
+		"DATABASE_URL":           "postgres://portal@localhost/portal",
+		"JWT_SECRET":             "dev-secret",
+		"KEYCLOAK_BASE_URL":      "http://localhost:8080",
+		"KEYCLOAK_REALM":         "portal",
+		"KEYCLOAK_CLIENT_ID":     "backend",
+		"KEYCLOAK_CLIENT_SECRET": "secret",
+		"FRONTEND_URL":           "http://localhost:5173",
+		"BACKEND_URL":            "http://localhost:3000",
+	} {
+		t.Setenv(k, v)
+	}
+}
+
+func writeFile(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func problems(t *testing.T, err error) []string {
+	t.Helper()
+	var cerr *Error
+	if !errors.As(err, &cerr) {
+		t.Fatalf("err = %v, want *config.Error", err)
+	}
+	return cerr.Problems
+}
+
+func TestLayering(t *testing.T) {
+	isolate(t)
+	validEnv(t)
+	t.Setenv("APP_ENV", "staging")
+
+	// config/<APP_ENV>.yaml is picked up without CONFIG_FILE
+	writeFile(t, "config/staging.yaml", `
+port: "4000"
+session_ttl: 2h
+cors:
+  allowed_origins: [https://staging.example.org]
+cookie:
+  secure: true
+  same_site: none
+scanner:
+  addr: clamav:3310
+`)
+	t.Setenv("PORT", "5000")
+	t.Setenv("SCANNER_TIMEOUT", "5s")
+	t.Setenv("RATE_LIMITS", "login_ip=5/1m, apply_user=1/1h")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Port != "5000" {
+		t.Errorf("Port = %q, env should beat the file", cfg.Port)
+	}
+	if cfg.SessionTTL != 2*time.Hour {
+		t.Errorf("SessionTTL = %s, want the file's 2h", cfg.SessionTTL)
+	}
+	if !reflect.DeepEqual(cfg.CORS.AllowedOrigins, []string{"https://staging.example.org"}) {
+		t.Errorf("AllowedOrigins = %v", cfg.CORS.AllowedOrigins)
+	}
+	if cfg.CORS.MaxAge != 12*time.Hour {
+		t.Errorf("MaxAge = %s, want the default", cfg.CORS.MaxAge)
+	}
+	if cfg.Scanner.Addr != "clamav:3310" || cfg.Scanner.Timeout != 5*time.Second || !cfg.Scanner.Enabled {
+		t.Errorf("Scanner = %+v", cfg.Scanner)
+	}
+	if cfg.RateLimits["apply_user"] != "1/1h" || cfg.RateLimits["login_ip"] != "5/1m" {
+		t.Errorf("RateLimits = %v", cfg.RateLimits)
+	}
+}
+
+func TestUnknownFileKeyIsAnError(t *testing.T) {
+	isolate(t)
+	validEnv(t)
+	t.Setenv("CONFIG_FILE", "custom.yaml")
+	writeFile(t, "custom.yaml", "cookie:\n  samesite: lax\n")
+
+	_, err := Load()
+	if got := problems(t, err); len(got) != 1 || !strings.Contains(got[0], "samesite") {
+		t.Fatalf("problems = %v", got)
+	}
+}
+
+func TestMissingExplicitFileIsAnError(t *testing.T) {
+	isolate(t)
+	validEnv(t)
+	t.Setenv("CONFIG_FILE", "nope.yaml")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("missing CONFIG_FILE was ignored")
+	}
+}
+
+func TestValidationListsEveryProblem(t *testing.T) {
+	isolate(t)
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("JWT_SECRET", "short")
+	t.Setenv("SESSION_TTL", "0s")
+	t.Setenv("COOKIE_SAME_SITE", "none")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "*,http://localhost:5173,https://ok.example.org/path")
+	t.Setenv("SCANNER_ENABLED", "false")
+	t.Setenv("UPLOAD_MAX_RESUME_BYTES", "0")
+	t.Setenv("UPLOAD_MAX_RESUMES", "21")
+	t.Setenv("MINIO_ENDPOINT", "minio:9000")
+	t.Setenv("RATE_LIMITS", "login_ipp=5/1m,apply_user=ten/1h,signup_ip=5/soon")
+
+	_, err := Load()
+	got := strings.Join(problems(t, err), "\n")
+
+	for _, want := range []string{
+		"DATABASE_URL: required",
+		"JWT_SECRET: must be at least",
+		"KEYCLOAK_BASE_URL: required",
+		"KEYCLOAK_CLIENT_SECRET: required",
+		"FRONTEND_URL: required",
+		"MINIO_BUCKET: required",
+		"SESSION_TTL:",
+		"CORS_ALLOWED_ORIGINS: \"*\"",
+		"\"http://localhost:5173\" is not allowed in production",
+		"\"https://ok.example.org/path\" is not an origin",
+		"COOKIE_SAME_SITE: none requires COOKIE_SECURE=true",
+		"COOKIE_SECURE: must be true in production",
+		"UPLOAD_MAX_RESUME_BYTES:",
+		"UPLOAD_MAX_RESUMES:",
+		"SCANNER_ENABLED:",
+		"METRICS_TOKEN: required in production",
+		"RATE_LIMITS: unknown policy \"login_ipp\"",
+		"RATE_LIMITS: apply_user: invalid rate limit \"ten/1h\": bad limit",
+		"RATE_LIMITS: signup_ip: invalid rate limit \"5/soon\": bad window",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestMalformedEnvValues(t *testing.T) {
+	isolate(t)
+	validEnv(t)
+	t.Setenv("COOKIE_SECURE", "maybe")
+	t.Setenv("SESSION_TTL", "a day")
+
+	_, err := Read()
+	got := problems(t, err)
+	if len(got) != 2 {
+		t.Fatalf("problems = %v", got)
+	}
+}
+
+func TestDevelopmentDefaultsAreValid(t *testing.T) {
+	isolate(t)
+	validEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Env != "development" || cfg.Cookie.Secure || cfg.Upload.MaxResumeBytes != DefaultMaxResumeBytes {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestShippedProductionFile(t *testing.T) {
+	path, err := filepath.Abs("../../config/production.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	isolate(t)
+	validEnv(t)
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("JWT_SECRET", strings.Repeat("x", 32))
+	t.Setenv("METRICS_TOKEN", "scrape-token")
+	t.Setenv("FRONTEND_URL", "https://careers.iiitn.ac.in")
+	t.Setenv("BACKEND_URL", "https://careers.iiitn.ac.in")
+
+	if _, err := Load(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Error lists every problem found while loading or validating, so one
+// failed start shows them all.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Load reads the layered configuration and validates it. Layers, later
+// wins:
+//
+//  1. Defaults()
+//  2. the YAML file named by CONFIG_FILE, or config/<APP_ENV>.yaml if it
+//     exists
+//  3. environment variables (empty ones are ignored)
+func Load() (Config, error) {
+	cfg, err := Read()
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// Read applies the layers without validating; for tools such as the
+// migrate command that need only part of the settings.
+func Read() (Config, error) {
+	cfg := Defaults()
+	var problems []string
+
+	if env := os.Getenv("APP_ENV"); env != "" {
+		cfg.Env = env
+	}
+
+	if path, explicit := configFile(cfg.Env); path != "" {
+		if err := readFile(path, &cfg); err != nil {
+			if explicit || !errors.Is(err, os.ErrNotExist) {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+
+	problems = append(problems, applyEnv(reflect.ValueOf(&cfg).Elem())...)
+
+	if len(problems) > 0 {
+		return cfg, &Error{Problems: problems}
+	}
+	return cfg, nil
+}
+
+func configFile(env string) (path string, explicit bool) {
+	if p := os.Getenv("CONFIG_FILE"); p != "" {
+		return p, true
+	}
+	return filepath.Join("config", env+".yaml"), false
+}
+
+// readFile decodes strictly: unknown keys are errors, not silently
+// ignored typos.
+func readFile(path string, cfg *Config) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("CONFIG_FILE: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv walks the struct and overrides every field whose env variable
+// is set.
+func applyEnv(v reflect.Value) []string {
+	var problems []string
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f, fv := t.Field(i), v.Field(i)
+
+		name := f.Tag.Get("env")
+		if name == "" {
+			if fv.Kind() == reflect.Struct {
+				problems = append(problems, applyEnv(fv)...)
+			}
+			continue
+		}
+
+		raw := strings.TrimSpace(os.Getenv(name))
+		if raw == "" {
+			continue
+		}
+		if err := setField(fv, raw); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	return problems
+}
+
+func setField(fv reflect.Value, raw string) error {
+	switch {
+	case fv.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		fv.SetInt(int64(d))
+
+	case fv.Kind() == reflect.String:
+		fv.SetString(raw)
+
+	case fv.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		fv.SetBool(b)
+
+	case fv.Kind() == reflect.Int64, fv.Kind() == reflect.Int:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		fv.SetInt(n)
+
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, s := range strings.Split(raw, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				items = append(items, s)
+			}
+		}
+		fv.Set(reflect.ValueOf(items))
+
+	case fv.Kind() == reflect.Map:
+		fv.Set(reflect.ValueOf(parsePairs(raw)))
+
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+	return nil
+}
+
+// parsePairs reads "a=1,b=2".
+func parsePairs(s string) map[string]string {
+	out := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		name, rule, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || name == "" {
+			continue
+		}
+		out[strings.TrimSpace(name)] = strings.TrimSpace(rule)
+	}
+	return out
+}
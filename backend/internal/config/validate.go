@@ -0,0 +1,252 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxSessionTTL     = 30 * 24 * time.Hour
+	maxResumeLimit    = 50 << 20
+	maxResumeVersions = 20
+	minProdSecretSize = 32
+
+	maxWebhookAttempts = 20
+)
+
+// Validate checks every setting and reports all problems at once, each
+// named by its environment variable.
+func (c Config) Validate() error {
+	v := &validator{}
+
+	switch c.Env {
+	case "development", "staging", "production":
+	default:
+		v.addf("APP_ENV: must be development, staging or production, got %q", c.Env)
+	}
+
+	v.required("DATABASE_URL", c.DB)
+
+	v.required("JWT_SECRET", c.JWTSecret)
+	if c.IsProduction() && c.JWTSecret != "" && len(c.JWTSecret) < minProdSecretSize {
+		v.addf("JWT_SECRET: must be at least %d characters in production", minProdSecretSize)
+	}
+
+	if n, err := strconv.Atoi(c.Port); err != nil || n < 1 || n > 65535 {
+		v.addf("PORT: must be a port number, got %q", c.Port)
+	}
+
+	v.absoluteURL("KEYCLOAK_BASE_URL", c.BaseURL)
+	v.required("KEYCLOAK_REALM", c.Realm)
+	v.required("KEYCLOAK_CLIENT_ID", c.ClientID)
+	v.required("KEYCLOAK_CLIENT_SECRET", c.ClientSecret)
+
+	v.absoluteURL("FRONTEND_URL", c.FrontendURL)
+	v.absoluteURL("BACKEND_URL", c.BackendBaseURL)
+
+	if c.Redis != "" {
+		if u, err := url.Parse(c.Redis); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+			v.add("REDIS_URL: must be a redis:// or rediss:// URL")
+		}
+	}
+
+	if c.MinioEndpoint != "" {
+		v.required("MINIO_ACCESS_KEY", c.MinioAccessKey)
+		v.required("MINIO_SECRET_KEY", c.MinioSecretKey)
+		v.required("MINIO_BUCKET", c.MinioBucket)
+		v.absoluteURL("MINIO_PUBLIC_URL", c.MinioPublicURL)
+	}
+
+	if c.SessionTTL <= 0 || c.SessionTTL > maxSessionTTL {
+		v.addf("SESSION_TTL: must be between 1s and %s, got %s", maxSessionTTL, c.SessionTTL)
+	}
+
+	if c.JobCacheTTL < 0 || c.JobCacheTTL > time.Hour {
+		v.addf("JOB_CACHE_TTL: must be between 0 (off) and 1h, got %s", c.JobCacheTTL)
+	}
+
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		v.addf("LOG_LEVEL: must be debug, info, warn or error, got %q", c.LogLevel)
+	}
+	switch c.LogFormat {
+	case "json", "text":
+	default:
+		v.addf("LOG_FORMAT: must be json or text, got %q", c.LogFormat)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.RateLimits)) {
+		if !slices.Contains(RateLimitPolicies, name) {
+			v.addf("RATE_LIMITS: unknown policy %q (known: %s)", name, strings.Join(RateLimitPolicies, ", "))
+			continue
+		}
+		if _, _, err := ParseRateLimit(c.RateLimits[name]); err != nil {
+			v.addf("RATE_LIMITS: %s: %v", name, err)
+		}
+	}
+
+	if c.IsProduction() && strings.TrimSpace(c.MetricsToken) == "" {
+		v.add("METRICS_TOKEN: required in production, /metrics would be open")
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		v.add("SHUTDOWN_TIMEOUT: must be positive")
+	}
+
+	c.validateCORS(v)
+	c.validateCookie(v)
+
+	if c.Upload.MaxResumeBytes < 1 || c.Upload.MaxResumeBytes > maxResumeLimit {
+		v.addf("UPLOAD_MAX_RESUME_BYTES: must be between 1 and %d, got %d", maxResumeLimit, c.Upload.MaxResumeBytes)
+	}
+	if c.Upload.MaxResumes < 1 || c.Upload.MaxResumes > maxResumeVersions {
+		v.addf("UPLOAD_MAX_RESUMES: must be between 1 and %d, got %d", maxResumeVersions, c.Upload.MaxResumes)
+	}
+
+	if c.Scanner.Enabled {
+		if _, port, err := net.SplitHostPort(c.Scanner.Addr); err != nil || port == "" {
+			v.addf("CLAMD_ADDR: must be host:port, got %q", c.Scanner.Addr)
+		}
+		if c.Scanner.Timeout <= 0 {
+			v.add("SCANNER_TIMEOUT: must be positive")
+		}
+	} else if c.IsProduction() {
+		v.add("SCANNER_ENABLED: uploads must be scanned in production")
+	}
+
+	if c.Webhooks.Timeout <= 0 || c.Webhooks.Timeout > time.Minute {
+		v.addf("WEBHOOK_TIMEOUT: must be between 1s and 1m, got %s", c.Webhooks.Timeout)
+	}
+	if c.Webhooks.MaxAttempts < 1 || c.Webhooks.MaxAttempts > maxWebhookAttempts {
+		v.addf("WEBHOOK_MAX_ATTEMPTS: must be between 1 and %d, got %d", maxWebhookAttempts, c.Webhooks.MaxAttempts)
+	}
+	if c.Webhooks.AllowPrivate && c.IsProduction() {
+		v.add("WEBHOOK_ALLOW_PRIVATE: not allowed in production")
+	}
+
+	return v.err()
+}
+
+// RateLimitPolicies are the policies RATE_LIMITS may override; each is
+// the name of a ratelimit.PolicyFromConfig call.
+var RateLimitPolicies = []string{
+	"login_ip",
+	"login_email",
+	"signup_ip",
+	"signup_email",
+	"apply_user",
+	"apply_ip",
+	"recruiter_accept_ip",
+}
+
+// ParseRateLimit reads "<limit>/<window>", e.g. "10/1m" or "5/15m".
+func ParseRateLimit(s string) (int, time.Duration, error) {
+	limitStr, windowStr, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid rate limit %q: want <limit>/<window>", s)
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		return 0, 0, fmt.Errorf("invalid rate limit %q: bad limit", s)
+	}
+
+	window, err := time.ParseDuration(windowStr)
+	if err != nil || window <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate limit %q: bad window", s)
+	}
+
+	return limit, window, nil
+}
+
+func (c Config) validateCORS(v *validator) {
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			v.add("CORS_ALLOWED_ORIGINS: \"*\" cannot be combined with credentialed requests; list the origins")
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.User != nil {
+			v.addf("CORS_ALLOWED_ORIGINS: %q is not an origin (scheme://host[:port])", origin)
+			continue
+		}
+		if c.IsProduction() && isLocalhost(u.Hostname()) {
+			v.addf("CORS_ALLOWED_ORIGINS: %q is not allowed in production", origin)
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		v.add("CORS_MAX_AGE: must not be negative")
+	}
+}
+
+func (c Config) validateCookie(v *validator) {
+	switch c.Cookie.SameSite {
+	case "lax", "strict":
+	case "none":
+		if !c.Cookie.Secure {
+			v.add("COOKIE_SAME_SITE: none requires COOKIE_SECURE=true")
+		}
+	default:
+		v.addf("COOKIE_SAME_SITE: must be lax, strict or none, got %q", c.Cookie.SameSite)
+	}
+
+	if c.IsProduction() && !c.Cookie.Secure {
+		v.add("COOKIE_SECURE: must be true in production")
+	}
+
+	if d := c.Cookie.Domain; d != "" && strings.ContainsAny(d, ":/ ") {
+		v.addf("COOKIE_DOMAIN: must be a bare domain, got %q", d)
+	}
+}
+
+func isLocalhost(host string) bool {
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) add(p string) {
+	v.problems = append(v.problems, p)
+}
+
+func (v *validator) addf(format string, args ...any) {
+	v.add(fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(key + ": required")
+	}
+}
+
+func (v *validator) absoluteURL(key, value string) {
+	if value == "" {
+		v.add(key + ": required")
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.addf("%s: must be an absolute http(s) URL, got %q", key, value)
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &Error{Problems: v.problems}
+}
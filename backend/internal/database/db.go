@@ -1,21 +1,33 @@
 package database
 
 import (
-	"log"
-	"strings" // Add this
+	"iiitn-career-portal/internal/logging"
+	"log/slog"
+	"strings"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func Connect(dsn string) *gorm.DB {
 	// Trim whitespace and hidden carriage returns
 	cleanDSN := strings.TrimSpace(dsn)
-	log.Println(cleanDSN)
 
-	db, err := gorm.Open(postgres.Open(cleanDSN), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(cleanDSN), &gorm.Config{
+		// unique violations surface as gorm.ErrDuplicatedKey
+		TranslateError: true,
+		// slow queries and errors only; bound values stay out of the logs
+		Logger: logger.NewSlogLogger(slog.Default(), logger.Config{
+			LogLevel:                  logger.Warn,
+			SlowThreshold:             200 * time.Millisecond,
+			ParameterizedQueries:      true,
+			IgnoreRecordNotFoundError: true,
+		}),
+	})
 	if err != nil {
-		log.Fatal("failed to connect database:", err)
+		logging.Fatal("failed to connect database", "error", err)
 	}
 
 	return db
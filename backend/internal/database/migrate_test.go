@@ -0,0 +1,172 @@
+package database
+
+import (
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/testutil/pgtest"
+	"maps"
+	"slices"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// every model the migrations create a table for
+var migratedModels = []interface{}{
+	&models.College{},
+	&models.User{},
+	&models.StudentProfile{},
+	&models.StudentProject{},
+	&models.StudentInternship{},
+	&models.StudentCertification{},
+	&models.StudentResume{},
+	&models.Job{},
+	&models.JobCollege{},
+	&models.JobBookmark{},
+	&models.SavedSearch{},
+	&models.PendingJobAlert{},
+	&models.SavedSearchMatch{},
+	&models.ApplicationIntent{},
+	&models.JobIntentStat{},
+	&models.Application{},
+	&models.RegistrationReconciliation{},
+	&models.InterviewSlot{},
+	&models.CalendarFeed{},
+	&models.Notification{},
+	&models.AuditLog{},
+	&models.Experience{},
+	&models.ExperienceComment{},
+	&models.AlumniProfile{},
+	&models.Company{},
+	&models.RecruiterJob{},
+	&models.RecruiterInvite{},
+	&models.WebhookEndpoint{},
+	&models.WebhookDelivery{},
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range migrations {
+		if m.Version != int64(i+1) {
+			t.Fatalf("migration %04d_%s is number %d; versions must not skip", m.Version, m.Name, i+1)
+		}
+	}
+}
+
+// TestMigrationsMatchModels applies every migration to an empty Postgres
+// schema and checks the result against the models, then rolls everything
+// back and applies it again. Set TEST_DATABASE_URL to run it.
+func TestMigrationsMatchModels(t *testing.T) {
+	db := pgtest.Open(t)
+
+	migrations, err := LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := MigrateUp(db, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != len(migrations) {
+		t.Fatalf("applied %d of %d migrations", len(applied), len(migrations))
+	}
+	compareWithModels(t, db)
+
+	if _, err := MigrateDown(db, len(migrations)); err != nil {
+		t.Fatal(err)
+	}
+	if left := tableColumns(t, db); len(left) != 1 || left["schema_migrations"] == nil {
+		t.Fatalf("tables left after rolling back every migration: %v", slices.Sorted(maps.Keys(left)))
+	}
+
+	if _, err := MigrateUp(db, 0); err != nil {
+		t.Fatalf("reapplying after a full rollback: %v", err)
+	}
+	compareWithModels(t, db)
+}
+
+type column struct {
+	nullable bool
+}
+
+// tableColumns reads the current schema's tables and their columns.
+func tableColumns(t *testing.T, db *gorm.DB) map[string]map[string]column {
+	t.Helper()
+
+	var rows []struct {
+		TableName  string
+		ColumnName string
+		IsNullable string
+	}
+	if err := db.Raw(`
+		SELECT table_name, column_name, is_nullable
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+	`).Scan(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tables := map[string]map[string]column{}
+	for _, r := range rows {
+		if tables[r.TableName] == nil {
+			tables[r.TableName] = map[string]column{}
+		}
+		tables[r.TableName][r.ColumnName] = column{nullable: r.IsNullable == "YES"}
+	}
+	return tables
+}
+
+// compareWithModels reports every table or column the migrations and the
+// models disagree on, and model fields tagged not null that the schema
+// lets be NULL.
+func compareWithModels(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	tables := tableColumns(t, db)
+	seen := map[string]bool{"schema_migrations": true}
+
+	for _, model := range migratedModels {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatal(err)
+		}
+		table := stmt.Schema.Table
+		seen[table] = true
+
+		columns, ok := tables[table]
+		if !ok {
+			t.Errorf("%s: no table %s", stmt.Schema.Name, table)
+			continue
+		}
+
+		fields := map[string]bool{}
+		for _, f := range stmt.Schema.Fields {
+			if f.DBName == "" {
+				continue
+			}
+			fields[f.DBName] = true
+
+			col, ok := columns[f.DBName]
+			switch {
+			case !ok:
+				t.Errorf("%s.%s: no column %s.%s", stmt.Schema.Name, f.Name, table, f.DBName)
+			case f.NotNull && col.nullable:
+				t.Errorf("%s.%s is not null, column %s.%s is nullable", stmt.Schema.Name, f.Name, table, f.DBName)
+			}
+		}
+		for name := range columns {
+			if !fields[name] {
+				t.Errorf("%s.%s: no field in %s", table, name, stmt.Schema.Name)
+			}
+		}
+	}
+
+	for table := range tables {
+		if !seen[table] {
+			t.Errorf("table %s has no model", table)
+		}
+	}
+}
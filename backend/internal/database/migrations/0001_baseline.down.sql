@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS application_intents;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS applications;
+DROP TABLE IF EXISTS jobs;
+DROP TABLE IF EXISTS student_profiles;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS colleges;
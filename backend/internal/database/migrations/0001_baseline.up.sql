@@ -0,0 +1,100 @@
+-- Schema as previously created by GORM AutoMigrate. IF NOT EXISTS lets
+-- databases bootstrapped by AutoMigrate adopt the baseline unchanged.
+
+CREATE TABLE IF NOT EXISTS colleges (
+    id          bigserial PRIMARY KEY,
+    name        text,
+    domain      text,
+    created_at  timestamptz,
+    updated_at  timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_colleges_name ON colleges (name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_colleges_domain ON colleges (domain);
+
+CREATE TABLE IF NOT EXISTS users (
+    id           bigserial PRIMARY KEY,
+    keycloak_id  text NOT NULL,
+    email        text,
+    name         text,
+    college_id   bigint,
+    role         varchar(20) DEFAULT 'student',
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    CONSTRAINT fk_users_college FOREIGN KEY (college_id) REFERENCES colleges (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_keycloak_id ON users (keycloak_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS student_profiles (
+    user_id           bigserial PRIMARY KEY,
+    cgpa              decimal,
+    resume_url        text,
+    profile_complete  boolean DEFAULT false,
+    batch             bigint,
+    linkedin_id       text,
+    created_at        timestamptz,
+    updated_at        timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id                     bigserial PRIMARY KEY,
+    college_id             bigint NOT NULL,
+    title                  text NOT NULL,
+    company                text NOT NULL,
+    job_type               varchar(20) NOT NULL,
+    domain                 varchar(20) NOT NULL,
+    eligible_batches       jsonb NOT NULL,
+    ctc                    decimal,
+    stipend                decimal,
+    registration_form_url  text,
+    description            text,
+    rounds                 jsonb,
+    is_active              boolean DEFAULT true,
+    created_at             timestamptz,
+    updated_at             timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_college_id ON jobs (college_id);
+
+CREATE TABLE IF NOT EXISTS applications (
+    id                   bigserial PRIMARY KEY,
+    job_id               bigint NOT NULL,
+    student_id           bigint NOT NULL,
+    college_id           bigint NOT NULL,
+    status               varchar(20) NOT NULL,
+    resume_snapshot_url  text NOT NULL,
+    created_at           timestamptz,
+    updated_at           timestamptz,
+    CONSTRAINT fk_applications_job FOREIGN KEY (job_id)
+        REFERENCES jobs (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT fk_applications_student FOREIGN KEY (student_id)
+        REFERENCES users (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_applications_job_id ON applications (job_id);
+CREATE INDEX IF NOT EXISTS idx_applications_student_id ON applications (student_id);
+CREATE INDEX IF NOT EXISTS idx_applications_college_id ON applications (college_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_student_job ON applications (job_id, student_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id          bigserial PRIMARY KEY,
+    user_id     bigint NOT NULL,
+    type        varchar(50) NOT NULL,
+    target_id   bigint NOT NULL,
+    payload     jsonb NOT NULL,
+    is_read     boolean DEFAULT false,
+    created_at  timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_target_id ON notifications (target_id);
+
+CREATE TABLE IF NOT EXISTS application_intents (
+    id          bigserial PRIMARY KEY,
+    job_id      bigint NOT NULL,
+    student_id  bigint NOT NULL,
+    college_id  bigint NOT NULL,
+    expires_at  timestamptz,
+    created_at  timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_application_intents_job_id ON application_intents (job_id);
+CREATE INDEX IF NOT EXISTS idx_application_intents_student_id ON application_intents (student_id);
+CREATE INDEX IF NOT EXISTS idx_application_intents_college_id ON application_intents (college_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_intent ON application_intents (job_id, student_id);
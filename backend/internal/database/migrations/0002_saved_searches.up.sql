@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id                bigserial PRIMARY KEY,
+    student_id        bigint NOT NULL,
+    college_id        bigint NOT NULL,
+    name              text NOT NULL,
+    filters           jsonb NOT NULL,
+    notify_on_match   boolean DEFAULT true,
+    last_notified_at  timestamptz,
+    created_at        timestamptz,
+    updated_at        timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_student_id ON saved_searches (student_id);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_college_id ON saved_searches (college_id);
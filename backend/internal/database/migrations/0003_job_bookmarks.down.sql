@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS job_bookmarks;
+
+DROP INDEX IF EXISTS idx_jobs_registration_deadline;
+ALTER TABLE jobs DROP COLUMN IF EXISTS registration_deadline;
@@ -0,0 +1,15 @@
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS registration_deadline timestamptz;
+CREATE INDEX IF NOT EXISTS idx_jobs_registration_deadline ON jobs (registration_deadline);
+
+CREATE TABLE IF NOT EXISTS job_bookmarks (
+    id           bigserial PRIMARY KEY,
+    job_id       bigint NOT NULL,
+    student_id   bigint NOT NULL,
+    college_id   bigint NOT NULL,
+    reminded_at  timestamptz,
+    created_at   timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_job_bookmarks_job_id ON job_bookmarks (job_id);
+CREATE INDEX IF NOT EXISTS idx_job_bookmarks_student_id ON job_bookmarks (student_id);
+CREATE INDEX IF NOT EXISTS idx_job_bookmarks_college_id ON job_bookmarks (college_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bookmark ON job_bookmarks (job_id, student_id);
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS interview_slots (
+    id                       bigserial PRIMARY KEY,
+    job_id                   bigint NOT NULL,
+    college_id               bigint NOT NULL,
+    starts_at                timestamptz NOT NULL,
+    duration_minutes         bigint NOT NULL,
+    venue                    text,
+    meeting_link             text,
+    panel                    jsonb,
+    application_id           bigint,
+    reschedule_reason        text,
+    reschedule_requested_at  timestamptz,
+    created_at               timestamptz,
+    updated_at               timestamptz,
+    CONSTRAINT fk_interview_slots_job FOREIGN KEY (job_id)
+        REFERENCES jobs (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_interview_slots_application FOREIGN KEY (application_id)
+        REFERENCES applications (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_interview_slots_job_id ON interview_slots (job_id);
+CREATE INDEX IF NOT EXISTS idx_interview_slots_college_id ON interview_slots (college_id);
+CREATE INDEX IF NOT EXISTS idx_interview_slots_starts_at ON interview_slots (starts_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_interview_slots_application_id ON interview_slots (application_id);
+
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    student_id  bigserial PRIMARY KEY,
+    token       text NOT NULL,
+    created_at  timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_token ON calendar_feeds (token);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS experience_comments;
+DROP TABLE IF EXISTS experiences;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS experiences (
+    id          bigserial PRIMARY KEY,
+    author_id   bigint NOT NULL,
+    college_id  bigint NOT NULL,
+    company     text NOT NULL,
+    role        text NOT NULL,
+    year        bigint NOT NULL,
+    rounds      jsonb,
+    questions   jsonb,
+    difficulty  varchar(20) NOT NULL,
+    verdict     varchar(20) NOT NULL,
+    content     text,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    CONSTRAINT fk_experiences_author FOREIGN KEY (author_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_experiences_author_id ON experiences (author_id);
+CREATE INDEX IF NOT EXISTS idx_experiences_college_id ON experiences (college_id);
+CREATE INDEX IF NOT EXISTS idx_experiences_company ON experiences (company);
+CREATE INDEX IF NOT EXISTS idx_experiences_year ON experiences (year);
+
+CREATE TABLE IF NOT EXISTS experience_comments (
+    id             bigserial PRIMARY KEY,
+    experience_id  bigint NOT NULL,
+    parent_id      bigint,
+    author_id      bigint NOT NULL,
+    content        text NOT NULL,
+    created_at     timestamptz,
+    CONSTRAINT fk_experience_comments_experience FOREIGN KEY (experience_id)
+        REFERENCES experiences (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_experience_comments_author FOREIGN KEY (author_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_experience_comments_experience_id ON experience_comments (experience_id);
+CREATE INDEX IF NOT EXISTS idx_experience_comments_parent_id ON experience_comments (parent_id);
+CREATE INDEX IF NOT EXISTS idx_experience_comments_author_id ON experience_comments (author_id);
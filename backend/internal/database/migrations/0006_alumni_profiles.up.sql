@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS alumni_profiles (
+    user_id             bigserial PRIMARY KEY,
+    college_id          bigint NOT NULL,
+    current_company     text,
+    "current_role"      text,
+    graduation_batch    bigint NOT NULL,
+    contact_preference  varchar(20) DEFAULT 'NONE',
+    is_public           boolean DEFAULT false,
+    created_at          timestamptz,
+    updated_at          timestamptz,
+    CONSTRAINT fk_alumni_profiles_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_alumni_profiles_college_id ON alumni_profiles (college_id);
+CREATE INDEX IF NOT EXISTS idx_alumni_profiles_current_company ON alumni_profiles (current_company);
+CREATE INDEX IF NOT EXISTS idx_alumni_profiles_graduation_batch ON alumni_profiles (graduation_batch);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS audit_logs_no_modify ON audit_logs;
+DROP FUNCTION IF EXISTS audit_logs_append_only();
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id           bigserial PRIMARY KEY,
+    chain_key    bigint NOT NULL,
+    actor_id     bigint NOT NULL,
+    actor_role   varchar(20) NOT NULL,
+    college_id   bigint,
+    action       varchar(50) NOT NULL,
+    entity_type  varchar(50) NOT NULL,
+    entity_id    varchar(64) NOT NULL,
+    diff         jsonb,
+    request_id   varchar(64),
+    ip           varchar(64),
+    prev_hash    char(64) NOT NULL,
+    hash         char(64) NOT NULL,
+    created_at   timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_audit_chain ON audit_logs (chain_key, id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_college_id ON audit_logs (college_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_entity ON audit_logs (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_logs_hash ON audit_logs (hash);
+
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_logs_no_modify ON audit_logs;
+CREATE TRIGGER audit_logs_no_modify
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_notifications_user_created;
+DROP INDEX IF EXISTS idx_applications_student_created;
+DROP INDEX IF EXISTS idx_applications_college_created;
+DROP INDEX IF EXISTS idx_jobs_active_stipend;
+DROP INDEX IF EXISTS idx_jobs_active_ctc;
+DROP INDEX IF EXISTS idx_jobs_active_created;
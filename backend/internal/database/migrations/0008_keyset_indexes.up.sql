@@ -0,0 +1,9 @@
+-- Keyset pagination: the newest-first listings are served by indexes
+-- ending in the id tiebreaker; the ctc/stipend ones cover the ascending
+-- sorts and the range filters.
+CREATE INDEX IF NOT EXISTS idx_jobs_active_created ON jobs (college_id, created_at DESC, id DESC) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_jobs_active_ctc ON jobs (college_id, ctc, id) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_jobs_active_stipend ON jobs (college_id, stipend, id) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_applications_college_created ON applications (college_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_applications_student_created ON applications (student_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications (user_id, created_at DESC, id DESC);
@@ -0,0 +1,16 @@
+-- Pooled drives: a job published to colleges other than its owner, each
+-- with its own eligible batches and visibility.
+CREATE TABLE IF NOT EXISTS job_colleges (
+    job_id            bigint NOT NULL,
+    college_id        bigint NOT NULL,
+    eligible_batches  jsonb NOT NULL,
+    is_visible        boolean NOT NULL DEFAULT true,
+    created_at        timestamptz,
+    updated_at        timestamptz,
+    PRIMARY KEY (job_id, college_id),
+    CONSTRAINT fk_job_colleges_job FOREIGN KEY (job_id)
+        REFERENCES jobs (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_job_colleges_college FOREIGN KEY (college_id)
+        REFERENCES colleges (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_job_colleges_college_id ON job_colleges (college_id);
@@ -0,0 +1,11 @@
+ALTER TABLE student_profiles
+    DROP COLUMN IF EXISTS hide_cgpa_from_recruiters,
+    DROP COLUMN IF EXISTS share_contact_with_recruiters;
+
+DROP TABLE IF EXISTS recruiter_invites;
+DROP TABLE IF EXISTS recruiter_jobs;
+
+DROP INDEX IF EXISTS idx_users_company_id;
+ALTER TABLE users DROP COLUMN IF EXISTS company_id;
+
+DROP TABLE IF EXISTS companies;
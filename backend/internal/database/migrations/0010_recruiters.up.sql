@@ -0,0 +1,50 @@
+-- Recruiters: company accounts invited by college admins for specific jobs.
+CREATE TABLE IF NOT EXISTS companies (
+    id          bigserial PRIMARY KEY,
+    name        text NOT NULL,
+    created_at  timestamptz,
+    updated_at  timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_companies_name ON companies (name);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS company_id bigint
+    REFERENCES companies (id) ON DELETE RESTRICT ON UPDATE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_users_company_id ON users (company_id);
+
+CREATE TABLE IF NOT EXISTS recruiter_jobs (
+    recruiter_id  bigint NOT NULL,
+    job_id        bigint NOT NULL,
+    college_id    bigint NOT NULL,
+    invited_by    bigint NOT NULL,
+    created_at    timestamptz,
+    PRIMARY KEY (recruiter_id, job_id, college_id),
+    CONSTRAINT fk_recruiter_jobs_recruiter FOREIGN KEY (recruiter_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_recruiter_jobs_job FOREIGN KEY (job_id)
+        REFERENCES jobs (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_recruiter_jobs_job_id ON recruiter_jobs (job_id);
+CREATE INDEX IF NOT EXISTS idx_recruiter_jobs_college_id ON recruiter_jobs (college_id);
+
+CREATE TABLE IF NOT EXISTS recruiter_invites (
+    id           bigserial PRIMARY KEY,
+    email        text NOT NULL,
+    name         text NOT NULL,
+    company_id   bigint NOT NULL,
+    college_id   bigint NOT NULL,
+    invited_by   bigint NOT NULL,
+    job_ids      jsonb NOT NULL,
+    token_hash   text NOT NULL,
+    expires_at   timestamptz NOT NULL,
+    accepted_at  timestamptz,
+    created_at   timestamptz,
+    CONSTRAINT fk_recruiter_invites_company FOREIGN KEY (company_id)
+        REFERENCES companies (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_recruiter_invites_email ON recruiter_invites (email);
+CREATE INDEX IF NOT EXISTS idx_recruiter_invites_college_id ON recruiter_invites (college_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recruiter_invites_token_hash ON recruiter_invites (token_hash);
+
+ALTER TABLE student_profiles
+    ADD COLUMN IF NOT EXISTS share_contact_with_recruiters boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS hide_cgpa_from_recruiters boolean NOT NULL DEFAULT false;
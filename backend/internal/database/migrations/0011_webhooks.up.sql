@@ -0,0 +1,36 @@
+-- Outbound webhooks: endpoints per college and their delivery log.
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id           bigserial PRIMARY KEY,
+    college_id   bigint NOT NULL,
+    url          text NOT NULL,
+    secret       text NOT NULL,
+    events       jsonb NOT NULL,
+    description  text,
+    is_active    boolean NOT NULL,
+    created_by   bigint NOT NULL,
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    CONSTRAINT fk_webhook_endpoints_college FOREIGN KEY (college_id)
+        REFERENCES colleges (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_college_id ON webhook_endpoints (college_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id                bigserial PRIMARY KEY,
+    endpoint_id       bigint NOT NULL,
+    event             varchar(50) NOT NULL,
+    payload           jsonb NOT NULL,
+    status            varchar(20) NOT NULL,
+    attempts          bigint NOT NULL,
+    next_attempt_at   timestamptz,
+    last_status_code  bigint,
+    last_error        text,
+    delivered_at      timestamptz,
+    created_at        timestamptz,
+    updated_at        timestamptz,
+    CONSTRAINT fk_webhook_deliveries_endpoint FOREIGN KEY (endpoint_id)
+        REFERENCES webhook_endpoints (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint_id ON webhook_deliveries (endpoint_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries (next_attempt_at);
@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS student_certifications;
+DROP TABLE IF EXISTS student_internships;
+DROP TABLE IF EXISTS student_projects;
+
+ALTER TABLE colleges DROP COLUMN IF EXISTS profile_required_fields;
+
+DROP INDEX IF EXISTS idx_student_profiles_roll_number;
+ALTER TABLE student_profiles
+    DROP COLUMN IF EXISTS coding_profiles,
+    DROP COLUMN IF EXISTS skills,
+    DROP COLUMN IF EXISTS twelfth_percentage,
+    DROP COLUMN IF EXISTS tenth_percentage,
+    DROP COLUMN IF EXISTS roll_number,
+    DROP COLUMN IF EXISTS branch;
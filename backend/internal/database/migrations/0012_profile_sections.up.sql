@@ -0,0 +1,55 @@
+-- Structured student profiles and per-college required profile fields.
+ALTER TABLE student_profiles
+    ADD COLUMN IF NOT EXISTS branch text,
+    ADD COLUMN IF NOT EXISTS roll_number text,
+    ADD COLUMN IF NOT EXISTS tenth_percentage decimal,
+    ADD COLUMN IF NOT EXISTS twelfth_percentage decimal,
+    ADD COLUMN IF NOT EXISTS skills jsonb,
+    ADD COLUMN IF NOT EXISTS coding_profiles jsonb;
+CREATE INDEX IF NOT EXISTS idx_student_profiles_roll_number ON student_profiles (roll_number);
+
+ALTER TABLE colleges ADD COLUMN IF NOT EXISTS profile_required_fields jsonb;
+
+CREATE TABLE IF NOT EXISTS student_projects (
+    id           bigserial PRIMARY KEY,
+    user_id      bigint NOT NULL,
+    title        text NOT NULL,
+    description  text,
+    url          text,
+    start_date   timestamptz,
+    end_date     timestamptz,
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    CONSTRAINT fk_student_projects_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_student_projects_user_id ON student_projects (user_id);
+
+CREATE TABLE IF NOT EXISTS student_internships (
+    id           bigserial PRIMARY KEY,
+    user_id      bigint NOT NULL,
+    company      text NOT NULL,
+    role         text NOT NULL,
+    description  text,
+    start_date   timestamptz,
+    end_date     timestamptz,
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    CONSTRAINT fk_student_internships_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_student_internships_user_id ON student_internships (user_id);
+
+CREATE TABLE IF NOT EXISTS student_certifications (
+    id              bigserial PRIMARY KEY,
+    user_id         bigint NOT NULL,
+    name            text NOT NULL,
+    issuer          text,
+    issued_on       timestamptz,
+    credential_url  text,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    CONSTRAINT fk_student_certifications_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_student_certifications_user_id ON student_certifications (user_id);
@@ -0,0 +1,4 @@
+ALTER TABLE applications DROP COLUMN IF EXISTS resume_name;
+ALTER TABLE application_intents DROP COLUMN IF EXISTS resume_id;
+
+DROP TABLE IF EXISTS student_resumes;
@@ -0,0 +1,29 @@
+-- Named resume versions per student, and the resume chosen per application.
+CREATE TABLE IF NOT EXISTS student_resumes (
+    id           bigserial PRIMARY KEY,
+    user_id      bigint NOT NULL,
+    name         text NOT NULL,
+    url          text NOT NULL,
+    object_path  text NOT NULL,
+    size         bigint,
+    is_default   boolean NOT NULL DEFAULT false,
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    CONSTRAINT fk_student_resumes_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_student_resumes_user_id ON student_resumes (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_student_resume_name ON student_resumes (user_id, name);
+
+-- the single resume uploaded so far becomes each student's default
+INSERT INTO student_resumes (user_id, name, url, object_path, size, is_default, created_at, updated_at)
+SELECT p.user_id, 'Resume', p.resume_url,
+       'resumes/' || u.college_id || '/' || p.user_id || '/resume.pdf',
+       0, true, p.updated_at, p.updated_at
+FROM student_profiles p
+JOIN users u ON u.id = p.user_id
+WHERE p.resume_url IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM student_resumes r WHERE r.user_id = p.user_id);
+
+ALTER TABLE application_intents ADD COLUMN IF NOT EXISTS resume_id bigint;
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS resume_name text;
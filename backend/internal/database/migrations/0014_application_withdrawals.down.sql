@@ -0,0 +1,6 @@
+ALTER TABLE colleges
+    DROP COLUMN IF EXISTS max_withdrawals_per_season,
+    DROP COLUMN IF EXISTS withdrawal_cutoff_hours;
+
+DROP INDEX IF EXISTS idx_applications_withdrawn_at;
+ALTER TABLE applications DROP COLUMN IF EXISTS withdrawn_at;
@@ -0,0 +1,7 @@
+-- Students withdrawing applications, within per-college limits.
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS withdrawn_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_applications_withdrawn_at ON applications (withdrawn_at);
+
+ALTER TABLE colleges
+    ADD COLUMN IF NOT EXISTS withdrawal_cutoff_hours bigint,
+    ADD COLUMN IF NOT EXISTS max_withdrawals_per_season bigint;
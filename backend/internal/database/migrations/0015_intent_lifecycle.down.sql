@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS job_intent_stats;
+
+DROP INDEX IF EXISTS idx_application_intents_expires_at;
+ALTER TABLE application_intents DROP COLUMN IF EXISTS reminded_at;
@@ -0,0 +1,14 @@
+-- Expiry reminders and sweeping of application intents, with the expired
+-- ones counted per job and college for conversion metrics.
+ALTER TABLE application_intents ADD COLUMN IF NOT EXISTS reminded_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_application_intents_expires_at ON application_intents (expires_at);
+
+CREATE TABLE IF NOT EXISTS job_intent_stats (
+    job_id      bigint NOT NULL,
+    college_id  bigint NOT NULL,
+    expired     bigint NOT NULL DEFAULT 0,
+    updated_at  timestamptz,
+    PRIMARY KEY (job_id, college_id),
+    CONSTRAINT fk_job_intent_stats_job FOREIGN KEY (job_id)
+        REFERENCES jobs (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS registration_reconciliations;
+
+DROP INDEX IF EXISTS idx_applications_registration_check;
+ALTER TABLE applications
+    DROP COLUMN IF EXISTS registration_checked_at,
+    DROP COLUMN IF EXISTS registration_check;
@@ -0,0 +1,26 @@
+-- Reconciling applications against a job's registration form responses.
+ALTER TABLE applications
+    ADD COLUMN IF NOT EXISTS registration_check varchar(20),
+    ADD COLUMN IF NOT EXISTS registration_checked_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_applications_registration_check ON applications (registration_check);
+
+CREATE TABLE IF NOT EXISTS registration_reconciliations (
+    id           bigserial PRIMARY KEY,
+    job_id       bigint NOT NULL,
+    college_id   bigint NOT NULL,
+    uploaded_by  bigint NOT NULL,
+    file_name    text,
+    rows         bigint NOT NULL,
+    confirmed    bigint NOT NULL,
+    verified     bigint NOT NULL,
+    missing      bigint NOT NULL,
+    unmatched    bigint NOT NULL,
+    report       jsonb NOT NULL,
+    created_at   timestamptz,
+    CONSTRAINT fk_registration_reconciliations_job FOREIGN KEY (job_id)
+        REFERENCES jobs (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_registration_reconciliations_college FOREIGN KEY (college_id)
+        REFERENCES colleges (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_registration_reconciliations_job_id ON registration_reconciliations (job_id);
+CREATE INDEX IF NOT EXISTS idx_registration_reconciliations_college_id ON registration_reconciliations (college_id);
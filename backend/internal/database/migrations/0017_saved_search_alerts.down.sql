@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS saved_search_matches;
+DROP TABLE IF EXISTS pending_job_alerts;
+
+ALTER TABLE saved_searches DROP COLUMN IF EXISTS frequency;
@@ -0,0 +1,23 @@
+-- Saved-search alerts leave the request path: new jobs are queued with
+-- the job and matched by a worker, and matches wait for their alert or
+-- daily digest.
+ALTER TABLE saved_searches ADD COLUMN IF NOT EXISTS frequency varchar(10) NOT NULL DEFAULT 'INSTANT';
+
+CREATE TABLE IF NOT EXISTS pending_job_alerts (
+    job_id      bigint PRIMARY KEY,
+    created_at  timestamptz,
+    CONSTRAINT fk_pending_job_alerts_job FOREIGN KEY (job_id)
+        REFERENCES jobs (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS saved_search_matches (
+    saved_search_id  bigint NOT NULL,
+    job_id           bigint NOT NULL,
+    created_at       timestamptz,
+    PRIMARY KEY (saved_search_id, job_id),
+    CONSTRAINT fk_saved_search_matches_search FOREIGN KEY (saved_search_id)
+        REFERENCES saved_searches (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_saved_search_matches_job FOREIGN KEY (job_id)
+        REFERENCES jobs (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_saved_search_matches_job_id ON saved_search_matches (job_id);
@@ -0,0 +1,6 @@
+UPDATE registration_reconciliations
+SET report = (report - 'total_rows') || jsonb_build_object('rows', report -> 'total_rows')
+WHERE report ? 'total_rows';
+
+ALTER TABLE registration_reconciliations DROP COLUMN IF EXISTS ambiguous;
+ALTER TABLE registration_reconciliations RENAME COLUMN total_rows TO rows;
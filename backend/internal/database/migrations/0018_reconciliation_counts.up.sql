@@ -0,0 +1,10 @@
+-- Upload summaries count ambiguous responses too, and "rows" becomes
+-- total_rows. Stored reports are rewritten to match.
+ALTER TABLE registration_reconciliations RENAME COLUMN rows TO total_rows;
+ALTER TABLE registration_reconciliations ADD COLUMN IF NOT EXISTS ambiguous bigint NOT NULL DEFAULT 0;
+ALTER TABLE registration_reconciliations ALTER COLUMN ambiguous DROP DEFAULT;
+
+UPDATE registration_reconciliations
+SET ambiguous = COALESCE(jsonb_array_length(report -> 'ambiguous'), 0),
+    report = (report - 'rows') || jsonb_build_object('total_rows', report -> 'rows')
+WHERE report ? 'rows';
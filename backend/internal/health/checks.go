@@ -0,0 +1,32 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func Postgres(db *sql.DB) Check {
+	return Check{
+		Name:     "postgres",
+		Critical: true,
+		Probe: func(ctx context.Context) error {
+			return db.PingContext(ctx)
+		},
+	}
+}
+
+// Redis is optional: without it rate limits fall back to per-instance
+// memory and real-time notification lists are skipped.
+func Redis(rdb *redis.Client) Check {
+	return Check{
+		Name: "redis",
+		Probe: func(ctx context.Context) error {
+			if rdb == nil {
+				return ErrDisabled
+			}
+			return rdb.Ping(ctx).Err()
+		},
+	}
+}
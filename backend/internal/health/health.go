@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"iiitn-career-portal/internal/logging"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultTimeout = 2 * time.Second
+
+// ErrDisabled marks a dependency that is not configured; it is reported
+// but never fails readiness.
+var ErrDisabled = errors.New("not configured")
+
+// Check probes one dependency. A failing Critical check makes the
+// instance unready; a failing optional one only marks it degraded.
+type Check struct {
+	Name     string
+	Critical bool
+	Probe    func(ctx context.Context) error
+}
+
+type Checker struct {
+	checks   []Check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+func New(checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: defaultTimeout}
+}
+
+// Drain makes readiness fail from now on so the load balancer stops
+// routing here while in-flight requests finish.
+func (h *Checker) Drain() {
+	h.draining.Store(true)
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Status     string  `json:"status"` // up, down, disabled
+	Critical   bool    `json:"critical"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// Run probes every dependency concurrently, each under its own timeout.
+func (h *Checker) Run(ctx context.Context) (status string, results map[string]Result) {
+	results = make(map[string]Result, len(h.checks))
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Probe(ctx)
+			r := Result{
+				Status:     "up",
+				Critical:   check.Critical,
+				DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			switch {
+			case errors.Is(err, ErrDisabled):
+				r.Status = "disabled"
+			case err != nil:
+				r.Status = "down"
+				r.Error = logging.Scrub(err.Error())
+			}
+
+			mu.Lock()
+			results[check.Name] = r
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	status = "ok"
+	for _, r := range results {
+		if r.Status != "down" {
+			continue
+		}
+		if r.Critical {
+			return "unavailable", results
+		}
+		status = "degraded"
+	}
+	return status, results
+}
+
+// Live only says the process is serving HTTP; it never looks at
+// dependencies, so a database outage doesn't get the pod restarted.
+func Live() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	}
+}
+
+// Ready answers 503 while draining or when a critical dependency is down,
+// 200 otherwise (including "degraded").
+func (h *Checker) Ready() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.draining.Load() {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "draining"})
+			return
+		}
+
+		status, results := h.Run(c.Request.Context())
+
+		code := http.StatusOK
+		if status == "unavailable" {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, gin.H{"status": status, "checks": results})
+	}
+}
@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func probe(err error) func(context.Context) error {
+	return func(context.Context) error { return err }
+}
+
+func ready(t *testing.T, h *Checker) (int, map[string]any) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/ready", h.Ready())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	return w.Code, body
+}
+
+func TestReadiness(t *testing.T) {
+	down := errors.New("connection refused")
+
+	cases := []struct {
+		name   string
+		checks []Check
+		code   int
+		status string
+	}{
+		{"all up", []Check{
+			{Name: "postgres", Critical: true, Probe: probe(nil)},
+			{Name: "redis", Probe: probe(nil)},
+		}, http.StatusOK, "ok"},
+		{"optional down", []Check{
+			{Name: "postgres", Critical: true, Probe: probe(nil)},
+			{Name: "redis", Probe: probe(down)},
+		}, http.StatusOK, "degraded"},
+		{"optional disabled", []Check{
+			{Name: "postgres", Critical: true, Probe: probe(nil)},
+			{Name: "redis", Probe: probe(ErrDisabled)},
+		}, http.StatusOK, "ok"},
+		{"critical down", []Check{
+			{Name: "postgres", Critical: true, Probe: probe(down)},
+			{Name: "redis", Probe: probe(nil)},
+		}, http.StatusServiceUnavailable, "unavailable"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, body := ready(t, New(tc.checks...))
+			if code != tc.code || body["status"] != tc.status {
+				t.Fatalf("got %d %v", code, body)
+			}
+		})
+	}
+}
+
+func TestSlowCheckTimesOut(t *testing.T) {
+	h := New(Check{Name: "keycloak", Probe: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	h.timeout = 20 * time.Millisecond
+
+	start := time.Now()
+	status, results := h.Run(context.Background())
+	if time.Since(start) > time.Second {
+		t.Fatal("timeout not applied")
+	}
+	if status != "degraded" || results["keycloak"].Status != "down" {
+		t.Fatalf("status=%s results=%v", status, results)
+	}
+}
+
+func TestDrainFailsReadiness(t *testing.T) {
+	h := New(Check{Name: "postgres", Critical: true, Probe: probe(nil)})
+	h.Drain()
+
+	code, body := ready(t, h)
+	if code != http.StatusServiceUnavailable || body["status"] != "draining" {
+		t.Fatalf("got %d %v", code, body)
+	}
+}
@@ -0,0 +1,175 @@
+package integration
+
+import (
+	"iiitn-career-portal/internal/models"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+)
+
+func TestSignupLoginMe(t *testing.T) {
+	h := newHarness(t)
+
+	w := h.signup("asha@"+collegeDomain, "correct-horse")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("signup: status %d body %s", w.Code, w.Body.String())
+	}
+
+	kcUser, ok := h.kc.UserByEmail("asha@" + collegeDomain)
+	if !ok {
+		t.Fatal("signup did not create a keycloak user")
+	}
+	if !slices.Contains(kcUser.Roles, "student") {
+		t.Fatalf("keycloak roles = %v, want student", kcUser.Roles)
+	}
+
+	var user models.User
+	if err := h.db.Where("email = ?", "asha@"+collegeDomain).First(&user).Error; err != nil {
+		t.Fatalf("portal user not created: %v", err)
+	}
+	if user.KeycloakID != kcUser.ID || user.Role != string(models.Student) {
+		t.Fatalf("portal user = %+v, want keycloak id %s and role student", user, kcUser.ID)
+	}
+
+	session := h.login("asha@"+collegeDomain, "correct-horse")
+
+	w = h.do(http.MethodGet, "/api/auth/me", nil, session)
+	if w.Code != http.StatusOK {
+		t.Fatalf("me: status %d body %s", w.Code, w.Body.String())
+	}
+	me := decode(t, w)
+	if me["email"] != "asha@"+collegeDomain || me["role"] != string(models.Student) {
+		t.Fatalf("me = %v", me)
+	}
+}
+
+func TestSignupRejections(t *testing.T) {
+	h := newHarness(t)
+
+	if w := h.signup("asha@gmail.com", "correct-horse"); w.Code != http.StatusBadRequest {
+		t.Fatalf("foreign domain: status %d, want 400", w.Code)
+	}
+
+	if w := h.signup("asha@"+collegeDomain, "short"); w.Code != http.StatusBadRequest {
+		t.Fatalf("short password: status %d, want 400", w.Code)
+	}
+
+	if w := h.signup("asha@"+collegeDomain, "correct-horse"); w.Code != http.StatusCreated {
+		t.Fatalf("first signup: status %d", w.Code)
+	}
+	if w := h.signup("asha@"+collegeDomain, "correct-horse"); w.Code != http.StatusBadRequest {
+		t.Fatalf("duplicate signup: status %d, want 400", w.Code)
+	}
+
+	if n := h.kc.UserCount(); n != 1 {
+		t.Fatalf("keycloak users = %d, want 1", n)
+	}
+}
+
+func TestSignupRollsBackKeycloakUserOnRoleFailure(t *testing.T) {
+	h := newHarness(t)
+	h.kc.FailRoleAssignment(true)
+
+	w := h.signup("asha@"+collegeDomain, "correct-horse")
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("signup: status %d, want 500", w.Code)
+	}
+
+	if _, ok := h.kc.UserByEmail("asha@" + collegeDomain); ok {
+		t.Fatal("keycloak user left behind after failed signup")
+	}
+
+	var count int64
+	h.db.Model(&models.User{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("portal users = %d, want 0", count)
+	}
+}
+
+func TestLoginFailures(t *testing.T) {
+	h := newHarness(t)
+	h.seedUser("asha@"+collegeDomain, "correct-horse", models.Student)
+
+	w := h.do(http.MethodPost, "/api/auth/login", map[string]string{
+		"email":    "asha@" + collegeDomain,
+		"password": "wrong",
+	})
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("wrong password: status %d, want 401", w.Code)
+	}
+
+	// exists in keycloak but was never registered in the portal
+	h.kc.AddUser("ghost@"+collegeDomain, "correct-horse", "Ghost", "student")
+	w = h.do(http.MethodPost, "/api/auth/login", map[string]string{
+		"email":    "ghost@" + collegeDomain,
+		"password": "correct-horse",
+	})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("unregistered user: status %d, want 403", w.Code)
+	}
+}
+
+func TestProtectedRoutesAndRoles(t *testing.T) {
+	h := newHarness(t)
+	h.seedUser("student@"+collegeDomain, "correct-horse", models.Student)
+	h.seedUser("tpo@"+collegeDomain, "correct-horse", models.CollegeAdmin)
+
+	// no session
+	if w := h.do(http.MethodPost, "/api/jobs", map[string]string{}); w.Code != http.StatusUnauthorized {
+		t.Fatalf("anonymous: status %d, want 401", w.Code)
+	}
+
+	// forged session
+	forged := &http.Cookie{Name: "portal_token", Value: "not-a-jwt"}
+	if w := h.do(http.MethodPost, "/api/jobs", map[string]string{}, forged); w.Code != http.StatusUnauthorized {
+		t.Fatalf("forged cookie: status %d, want 401", w.Code)
+	}
+
+	student := h.login("student@"+collegeDomain, "correct-horse")
+	if w := h.do(http.MethodPost, "/api/jobs", map[string]string{}, student); w.Code != http.StatusForbidden {
+		t.Fatalf("student creating job: status %d, want 403", w.Code)
+	}
+
+	// a college admin gets past the role check to request validation
+	admin := h.login("tpo@"+collegeDomain, "correct-horse")
+	if w := h.do(http.MethodPost, "/api/jobs", map[string]string{}, admin); w.Code != http.StatusBadRequest {
+		t.Fatalf("college admin creating empty job: status %d, want 400", w.Code)
+	}
+
+	// and is kept off student-only routes
+	if w := h.do(http.MethodGet, "/api/jobs/bookmarked", nil, admin); w.Code != http.StatusForbidden {
+		t.Fatalf("college admin on student route: status %d, want 403", w.Code)
+	}
+}
+
+func TestSSOCallback(t *testing.T) {
+	h := newHarness(t)
+	user := h.seedUser("asha@"+collegeDomain, "correct-horse", models.Student)
+
+	w := h.do(http.MethodGet, "/api/auth/sso/login", nil)
+	if w.Code != http.StatusFound {
+		t.Fatalf("sso login: status %d, want 302", w.Code)
+	}
+	loc, _ := url.Parse(w.Header().Get("Location"))
+	if loc.Query().Get("redirect_uri") != h.cfg.BackendBaseURL+"/api/auth/sso/callback" {
+		t.Fatalf("sso login redirect = %s", loc)
+	}
+
+	code := h.kc.IssueCode(user.KeycloakID)
+	w = h.do(http.MethodGet, "/api/auth/sso/callback?code="+code, nil)
+	if w.Code != http.StatusFound || w.Header().Get("Location") != h.cfg.FrontendURL {
+		t.Fatalf("sso callback: status %d location %q", w.Code, w.Header().Get("Location"))
+	}
+	session := sessionCookie(t, w)
+
+	if w := h.do(http.MethodGet, "/api/auth/me", nil, session); w.Code != http.StatusOK {
+		t.Fatalf("me after sso: status %d", w.Code)
+	}
+
+	// codes are single use
+	w = h.do(http.MethodGet, "/api/auth/sso/callback?code="+code, nil)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("replayed code: status %d, want 401", w.Code)
+	}
+}
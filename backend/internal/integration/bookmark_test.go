@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/jobs"
+	"iiitn-career-portal/internal/packages/notifications"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBookmarkReminders(t *testing.T) {
+	h := newHarness(t)
+
+	closing := h.seedJob(nil, time.Now())
+	h.db.Model(&closing).Update("registration_deadline", time.Now().Add(time.Hour))
+	open := h.seedJob(nil, time.Now())
+	h.db.Model(&open).Update("registration_deadline", time.Now().Add(72*time.Hour))
+	applied := h.seedJob(nil, time.Now())
+	h.db.Model(&applied).Update("registration_deadline", time.Now().Add(time.Hour))
+
+	app := h.applyAs("watcher@"+collegeDomain, applied.ID, models.StudentProfile{Batch: 2026})
+	session := h.login("watcher@"+collegeDomain, "password123")
+	for _, id := range []uint{closing.ID, open.ID, applied.ID} {
+		if w := h.do(http.MethodPost, fmt.Sprintf("/api/jobs/%d/bookmark", id), nil, session); w.Code != http.StatusOK {
+			t.Fatalf("bookmark: status %d: %s", w.Code, w.Body)
+		}
+	}
+
+	// two workers racing: the claim lets one reminder through
+	svc := jobs.NewService(jobs.NewGormRepository(h.db), notifications.New(h.db, nil))
+	for range 2 {
+		if err := svc.SendBookmarkReminders(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var reminders []models.Notification
+	h.db.Where("user_id = ? AND type = ?", app.StudentID, models.NotificationBookmarkReminder).Find(&reminders)
+	if len(reminders) != 1 || reminders[0].TargetID != closing.ID {
+		t.Fatalf("reminders = %+v, want one for job %d", reminders, closing.ID)
+	}
+}
+
+func TestBookmarkedJobsPaging(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("saver@"+collegeDomain, "password123", models.Student)
+	session := h.login("saver@"+collegeDomain, "password123")
+
+	var ids []uint
+	for range 4 {
+		job := h.seedJob(nil, time.Now())
+		if w := h.do(http.MethodPost, fmt.Sprintf("/api/jobs/%d/bookmark", job.ID), nil, session); w.Code != http.StatusOK {
+			t.Fatalf("bookmark: status %d: %s", w.Code, w.Body)
+		}
+		ids = append(ids, job.ID)
+	}
+	// a closed job drops out of the list
+	h.db.Model(&models.Job{}).Where("id = ?", ids[0]).Update("is_active", false)
+
+	got := h.walk("/api/jobs/bookmarked", url.Values{"limit": {"2"}}, session, nil)
+	if want := []uint{ids[3], ids[2], ids[1]}; !slices.Equal(got, want) {
+		t.Fatalf("keyset pages = %v, want %v", got, want)
+	}
+
+	w := h.do(http.MethodGet, "/api/jobs/bookmarked?page=2&limit=2", nil, session)
+	if w.Code != http.StatusOK {
+		t.Fatalf("offset page: status %d: %s", w.Code, w.Body)
+	}
+	body := decode(t, w)
+	data := body["data"].([]interface{})
+	meta := body["meta"].(map[string]interface{})
+	if len(data) != 1 || idOf(data[0]) != ids[1] || meta["total"] != float64(3) {
+		t.Fatalf("page 2 = %v, meta %v", data, meta)
+	}
+}
@@ -0,0 +1,289 @@
+package integration
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/database"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/applications"
+	"iiitn-career-portal/internal/packages/auth"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/packages/interviews"
+	"iiitn-career-portal/internal/packages/jobs"
+	"iiitn-career-portal/internal/packages/keycloak"
+	"iiitn-career-portal/internal/packages/notifications"
+	"iiitn-career-portal/internal/packages/profile"
+	"iiitn-career-portal/internal/packages/ratelimit"
+	"iiitn-career-portal/internal/packages/recruiters"
+	"iiitn-career-portal/internal/packages/webhooks"
+	"iiitn-career-portal/internal/testutil/fakekeycloak"
+	"iiitn-career-portal/internal/testutil/pgtest"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	sqlitedriver "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const collegeDomain = "iiitn.ac.in"
+
+func init() {
+	// audit.RecordAs serializes each chain with a Postgres advisory lock;
+	// SQLite connections here are already serialized, so it is a no-op.
+	sqlitedriver.MustRegisterScalarFunction("pg_advisory_xact_lock", 1,
+		func(*sqlitedriver.FunctionContext, []driver.Value) (driver.Value, error) {
+			return nil, nil
+		})
+}
+
+type harness struct {
+	t      *testing.T
+	kc     *fakekeycloak.Server
+	db     *gorm.DB
+	cfg    config.Config
+	router *gin.Engine
+
+	college models.College
+}
+
+// newHarness wires the real auth, profile, jobs, applications,
+// interviews, notifications, recruiters and webhooks routes against a fake
+// Keycloak and a database: by default an in-memory SQLite holding the
+// tables they touch, with TEST_DATABASE_URL a fresh Postgres schema built
+// by the migrations production runs.
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	kcServer := fakekeycloak.Start(t)
+
+	var db *gorm.DB
+	if pgtest.Enabled() {
+		db = pgtest.Open(t)
+		if _, err := database.MigrateUp(db, 0); err != nil {
+			t.Fatalf("migrate: %v", err)
+		}
+	} else {
+		db = openSQLite(t)
+	}
+
+	college := models.College{Name: "IIIT Nagpur", Domain: collegeDomain}
+	if err := db.Create(&college).Error; err != nil {
+		t.Fatalf("seed college: %v", err)
+	}
+
+	cfg := config.Config{
+		JWTSecret:      "integration-secret",
+		BaseURL:        kcServer.URL,
+		Realm:          fakekeycloak.Realm,
+		ClientID:       fakekeycloak.ClientID,
+		ClientSecret:   fakekeycloak.ClientSecret,
+		FrontendURL:    "http://frontend.test",
+		BackendBaseURL: "http://backend.test",
+		// receivers in tests are httptest servers on loopback
+		Webhooks: config.WebhookConfig{Timeout: 5 * time.Second, MaxAttempts: 3, AllowPrivate: true},
+	}
+
+	kc := keycloak.New(cfg, keycloak.WithHTTPClient(kcServer.Client()))
+	limiter := ratelimit.New(nil)
+
+	router := gin.New()
+	api := router.Group("/api")
+	auth.RegisterRoutes(api, cfg, db, kc, limiter)
+	recruiters.RegisterPublicRoutes(api, db, cfg, kc, limiter)
+	protected := api.Group("/")
+	protected.Use(authorization.RequireAuth(cfg))
+	jobs.RegisterRoutes(protected, db, nil, cfg, limiter)
+	applications.RegisterRoutes(protected, db, nil)
+	profile.RegisterRoutes(protected, db, cfg)
+	notifications.RegisterRoutes(protected, db, nil)
+	recruiters.RegisterRoutes(protected, db, cfg, kc)
+	webhooks.RegisterRoutes(protected, db, cfg)
+	interviews.RegisterRoutes(protected, db, nil, cfg)
+
+	return &harness{
+		t:       t,
+		kc:      kcServer,
+		db:      db,
+		cfg:     cfg,
+		router:  router,
+		college: college,
+	}
+}
+
+// openSQLite opens an in-memory database named after the test, with the
+// tables the harness's routes touch.
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(
+		&models.College{},
+		&models.User{},
+		&models.StudentProfile{},
+		&models.StudentProject{},
+		&models.StudentInternship{},
+		&models.StudentCertification{},
+		&models.StudentResume{},
+		&models.Job{},
+		&models.JobCollege{},
+		&models.JobBookmark{},
+		&models.SavedSearch{},
+		&models.PendingJobAlert{},
+		&models.SavedSearchMatch{},
+		&models.ApplicationIntent{},
+		&models.JobIntentStat{},
+		&models.Application{},
+		&models.RegistrationReconciliation{},
+		&models.InterviewSlot{},
+		&models.Notification{},
+		&models.AuditLog{},
+		&models.Company{},
+		&models.RecruiterJob{},
+		&models.RecruiterInvite{},
+		&models.WebhookEndpoint{},
+		&models.WebhookDelivery{},
+	); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return db
+}
+
+// do sends a request through the router; cookies are attached as given.
+func (h *harness) do(method, path string, body interface{}, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	h.t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			h.t.Fatalf("encode body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &buf)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+
+	w := httptest.NewRecorder()
+	h.router.ServeHTTP(w, req)
+	return w
+}
+
+// upload posts content as the multipart file field.
+func (h *harness) upload(path, field, fileName, content string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	h.t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile(field, fileName)
+	if err != nil {
+		h.t.Fatalf("create form file: %v", err)
+	}
+	if _, err := part.Write([]byte(content)); err != nil {
+		h.t.Fatalf("write form file: %v", err)
+	}
+	if err := mw.Close(); err != nil {
+		h.t.Fatalf("close multipart: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+
+	w := httptest.NewRecorder()
+	h.router.ServeHTTP(w, req)
+	return w
+}
+
+func (h *harness) signup(email, password string) *httptest.ResponseRecorder {
+	return h.do(http.MethodPost, "/api/auth/signup", gin.H{
+		"email":      email,
+		"password":   password,
+		"name":       "Test Student",
+		"college_id": h.college.ID,
+	})
+}
+
+// login returns the portal session cookie, failing the test if login does.
+func (h *harness) login(email, password string) *http.Cookie {
+	h.t.Helper()
+
+	w := h.do(http.MethodPost, "/api/auth/login", gin.H{
+		"email":    email,
+		"password": password,
+	})
+	if w.Code != http.StatusOK {
+		h.t.Fatalf("login %s: status %d body %s", email, w.Code, w.Body.String())
+	}
+
+	return sessionCookie(h.t, w)
+}
+
+// seedUser provisions a user in both Keycloak and the portal DB, the way
+// admins are created outside signup.
+func (h *harness) seedUser(email, password string, role models.Role) models.User {
+	h.t.Helper()
+
+	kcUser := h.kc.AddUser(email, password, "Seeded", string(role))
+	collegeID := h.college.ID // a copy: updates to the user must not move h.college
+	user := models.User{
+		KeycloakID: kcUser.ID,
+		Email:      email,
+		Name:       "Seeded",
+		CollegeID:  &collegeID,
+		Role:       string(role),
+	}
+	if err := h.db.Create(&user).Error; err != nil {
+		h.t.Fatalf("seed user: %v", err)
+	}
+	return user
+}
+
+func sessionCookie(t *testing.T, w *httptest.ResponseRecorder) *http.Cookie {
+	t.Helper()
+
+	for _, c := range w.Result().Cookies() {
+		if c.Name == "portal_token" && c.Value != "" {
+			return c
+		}
+	}
+	t.Fatalf("no portal_token cookie in response")
+	return nil
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+	return out
+}
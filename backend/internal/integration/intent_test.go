@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/applications"
+	"iiitn-career-portal/internal/packages/notifications"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIntentLifecycle(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+
+	job := h.seedJob(nil, time.Now())
+	h.applyAs("done@"+collegeDomain, job.ID, models.StudentProfile{Batch: 2026})
+
+	student := h.seedUser("pending@"+collegeDomain, "password123", models.Student)
+	h.db.Create(&models.StudentProfile{UserID: student.ID, Batch: 2026, ProfileComplete: true})
+	session := h.login("pending@"+collegeDomain, "password123")
+	if w := h.do(http.MethodPost, fmt.Sprintf("/api/jobs/%d/apply", job.ID), nil, session); w.Code != http.StatusOK {
+		t.Fatalf("apply: status %d: %s", w.Code, w.Body)
+	}
+
+	// the intent can be found again
+	w := h.do(http.MethodGet, "/api/applications/intents", nil, session)
+	data, _ := decode(t, w)["data"].([]interface{})
+	if w.Code != http.StatusOK || len(data) != 1 {
+		t.Fatalf("intents: status %d: %s", w.Code, w.Body)
+	}
+	intent := data[0].(map[string]interface{})
+	if idOf(intent) == 0 || intent["confirm_url"] != fmt.Sprintf("/api/applications/%d/confirm", idOf(intent)) {
+		t.Fatalf("intent = %v", intent)
+	}
+
+	// close to expiry: reminded once
+	h.db.Model(&models.ApplicationIntent{}).Where("id = ?", idOf(intent)).Update("expires_at", time.Now().Add(time.Hour))
+	svc := applications.NewService(applications.NewGormRepository(h.db), notifications.New(h.db, nil))
+	for range 2 {
+		if err := svc.SweepIntents(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var reminders int64
+	h.db.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ?", student.ID, models.NotificationIntentExpiring).
+		Count(&reminders)
+	if reminders != 1 {
+		t.Fatalf("%d reminders, want 1", reminders)
+	}
+
+	// expired: swept and counted
+	h.db.Model(&models.ApplicationIntent{}).Where("id = ?", idOf(intent)).Update("expires_at", time.Now().Add(-time.Minute))
+	if err := svc.SweepIntents(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var left int64
+	h.db.Model(&models.ApplicationIntent{}).Count(&left)
+	if left != 0 {
+		t.Fatalf("%d intents left after the sweep", left)
+	}
+
+	w = h.do(http.MethodGet, fmt.Sprintf("/api/applications/intents/metrics?job_id=%d", job.ID), nil, admin)
+	data, _ = decode(t, w)["data"].([]interface{})
+	if w.Code != http.StatusOK || len(data) != 1 {
+		t.Fatalf("metrics: status %d: %s", w.Code, w.Body)
+	}
+	m := data[0].(map[string]interface{})
+	if m["confirmed"] != float64(1) || m["expired"] != float64(1) || m["pending"] != float64(0) || m["conversion_rate"] != 0.5 {
+		t.Fatalf("metrics = %v", m)
+	}
+}
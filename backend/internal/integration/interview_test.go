@@ -0,0 +1,194 @@
+package integration
+
+import (
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestInterviewSlotAssignment(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+
+	job := h.seedJob(nil, time.Now())
+	first := h.applyAs("first@"+collegeDomain, job.ID, models.StudentProfile{Batch: 2026})
+	second := h.applyAs("second@"+collegeDomain, job.ID, models.StudentProfile{Batch: 2026})
+	h.db.Model(&models.Application{}).Where("job_id = ?", job.ID).Update("status", models.Interview)
+
+	slotsPath := fmt.Sprintf("/api/interviews/jobs/%d/slots", job.ID)
+	venue := "Room 101"
+	for name, slot := range map[string]gin.H{
+		"past":          {"starts_at": time.Now().Add(-time.Hour), "duration_minutes": 30, "venue": venue},
+		"zero duration": {"starts_at": time.Now().Add(time.Hour), "duration_minutes": 0, "venue": venue},
+		"negative":      {"starts_at": time.Now().Add(time.Hour), "duration_minutes": -15, "venue": venue},
+	} {
+		if w := h.do(http.MethodPost, slotsPath, gin.H{"slots": []gin.H{slot}}, admin); w.Code != http.StatusBadRequest {
+			t.Errorf("%s slot: status %d, want 400: %s", name, w.Code, w.Body)
+		}
+	}
+
+	w := h.do(http.MethodPost, slotsPath, gin.H{"slots": []gin.H{
+		{"starts_at": time.Now().Add(time.Hour), "duration_minutes": 30, "venue": venue},
+	}}, admin)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create slot: status %d: %s", w.Code, w.Body)
+	}
+	slotID := uint(decode(t, w)["ids"].([]interface{})[0].(float64))
+	assignPath := fmt.Sprintf("/api/interviews/slots/%d/assign", slotID)
+
+	if w := h.do(http.MethodPut, assignPath, gin.H{"application_id": first.ID}, admin); w.Code != http.StatusOK {
+		t.Fatalf("assign: status %d: %s", w.Code, w.Body)
+	}
+	// reassigning the same applicant is a no-op, not a conflict
+	if w := h.do(http.MethodPut, assignPath, gin.H{"application_id": first.ID}, admin); w.Code != http.StatusOK {
+		t.Fatalf("reassign same: status %d: %s", w.Code, w.Body)
+	}
+	if w := h.do(http.MethodPut, assignPath, gin.H{"application_id": second.ID}, admin); w.Code != http.StatusConflict {
+		t.Fatalf("assign occupied: status %d, want 409: %s", w.Code, w.Body)
+	}
+
+	var slot models.InterviewSlot
+	h.db.First(&slot, slotID)
+	if slot.ApplicationID == nil || *slot.ApplicationID != first.ID {
+		t.Fatalf("slot application = %v, want %d kept", slot.ApplicationID, first.ID)
+	}
+
+	if w := h.do(http.MethodPut, assignPath, gin.H{"application_id": second.ID, "replace": true}, admin); w.Code != http.StatusOK {
+		t.Fatalf("replace: status %d: %s", w.Code, w.Body)
+	}
+	h.db.First(&slot, slotID)
+	if slot.ApplicationID == nil || *slot.ApplicationID != second.ID {
+		t.Fatalf("slot application = %v, want %d", slot.ApplicationID, second.ID)
+	}
+
+	var cancelled int64
+	h.db.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ? AND target_id = ?", first.StudentID, models.NotificationInterviewCancelled, slotID).
+		Count(&cancelled)
+	if cancelled != 1 {
+		t.Fatalf("displaced student notifications = %d, want 1", cancelled)
+	}
+
+	var audits int64
+	h.db.Model(&models.AuditLog{}).
+		Where("action = ? AND entity_type = ? AND entity_id = ?", "interview.slot_reassign", "interview_slot", slotID).
+		Count(&audits)
+	if audits != 1 {
+		t.Fatalf("audit entries = %d, want 1", audits)
+	}
+}
+
+func TestPooledInterviewSlots(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	host := h.login("admin@"+collegeDomain, "password123")
+	partner, partnerAdmin, partnerStudent := h.seedPartner(2026)
+
+	w := h.do(http.MethodPost, "/api/jobs", gin.H{
+		"company":               "Acme",
+		"title":                 "SDE",
+		"job_type":              models.JobFTE,
+		"domain":                models.DomainSDE,
+		"eligible_batches":      []int{2026},
+		"ctc":                   12,
+		"registration_form_url": "https://forms.test/acme",
+		"pool": []gin.H{
+			{"college_id": partner.ID, "eligible_batches": []int{2026}},
+		},
+	}, host)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", w.Code, w.Body)
+	}
+	jobID := idOf(decode(t, w))
+
+	hostApp := h.applyAs("asha@"+collegeDomain, jobID, models.StudentProfile{Batch: 2026})
+	if w := h.do(http.MethodPost, fmt.Sprintf("/api/jobs/%d/apply", jobID), nil, partnerStudent); w.Code != http.StatusOK {
+		t.Fatalf("partner apply: status %d: %s", w.Code, w.Body)
+	}
+	var intent models.ApplicationIntent
+	h.db.Where("job_id = ? AND college_id = ?", jobID, partner.ID).First(&intent)
+	if w := h.do(http.MethodPost, fmt.Sprintf("/api/applications/%d/confirm", intent.ID), nil, partnerStudent); w.Code != http.StatusOK {
+		t.Fatalf("partner confirm: status %d: %s", w.Code, w.Body)
+	}
+	var partnerApp models.Application
+	h.db.Where("job_id = ? AND college_id = ?", jobID, partner.ID).First(&partnerApp)
+	h.db.Model(&models.Application{}).Where("job_id = ?", jobID).Update("status", models.Interview)
+
+	// both colleges add to the drive's shared slots
+	slotsPath := fmt.Sprintf("/api/interviews/jobs/%d/slots", jobID)
+	createSlot := func(session *http.Cookie, in time.Duration) uint {
+		w := h.do(http.MethodPost, slotsPath, gin.H{"slots": []gin.H{
+			{"starts_at": time.Now().Add(in), "duration_minutes": 30, "venue": "Room 101"},
+		}}, session)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("create slot: status %d: %s", w.Code, w.Body)
+		}
+		return uint(decode(t, w)["ids"].([]interface{})[0].(float64))
+	}
+	hostSlot := createSlot(host, time.Hour)
+	partnerSlot := createSlot(partnerAdmin, 2*time.Hour)
+
+	// auto-assign only schedules the partner's own applicant
+	w = h.do(http.MethodPost, slotsPath+"/auto-assign", nil, partnerAdmin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("auto-assign: status %d: %s", w.Code, w.Body)
+	}
+	if body := decode(t, w); body["assigned_count"] != float64(1) || body["unassigned_count"] != float64(0) {
+		t.Fatalf("auto-assign = %v, want 1 assigned, 0 left", body)
+	}
+	var taken models.InterviewSlot
+	h.db.First(&taken, hostSlot)
+	if taken.ApplicationID == nil || *taken.ApplicationID != partnerApp.ID {
+		t.Fatalf("earliest slot holds %v, want partner application %d", taken.ApplicationID, partnerApp.ID)
+	}
+
+	// the host neither sees nor displaces the partner's student
+	w = h.do(http.MethodGet, slotsPath, nil, host)
+	if w.Code != http.StatusOK {
+		t.Fatalf("host list: status %d: %s", w.Code, w.Body)
+	}
+	listed := decode(t, w)["data"].([]interface{})
+	if len(listed) != 1 || idOf(listed[0]) != partnerSlot {
+		t.Fatalf("host lists %v, want only the free slot %d", listed, partnerSlot)
+	}
+	w = h.do(http.MethodPut, fmt.Sprintf("/api/interviews/slots/%d/assign", hostSlot), gin.H{"application_id": hostApp.ID, "replace": true}, host)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("host replacing partner student: status %d, want 409: %s", w.Code, w.Body)
+	}
+	w = h.do(http.MethodPut, fmt.Sprintf("/api/interviews/slots/%d/assign", hostSlot), gin.H{"application_id": nil}, host)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("host unassigning partner student: status %d, want 409: %s", w.Code, w.Body)
+	}
+
+	// nor can the partner schedule the host's applicant
+	w = h.do(http.MethodPut, fmt.Sprintf("/api/interviews/slots/%d/assign", partnerSlot), gin.H{"application_id": hostApp.ID}, partnerAdmin)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("partner assigning host student: status %d, want 400: %s", w.Code, w.Body)
+	}
+	w = h.do(http.MethodPut, fmt.Sprintf("/api/interviews/slots/%d/assign", partnerSlot), gin.H{"application_id": hostApp.ID}, host)
+	if w.Code != http.StatusOK {
+		t.Fatalf("host assigning own student: status %d: %s", w.Code, w.Body)
+	}
+
+	// the partner's listing shows its own student, not the host's
+	w = h.do(http.MethodGet, slotsPath, nil, partnerAdmin)
+	listed = decode(t, w)["data"].([]interface{})
+	if len(listed) != 1 || idOf(listed[0]) != hostSlot {
+		t.Fatalf("partner lists %v, want only slot %d", listed, hostSlot)
+	}
+	// a college that hides the drive from its students is out of it
+	w = h.do(http.MethodPatch, fmt.Sprintf("/api/jobs/%d/pool/%d", jobID, partner.ID), gin.H{"is_visible": false}, partnerAdmin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("hide drive: status %d: %s", w.Code, w.Body)
+	}
+	if w := h.do(http.MethodGet, slotsPath, nil, partnerAdmin); w.Code != http.StatusNotFound {
+		t.Fatalf("hidden drive slots: status %d, want 404: %s", w.Code, w.Body)
+	}
+}
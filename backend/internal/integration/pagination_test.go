@@ -0,0 +1,218 @@
+package integration
+
+import (
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var base = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func ctc(v float64) *float64 { return &v }
+
+func (h *harness) seedJob(ctc *float64, createdAt time.Time) models.Job {
+	h.t.Helper()
+
+	job := models.Job{
+		CollegeID:       h.college.ID,
+		Title:           "SDE",
+		Company:         "Acme",
+		JobType:         models.JobIntern,
+		Domain:          models.DomainSDE,
+		EligibleBatches: []byte("[2026]"),
+		CTC:             ctc,
+		IsActive:        true,
+		CreatedAt:       createdAt,
+	}
+	if err := h.db.Create(&job).Error; err != nil {
+		h.t.Fatalf("seed job: %v", err)
+	}
+	return job
+}
+
+// idOf reads the id of a list item; models.Application is serialized
+// without json tags.
+func idOf(item interface{}) uint {
+	m := item.(map[string]interface{})
+	if id, ok := m["id"].(float64); ok {
+		return uint(id)
+	}
+	return uint(m["ID"].(float64))
+}
+
+// walk follows next_cursor from the first keyset page and returns the ids
+// in order; between runs after every page but the last.
+func (h *harness) walk(path string, query url.Values, session *http.Cookie, between func(page int)) []uint {
+	h.t.Helper()
+
+	var ids []uint
+	query.Set("cursor", "")
+	for page := 1; ; page++ {
+		w := h.do(http.MethodGet, path+"?"+query.Encode(), nil, session)
+		if w.Code != http.StatusOK {
+			h.t.Fatalf("page %d: status %d: %s", page, w.Code, w.Body)
+		}
+		body := decode(h.t, w)
+
+		for _, item := range body["data"].([]interface{}) {
+			ids = append(ids, idOf(item))
+		}
+
+		meta := body["meta"].(map[string]interface{})
+		if _, ok := meta["total"]; ok {
+			h.t.Fatalf("keyset page %d counted the total without count=true", page)
+		}
+		next, _ := meta["next_cursor"].(string)
+		if next == "" {
+			return ids
+		}
+		if page > 50 {
+			h.t.Fatal("cursor never ends")
+		}
+		if between != nil {
+			between(page)
+		}
+		query.Set("cursor", next)
+	}
+}
+
+func TestJobKeysetPagination(t *testing.T) {
+	h := newHarness(t)
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	session := h.login("admin@"+collegeDomain, "password123")
+
+	// ties on ctc and on created_at, plus NULLs
+	a := h.seedJob(ctc(10), base)
+	b := h.seedJob(nil, base.Add(time.Minute))
+	c := h.seedJob(ctc(20), base.Add(time.Minute))
+	d := h.seedJob(ctc(10), base.Add(2*time.Minute))
+	e := h.seedJob(nil, base.Add(3*time.Minute))
+	f := h.seedJob(ctc(5), base.Add(3*time.Minute))
+
+	cases := map[string][]uint{
+		"ctc_desc": {c.ID, d.ID, a.ID, f.ID, e.ID, b.ID},
+		"ctc_asc":  {f.ID, a.ID, d.ID, c.ID, b.ID, e.ID},
+		"latest":   {f.ID, e.ID, d.ID, c.ID, b.ID, a.ID},
+	}
+	for sort, want := range cases {
+		t.Run(sort, func(t *testing.T) {
+			got := h.walk("/api/jobs", url.Values{"sort": {sort}, "limit": {"2"}}, session, nil)
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Fatalf("keyset order = %v, want %v", got, want)
+			}
+
+			// offset mode returns the same order
+			w := h.do(http.MethodGet, "/api/jobs?limit=50&sort="+sort, nil, session)
+			var offset []uint
+			for _, item := range decode(t, w)["data"].([]interface{}) {
+				offset = append(offset, idOf(item))
+			}
+			if fmt.Sprint(offset) != fmt.Sprint(want) {
+				t.Fatalf("offset order = %v, want %v", offset, want)
+			}
+		})
+	}
+
+	t.Run("inserts while paging", func(t *testing.T) {
+		got := h.walk("/api/jobs", url.Values{"limit": {"2"}}, session, func(page int) {
+			h.seedJob(ctc(99), base.Add(time.Hour*time.Duration(page)))
+		})
+		want := cases["latest"]
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("pages shifted: %v, want %v", got, want)
+		}
+	})
+
+	t.Run("count on request", func(t *testing.T) {
+		body := decode(t, h.do(http.MethodGet, "/api/jobs?cursor=&count=true", nil, session))
+		if _, ok := body["meta"].(map[string]interface{})["total"]; !ok {
+			t.Fatal("count=true did not include the total")
+		}
+		body = decode(t, h.do(http.MethodGet, "/api/jobs?count=false", nil, session))
+		if _, ok := body["meta"].(map[string]interface{})["total"]; ok {
+			t.Fatal("count=false still counted")
+		}
+	})
+
+	t.Run("cursor from another sort", func(t *testing.T) {
+		first := decode(t, h.do(http.MethodGet, "/api/jobs?cursor=&limit=1&sort=latest", nil, session))
+		next := first["meta"].(map[string]interface{})["next_cursor"].(string)
+
+		w := h.do(http.MethodGet, "/api/jobs?sort=ctc_asc&cursor="+url.QueryEscape(next), nil, session)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want 400", w.Code)
+		}
+	})
+}
+
+func TestApplicationKeysetPagination(t *testing.T) {
+	h := newHarness(t)
+	admin := h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	session := h.login("admin@"+collegeDomain, "password123")
+
+	statuses := []models.ApplicationStatus{
+		models.Shortlisted, models.Applied, models.Rejected, models.Applied, models.Shortlisted,
+	}
+	for i, status := range statuses {
+		job := h.seedJob(ctc(10), base)
+		app := models.Application{
+			JobID:             job.ID,
+			StudentID:         admin.ID,
+			CollegeID:         h.college.ID,
+			Status:            status,
+			ResumeSnapshotURL: "https://files.test/resume.pdf",
+			CreatedAt:         base.Add(time.Duration(i%2) * time.Minute),
+		}
+		if err := h.db.Create(&app).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := h.walk("/api/applications", url.Values{"sort_by": {"status"}, "sort_dir": {"asc"}, "limit": {"2"}}, session, nil)
+	want := []uint{2, 4, 3, 1, 5} // APPLIED, REJECTED, SHORTLISTED; ties by id
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("by status = %v, want %v", got, want)
+	}
+
+	got = h.walk("/api/applications", url.Values{"limit": {"3"}}, session, nil)
+	want = []uint{4, 2, 5, 3, 1} // created_at desc, ties by id desc
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("by created_at = %v, want %v", got, want)
+	}
+}
+
+func TestNotificationList(t *testing.T) {
+	h := newHarness(t)
+	h.signup("student@"+collegeDomain, "password123")
+	session := h.login("student@"+collegeDomain, "password123")
+
+	var user models.User
+	h.db.Where("email = ?", "student@"+collegeDomain).First(&user)
+
+	for i := 0; i < 5; i++ {
+		n := models.Notification{
+			UserID:    user.ID,
+			Type:      models.NotificationType("TEST"),
+			TargetID:  uint(i),
+			Payload:   []byte(`{}`),
+			IsRead:    i%2 == 0,
+			CreatedAt: base.Add(time.Duration(i/2) * time.Minute),
+		}
+		if err := h.db.Create(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := h.walk("/api/notifications", url.Values{"limit": {"2"}}, session, nil)
+	if fmt.Sprint(got) != fmt.Sprint([]uint{5, 4, 3, 2, 1}) {
+		t.Fatalf("order = %v", got)
+	}
+
+	got = h.walk("/api/notifications", url.Values{"unread": {"true"}}, session, nil)
+	if fmt.Sprint(got) != fmt.Sprint([]uint{4, 2}) {
+		t.Fatalf("unread = %v", got)
+	}
+}
@@ -0,0 +1,258 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// seedPartner adds a second college with an admin and a student of the
+// given batch, returning their sessions.
+func (h *harness) seedPartner(batch int) (models.College, *http.Cookie, *http.Cookie) {
+	h.t.Helper()
+
+	partner := models.College{Name: "Partner College", Domain: "partner.test"}
+	if err := h.db.Create(&partner).Error; err != nil {
+		h.t.Fatalf("seed college: %v", err)
+	}
+
+	for _, u := range []struct {
+		email string
+		role  models.Role
+	}{
+		{"admin@partner.test", models.CollegeAdmin},
+		{"student@partner.test", models.Student},
+	} {
+		user := h.seedUser(u.email, "password123", u.role)
+		if err := h.db.Model(&user).Update("college_id", partner.ID).Error; err != nil {
+			h.t.Fatal(err)
+		}
+		if u.role == models.Student {
+			h.db.Create(&models.StudentProfile{UserID: user.ID, Batch: batch, ProfileComplete: true})
+		}
+	}
+
+	return partner,
+		h.login("admin@partner.test", "password123"),
+		h.login("student@partner.test", "password123")
+}
+
+func (h *harness) listedJobIDs(session *http.Cookie) []uint {
+	h.t.Helper()
+
+	w := h.do(http.MethodGet, "/api/jobs", nil, session)
+	if w.Code != http.StatusOK {
+		h.t.Fatalf("list jobs: status %d: %s", w.Code, w.Body)
+	}
+	var ids []uint
+	for _, item := range decode(h.t, w)["data"].([]interface{}) {
+		ids = append(ids, idOf(item))
+	}
+	return ids
+}
+
+func TestPooledDrive(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	host := h.login("admin@"+collegeDomain, "password123")
+	partner, partnerAdmin, partnerStudent := h.seedPartner(2027)
+
+	w := h.do(http.MethodPost, "/api/jobs", gin.H{
+		"company":               "Acme",
+		"title":                 "SDE",
+		"job_type":              models.JobFTE,
+		"domain":                models.DomainSDE,
+		"eligible_batches":      []int{2026},
+		"ctc":                   12,
+		"registration_form_url": "https://forms.test/acme",
+		"pool": []gin.H{
+			{"college_id": partner.ID, "eligible_batches": []int{2027}},
+		},
+	}, host)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", w.Code, w.Body)
+	}
+	jobID := idOf(decode(t, w))
+
+	if got := h.listedJobIDs(partnerStudent); fmt.Sprint(got) != fmt.Sprint([]uint{jobID}) {
+		t.Fatalf("partner student lists %v, want the pooled job", got)
+	}
+
+	w = h.do(http.MethodGet, fmt.Sprintf("/api/jobs/%d", jobID), nil, partnerStudent)
+	detail := decode(t, w)
+	if fmt.Sprint(detail["eligible_batches"]) != "[2027]" || uint(detail["host_college_id"].(float64)) != h.college.ID {
+		t.Fatalf("partner detail = %v", detail)
+	}
+
+	// apply and confirm as a partner student
+	w = h.do(http.MethodPost, fmt.Sprintf("/api/jobs/%d/apply", jobID), nil, partnerStudent)
+	if w.Code != http.StatusOK {
+		t.Fatalf("apply: status %d: %s", w.Code, w.Body)
+	}
+	var intent models.ApplicationIntent
+	if err := h.db.Where("job_id = ?", jobID).First(&intent).Error; err != nil {
+		t.Fatal(err)
+	}
+	w = h.do(http.MethodPost, fmt.Sprintf("/api/applications/%d/confirm", intent.ID), nil, partnerStudent)
+	if w.Code != http.StatusOK {
+		t.Fatalf("confirm: status %d: %s", w.Code, w.Body)
+	}
+	var app models.Application
+	h.db.Where("job_id = ?", jobID).First(&app)
+	if app.CollegeID != partner.ID {
+		t.Fatalf("application college = %d, want the student's %d", app.CollegeID, partner.ID)
+	}
+
+	// each admin sees only their own students' applications
+	count := func(session *http.Cookie) int {
+		w := h.do(http.MethodGet, "/api/applications", nil, session)
+		if w.Code != http.StatusOK {
+			t.Fatalf("list applications: status %d: %s", w.Code, w.Body)
+		}
+		return len(decode(t, w)["data"].([]interface{}))
+	}
+	if host, partner := count(host), count(partnerAdmin); host != 0 || partner != 1 {
+		t.Fatalf("applications seen: host %d, partner %d; want 0 and 1", host, partner)
+	}
+
+	bulk := gin.H{"application_ids": []uint{app.ID}, "new_status": models.Shortlisted}
+	if w := h.do(http.MethodPatch, "/api/applications/status/bulk", bulk, host); w.Code != http.StatusForbidden {
+		t.Fatalf("host bulk update: status %d, want 403", w.Code)
+	}
+	if w := h.do(http.MethodPatch, "/api/applications/status/bulk", bulk, partnerAdmin); w.Code != http.StatusOK {
+		t.Fatalf("partner bulk update: status %d: %s", w.Code, w.Body)
+	}
+
+	// only the host manages the pool; the partner hides the drive
+	if w := h.do(http.MethodPut, fmt.Sprintf("/api/jobs/%d/pool", jobID), gin.H{"colleges": []gin.H{}}, partnerAdmin); w.Code != http.StatusForbidden {
+		t.Fatalf("partner replacing the pool: status %d, want 403", w.Code)
+	}
+	w = h.do(http.MethodPatch, fmt.Sprintf("/api/jobs/%d/pool/%d", jobID, partner.ID), gin.H{"is_visible": false}, partnerAdmin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("hide: status %d: %s", w.Code, w.Body)
+	}
+	if got := h.listedJobIDs(partnerStudent); len(got) != 0 {
+		t.Fatalf("hidden drive still listed: %v", got)
+	}
+	if w := h.do(http.MethodGet, fmt.Sprintf("/api/jobs/%d", jobID), nil, partnerStudent); w.Code != http.StatusNotFound {
+		t.Fatalf("hidden drive detail: status %d, want 404", w.Code)
+	}
+}
+
+// Job events reach every college a drive is pooled with, each with its
+// own eligible batches, and colleges joining or leaving the pool hear of
+// it as a created or deleted job.
+func TestPooledDriveWebhooks(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	host := h.login("admin@"+collegeDomain, "password123")
+	partner, _, _ := h.seedPartner(2027)
+	third := models.College{Name: "Third College", Domain: "third.test"}
+	if err := h.db.Create(&third).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	endpoints := map[uint]uint{}
+	for _, collegeID := range []uint{h.college.ID, partner.ID, third.ID} {
+		endpoint := models.WebhookEndpoint{
+			CollegeID: collegeID,
+			URL:       "http://127.0.0.1:9/hooks",
+			Secret:    "whsec_test",
+			Events:    []byte(`["job.created","job.updated","job.deleted"]`),
+			IsActive:  true,
+			CreatedBy: 1,
+		}
+		if err := h.db.Create(&endpoint).Error; err != nil {
+			t.Fatal(err)
+		}
+		endpoints[endpoint.ID] = collegeID
+	}
+
+	// deliveries returns "college:event:batches" for every delivery
+	// queued since the last call
+	var lastID uint
+	deliveries := func() []string {
+		var rows []models.WebhookDelivery
+		h.db.Where("id > ?", lastID).Order("id").Find(&rows)
+		var got []string
+		for _, d := range rows {
+			var payload struct {
+				Data struct {
+					EligibleBatches []int `json:"eligible_batches"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(d.Payload, &payload); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, fmt.Sprintf("%d:%s:%v", endpoints[d.EndpointID], d.Event, payload.Data.EligibleBatches))
+			lastID = d.ID
+		}
+		slices.Sort(got)
+		return got
+	}
+	expect := func(step string, want ...string) {
+		t.Helper()
+		slices.Sort(want)
+		if got := deliveries(); !slices.Equal(got, want) {
+			t.Fatalf("%s: deliveries = %v, want %v", step, got, want)
+		}
+	}
+	on := func(collegeID uint, event models.WebhookEvent, batches string) string {
+		return fmt.Sprintf("%d:%s:%s", collegeID, event, batches)
+	}
+
+	w := h.do(http.MethodPost, "/api/jobs", gin.H{
+		"company":               "Acme",
+		"title":                 "SDE",
+		"job_type":              models.JobFTE,
+		"domain":                models.DomainSDE,
+		"eligible_batches":      []int{2026},
+		"ctc":                   12,
+		"registration_form_url": "https://forms.test/acme",
+		"pool": []gin.H{
+			{"college_id": partner.ID, "eligible_batches": []int{2027}},
+		},
+	}, host)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", w.Code, w.Body)
+	}
+	jobID := idOf(decode(t, w))
+	expect("create",
+		on(h.college.ID, models.WebhookJobCreated, "[2026]"),
+		on(partner.ID, models.WebhookJobCreated, "[2027]"),
+	)
+
+	if w := h.do(http.MethodPatch, fmt.Sprintf("/api/jobs/%d", jobID), gin.H{"title": "SDE II"}, host); w.Code != http.StatusOK {
+		t.Fatalf("update: status %d: %s", w.Code, w.Body)
+	}
+	expect("update",
+		on(h.college.ID, models.WebhookJobUpdated, "[2026]"),
+		on(partner.ID, models.WebhookJobUpdated, "[2027]"),
+	)
+
+	w = h.do(http.MethodPut, fmt.Sprintf("/api/jobs/%d/pool", jobID), gin.H{
+		"colleges": []gin.H{{"college_id": third.ID, "eligible_batches": []int{2028}}},
+	}, host)
+	if w.Code != http.StatusOK {
+		t.Fatalf("replace pool: status %d: %s", w.Code, w.Body)
+	}
+	expect("replace pool",
+		on(partner.ID, models.WebhookJobDeleted, "[2027]"),
+		on(third.ID, models.WebhookJobCreated, "[2028]"),
+	)
+
+	if w := h.do(http.MethodDelete, fmt.Sprintf("/api/jobs/%d", jobID), nil, host); w.Code != http.StatusOK {
+		t.Fatalf("delete: status %d: %s", w.Code, w.Body)
+	}
+	expect("delete",
+		on(h.college.ID, models.WebhookJobDeleted, "[2026]"),
+		on(third.ID, models.WebhookJobDeleted, "[2028]"),
+	)
+}
@@ -0,0 +1,185 @@
+package integration
+
+import (
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestProfileSectionsAndRequirements(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+	h.seedUser("asha@"+collegeDomain, "password123", models.Student)
+	asha := h.login("asha@"+collegeDomain, "password123")
+	h.seedUser("ravi@"+collegeDomain, "password123", models.Student)
+	ravi := h.login("ravi@"+collegeDomain, "password123")
+
+	w := h.do(http.MethodPut, "/api/profile/requirements", gin.H{
+		"required_fields": []string{"batch", "roll_number", "projects"},
+	}, admin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("set requirements: status %d: %s", w.Code, w.Body)
+	}
+	if w := h.do(http.MethodPut, "/api/profile/requirements", gin.H{"required_fields": []string{}}, asha); w.Code != http.StatusForbidden {
+		t.Fatalf("student setting requirements: status %d, want 403", w.Code)
+	}
+
+	if w := h.do(http.MethodPatch, "/api/profile", gin.H{"batch": 2026, "roll_number": "BT22CSE001", "skills": []string{"Go"}}, asha); w.Code != http.StatusOK {
+		t.Fatalf("update: status %d: %s", w.Code, w.Body)
+	}
+	if w := h.do(http.MethodPatch, "/api/profile", gin.H{"roll_number": "bt22cse001"}, ravi); w.Code != http.StatusConflict {
+		t.Fatalf("duplicate roll number: status %d, want 409", w.Code)
+	}
+
+	w = h.do(http.MethodPost, "/api/profile/projects", gin.H{"title": "Compiler", "url": "https://github.com/asha/cc"}, asha)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("add project: status %d: %s", w.Code, w.Body)
+	}
+	projectID := idOf(decode(t, w))
+
+	w = h.do(http.MethodGet, "/api/profile", nil, asha)
+	profile := decode(t, w)["profile"].(map[string]interface{})
+	if profile["profile_complete"] != true || len(profile["missing_fields"].([]interface{})) != 0 {
+		t.Fatalf("profile = %v", profile)
+	}
+
+	// entries belong to their student
+	path := fmt.Sprintf("/api/profile/projects/%d", projectID)
+	if w := h.do(http.MethodPut, path, gin.H{"title": "Stolen"}, ravi); w.Code != http.StatusNotFound {
+		t.Fatalf("foreign update: status %d, want 404", w.Code)
+	}
+	if w := h.do(http.MethodPut, path, gin.H{"title": "Optimizing compiler"}, asha); w.Code != http.StatusOK {
+		t.Fatalf("update project: status %d: %s", w.Code, w.Body)
+	}
+	var stored models.StudentProject
+	h.db.First(&stored, projectID)
+	if stored.Title != "Optimizing compiler" || stored.URL != "" {
+		t.Fatalf("stored project = %+v", stored)
+	}
+
+	if w := h.do(http.MethodDelete, path, nil, asha); w.Code != http.StatusOK {
+		t.Fatalf("delete project: status %d: %s", w.Code, w.Body)
+	}
+	w = h.do(http.MethodGet, "/api/profile", nil, asha)
+	profile = decode(t, w)["profile"].(map[string]interface{})
+	if profile["profile_complete"] != false || fmt.Sprint(profile["missing_fields"]) != "[projects]" {
+		t.Fatalf("profile after delete = %v", profile)
+	}
+
+	// relaxing the rule completes existing profiles
+	w = h.do(http.MethodPut, "/api/profile/requirements", gin.H{"required_fields": []string{"batch"}}, admin)
+	if got := decode(t, w); got["complete_profiles"].(float64) != 1 || got["incomplete_profiles"].(float64) != 0 {
+		t.Fatalf("requirements update = %v", got)
+	}
+	var p models.StudentProfile
+	h.db.Joins("JOIN users ON users.id = student_profiles.user_id").Where("users.email = ?", "asha@"+collegeDomain).First(&p)
+	if !p.ProfileComplete {
+		t.Fatal("profile not re-evaluated")
+	}
+}
+
+func TestResumeChoiceIsSnapshotted(t *testing.T) {
+	h := newHarness(t)
+	job := h.seedJob(nil, time.Now())
+
+	asha := h.seedUser("asha@"+collegeDomain, "password123", models.Student)
+	ravi := h.seedUser("ravi@"+collegeDomain, "password123", models.Student)
+	frontend := models.StudentResume{UserID: asha.ID, Name: "Frontend", URL: "https://files.test/frontend.pdf", ObjectPath: "frontend.pdf", IsDefault: true}
+	ml := models.StudentResume{UserID: asha.ID, Name: "ML", URL: "https://files.test/ml.pdf", ObjectPath: "ml.pdf"}
+	foreign := models.StudentResume{UserID: ravi.ID, Name: "Resume", URL: "https://files.test/ravi.pdf", ObjectPath: "ravi.pdf", IsDefault: true}
+	for _, r := range []*models.StudentResume{&frontend, &ml, &foreign} {
+		if err := h.db.Create(r).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.db.Create(&models.StudentProfile{UserID: asha.ID, Batch: 2026, ResumeURL: &frontend.URL, ProfileComplete: true})
+	session := h.login("asha@"+collegeDomain, "password123")
+
+	w := h.do(http.MethodGet, "/api/profile/resumes", nil, session)
+	if got := decode(t, w)["data"].([]interface{}); len(got) != 2 {
+		t.Fatalf("resumes = %v", got)
+	}
+
+	apply := fmt.Sprintf("/api/jobs/%d/apply", job.ID)
+	if w := h.do(http.MethodPost, apply, gin.H{"resume_id": foreign.ID}, session); w.Code != http.StatusNotFound {
+		t.Fatalf("apply with another student's resume: status %d, want 404", w.Code)
+	}
+	if w := h.do(http.MethodPost, apply, gin.H{"resume_id": ml.ID}, session); w.Code != http.StatusOK {
+		t.Fatalf("apply: status %d: %s", w.Code, w.Body)
+	}
+	var intent models.ApplicationIntent
+	h.db.Where("job_id = ? AND student_id = ?", job.ID, asha.ID).First(&intent)
+	if intent.ResumeID == nil || *intent.ResumeID != ml.ID {
+		t.Fatalf("intent resume = %v, want %d", intent.ResumeID, ml.ID)
+	}
+
+	// the choice can still change when confirming
+	confirm := fmt.Sprintf("/api/applications/%d/confirm", intent.ID)
+	if w := h.do(http.MethodPost, confirm, gin.H{"resume_id": frontend.ID}, session); w.Code != http.StatusOK {
+		t.Fatalf("confirm: status %d: %s", w.Code, w.Body)
+	}
+	var app models.Application
+	h.db.Where("job_id = ? AND student_id = ?", job.ID, asha.ID).First(&app)
+	if app.ResumeSnapshotURL != frontend.URL || app.ResumeName != "Frontend" {
+		t.Fatalf("application resume = %q %q", app.ResumeName, app.ResumeSnapshotURL)
+	}
+
+	// deleting the submitted resume promotes ML and leaves the snapshot
+	if w := h.do(http.MethodDelete, fmt.Sprintf("/api/profile/resumes/%d", frontend.ID), nil, session); w.Code != http.StatusOK {
+		t.Fatalf("delete: status %d: %s", w.Code, w.Body)
+	}
+	w = h.do(http.MethodGet, "/api/profile", nil, session)
+	if got := decode(t, w)["profile"].(map[string]interface{}); got["resume_url"] != ml.URL {
+		t.Fatalf("default after delete = %v", got["resume_url"])
+	}
+	h.db.First(&app, app.ID)
+	if app.ResumeSnapshotURL != frontend.URL {
+		t.Fatalf("snapshot changed to %q", app.ResumeSnapshotURL)
+	}
+
+	if w := h.do(http.MethodPatch, fmt.Sprintf("/api/profile/resumes/%d", ml.ID), gin.H{"name": "Machine learning"}, session); w.Code != http.StatusOK {
+		t.Fatalf("rename: status %d: %s", w.Code, w.Body)
+	}
+	if w := h.do(http.MethodPatch, fmt.Sprintf("/api/profile/resumes/%d", foreign.ID), gin.H{"name": "Mine"}, session); w.Code != http.StatusNotFound {
+		t.Fatalf("foreign rename: status %d, want 404", w.Code)
+	}
+}
+
+// Applying is gated on the college's required fields, not just the batch.
+func TestRequiredFieldsGateApplying(t *testing.T) {
+	h := newHarness(t)
+	job := h.seedJob(nil, time.Now())
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+	if w := h.do(http.MethodPut, "/api/profile/requirements", gin.H{
+		"required_fields": []string{"batch", "skills"},
+	}, admin); w.Code != http.StatusOK {
+		t.Fatalf("set requirements: status %d: %s", w.Code, w.Body)
+	}
+
+	h.seedUser("asha@"+collegeDomain, "password123", models.Student)
+	asha := h.login("asha@"+collegeDomain, "password123")
+	if w := h.do(http.MethodPatch, "/api/profile", gin.H{"batch": 2026}, asha); w.Code != http.StatusOK {
+		t.Fatalf("update: status %d: %s", w.Code, w.Body)
+	}
+
+	apply := fmt.Sprintf("/api/jobs/%d/apply", job.ID)
+	w := h.do(http.MethodPost, apply, nil, asha)
+	if w.Code != http.StatusBadRequest || decode(t, w)["error"] != "complete profile before applying" {
+		t.Fatalf("apply without skills: status %d: %s", w.Code, w.Body)
+	}
+
+	if w := h.do(http.MethodPatch, "/api/profile", gin.H{"skills": []string{"Go"}}, asha); w.Code != http.StatusOK {
+		t.Fatalf("update: status %d: %s", w.Code, w.Body)
+	}
+	if w := h.do(http.MethodPost, apply, nil, asha); w.Code != http.StatusOK {
+		t.Fatalf("apply with skills: status %d: %s", w.Code, w.Body)
+	}
+}
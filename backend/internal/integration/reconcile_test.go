@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReconcileRegistrations(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+
+	job := h.seedJob(nil, time.Now())
+	verified := h.applyAs("verified@"+collegeDomain, job.ID, models.StudentProfile{Batch: 2026, RollNumber: "BT21CSE001"})
+	missing := h.applyAs("missing@"+collegeDomain, job.ID, models.StudentProfile{Batch: 2026})
+
+	pending := h.seedUser("pending@"+collegeDomain, "password123", models.Student)
+	h.db.Create(&models.StudentProfile{UserID: pending.ID, Batch: 2026, ProfileComplete: true})
+	session := h.login("pending@"+collegeDomain, "password123")
+	if w := h.do(http.MethodPost, fmt.Sprintf("/api/jobs/%d/apply", job.ID), nil, session); w.Code != http.StatusOK {
+		t.Fatalf("apply: status %d: %s", w.Code, w.Body)
+	}
+
+	responses := "Timestamp,Email Address,Roll Number\n" +
+		"1,PENDING@" + collegeDomain + ",\n" +
+		"2,,bt21cse001\n" +
+		"3,someone@gmail.com,\n"
+	path := fmt.Sprintf("/api/applications/reconciliations?job_id=%d", job.ID)
+
+	if w := h.upload(path, "responses", "responses.csv", responses, session); w.Code != http.StatusForbidden {
+		t.Fatalf("student upload: status %d, want 403", w.Code)
+	}
+	if w := h.upload(path, "responses", "responses.csv", "Name\nA\n", admin); w.Code != http.StatusBadRequest {
+		t.Fatalf("no match column: status %d, want 400", w.Code)
+	}
+
+	w := h.upload(path, "responses", "responses.csv", responses, admin)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("reconcile: status %d: %s", w.Code, w.Body)
+	}
+	report := decode(t, w)
+	for key, want := range map[string]int{"confirmed": 1, "verified": 1, "missing": 1, "not_applied": 0, "unmatched": 1, "failed": 0} {
+		if got, _ := report[key].([]interface{}); len(got) != want {
+			t.Fatalf("%s = %v, want %d entries", key, report[key], want)
+		}
+	}
+
+	// the intent became an application, verified like the existing one
+	var confirmed models.Application
+	if err := h.db.Where("job_id = ? AND student_id = ?", job.ID, pending.ID).First(&confirmed).Error; err != nil {
+		t.Fatalf("confirmed application: %v", err)
+	}
+	checks := map[uint]models.RegistrationCheck{
+		confirmed.ID: models.RegistrationVerified,
+		verified.ID:  models.RegistrationVerified,
+		missing.ID:   models.RegistrationMissing,
+	}
+	for id, want := range checks {
+		var app models.Application
+		h.db.First(&app, id)
+		if app.RegistrationCheck != want || app.RegistrationCheckedAt == nil {
+			t.Fatalf("application %d: check %q, want %q", id, app.RegistrationCheck, want)
+		}
+	}
+
+	w = h.do(http.MethodGet, "/api/applications?registration_check=MISSING", nil, admin)
+	data, _ := decode(t, w)["data"].([]interface{})
+	if w.Code != http.StatusOK || len(data) != 1 || idOf(data[0].(map[string]interface{})) != missing.ID {
+		t.Fatalf("missing filter: status %d: %s", w.Code, w.Body)
+	}
+
+	var logged int64
+	h.db.Model(&models.AuditLog{}).Where("action = ? AND entity_id = ?", "job.registration_reconcile", job.ID).Count(&logged)
+	if logged != 1 {
+		t.Fatalf("%d audit entries, want 1", logged)
+	}
+
+	// the report is kept
+	w = h.do(http.MethodGet, fmt.Sprintf("/api/applications/reconciliations?job_id=%d", job.ID), nil, admin)
+	data, _ = decode(t, w)["data"].([]interface{})
+	if w.Code != http.StatusOK || len(data) != 1 {
+		t.Fatalf("list: status %d: %s", w.Code, w.Body)
+	}
+	summary := data[0].(map[string]interface{})
+	if summary["confirmed"] != float64(1) || summary["missing"] != float64(1) ||
+		summary["total_rows"] != float64(3) || summary["ambiguous"] != float64(0) {
+		t.Fatalf("summary = %v", summary)
+	}
+
+	w = h.do(http.MethodGet, fmt.Sprintf("/api/applications/reconciliations/%d", idOf(summary)), nil, admin)
+	if body := decode(t, w); w.Code != http.StatusOK || body["file_name"] != "responses.csv" || len(body["missing"].([]interface{})) != 1 {
+		t.Fatalf("report: status %d: %v", w.Code, body)
+	}
+	if w := h.do(http.MethodGet, "/api/applications/reconciliations/999", nil, admin); w.Code != http.StatusNotFound {
+		t.Fatalf("unknown report: status %d, want 404", w.Code)
+	}
+}
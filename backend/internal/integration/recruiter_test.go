@@ -0,0 +1,155 @@
+package integration
+
+import (
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// applyAs seeds a student with a complete profile and applies them to
+// the job, returning the confirmed application.
+func (h *harness) applyAs(email string, jobID uint, profile models.StudentProfile) models.Application {
+	h.t.Helper()
+
+	student := h.seedUser(email, "password123", models.Student)
+	profile.UserID = student.ID
+	profile.ProfileComplete = true
+	if err := h.db.Create(&profile).Error; err != nil {
+		h.t.Fatal(err)
+	}
+	session := h.login(email, "password123")
+
+	if w := h.do(http.MethodPost, fmt.Sprintf("/api/jobs/%d/apply", jobID), nil, session); w.Code != http.StatusOK {
+		h.t.Fatalf("apply: status %d: %s", w.Code, w.Body)
+	}
+	var intent models.ApplicationIntent
+	if err := h.db.Where("job_id = ? AND student_id = ?", jobID, student.ID).First(&intent).Error; err != nil {
+		h.t.Fatal(err)
+	}
+	if w := h.do(http.MethodPost, fmt.Sprintf("/api/applications/%d/confirm", intent.ID), nil, session); w.Code != http.StatusOK {
+		h.t.Fatalf("confirm: status %d: %s", w.Code, w.Body)
+	}
+
+	var app models.Application
+	if err := h.db.Where("job_id = ? AND student_id = ?", jobID, student.ID).First(&app).Error; err != nil {
+		h.t.Fatal(err)
+	}
+	return app
+}
+
+func TestRecruiterFlow(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+
+	w := h.do(http.MethodPost, "/api/jobs", gin.H{
+		"company":               "Acme",
+		"title":                 "SDE",
+		"job_type":              models.JobFTE,
+		"domain":                models.DomainSDE,
+		"eligible_batches":      []int{2026},
+		"ctc":                   12,
+		"registration_form_url": "https://forms.test/acme",
+	}, admin)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", w.Code, w.Body)
+	}
+	jobID := idOf(decode(t, w))
+
+	cgpa := float32(8.5)
+	resume := "http://minio.test/resumes/private.pdf"
+	private := h.applyAs("private@"+collegeDomain, jobID, models.StudentProfile{
+		Batch: 2026, CGPA: &cgpa, LinkedinID: "in/private", ResumeURL: &resume,
+	})
+	open := h.applyAs("open@"+collegeDomain, jobID, models.StudentProfile{
+		Batch: 2026, CGPA: &cgpa, LinkedinID: "in/open",
+		ShareContactWithRecruiters: true, HideCGPAFromRecruiters: true,
+	})
+	if private.ResumeSnapshotURL != resume {
+		t.Fatalf("resume snapshot = %q, want %q", private.ResumeSnapshotURL, resume)
+	}
+
+	// invite, then accept the forwarded link
+	w = h.do(http.MethodPost, "/api/recruiters/invitations", gin.H{
+		"email":   "hr@acme.test",
+		"company": "Acme",
+		"job_ids": []uint{jobID},
+	}, admin)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("invite: status %d: %s", w.Code, w.Body)
+	}
+	link, err := url.Parse(decode(t, w)["invite_url"].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = h.do(http.MethodPost, "/api/recruiters/invitations/accept", gin.H{
+		"token":    link.Query().Get("token"),
+		"password": "password123",
+		"name":     "Acme HR",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("accept: status %d: %s", w.Code, w.Body)
+	}
+
+	recruiter := h.login("hr@acme.test", "password123")
+
+	w = h.do(http.MethodGet, "/api/recruiters/me/jobs", nil, recruiter)
+	myJobs := decode(t, w)["data"].([]interface{})
+	if len(myJobs) != 1 || myJobs[0].(map[string]interface{})["applicants"].(float64) != 2 {
+		t.Fatalf("my jobs = %v", myJobs)
+	}
+
+	// applicants, filtered by each student's privacy settings
+	w = h.do(http.MethodGet, "/api/applications", nil, recruiter)
+	if w.Code != http.StatusOK {
+		t.Fatalf("list: status %d: %s", w.Code, w.Body)
+	}
+	candidates := map[uint]map[string]interface{}{}
+	for _, item := range decode(t, w)["data"].([]interface{}) {
+		c := item.(map[string]interface{})
+		candidates[uint(c["application_id"].(float64))] = c
+	}
+	if c := candidates[private.ID]; c == nil || c["email"] != nil || c["linkedin_id"] != nil || c["cgpa"] == nil {
+		t.Fatalf("private candidate = %v", c)
+	}
+	if c := candidates[open.ID]; c == nil || c["email"] != "open@"+collegeDomain || c["cgpa"] != nil {
+		t.Fatalf("open candidate = %v", c)
+	}
+
+	w = h.do(http.MethodGet, fmt.Sprintf("/api/applications/%d/resume", private.ID), nil, recruiter)
+	if w.Code != http.StatusFound || w.Header().Get("Location") != resume {
+		t.Fatalf("resume: status %d, location %q", w.Code, w.Header().Get("Location"))
+	}
+	if w := h.do(http.MethodGet, fmt.Sprintf("/api/applications/%d/resume", open.ID), nil, recruiter); w.Code != http.StatusNotFound {
+		t.Fatalf("missing resume: status %d, want 404", w.Code)
+	}
+
+	bulk := gin.H{"application_ids": []uint{private.ID, open.ID}, "new_status": models.Shortlisted}
+	if w := h.do(http.MethodPatch, "/api/applications/status/bulk", bulk, recruiter); w.Code != http.StatusOK {
+		t.Fatalf("recruiter bulk update: status %d: %s", w.Code, w.Body)
+	}
+
+	// recruiters stay out of admin routes
+	if w := h.do(http.MethodGet, "/api/recruiters", nil, recruiter); w.Code != http.StatusForbidden {
+		t.Fatalf("recruiter listing recruiters: status %d, want 403", w.Code)
+	}
+
+	// once revoked, the applicants are gone
+	var hr models.User
+	h.db.Where("email = ?", "hr@acme.test").First(&hr)
+	if w := h.do(http.MethodDelete, fmt.Sprintf("/api/recruiters/%d/jobs/%d", hr.ID, jobID), nil, admin); w.Code != http.StatusOK {
+		t.Fatalf("revoke: status %d: %s", w.Code, w.Body)
+	}
+	w = h.do(http.MethodGet, "/api/applications", nil, recruiter)
+	if got := decode(t, w)["data"].([]interface{}); len(got) != 0 {
+		t.Fatalf("applications after revoke = %v", got)
+	}
+	if w := h.do(http.MethodGet, fmt.Sprintf("/api/applications/%d", private.ID), nil, recruiter); w.Code != http.StatusForbidden {
+		t.Fatalf("get after revoke: status %d, want 403", w.Code)
+	}
+}
@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"context"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/jobs"
+	"iiitn-career-portal/internal/packages/notifications"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSavedSearchAlerts(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+	partner, _, partnerStudent := h.seedPartner(2027)
+
+	h.seedUser("instant@"+collegeDomain, "password123", models.Student)
+	instant := h.login("instant@"+collegeDomain, "password123")
+	h.seedUser("daily@"+collegeDomain, "password123", models.Student)
+	daily := h.login("daily@"+collegeDomain, "password123")
+
+	fte := gin.H{"job_type": models.JobFTE}
+	for _, s := range []struct {
+		session *http.Cookie
+		body    gin.H
+	}{
+		{instant, gin.H{"name": "fte", "filters": fte}},
+		{daily, gin.H{"name": "fte daily", "filters": fte, "frequency": models.AlertDaily}},
+		{partnerStudent, gin.H{"name": "fte", "filters": fte}},
+	} {
+		if w := h.do(http.MethodPost, "/api/jobs/saved-searches", s.body, s.session); w.Code != http.StatusCreated {
+			t.Fatalf("save search: status %d: %s", w.Code, w.Body)
+		}
+	}
+	if w := h.do(http.MethodPost, "/api/jobs/saved-searches", gin.H{"name": "x", "frequency": "HOURLY"}, instant); w.Code != http.StatusBadRequest {
+		t.Fatalf("bad frequency: status %d, want 400", w.Code)
+	}
+
+	create := func(jobType models.JobType, pool []gin.H) {
+		t.Helper()
+		w := h.do(http.MethodPost, "/api/jobs", gin.H{
+			"company":               "Acme",
+			"title":                 "SDE",
+			"job_type":              jobType,
+			"domain":                models.DomainSDE,
+			"eligible_batches":      []int{2026},
+			"ctc":                   12,
+			"registration_form_url": "https://forms.test/acme",
+			"pool":                  pool,
+		}, admin)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("create: status %d: %s", w.Code, w.Body)
+		}
+	}
+	create(models.JobFTE, []gin.H{{"college_id": partner.ID, "eligible_batches": []int{2027}}})
+	create(models.JobFTE, nil)
+	create(models.JobIntern, nil)
+
+	// creating a job alerts nobody by itself
+	count := func(typ models.NotificationType) int64 {
+		var n int64
+		h.db.Model(&models.Notification{}).Where("type = ?", typ).Count(&n)
+		return n
+	}
+	if n := count(models.NotificationSavedSearchMatch); n != 0 {
+		t.Fatalf("%d alerts before the worker ran", n)
+	}
+
+	svc := jobs.NewService(jobs.NewGormRepository(h.db), notifications.New(h.db, nil))
+	for range 2 {
+		if err := svc.SendSavedSearchAlerts(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// two FTE jobs for the instant search, the pooled one for the partner
+	if n := count(models.NotificationSavedSearchMatch); n != 3 {
+		t.Fatalf("%d instant alerts, want 3", n)
+	}
+	if n := count(models.NotificationSavedSearchDigest); n != 1 {
+		t.Fatalf("%d digests, want 1", n)
+	}
+
+	var left int64
+	h.db.Model(&models.PendingJobAlert{}).Count(&left)
+	if left != 0 {
+		t.Fatalf("%d jobs still queued", left)
+	}
+	h.db.Model(&models.SavedSearchMatch{}).Count(&left)
+	if left != 0 {
+		t.Fatalf("%d matches still waiting", left)
+	}
+}
@@ -0,0 +1,226 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/webhooks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type received struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+func TestWebhookFlow(t *testing.T) {
+	h := newHarness(t)
+
+	var (
+		mu   sync.Mutex
+		got  []received
+		fail = true
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, received{r.Header.Get("X-Webhook-Event"), r.Header.Get("X-Webhook-Signature"), body})
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer receiver.Close()
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+
+	w := h.do(http.MethodPost, "/api/webhooks", gin.H{
+		"url":    receiver.URL + "/hooks",
+		"events": []models.WebhookEvent{models.WebhookJobCreated, models.WebhookApplicationStatusChanged},
+	}, admin)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create webhook: status %d: %s", w.Code, w.Body)
+	}
+	endpoint := decode(t, w)
+	endpointID := idOf(endpoint)
+	secret, _ := endpoint["secret"].(string)
+	if !strings.HasPrefix(secret, "whsec_") {
+		t.Fatalf("secret = %q", secret)
+	}
+
+	// the secret is only shown once
+	w = h.do(http.MethodGet, "/api/webhooks", nil, admin)
+	listed := decode(t, w)["data"].([]interface{})
+	if len(listed) != 1 || listed[0].(map[string]interface{})["secret"] != nil {
+		t.Fatalf("listed = %v", listed)
+	}
+
+	w = h.do(http.MethodPost, "/api/jobs", gin.H{
+		"company":               "Acme",
+		"title":                 "SDE",
+		"job_type":              models.JobFTE,
+		"domain":                models.DomainSDE,
+		"eligible_batches":      []int{2026},
+		"ctc":                   12,
+		"registration_form_url": "https://forms.test/acme",
+	}, admin)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create job: status %d: %s", w.Code, w.Body)
+	}
+	jobID := idOf(decode(t, w))
+
+	// confirmed is not subscribed to, status_changed is
+	cgpa := float32(8)
+	app := h.applyAs("student@"+collegeDomain, jobID, models.StudentProfile{Batch: 2026, CGPA: &cgpa})
+	bulk := gin.H{"application_ids": []uint{app.ID}, "new_status": models.Shortlisted}
+	if w := h.do(http.MethodPatch, "/api/applications/status/bulk", bulk, admin); w.Code != http.StatusOK {
+		t.Fatalf("bulk update: status %d: %s", w.Code, w.Body)
+	}
+
+	worker := webhooks.NewWorker(webhooks.NewGormRepository(h.db), h.cfg.Webhooks)
+	if n, err := worker.RunOnce(context.Background()); err != nil || n != 2 {
+		t.Fatalf("run: n=%d err=%v", n, err)
+	}
+
+	mu.Lock()
+	if len(got) != 2 || got[0].event != string(models.WebhookJobCreated) || got[1].event != string(models.WebhookApplicationStatusChanged) {
+		t.Fatalf("received %v", got)
+	}
+	for _, r := range got {
+		ts := strings.TrimPrefix(strings.Split(r.signature, ",")[0], "t=")
+		if r.signature != webhooks.Sign(secret, ts, r.body) {
+			t.Fatalf("signature %q does not verify", r.signature)
+		}
+	}
+	var change struct {
+		Event string `json:"event"`
+		Data  struct {
+			ApplicationID  uint   `json:"application_id"`
+			Status         string `json:"status"`
+			PreviousStatus string `json:"previous_status"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(got[1].body, &change); err != nil {
+		t.Fatal(err)
+	}
+	if change.Data.ApplicationID != app.ID || change.Data.Status != string(models.Shortlisted) || change.Data.PreviousStatus != string(models.Applied) {
+		t.Fatalf("status change payload = %s", got[1].body)
+	}
+	fail = false
+	mu.Unlock()
+
+	// both failed once and wait for their retry
+	w = h.do(http.MethodGet, fmt.Sprintf("/api/webhooks/%d/deliveries", endpointID), nil, admin)
+	deliveries := decode(t, w)["data"].([]interface{})
+	if len(deliveries) != 2 {
+		t.Fatalf("deliveries = %v", deliveries)
+	}
+	for _, item := range deliveries {
+		d := item.(map[string]interface{})
+		if d["status"] != string(models.DeliveryPending) || d["attempts"].(float64) != 1 || d["last_status_code"].(float64) != 503 {
+			t.Fatalf("delivery = %v", d)
+		}
+	}
+
+	// a redelivery goes out right away, with the same event id
+	first := deliveries[1].(map[string]interface{})
+	w = h.do(http.MethodPost, fmt.Sprintf("/api/webhooks/%d/deliveries/%d/redeliver", endpointID, idOf(first)), nil, admin)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("redeliver: status %d: %s", w.Code, w.Body)
+	}
+	if n, err := worker.RunOnce(context.Background()); err != nil || n != 1 {
+		t.Fatalf("run after redeliver: n=%d err=%v", n, err)
+	}
+	mu.Lock()
+	if len(got) != 3 || string(got[2].body) != string(got[0].body) {
+		t.Fatalf("redelivered %s, want %s", got[len(got)-1].body, got[0].body)
+	}
+	mu.Unlock()
+
+	w = h.do(http.MethodGet, fmt.Sprintf("/api/webhooks/%d/deliveries?status=SUCCEEDED", endpointID), nil, admin)
+	if succeeded := decode(t, w)["data"].([]interface{}); len(succeeded) != 1 {
+		t.Fatalf("succeeded deliveries = %v", succeeded)
+	}
+
+	// other roles stay out
+	student := h.login("student@"+collegeDomain, "password123")
+	if w := h.do(http.MethodGet, "/api/webhooks", nil, student); w.Code != http.StatusForbidden {
+		t.Fatalf("student listing webhooks: status %d, want 403", w.Code)
+	}
+
+	if w := h.do(http.MethodDelete, fmt.Sprintf("/api/webhooks/%d", endpointID), nil, admin); w.Code != http.StatusOK {
+		t.Fatalf("delete: status %d: %s", w.Code, w.Body)
+	}
+	var left int64
+	h.db.Model(&models.WebhookDelivery{}).Count(&left)
+	if left != 0 {
+		t.Fatalf("%d deliveries left after delete", left)
+	}
+}
+
+// Deliveries are written in the transaction of the change: if queueing
+// fails, the change is rolled back instead of committing silently.
+func TestWebhookDeliveriesShareTransaction(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+
+	w := h.do(http.MethodPost, "/api/webhooks", gin.H{
+		"url":    "http://127.0.0.1:9/hooks",
+		"events": []models.WebhookEvent{models.WebhookJobCreated},
+	}, admin)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create webhook: status %d: %s", w.Code, w.Body)
+	}
+
+	if err := h.db.Callback().Create().Before("gorm:create").Register("fail_deliveries", func(db *gorm.DB) {
+		if db.Statement.Table == "webhook_deliveries" {
+			_ = db.AddError(errors.New("delivery insert failed"))
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	job := gin.H{
+		"company":               "Acme",
+		"title":                 "SDE",
+		"job_type":              models.JobFTE,
+		"domain":                models.DomainSDE,
+		"eligible_batches":      []int{2026},
+		"ctc":                   12,
+		"registration_form_url": "https://forms.test/acme",
+	}
+	if w := h.do(http.MethodPost, "/api/jobs", job, admin); w.Code != http.StatusInternalServerError {
+		t.Fatalf("create job with failing outbox: status %d, want 500: %s", w.Code, w.Body)
+	}
+	var jobs int64
+	h.db.Model(&models.Job{}).Count(&jobs)
+	if jobs != 0 {
+		t.Fatalf("jobs = %d, want the create rolled back", jobs)
+	}
+
+	if err := h.db.Callback().Create().Remove("fail_deliveries"); err != nil {
+		t.Fatal(err)
+	}
+	if w := h.do(http.MethodPost, "/api/jobs", job, admin); w.Code != http.StatusCreated {
+		t.Fatalf("create job: status %d: %s", w.Code, w.Body)
+	}
+	var deliveries int64
+	h.db.Model(&models.WebhookDelivery{}).Where("event = ?", models.WebhookJobCreated).Count(&deliveries)
+	if deliveries != 1 {
+		t.Fatalf("deliveries = %d, want 1", deliveries)
+	}
+}
@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWithdrawApplication(t *testing.T) {
+	h := newHarness(t)
+
+	h.seedUser("admin@"+collegeDomain, "password123", models.CollegeAdmin)
+	admin := h.login("admin@"+collegeDomain, "password123")
+
+	first := h.seedJob(nil, time.Now())
+	second := h.seedJob(nil, time.Now())
+	app := h.applyAs("quitter@"+collegeDomain, first.ID, models.StudentProfile{Batch: 2026})
+	session := h.login("quitter@"+collegeDomain, "password123")
+
+	// one withdrawal per season
+	w := h.do(http.MethodPut, "/api/applications/withdrawal-policy", gin.H{"cutoff_hours": 0, "max_per_season": 1}, admin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("set policy: status %d: %s", w.Code, w.Body)
+	}
+
+	withdraw := fmt.Sprintf("/api/applications/%d/withdraw", app.ID)
+	w = h.do(http.MethodPost, withdraw, gin.H{"reason": "accepted another offer"}, session)
+	if w.Code != http.StatusOK {
+		t.Fatalf("withdraw: status %d: %s", w.Code, w.Body)
+	}
+	if body := decode(t, w); body["status"] != string(models.Withdrawn) || body["withdrawals_left"] != float64(0) {
+		t.Fatalf("withdraw response = %v", body)
+	}
+
+	var stored models.Application
+	h.db.First(&stored, app.ID)
+	if stored.Status != models.Withdrawn || stored.WithdrawnAt == nil {
+		t.Fatalf("stored application = %+v", stored)
+	}
+
+	var notified int64
+	h.db.Model(&models.Notification{}).Where("type = ?", models.NotificationApplicationWithdrawn).Count(&notified)
+	if notified != 1 {
+		t.Fatalf("%d admin notifications, want 1", notified)
+	}
+	var logged int64
+	h.db.Model(&models.AuditLog{}).Where("action = ? AND entity_id = ?", "application.withdraw", app.ID).Count(&logged)
+	if logged != 1 {
+		t.Fatalf("%d audit entries, want 1", logged)
+	}
+
+	// final: neither the student nor the admin can move it on
+	if w := h.do(http.MethodPost, withdraw, nil, session); w.Code != http.StatusConflict {
+		t.Fatalf("withdraw twice: status %d, want 409", w.Code)
+	}
+	w = h.do(http.MethodPatch, "/api/applications/status/bulk", gin.H{
+		"application_ids": []uint{app.ID},
+		"new_status":      models.Shortlisted,
+	}, admin)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("move a withdrawn application: status %d, want 400", w.Code)
+	}
+
+	// the season's limit is used up
+	if w := h.do(http.MethodPost, fmt.Sprintf("/api/jobs/%d/apply", second.ID), nil, session); w.Code != http.StatusOK {
+		t.Fatalf("apply: status %d: %s", w.Code, w.Body)
+	}
+	var intent models.ApplicationIntent
+	h.db.Where("job_id = ?", second.ID).First(&intent)
+	if w := h.do(http.MethodPost, fmt.Sprintf("/api/applications/%d/confirm", intent.ID), nil, session); w.Code != http.StatusOK {
+		t.Fatalf("confirm: status %d: %s", w.Code, w.Body)
+	}
+	var next models.Application
+	h.db.Where("job_id = ?", second.ID).First(&next)
+	if w := h.do(http.MethodPost, fmt.Sprintf("/api/applications/%d/withdraw", next.ID), nil, session); w.Code != http.StatusConflict {
+		t.Fatalf("withdraw over the limit: status %d, want 409", w.Code)
+	}
+
+	w = h.do(http.MethodGet, "/api/applications/withdrawal-policy", nil, session)
+	if body := decode(t, w); w.Code != http.StatusOK || body["withdrawals_used"] != float64(1) || body["max_per_season"] != float64(1) {
+		t.Fatalf("policy: status %d: %v", w.Code, body)
+	}
+}
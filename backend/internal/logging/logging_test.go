@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf, "debug", "json")
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	log.InfoContext(ctx, "connecting to postgres://portal:hunter2@db:5432/portal",
+		"client_secret", "s3cret",
+		"error", errors.New(`token rejected: {"access_token":"eyJabc","token_type":"Bearer"}`),
+		"note", "password=hunter2 host=db",
+		"header", "Bearer eyJhbGciOi.payload.sig",
+		"user_id", 7,
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "s3cret", "eyJabc", "eyJhbGciOi"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("%q leaked: %s", secret, out)
+		}
+	}
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec["request_id"] != "req-1" || rec["user_id"] != float64(7) {
+		t.Errorf("record = %v", rec)
+	}
+}
+
+func TestRequestIDInErrorBodies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestID())
+	r.GET("/fail", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+	})
+	r.GET("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"request_id_seen": c.GetString("request_id")})
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	r.ServeHTTP(w, req)
+
+	var body map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%v: %s", err, w.Body)
+	}
+	if body["request_id"] != "abc-123" || body["error"] != "job not found" {
+		t.Fatalf("body = %v", body)
+	}
+	if w.Header().Get(RequestIDHeader) != "abc-123" {
+		t.Fatalf("header = %q", w.Header().Get(RequestIDHeader))
+	}
+
+	// unsafe upstream ids are replaced; success bodies are untouched
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set(RequestIDHeader, `"><script>`)
+	r.ServeHTTP(w, req)
+
+	id := w.Header().Get(RequestIDHeader)
+	if id == "" || strings.Contains(id, "<") {
+		t.Fatalf("id = %q", id)
+	}
+	if w.Body.String() != `{"request_id_seen":"`+id+`"}` {
+		t.Fatalf("body = %s", w.Body)
+	}
+}
+
+func TestAccessLogUserFromAuthContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(New(&buf, "debug", "json"))
+	defer slog.SetDefault(prev)
+
+	r := gin.New()
+	r.Use(AccessLog())
+	r.GET("/jobs", func(c *gin.Context) {
+		c.Set("auth", &authorization.AuthContext{UserID: 7, Role: string(models.Student)})
+		c.Status(http.StatusOK)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/jobs", nil))
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("%v: %s", err, buf.String())
+	}
+	if rec["user_id"] != float64(7) {
+		t.Fatalf("record = %v", rec)
+	}
+}
@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"iiitn-career-portal/internal/packages/authorization"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// accept upstream ids (load balancer, frontend) only if they are tame
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID reuses the caller's X-Request-ID or mints one, and makes it
+// available as c.GetString("request_id"), in the request ctx (so log
+// lines carry it), in the response header and in JSON error bodies.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+
+		c.Set("request_id", id)
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
+		c.Writer = &errorBodyWriter{ResponseWriter: c.Writer, id: id}
+
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// errorBodyWriter adds "request_id" to {"error": ...} responses so a
+// user-reported error can be matched to its log lines.
+type errorBodyWriter struct {
+	gin.ResponseWriter
+	id      string
+	written bool
+}
+
+func (w *errorBodyWriter) Write(b []byte) (int, error) {
+	first := !w.written
+	w.written = true
+
+	if !first || w.Status() < http.StatusBadRequest ||
+		!strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") ||
+		len(b) < 2 || b[0] != '{' || bytes.Contains(b, []byte(`"request_id"`)) {
+		return w.ResponseWriter.Write(b)
+	}
+
+	out := make([]byte, 0, len(b)+len(w.id)+20)
+	out = append(out, `{"request_id":"`...)
+	out = append(out, w.id...)
+	out = append(out, '"')
+	if b[1] != '}' {
+		out = append(out, ',')
+	}
+	out = append(out, b[1:]...)
+
+	if _, err := w.ResponseWriter.Write(out); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *errorBodyWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// AccessLog writes one line per request. Only the route template is
+// logged, never the raw path or query, since those can carry secrets
+// (calendar feed tokens, SSO codes).
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		attrs := []any{
+			"method", c.Request.Method,
+			"route", route,
+			"status", c.Writer.Status(),
+			"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"ip", c.ClientIP(),
+		}
+		if auth, ok := c.Get("auth"); ok {
+			attrs = append(attrs, "user_id", auth.(*authorization.AuthContext).UserID)
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case c.Writer.Status() >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case route == "/health" || route == "/metrics":
+			level = slog.LevelDebug
+		}
+		slog.Log(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// Recovery turns a panic into a logged 500.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if p := recover(); p != nil {
+				slog.ErrorContext(c.Request.Context(), "panic",
+					"panic", p,
+					"stack", string(debug.Stack()),
+				)
+				c.AbortWithStatusJSON(
+					http.StatusInternalServerError,
+					gin.H{"error": "internal server error"},
+				)
+			}
+		}()
+		c.Next()
+	}
+}
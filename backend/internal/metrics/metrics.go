@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+)
+
+// Registry holds every portal metric. A private registry (rather than
+// prometheus.DefaultRegisterer) keeps tests free of duplicate
+// registration panics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by route template.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	keycloakDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "keycloak_request_duration_seconds",
+		Help:    "Latency of calls to Keycloak.",
+		Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"operation"})
+
+	keycloakErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "keycloak_request_errors_total",
+		Help: "Keycloak calls that failed in transport or returned 5xx.",
+	}, []string{"operation"})
+
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_requests_total",
+		Help: "Read-through cache lookups by cache and result (hit, miss, error).",
+	}, []string{"cache", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpDuration,
+		keycloakDuration,
+		keycloakErrors,
+		cacheRequests,
+	)
+}
+
+// Middleware records request latency. Unmatched paths share one label so
+// scanners can't blow up the series count.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpDuration.WithLabelValues(
+			c.Request.Method,
+			route,
+			strconv.Itoa(c.Writer.Status()),
+		).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler serves the registry. With a non-empty token the scraper must
+// send "Authorization: Bearer <token>".
+func Handler(token string) gin.HandlerFunc {
+	h := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+	want := []byte("Bearer " + token)
+
+	return func(c *gin.Context) {
+		if token != "" &&
+			subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), want) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// ObserveKeycloak records one Keycloak call.
+func ObserveKeycloak(operation string, d time.Duration, failed bool) {
+	keycloakDuration.WithLabelValues(operation).Observe(d.Seconds())
+	if failed {
+		keycloakErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// Cache results for ObserveCache.
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+)
+
+// ObserveCache counts one cache lookup.
+func ObserveCache(cache, result string) {
+	cacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// RegisterDB exports the connection pool stats (open, in use, idle,
+// wait count/duration, ...) of db.
+func RegisterDB(db *sql.DB) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+// RegisterQueues exports the length of Redis lists used as work queues,
+// read at scrape time. queues maps a label to the Redis key.
+func RegisterQueues(rdb *redis.Client, queues map[string]string) error {
+	return Registry.Register(&queueCollector{rdb: rdb, queues: queues})
+}
+
+var (
+	queueDepthDesc = prometheus.NewDesc(
+		"redis_queue_depth",
+		"Pending items in a Redis work queue.",
+		[]string{"queue"}, nil,
+	)
+	queueUpDesc = prometheus.NewDesc(
+		"redis_queue_scrape_success",
+		"1 if the queue depths could be read from Redis.",
+		nil, nil,
+	)
+)
+
+type queueCollector struct {
+	rdb    *redis.Client
+	queues map[string]string
+}
+
+func (q *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueDepthDesc
+	ch <- queueUpDesc
+}
+
+func (q *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	if q.rdb == nil {
+		ch <- prometheus.MustNewConstMetric(queueUpDesc, prometheus.GaugeValue, 0)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	up := 1.0
+	for name, key := range q.queues {
+		n, err := q.rdb.LLen(ctx, key).Result()
+		if err != nil {
+			up = 0
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(n), name)
+	}
+	ch <- prometheus.MustNewConstMetric(queueUpDesc, prometheus.GaugeValue, up)
+}
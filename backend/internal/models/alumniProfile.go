@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type AlumniProfile struct {
+	UserID uint `gorm:"primaryKey"`
+	User   User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	CollegeID uint `gorm:"not null;index"`
+
+	CurrentCompany  string `gorm:"index"`
+	CurrentRole     string
+	GraduationBatch int `gorm:"not null;index"`
+
+	ContactPreference ContactPreference `gorm:"type:varchar(20);default:'NONE'"`
+
+	// opt-in: hidden from the directory until the alumnus publishes it
+	IsPublic bool `gorm:"default:false"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
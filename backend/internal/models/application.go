@@ -15,7 +15,17 @@ type Application struct {
 
 	Status ApplicationStatus `gorm:"type:varchar(20);not null"`
 
+	// the resume submitted, as it was when confirming
 	ResumeSnapshotURL string `gorm:"not null"`
+	ResumeName        string
+
+	// set when the student withdrew; counts toward the season's limit
+	WithdrawnAt *time.Time `gorm:"index"`
+
+	// outcome of the latest reconciliation against the registration form
+	// responses; empty until one ran
+	RegistrationCheck     RegistrationCheck `gorm:"type:varchar(20);index"`
+	RegistrationCheckedAt *time.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -9,7 +9,24 @@ type ApplicationIntent struct {
 	StudentID uint `gorm:"index;not null;uniqueIndex:uniq_intent"`
 	CollegeID uint `gorm:"index;not null"`
 
-	ExpiresAt time.Time
+	// resume chosen when applying; nil uses the default when confirming
+	ResumeID *uint
+
+	ExpiresAt time.Time `gorm:"index"`
+	// set once the expiry reminder has been sent
+	RemindedAt *time.Time
 
 	CreatedAt time.Time
 }
+
+// JobIntentStat counts a college's intents for a job that expired
+// unconfirmed. Pending intents and confirmed applications are counted from
+// their own tables; expired intents are deleted.
+type JobIntentStat struct {
+	JobID     uint `gorm:"primaryKey;autoIncrement:false"`
+	CollegeID uint `gorm:"primaryKey;autoIncrement:false"`
+
+	Expired int64 `gorm:"not null;default:0"`
+
+	UpdatedAt time.Time
+}
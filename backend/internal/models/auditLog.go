@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AuditLog is append-only (enforced by a trigger). Entries form one hash
+// chain per college; ChainKey 0 is the platform-level chain.
+type AuditLog struct {
+	ID uint `gorm:"primaryKey"`
+
+	ChainKey uint `gorm:"not null;index:idx_audit_chain,priority:1"`
+
+	ActorID   uint   `gorm:"not null;index"`
+	ActorRole string `gorm:"type:varchar(20);not null"`
+	CollegeID *uint  `gorm:"index"`
+
+	Action     string `gorm:"type:varchar(50);not null;index"`
+	EntityType string `gorm:"type:varchar(50);not null;index:idx_audit_entity,priority:1"`
+	EntityID   string `gorm:"type:varchar(64);not null;index:idx_audit_entity,priority:2"`
+
+	// {"field": {"before": x, "after": y}}
+	Diff datatypes.JSON
+
+	RequestID string `gorm:"type:varchar(64)"`
+	IP        string `gorm:"type:varchar(64)"`
+
+	PrevHash string `gorm:"type:char(64);not null"`
+	Hash     string `gorm:"type:char(64);not null;uniqueIndex"`
+
+	CreatedAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// CalendarFeed holds the secret token behind a student's ICS feed URL.
+// Calendar apps can't send the portal cookie, so the token is the auth.
+type CalendarFeed struct {
+	StudentID uint   `gorm:"primaryKey"`
+	Token     string `gorm:"uniqueIndex;not null"`
+
+	CreatedAt time.Time
+}
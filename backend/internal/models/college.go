@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
 
 type College struct {
 	ID   uint   `gorm:"primaryKey"`
@@ -8,6 +12,15 @@ type College struct {
 
 	Domain string `gorm:"uniqueIndex"`
 
+	// profile fields a student must fill before applying, e.g.
+	// ["batch", "resume", "roll_number"]; null means the default set
+	ProfileRequiredFields datatypes.JSON
+
+	// withdrawal rules; null means the default. The cutoff is in hours
+	// relative to a job's registration deadline, negative being before it
+	WithdrawalCutoffHours   *int
+	MaxWithdrawalsPerSeason *int
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Company is the employer recruiters belong to.
+type Company struct {
+	ID   uint   `gorm:"primaryKey"`
+	Name string `gorm:"uniqueIndex;not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -5,11 +5,20 @@ type JobType string
 type JobDomain string
 type ApplicationStatus string
 type NotificationType string
+type ExperienceDifficulty string
+type ExperienceVerdict string
+type ContactPreference string
+type WebhookEvent string
+type WebhookDeliveryStatus string
+type RegistrationCheck string
+type AlertFrequency string
 
 const (
 	Admin        Role = "admin"
 	CollegeAdmin Role = "college_admin"
 	Student      Role = "student"
+	Alumni       Role = "alumni"
+	Recruiter    Role = "recruiter"
 )
 
 const (
@@ -34,17 +43,73 @@ const (
 	Interview   ApplicationStatus = "INTERVIEW"
 	Offered     ApplicationStatus = "OFFERED"
 	Rejected    ApplicationStatus = "REJECTED"
+	Withdrawn   ApplicationStatus = "WITHDRAWN"
+)
+
+// Whether an application's student turned up in the job's registration
+// form responses.
+const (
+	RegistrationVerified RegistrationCheck = "VERIFIED"
+	RegistrationMissing  RegistrationCheck = "MISSING"
+)
+
+const (
+	DifficultyEasy   ExperienceDifficulty = "EASY"
+	DifficultyMedium ExperienceDifficulty = "MEDIUM"
+	DifficultyHard   ExperienceDifficulty = "HARD"
+)
+
+const (
+	VerdictSelected ExperienceVerdict = "SELECTED"
+	VerdictRejected ExperienceVerdict = "REJECTED"
+	VerdictPending  ExperienceVerdict = "PENDING"
+)
+
+const (
+	ContactNone     ContactPreference = "NONE"
+	ContactEmail    ContactPreference = "EMAIL"
+	ContactLinkedin ContactPreference = "LINKEDIN"
 )
 
 const (
 	// Jobs
-	NotificationNewJob NotificationType = "NEW_JOB"
+	NotificationNewJob            NotificationType = "NEW_JOB"
+	NotificationSavedSearchMatch  NotificationType = "SAVED_SEARCH_MATCH"
+	NotificationSavedSearchDigest NotificationType = "SAVED_SEARCH_DIGEST"
+	NotificationBookmarkReminder  NotificationType = "BOOKMARK_REMINDER"
+	NotificationBookmarkClosed    NotificationType = "BOOKMARK_CLOSED"
 
 	// Applications
-	NotificationJobApplyIntent    NotificationType = "JOB_APPLY_INTENT"
-	NotificationJobApplied        NotificationType = "JOB_APPLIED"
-	NotificationApplicationStatus NotificationType = "APPLICATION_STATUS_UPDATE"
+	NotificationJobApplyIntent       NotificationType = "JOB_APPLY_INTENT"
+	NotificationJobApplied           NotificationType = "JOB_APPLIED"
+	NotificationIntentExpiring       NotificationType = "INTENT_EXPIRING"
+	NotificationApplicationStatus    NotificationType = "APPLICATION_STATUS_UPDATE"
+	NotificationApplicationWithdrawn NotificationType = "APPLICATION_WITHDRAWN"
+
+	// Interviews
+	NotificationInterviewScheduled         NotificationType = "INTERVIEW_SCHEDULED"
+	NotificationInterviewRescheduleRequest NotificationType = "INTERVIEW_RESCHEDULE_REQUEST"
+	NotificationInterviewCancelled         NotificationType = "INTERVIEW_CANCELLED"
 
 	// Discussions
 	NotificationDiscussionUpdate NotificationType = "DISCUSSION_UPDATE"
 )
+
+const (
+	WebhookJobCreated               WebhookEvent = "job.created"
+	WebhookJobUpdated               WebhookEvent = "job.updated"
+	WebhookJobDeleted               WebhookEvent = "job.deleted"
+	WebhookApplicationConfirmed     WebhookEvent = "application.confirmed"
+	WebhookApplicationStatusChanged WebhookEvent = "application.status_changed"
+)
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "PENDING"
+	DeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	DeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+const (
+	AlertInstant AlertFrequency = "INSTANT"
+	AlertDaily   AlertFrequency = "DAILY"
+)
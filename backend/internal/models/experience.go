@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type Experience struct {
+	ID uint `gorm:"primaryKey"`
+
+	AuthorID uint `gorm:"not null;index"`
+	Author   User `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	CollegeID uint `gorm:"not null;index"`
+
+	Company string `gorm:"not null;index"`
+	Role    string `gorm:"not null"`
+	Year    int    `gorm:"not null;index"`
+
+	// JSON array of {name, description}
+	Rounds datatypes.JSON
+	// JSON array of strings
+	Questions datatypes.JSON
+
+	Difficulty ExperienceDifficulty `gorm:"type:varchar(20);not null"`
+	Verdict    ExperienceVerdict    `gorm:"type:varchar(20);not null"`
+
+	Content string `gorm:"type:text"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ExperienceComment struct {
+	ID uint `gorm:"primaryKey"`
+
+	ExperienceID uint       `gorm:"not null;index"`
+	Experience   Experience `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	// flat list + parent_id threads
+	ParentID *uint `gorm:"index"`
+
+	AuthorID uint `gorm:"not null;index"`
+	Author   User `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Content string `gorm:"type:text;not null"`
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type InterviewSlot struct {
+	ID uint `gorm:"primaryKey"`
+
+	JobID uint `gorm:"not null;index"`
+	Job   Job  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	CollegeID uint `gorm:"not null;index"`
+
+	StartsAt        time.Time `gorm:"not null;index"`
+	DurationMinutes int       `gorm:"not null"`
+
+	Venue       *string `gorm:"type:text"`
+	MeetingLink *string `gorm:"type:text"`
+
+	// interviewer names, JSON array of strings
+	Panel datatypes.JSON
+
+	// nil while the slot is free
+	ApplicationID *uint        `gorm:"uniqueIndex"`
+	Application   *Application `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
+	RescheduleReason      *string `gorm:"type:text"`
+	RescheduleRequestedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
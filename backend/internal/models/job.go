@@ -28,6 +28,8 @@ type Job struct {
 	Description string `gorm:"type:text"`
 	Rounds      datatypes.JSON
 
+	RegistrationDeadline *time.Time `gorm:"index"`
+
 	IsActive bool `gorm:"default:true"`
 
 	CreatedAt time.Time
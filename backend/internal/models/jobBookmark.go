@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type JobBookmark struct {
+	ID uint `gorm:"primaryKey"`
+
+	JobID     uint `gorm:"not null;index;uniqueIndex:uniq_bookmark"`
+	StudentID uint `gorm:"not null;index;uniqueIndex:uniq_bookmark"`
+	CollegeID uint `gorm:"not null;index"`
+
+	// set once the deadline reminder has been sent
+	RemindedAt *time.Time
+
+	CreatedAt time.Time
+}
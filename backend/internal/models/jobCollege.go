@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// JobCollege shares a job with a college other than the one that posted
+// it (a pooled off-campus drive). Each participating college has its own
+// eligible batches and may hide the drive from its students.
+type JobCollege struct {
+	JobID     uint `gorm:"primaryKey"`
+	CollegeID uint `gorm:"primaryKey;index"`
+
+	EligibleBatches datatypes.JSON `gorm:"not null"`
+
+	// no gorm default: a false value must survive Create
+	IsVisible bool `gorm:"not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// RecruiterJob lets a recruiter see one college's applicants for a job.
+// It is granted by that college's admin, so for a pooled drive each
+// participating college shares only its own students.
+type RecruiterJob struct {
+	RecruiterID uint `gorm:"primaryKey"`
+	JobID       uint `gorm:"primaryKey;index"`
+	CollegeID   uint `gorm:"primaryKey;index"`
+
+	InvitedBy uint `gorm:"not null"`
+
+	CreatedAt time.Time
+}
+
+// RecruiterInvite is a pending invitation for someone without an account.
+// Only the token's hash is stored.
+type RecruiterInvite struct {
+	ID uint `gorm:"primaryKey"`
+
+	Email     string `gorm:"not null;index"`
+	Name      string `gorm:"not null"`
+	CompanyID uint   `gorm:"not null"`
+	CollegeID uint   `gorm:"not null;index"`
+	InvitedBy uint   `gorm:"not null"`
+
+	// job ids granted on acceptance
+	JobIDs datatypes.JSON `gorm:"not null"`
+
+	TokenHash string `gorm:"uniqueIndex;not null"`
+
+	ExpiresAt  time.Time `gorm:"not null"`
+	AcceptedAt *time.Time
+
+	CreatedAt time.Time
+}
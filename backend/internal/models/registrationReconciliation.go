@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// RegistrationReconciliation is one upload of a job's registration form
+// responses by a college admin, with the report it produced.
+type RegistrationReconciliation struct {
+	ID uint `gorm:"primaryKey"`
+
+	JobID      uint `gorm:"not null;index"`
+	CollegeID  uint `gorm:"not null;index"`
+	UploadedBy uint `gorm:"not null"`
+
+	FileName  string
+	TotalRows int `gorm:"not null"`
+
+	Confirmed int `gorm:"not null"`
+	Verified  int `gorm:"not null"`
+	Missing   int `gorm:"not null"`
+	Unmatched int `gorm:"not null"`
+	Ambiguous int `gorm:"not null"`
+
+	// the full report, as returned by the upload
+	Report datatypes.JSON `gorm:"not null"`
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type SavedSearch struct {
+	ID uint `gorm:"primaryKey"`
+
+	StudentID uint `gorm:"not null;index"`
+	CollegeID uint `gorm:"not null;index"`
+
+	Name string `gorm:"not null"`
+
+	// Same shape as the GetJobs query params (jobs.JobFilter)
+	Filters datatypes.JSON `gorm:"not null"`
+
+	NotifyOnMatch bool `gorm:"default:true"`
+	// INSTANT alerts per job, DAILY collects matches into one digest
+	Frequency AlertFrequency `gorm:"type:varchar(10);not null;default:INSTANT"`
+
+	LastNotifiedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PendingJobAlert queues a new job for matching against saved searches.
+// It is written with the job, so no job is missed.
+type PendingJobAlert struct {
+	JobID uint `gorm:"primaryKey;autoIncrement:false"`
+
+	CreatedAt time.Time
+}
+
+// SavedSearchMatch is a job a saved search matched, kept until it is
+// sent: right away or in the search's next digest.
+type SavedSearchMatch struct {
+	SavedSearchID uint `gorm:"primaryKey;autoIncrement:false"`
+	JobID         uint `gorm:"primaryKey;autoIncrement:false;index"`
+
+	CreatedAt time.Time
+}
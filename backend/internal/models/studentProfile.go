@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
 
 type StudentProfile struct {
 	UserID          uint     `gorm:"primaryKey"`
@@ -9,6 +13,82 @@ type StudentProfile struct {
 	ProfileComplete bool     `gorm:"default:false"`
 	Batch           int
 	LinkedinID      string `gorm:"type:text"`
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+
+	// education
+	Branch            string   `gorm:"type:text"`
+	RollNumber        string   `gorm:"type:text;index"`
+	TenthPercentage   *float32 // nullable
+	TwelfthPercentage *float32 // nullable
+
+	Skills datatypes.JSON // ["Go", "React"]
+	// platform -> profile URL, e.g. {"github": "https://github.com/asha"}
+	CodingProfiles datatypes.JSON
+
+	// what recruiters of the jobs the student applied to may see: contact
+	// details only on opt-in, CGPA unless hidden
+	ShareContactWithRecruiters bool `gorm:"not null;default:false"`
+	HideCGPAFromRecruiters     bool `gorm:"not null;default:false"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type StudentProject struct {
+	ID     uint `gorm:"primaryKey"`
+	UserID uint `gorm:"not null;index"`
+
+	Title       string `gorm:"not null"`
+	Description string `gorm:"type:text"`
+	URL         string `gorm:"type:text"`
+
+	StartDate *time.Time
+	EndDate   *time.Time // nil while ongoing
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type StudentInternship struct {
+	ID     uint `gorm:"primaryKey"`
+	UserID uint `gorm:"not null;index"`
+
+	Company     string `gorm:"not null"`
+	Role        string `gorm:"not null"`
+	Description string `gorm:"type:text"`
+
+	StartDate *time.Time
+	EndDate   *time.Time // nil while ongoing
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type StudentCertification struct {
+	ID     uint `gorm:"primaryKey"`
+	UserID uint `gorm:"not null;index"`
+
+	Name          string `gorm:"not null"`
+	Issuer        string
+	IssuedOn      *time.Time
+	CredentialURL string `gorm:"type:text"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// StudentResume is one named version of a student's resume. The default
+// one is mirrored in StudentProfile.ResumeURL.
+type StudentResume struct {
+	ID     uint   `gorm:"primaryKey"`
+	UserID uint   `gorm:"not null;index;uniqueIndex:uniq_student_resume_name"`
+	Name   string `gorm:"not null;uniqueIndex:uniq_student_resume_name"`
+
+	URL        string `gorm:"type:text;not null"` // MinIO URL
+	ObjectPath string `gorm:"type:text;not null"`
+	Size       int64
+
+	IsDefault bool `gorm:"not null;default:false"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
@@ -12,6 +12,9 @@ type User struct {
 
 	CollegeID *uint
 	College   College
+
+	// set for recruiters only
+	CompanyID *uint  `gorm:"index"`
 	Role      string `gorm:"type:varchar(20);default:'student'" json:"-"`
 
 	CreatedAt time.Time
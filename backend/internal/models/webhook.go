@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// WebhookEndpoint is a URL a college admin subscribed to events of
+// their college.
+type WebhookEndpoint struct {
+	ID uint `gorm:"primaryKey"`
+
+	CollegeID uint   `gorm:"not null;index"`
+	URL       string `gorm:"type:text;not null"`
+	// HMAC key of the signatures; only shown when created or rotated
+	Secret string `gorm:"type:text;not null" json:"-"`
+	// []WebhookEvent
+	Events      datatypes.JSON `gorm:"not null"`
+	Description string         `gorm:"type:text"`
+	IsActive    bool           `gorm:"not null"`
+
+	CreatedBy uint `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookDelivery is one event for one endpoint, retried until it
+// succeeds or runs out of attempts.
+type WebhookDelivery struct {
+	ID uint `gorm:"primaryKey"`
+
+	EndpointID uint            `gorm:"not null;index"`
+	Endpoint   WebhookEndpoint `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	Event   WebhookEvent   `gorm:"type:varchar(50);not null"`
+	Payload datatypes.JSON `gorm:"not null"`
+
+	Status   WebhookDeliveryStatus `gorm:"type:varchar(20);not null;index"`
+	Attempts int                   `gorm:"not null"`
+	// when the worker picks it up next; nil once it is done
+	NextAttemptAt *time.Time `gorm:"index"`
+
+	LastStatusCode *int
+	LastError      string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
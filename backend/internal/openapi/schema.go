@@ -0,0 +1,270 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// generator turns Go types into schemas. Named structs become components
+// and are referenced, so recursive models terminate.
+type generator struct {
+	enums   map[reflect.Type][]any
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator(enums map[reflect.Type][]any) *generator {
+	return &generator{
+		enums:   enums,
+		schemas: map[string]*Schema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// schemaOf mirrors what encoding/json would produce for v.
+func (g *generator) schemaOf(v any) *Schema {
+	if obj, ok := v.(Object); ok {
+		return g.objectSchema(obj)
+	}
+	return g.schemaFor(reflect.TypeOf(v))
+}
+
+func (g *generator) objectSchema(obj Object) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for name, v := range obj {
+		s.Properties[name] = g.schemaOf(v)
+	}
+	return s
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	s := g.baseSchema(t)
+	if nullable {
+		s = withNullable(s)
+	}
+	return s
+}
+
+func (g *generator) baseSchema(t reflect.Type) *Schema {
+	if values, ok := g.enums[t]; ok {
+		return &Schema{Type: "string", Enum: values}
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType):
+		// custom JSON (datatypes.JSON, gorm.DeletedAt, ...): shape unknown
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Minimum: float(0)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	default:
+		return &Schema{}
+	}
+}
+
+func (g *generator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := g.schemas[name]; taken {
+		name = exportedName(path.Base(t.PkgPath())) + name
+	}
+
+	// register before recursing so self-references resolve
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for _, f := range jsonFields(t) {
+		fs := g.schemaFor(f.Type)
+		required := applyBinding(fs, f.Tag.Get("binding"))
+		if required {
+			s.Required = append(s.Required, f.name)
+			fs = withoutNullable(fs)
+		}
+		s.Properties[f.name] = fs
+	}
+	return s
+}
+
+type field struct {
+	reflect.StructField
+	name string
+}
+
+// jsonFields lists the fields encoding/json would emit, flattening
+// untagged embedded structs the same way.
+func jsonFields(t reflect.Type) []field {
+	return taggedFields(t, "json")
+}
+
+func taggedFields(t reflect.Type, tag string) []field {
+	var out []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			out = append(out, taggedFields(ft, tag)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		out = append(out, field{StructField: f, name: name})
+	}
+	return out
+}
+
+// applyBinding copies the validator rules gin enforces onto the schema
+// and reports whether the field is required. Rules after "dive" apply to
+// elements and are ignored.
+func applyBinding(s *Schema, binding string) bool {
+	required := false
+	target := s
+	if len(s.AllOf) == 1 {
+		target = s.AllOf[0]
+	}
+
+	for _, rule := range strings.Split(binding, ",") {
+		key, arg, _ := strings.Cut(rule, "=")
+		switch key {
+		case "dive":
+			return required
+		case "required":
+			required = true
+		case "email":
+			target.Format = "email"
+		case "oneof":
+			for _, v := range strings.Fields(arg) {
+				target.Enum = append(target.Enum, v)
+			}
+		case "min", "max":
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				continue
+			}
+			setBound(target, key == "min", n)
+		}
+	}
+	return required
+}
+
+func setBound(s *Schema, lower bool, n int) {
+	switch s.Type {
+	case "array":
+		if lower {
+			s.MinItems = &n
+		} else {
+			s.MaxItems = &n
+		}
+	case "string":
+		if lower {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case "integer", "number":
+		if lower {
+			s.Minimum = float(float64(n))
+		} else {
+			s.Maximum = float(float64(n))
+		}
+	}
+}
+
+// $ref siblings are ignored in 3.0, so nullable refs go through allOf.
+func withNullable(s *Schema) *Schema {
+	if s.Ref != "" {
+		return &Schema{AllOf: []*Schema{s}, Nullable: true}
+	}
+	if s.Type == "" {
+		return s
+	}
+	s.Nullable = true
+	return s
+}
+
+func withoutNullable(s *Schema) *Schema {
+	if len(s.AllOf) == 1 && s.Nullable {
+		return s.AllOf[0]
+	}
+	s.Nullable = false
+	return s
+}
+
+func float(f float64) *float64 { return &f }
+
+func exportedName(s string) string {
+	r := []rune(s)
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+	return string(r)
+}
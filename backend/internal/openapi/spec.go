@@ -0,0 +1,333 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Object describes an ad-hoc gin.H response: each value is a zero value
+// of the type the handler puts under that key.
+type Object map[string]any
+
+// Route documents one registered gin route.
+type Route struct {
+	Summary     string
+	Description string
+
+	// Public routes need no session cookie. Roles are the ones passed to
+	// RequireRole; empty means any signed-in user.
+	Public bool
+	Roles  []string
+
+	Body  any // JSON request body
+	Query any // struct with form tags, as bound by ShouldBindQuery
+
+	// OptionalBody lets clients leave Body out entirely.
+	OptionalBody bool
+
+	// FileField is the multipart field of an upload endpoint.
+	FileField string
+
+	Status   int // success status, 200 by default
+	Response any // JSON body; nil with ContentType set for non-JSON
+	// ContentType overrides application/json for the success response.
+	ContentType string
+}
+
+// Spec is the hand-maintained route table the document is generated
+// from. It is checked against the gin route table (see Diff).
+type Spec struct {
+	info   Info
+	routes map[string]Route
+	enums  map[reflect.Type][]any
+
+	once     sync.Once
+	doc      *Document
+	compiled map[string]*compiledOp
+}
+
+func New(info Info) *Spec {
+	return &Spec{
+		info:   info,
+		routes: map[string]Route{},
+		enums:  map[reflect.Type][]any{},
+	}
+}
+
+// Key is how routes are identified: "GET /api/jobs/:id".
+func Key(method, path string) string {
+	return method + " " + path
+}
+
+// Route adds an operation; path uses gin syntax.
+func (s *Spec) Route(method, path string, r Route) {
+	s.routes[Key(method, path)] = r
+}
+
+// Enum lists the allowed values of a string type. All values must share
+// one type.
+func (s *Spec) Enum(values ...any) {
+	if len(values) == 0 {
+		return
+	}
+	s.enums[reflect.TypeOf(values[0])] = values
+}
+
+// Diff reports gin routes missing from the spec and spec entries with no
+// gin route behind them.
+func (s *Spec) Diff(routes gin.RoutesInfo) (undocumented, stale []string) {
+	registered := map[string]bool{}
+	for _, r := range routes {
+		key := Key(r.Method, r.Path)
+		registered[key] = true
+		if _, ok := s.routes[key]; !ok {
+			undocumented = append(undocumented, key)
+		}
+	}
+	for key := range s.routes {
+		if !registered[key] {
+			stale = append(stale, key)
+		}
+	}
+
+	sort.Strings(undocumented)
+	sort.Strings(stale)
+	return undocumented, stale
+}
+
+// Build returns the document, refusing to describe a route table it
+// does not match.
+func (s *Spec) Build(routes gin.RoutesInfo) (*Document, error) {
+	undocumented, stale := s.Diff(routes)
+	if len(undocumented) > 0 || len(stale) > 0 {
+		return nil, fmt.Errorf(
+			"openapi: spec out of sync (undocumented: %v, stale: %v)",
+			undocumented, stale,
+		)
+	}
+
+	s.compile()
+	return s.doc, nil
+}
+
+// Handler serves the document for engine. It is built on first request,
+// once every route is registered.
+func (s *Spec) Handler(engine *gin.Engine) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  *Document
+		err  error
+	)
+	return func(c *gin.Context) {
+		once.Do(func() { doc, err = s.Build(engine.Routes()) })
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// compiledOp keeps what the validator needs per route.
+type compiledOp struct {
+	op   *Operation
+	body *Schema
+}
+
+func (s *Spec) compile() {
+	s.once.Do(func() {
+		g := newGenerator(s.enums)
+		doc := &Document{
+			OpenAPI: "3.0.3",
+			Info:    s.info,
+			Paths:   map[string]PathItem{},
+			Components: Components{
+				SecuritySchemes: map[string]SecurityScheme{
+					"cookieAuth": {
+						Type:        "apiKey",
+						In:          "cookie",
+						Name:        "portal_token",
+						Description: "session cookie set by /api/auth/login and the SSO callback",
+					},
+				},
+			},
+		}
+		g.schemas["Error"] = &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"error":      {Type: "string"},
+				"request_id": {Type: "string", Description: "also sent as X-Request-ID"},
+			},
+			Required: []string{"error"},
+		}
+
+		// sorted so component names are stable across runs
+		keys := make([]string, 0, len(s.routes))
+		for key := range s.routes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		s.compiled = map[string]*compiledOp{}
+		for _, key := range keys {
+			method, ginPath, _ := strings.Cut(key, " ")
+			op, body := buildOperation(g, method, ginPath, s.routes[key])
+
+			p := oasPath(ginPath)
+			if doc.Paths[p] == nil {
+				doc.Paths[p] = PathItem{}
+			}
+			doc.Paths[p][strings.ToLower(method)] = op
+			s.compiled[key] = &compiledOp{op: op, body: body}
+		}
+
+		doc.Components.Schemas = g.schemas
+		s.doc = doc
+	})
+}
+
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// oasPath turns /jobs/:id into /jobs/{id}.
+func oasPath(ginPath string) string {
+	return pathParam.ReplaceAllString(ginPath, "{$1}")
+}
+
+func buildOperation(g *generator, method, ginPath string, r Route) (*Operation, *Schema) {
+	op := &Operation{
+		OperationID: operationID(method, ginPath),
+		Summary:     r.Summary,
+		Description: r.Description,
+		Tags:        []string{tagOf(ginPath)},
+		Responses:   map[string]Response{},
+		Roles:       r.Roles,
+	}
+
+	for _, m := range pathParam.FindAllStringSubmatch(ginPath, -1) {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   pathParamSchema(m[1]),
+		})
+	}
+	if r.Query != nil {
+		op.Parameters = append(op.Parameters, queryParams(g, reflect.TypeOf(r.Query))...)
+	}
+
+	var body *Schema
+	switch {
+	case r.Body != nil:
+		body = g.schemaOf(r.Body)
+		op.RequestBody = &RequestBody{
+			Required: !r.OptionalBody,
+			Content:  map[string]MediaType{"application/json": {Schema: body}},
+		}
+	case r.FileField != "":
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{"multipart/form-data": {Schema: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					r.FileField: {Type: "string", Format: "binary"},
+				},
+				Required: []string{r.FileField},
+			}}},
+		}
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := Response{Description: http.StatusText(status)}
+	switch {
+	case r.ContentType != "":
+		success.Content = map[string]MediaType{r.ContentType: {Schema: &Schema{Type: "string"}}}
+	case r.Response != nil:
+		success.Content = map[string]MediaType{"application/json": {Schema: g.schemaOf(r.Response)}}
+	}
+	op.Responses[fmt.Sprint(status)] = success
+
+	errorBody := map[string]MediaType{
+		"application/json": {Schema: &Schema{Ref: "#/components/schemas/Error"}},
+	}
+	op.Responses["default"] = Response{Description: "error", Content: errorBody}
+	if !r.Public {
+		op.Security = []map[string][]string{{"cookieAuth": {}}}
+		op.Responses["401"] = Response{Description: "not signed in", Content: errorBody}
+		if len(r.Roles) > 0 {
+			op.Responses["403"] = Response{Description: "role not allowed", Content: errorBody}
+		}
+	}
+
+	return op, body
+}
+
+// queryParams expands a query struct the way gin's form binding reads it.
+func queryParams(g *generator, t reflect.Type) []Parameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var params []Parameter
+	for _, f := range taggedFields(t, "form") {
+		s := g.schemaFor(f.Type)
+		required := applyBinding(s, f.Tag.Get("binding"))
+		if len(s.AllOf) == 1 {
+			s = s.AllOf[0]
+		}
+		s.Nullable = false
+		if f.Type == timeType || (f.Type.Kind() == reflect.Ptr && f.Type.Elem() == timeType) {
+			s = &Schema{Type: "string", Format: "date-time"}
+		}
+		params = append(params, Parameter{
+			Name:     f.name,
+			In:       "query",
+			Required: required,
+			Schema:   s,
+		})
+	}
+	return params
+}
+
+// ids are numeric by convention; anything else (tokens) is a string.
+func pathParamSchema(name string) *Schema {
+	if name == "id" || strings.HasSuffix(name, "_id") {
+		return &Schema{Type: "integer", Format: "int64", Minimum: float(1)}
+	}
+	return &Schema{Type: "string"}
+}
+
+// tagOf groups by the first segment after /api.
+func tagOf(ginPath string) string {
+	parts := strings.Split(strings.TrimPrefix(ginPath, "/api"), "/")
+	for _, p := range parts {
+		if p != "" && !strings.HasPrefix(p, ":") {
+			return strings.TrimSuffix(p, ".json")
+		}
+	}
+	return "default"
+}
+
+// operationID is derived from the route, e.g. "patch_jobs_by_id".
+func operationID(method, ginPath string) string {
+	parts := []string{strings.ToLower(method)}
+	for _, p := range strings.Split(strings.TrimPrefix(ginPath, "/api"), "/") {
+		switch {
+		case p == "":
+		case strings.HasPrefix(p, ":"), strings.HasPrefix(p, "*"):
+			parts = append(parts, "by", p[1:])
+		default:
+			parts = append(parts, strings.NewReplacer("-", "_", ".", "_").Replace(p))
+		}
+	}
+	return strings.Join(parts, "_")
+}
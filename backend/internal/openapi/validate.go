@@ -0,0 +1,273 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxValidatedBody = 1 << 20
+
+// Validator rejects requests whose path parameters, query string or JSON
+// body do not match the operation documented for c.FullPath(). Routes
+// the spec does not know pass through untouched; handlers still bind
+// and validate on their own, so this is an early, uniform 400.
+func (s *Spec) Validator() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		s.compile()
+		compiled, ok := s.compiled[Key(c.Request.Method, c.FullPath())]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		v := &validation{components: s.doc.Components.Schemas}
+
+		for _, p := range compiled.op.Parameters {
+			switch p.In {
+			case "path":
+				v.param("path."+p.Name, c.Param(p.Name), p.Schema)
+			case "query":
+				values, present := c.GetQueryArray(p.Name)
+				if !present {
+					if p.Required {
+						v.fail("query."+p.Name, "is required")
+					}
+					continue
+				}
+				for _, raw := range values {
+					v.param("query."+p.Name, raw, p.Schema)
+				}
+			}
+		}
+
+		if compiled.body != nil && isJSON(c.ContentType()) {
+			v.body(c, compiled.body, compiled.op.RequestBody.Required)
+		}
+
+		if len(v.errors) > 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "request does not match the API schema",
+				"details": v.errors,
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+func isJSON(contentType string) bool {
+	return contentType == "" || strings.HasSuffix(contentType, "json")
+}
+
+type validation struct {
+	components map[string]*Schema
+	errors     []string
+}
+
+func (v *validation) fail(at, format string, args ...any) {
+	v.errors = append(v.errors, at+": "+fmt.Sprintf(format, args...))
+}
+
+// body reads and restores the request body so the handler can bind it.
+// An optional body may be missing or empty.
+func (v *validation) body(c *gin.Context, schema *Schema, required bool) {
+	if c.Request.Body == nil {
+		if required {
+			v.fail("body", "is required")
+		}
+		return
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(c.Request.Body, maxValidatedBody))
+	_ = c.Request.Body.Close()
+	c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+	if err != nil {
+		v.fail("body", "could not be read")
+		return
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		if required {
+			v.fail("body", "is required")
+		}
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		v.fail("body", "is not valid JSON")
+		return
+	}
+
+	v.value("body", doc, schema)
+}
+
+func (v *validation) resolve(s *Schema) *Schema {
+	for s != nil {
+		switch {
+		case s.Ref != "":
+			s = v.components[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		case len(s.AllOf) == 1:
+			s = s.AllOf[0]
+		default:
+			return s
+		}
+	}
+	return &Schema{}
+}
+
+func (v *validation) value(at string, val any, schema *Schema) {
+	nullable := schema.Nullable
+	s := v.resolve(schema)
+
+	if val == nil {
+		if !nullable && !s.Nullable && s.Type != "" {
+			v.fail(at, "must not be null")
+		}
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := val.(map[string]any)
+		if !ok {
+			v.fail(at, "must be an object")
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				v.fail(at+"."+name, "is required")
+			}
+		}
+		for name, fv := range obj {
+			if ps, ok := s.Properties[name]; ok {
+				v.value(at+"."+name, fv, ps)
+			} else if s.AdditionalProperties != nil {
+				v.value(at+"."+name, fv, s.AdditionalProperties)
+			}
+		}
+
+	case "array":
+		arr, ok := val.([]any)
+		if !ok {
+			v.fail(at, "must be an array")
+			return
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			v.fail(at, "must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(arr) > *s.MaxItems {
+			v.fail(at, "must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				v.value(fmt.Sprintf("%s[%d]", at, i), item, s.Items)
+			}
+		}
+
+	case "string":
+		str, ok := val.(string)
+		if !ok {
+			v.fail(at, "must be a string")
+			return
+		}
+		v.str(at, str, s)
+
+	case "integer", "number":
+		n, ok := val.(json.Number)
+		if !ok {
+			v.fail(at, "must be a %s", s.Type)
+			return
+		}
+		v.number(at, n.String(), s)
+
+	case "boolean":
+		if _, ok := val.(bool); !ok {
+			v.fail(at, "must be a boolean")
+		}
+	}
+}
+
+// param checks a path or query value, which always arrives as a string.
+func (v *validation) param(at, raw string, s *Schema) {
+	switch s.Type {
+	case "integer", "number":
+		v.number(at, raw, s)
+	case "boolean":
+		if _, err := strconv.ParseBool(raw); err != nil {
+			v.fail(at, "must be a boolean")
+		}
+	case "string":
+		v.str(at, raw, s)
+	}
+}
+
+func (v *validation) number(at, raw string, s *Schema) {
+	var f float64
+	if s.Type == "integer" {
+		i, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			v.fail(at, "must be an integer")
+			return
+		}
+		f = float64(i)
+	} else {
+		var err error
+		if f, err = strconv.ParseFloat(raw, 64); err != nil {
+			v.fail(at, "must be a number")
+			return
+		}
+	}
+
+	if s.Minimum != nil && f < *s.Minimum {
+		v.fail(at, "must be >= %v", *s.Minimum)
+	}
+	if s.Maximum != nil && f > *s.Maximum {
+		v.fail(at, "must be <= %v", *s.Maximum)
+	}
+}
+
+func (v *validation) str(at, str string, s *Schema) {
+	if len(s.Enum) > 0 && !inEnum(str, s.Enum) {
+		v.fail(at, "must be one of %v", s.Enum)
+	}
+
+	switch s.Format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			v.fail(at, "must be an RFC 3339 date-time")
+		}
+	case "email":
+		if _, err := mail.ParseAddress(str); err != nil {
+			v.fail(at, "must be an email address")
+		}
+	}
+
+	n := utf8.RuneCountInString(str)
+	if s.MinLength != nil && n < *s.MinLength {
+		v.fail(at, "must be at least %d characters", *s.MinLength)
+	}
+	if s.MaxLength != nil && n > *s.MaxLength {
+		v.fail(at, "must be at most %d characters", *s.MaxLength)
+	}
+}
+
+func inEnum(s string, values []any) bool {
+	for _, v := range values {
+		if fmt.Sprint(v) == s {
+			return true
+		}
+	}
+	return false
+}
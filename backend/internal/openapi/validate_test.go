@@ -0,0 +1,127 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type level string
+
+type slot struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	Minutes  int       `json:"minutes" binding:"required,min=5,max=480"`
+	Venue    *string   `json:"venue"`
+}
+
+type createRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Level  level    `json:"level"`
+	Parent *slot    `json:"parent"`
+	Slots  []slot   `json:"slots" binding:"required,min=1,dive"`
+	Tags   []string `json:"tags"`
+}
+
+type listQuery struct {
+	Page  int   `form:"page" binding:"min=1"`
+	Level level `form:"level"`
+}
+
+func newTestEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	s := New(Info{Title: "test", Version: "0"})
+	s.Enum(level("LOW"), level("HIGH"))
+	s.Route(http.MethodPost, "/things/:id", Route{Body: createRequest{}, Query: listQuery{}})
+	s.Route(http.MethodPost, "/things/:id/slots", Route{Body: slot{}, OptionalBody: true})
+
+	r := gin.New()
+	r.Use(s.Validator())
+	r.POST("/things/:id", func(c *gin.Context) {
+		var req createRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	r.POST("/things/:id/slots", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	r.POST("/other", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return r
+}
+
+func TestValidator(t *testing.T) {
+	r := newTestEngine()
+	valid := `{"name":"x","level":"LOW","slots":[{"starts_at":"2026-03-01T10:00:00Z","minutes":30}]}`
+
+	cases := []struct {
+		name, target, body string
+		want               int
+		detail             string
+	}{
+		{"valid, body still bindable", "/things/1?page=2", valid, http.StatusNoContent, ""},
+		{"undocumented route", "/other", `nope`, http.StatusNoContent, ""},
+		{"path id", "/things/x", valid, http.StatusBadRequest, "path.id"},
+		{"query minimum", "/things/1?page=0", valid, http.StatusBadRequest, "query.page"},
+		{"query enum", "/things/1?level=MID", valid, http.StatusBadRequest, "query.level"},
+		{"malformed", "/things/1", `{`, http.StatusBadRequest, "not valid JSON"},
+		{"missing required", "/things/1", `{"slots":[]}`, http.StatusBadRequest, "body.name: is required"},
+		{"min items", "/things/1", `{"name":"x","slots":[]}`, http.StatusBadRequest, "at least 1"},
+		{"nested ref", "/things/1", `{"name":"x","slots":[{"starts_at":"soon","minutes":3}]}`, http.StatusBadRequest, "body.slots[0].starts_at"},
+		{"nullable ok", "/things/1", `{"name":"x","parent":null,"slots":[{"starts_at":"2026-03-01T10:00:00Z","minutes":30,"venue":null}]}`, http.StatusNoContent, ""},
+		{"required body missing", "/things/1", ``, http.StatusBadRequest, "body: is required"},
+		{"optional body missing", "/things/1/slots", ``, http.StatusNoContent, ""},
+		{"optional body still checked", "/things/1/slots", `{"minutes":"x"}`, http.StatusBadRequest, "body.minutes"},
+		{"wrong type", "/things/1", `{"name":1,"tags":"a","slots":[{"starts_at":"2026-03-01T10:00:00Z","minutes":30.5}]}`, http.StatusBadRequest, "body.tags: must be an array"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tc.target, strings.NewReader(tc.body)))
+
+			if w.Code != tc.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tc.want, w.Body)
+			}
+			if tc.detail != "" && !strings.Contains(w.Body.String(), tc.detail) {
+				t.Fatalf("body %s does not mention %q", w.Body, tc.detail)
+			}
+		})
+	}
+}
+
+func TestSchemaFollowsEncodingJSON(t *testing.T) {
+	type base struct {
+		ID uint
+	}
+	type model struct {
+		base
+		Name    string `json:"name,omitempty"`
+		Secret  string `json:"-"`
+		private int
+		Self    *model `json:"self"`
+	}
+
+	g := newGenerator(nil)
+	ref := g.schemaFor(reflect.TypeOf(model{}))
+	if ref.Ref != "#/components/schemas/model" {
+		t.Fatalf("ref = %q", ref.Ref)
+	}
+
+	props := g.schemas["model"].Properties
+	for _, name := range []string{"ID", "name", "self"} {
+		if props[name] == nil {
+			t.Errorf("property %s missing", name)
+		}
+	}
+	if len(props) != 3 {
+		t.Errorf("properties = %v", props)
+	}
+	if !props["self"].Nullable || props["self"].AllOf[0].Ref != ref.Ref {
+		t.Errorf("self = %+v", props["self"])
+	}
+}
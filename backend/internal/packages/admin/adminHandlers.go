@@ -2,17 +2,20 @@ package admin
 
 import (
 	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/audit"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
+type CreateCollegeRequest struct {
+	Name   string `json:"name" binding:"required"`
+	Domain string `json:"domain" binding:"required"`
+}
+
 func CreateCollege(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req struct {
-			Name   string `json:"name" binding:"required"`
-			Domain string `json:"domain" binding:"required"`
-		}
+		var req CreateCollegeRequest
 
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(400, gin.H{"error": err.Error()})
@@ -24,7 +27,21 @@ func CreateCollege(db *gorm.DB) gin.HandlerFunc {
 			Domain: req.Domain,
 		}
 
-		if err := db.Create(&college).Error; err != nil {
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&college).Error; err != nil {
+				return err
+			}
+			// platform chain: the college has no admins yet
+			return audit.Record(tx, c, audit.Entry{
+				Action:     "college.create",
+				EntityType: "college",
+				EntityID:   college.ID,
+				Diff: audit.Created(map[string]interface{}{
+					"name":   college.Name,
+					"domain": college.Domain,
+				}),
+			})
+		}); err != nil {
 			c.JSON(400, gin.H{"error": "college already exists"})
 			return
 		}
@@ -0,0 +1,251 @@
+package alumni
+
+import (
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GraduateStudents moves students of the admin's college to the alumni
+// role. Their login keeps working; only the role changes.
+func GraduateStudents(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req GraduateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if req.Batch == 0 && len(req.UserIDs) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "batch or user_ids required"})
+			return
+		}
+
+		var students []struct {
+			ID    uint
+			Batch int
+		}
+
+		query := db.
+			Table("users").
+			Select("users.id, student_profiles.batch").
+			Joins("JOIN student_profiles ON student_profiles.user_id = users.id").
+			Where("users.college_id = ?", auth.CollegeID).
+			Where("users.role = ?", models.Student)
+
+		if req.Batch != 0 {
+			query = query.Where("student_profiles.batch = ?", req.Batch)
+		}
+		if len(req.UserIDs) > 0 {
+			query = query.Where("users.id IN ?", req.UserIDs)
+		}
+
+		if err := query.Scan(&students).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+			return
+		}
+
+		if len(req.UserIDs) > 0 && len(students) != len(req.UserIDs) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "one or more users are not students of your college with a batch set",
+			})
+			return
+		}
+
+		if len(students) == 0 {
+			c.JSON(http.StatusOK, gin.H{"graduated_count": 0})
+			return
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			ids := make([]uint, 0, len(students))
+			profiles := make([]models.AlumniProfile, 0, len(students))
+			for _, s := range students {
+				ids = append(ids, s.ID)
+				profiles = append(profiles, models.AlumniProfile{
+					UserID:            s.ID,
+					CollegeID:         *auth.CollegeID,
+					GraduationBatch:   s.Batch,
+					ContactPreference: models.ContactNone,
+				})
+			}
+
+			if err := tx.Model(&models.User{}).
+				Where("id IN ?", ids).
+				Update("role", models.Alumni).Error; err != nil {
+				return err
+			}
+
+			return tx.Create(&profiles).Error
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to graduate students"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"graduated_count": len(students)})
+	}
+}
+
+func GetMyAlumniProfile(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var profile models.AlumniProfile
+		if err := db.
+			Preload("User").
+			Where("user_id = ?", auth.UserID).
+			First(&profile).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "alumni profile not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch profile"})
+			return
+		}
+
+		c.JSON(http.StatusOK, AlumniProfileResponse{
+			UserID:            profile.UserID,
+			Name:              profile.User.Name,
+			CurrentCompany:    profile.CurrentCompany,
+			CurrentRole:       profile.CurrentRole,
+			GraduationBatch:   profile.GraduationBatch,
+			ContactPreference: profile.ContactPreference,
+			IsPublic:          profile.IsPublic,
+		})
+	}
+}
+
+func UpdateMyAlumniProfile(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req UpdateAlumniProfileRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		updates := map[string]interface{}{}
+
+		if req.CurrentCompany != nil {
+			updates["current_company"] = strings.TrimSpace(*req.CurrentCompany)
+		}
+		if req.CurrentRole != nil {
+			updates["current_role"] = strings.TrimSpace(*req.CurrentRole)
+		}
+		if req.ContactPreference != nil {
+			if !isValidContactPreference(*req.ContactPreference) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contact_preference"})
+				return
+			}
+			updates["contact_preference"] = *req.ContactPreference
+		}
+		if req.IsPublic != nil {
+			updates["is_public"] = *req.IsPublic
+		}
+
+		if len(updates) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "no fields to update"})
+			return
+		}
+
+		res := db.Model(&models.AlumniProfile{}).
+			Where("user_id = ?", auth.UserID).
+			Updates(updates)
+		if res.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update profile"})
+			return
+		}
+		if res.RowsAffected == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "alumni profile not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "profile updated"})
+	}
+}
+
+// SearchDirectory lists public alumni profiles of the caller's college.
+func SearchDirectory(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var q DirectoryQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		applyDefaults(&q)
+
+		query := db.
+			Table("alumni_profiles").
+			Joins("JOIN users ON users.id = alumni_profiles.user_id").
+			Joins("LEFT JOIN student_profiles ON student_profiles.user_id = alumni_profiles.user_id").
+			Where("alumni_profiles.college_id = ?", auth.CollegeID).
+			Where("alumni_profiles.is_public = true").
+			Where("users.role = ?", models.Alumni)
+
+		if company := strings.TrimSpace(q.Company); company != "" {
+			query = query.Where("alumni_profiles.current_company ILIKE ?", "%"+company+"%")
+		}
+		if q.Batch > 0 {
+			query = query.Where("alumni_profiles.graduation_batch = ?", q.Batch)
+		}
+		if search := strings.TrimSpace(q.Q); search != "" {
+			query = query.Where(
+				"(users.name ILIKE ? OR alumni_profiles.current_role ILIKE ?)",
+				"%"+search+"%",
+				"%"+search+"%",
+			)
+		}
+
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count alumni"})
+			return
+		}
+
+		var rows []directoryRow
+		if err := query.
+			Select(`
+				alumni_profiles.user_id,
+				users.name,
+				users.email,
+				student_profiles.linkedin_id,
+				alumni_profiles.current_company,
+				alumni_profiles.current_role,
+				alumni_profiles.graduation_batch,
+				alumni_profiles.contact_preference
+			`).
+			Order("alumni_profiles.graduation_batch DESC, users.name ASC").
+			Limit(q.Limit).
+			Offset((q.Page - 1) * q.Limit).
+			Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch alumni"})
+			return
+		}
+
+		data := make([]DirectoryEntry, 0, len(rows))
+		for _, r := range rows {
+			data = append(data, toDirectoryEntry(r))
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": data,
+			"meta": gin.H{
+				"page":  q.Page,
+				"limit": q.Limit,
+				"total": total,
+			},
+		})
+	}
+}
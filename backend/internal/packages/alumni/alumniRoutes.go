@@ -0,0 +1,44 @@
+package alumni
+
+import (
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	alumni := rg.Group("/alumni")
+	{
+		// Directory: same-college students, alumni and admins
+		alumni.GET(
+			"",
+			authorization.RequireRole(
+				string(models.Student),
+				string(models.Alumni),
+				string(models.CollegeAdmin),
+			),
+			SearchDirectory(db),
+		)
+
+		// Alumni only
+		alumni.GET(
+			"/me",
+			authorization.RequireRole(string(models.Alumni)),
+			GetMyAlumniProfile(db),
+		)
+		alumni.PATCH(
+			"/me",
+			authorization.RequireRole(string(models.Alumni)),
+			UpdateMyAlumniProfile(db),
+		)
+
+		// College admin only
+		alumni.POST(
+			"/graduate",
+			authorization.RequireRole(string(models.CollegeAdmin)),
+			GraduateStudents(db),
+		)
+	}
+}
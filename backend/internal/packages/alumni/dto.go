@@ -0,0 +1,45 @@
+package alumni
+
+import "iiitn-career-portal/internal/models"
+
+type GraduateRequest struct {
+	// either a whole batch or explicit students
+	Batch   int    `json:"batch"`
+	UserIDs []uint `json:"user_ids"`
+}
+
+type UpdateAlumniProfileRequest struct {
+	CurrentCompany    *string                   `json:"current_company"`
+	CurrentRole       *string                   `json:"current_role"`
+	ContactPreference *models.ContactPreference `json:"contact_preference"`
+	IsPublic          *bool                     `json:"is_public"`
+}
+
+type DirectoryQuery struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+
+	Company string `form:"company"`
+	Batch   int    `form:"batch"`
+	Q       string `form:"q"`
+}
+
+type AlumniProfileResponse struct {
+	UserID            uint                     `json:"user_id"`
+	Name              string                   `json:"name"`
+	CurrentCompany    string                   `json:"current_company"`
+	CurrentRole       string                   `json:"current_role"`
+	GraduationBatch   int                      `json:"graduation_batch"`
+	ContactPreference models.ContactPreference `json:"contact_preference"`
+	IsPublic          bool                     `json:"is_public"`
+}
+
+type DirectoryEntry struct {
+	UserID          uint    `json:"user_id"`
+	Name            string  `json:"name"`
+	CurrentCompany  string  `json:"current_company"`
+	CurrentRole     string  `json:"current_role"`
+	GraduationBatch int     `json:"graduation_batch"`
+	Email           *string `json:"email"`
+	LinkedinID      *string `json:"linkedin_id"`
+}
@@ -0,0 +1,56 @@
+package alumni
+
+import "iiitn-career-portal/internal/models"
+
+func isValidContactPreference(p models.ContactPreference) bool {
+	switch p {
+	case models.ContactNone, models.ContactEmail, models.ContactLinkedin:
+		return true
+	default:
+		return false
+	}
+}
+
+func applyDefaults(q *DirectoryQuery) {
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.Limit <= 0 || q.Limit > 50 {
+		q.Limit = 20
+	}
+}
+
+type directoryRow struct {
+	UserID            uint
+	Name              string
+	Email             string
+	LinkedinID        *string
+	CurrentCompany    string
+	CurrentRole       string
+	GraduationBatch   int
+	ContactPreference models.ContactPreference
+}
+
+// toDirectoryEntry applies the alumnus' privacy choice: only the contact
+// channel they picked is exposed.
+func toDirectoryEntry(r directoryRow) DirectoryEntry {
+	entry := DirectoryEntry{
+		UserID:          r.UserID,
+		Name:            r.Name,
+		CurrentCompany:  r.CurrentCompany,
+		CurrentRole:     r.CurrentRole,
+		GraduationBatch: r.GraduationBatch,
+	}
+
+	switch r.ContactPreference {
+	case models.ContactEmail:
+		email := r.Email
+		entry.Email = &email
+	case models.ContactLinkedin:
+		if r.LinkedinID != nil && *r.LinkedinID != "" {
+			entry.LinkedinID = r.LinkedinID
+		}
+	}
+
+	return entry
+}
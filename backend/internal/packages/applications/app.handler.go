@@ -1,337 +1,380 @@
 package applications
 
 import (
-	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/audit"
 	"iiitn-career-portal/internal/packages/authorization"
-	"log"
+	"iiitn-career-portal/internal/pagination"
+	"io"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
-	"gorm.io/gorm"
 )
 
-func ConfirmApplication(db *gorm.DB, rdb *redis.Client) gin.HandlerFunc {
+func ConfirmApplication(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		// 1️⃣ Parse intent ID
-		intentID, err := strconv.Atoi(c.Param("intent_id"))
-		if err != nil || intentID <= 0 {
+		intentID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || intentID == 0 {
 			c.JSON(400, gin.H{"error": "invalid intent id"})
 			return
 		}
 
-		// 2️⃣ Fetch intent
-		var intent models.ApplicationIntent
-		if err := db.
-			Where("id = ?", intentID).
-			Where("student_id = ?", auth.UserID).
-			First(&intent).Error; err != nil {
-
-			c.JSON(404, gin.H{"error": "application intent not found"})
+		// the body is optional
+		var req ConfirmRequest
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		// 3️⃣ Check expiry
-		if time.Now().After(intent.ExpiresAt) {
-			_ = db.Delete(&intent)
-			c.JSON(400, gin.H{"error": "application intent expired"})
+		if _, err := svc.Confirm(c.Request.Context(), auth, uint(intentID), req); err != nil {
+			writeServiceError(c, err, "failed to finalize application")
 			return
 		}
 
-		// 4️⃣ Fetch job (safety)
-		var job models.Job
-		if err := db.
-			Where("id = ?", intent.JobID).
-			Where("college_id = ?", intent.CollegeID).
-			First(&job).Error; err != nil {
+		c.JSON(200, gin.H{
+			"message": "application confirmed",
+		})
+	}
+}
 
-			c.JSON(404, gin.H{"error": "job not found"})
+func BulkUpdateApplicationStatus(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req BulkStatusUpdateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
 			return
 		}
 
-		tx := db.Begin()
-
-		// 5️⃣ Create application
-		app := models.Application{
-			JobID:     intent.JobID,
-			StudentID: intent.StudentID,
-			CollegeID: intent.CollegeID,
-			Status:    models.Applied,
+		apps, err := svc.BulkUpdateStatus(audit.Context(c), auth, req.ApplicationIDs, req.NewStatus)
+		if err != nil {
+			writeServiceError(c, err, "failed to update application statuses")
+			return
 		}
 
-		if err := tx.Create(&app).Error; err != nil {
-			tx.Rollback()
-			c.JSON(409, gin.H{"error": "application already exists"})
+		c.JSON(http.StatusOK, gin.H{
+			"updated_count": len(apps),
+			"new_status":    req.NewStatus,
+		})
+	}
+}
+
+func ListApplications(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var q ApplicationListQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
 			return
 		}
 
-		// 6️⃣ Delete intent
-		if err := tx.Delete(&intent).Error; err != nil {
-			tx.Rollback()
-			c.JSON(500, gin.H{"error": "failed to finalize application"})
+		page, q, err := svc.List(c.Request.Context(), auth, q)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch applications")
 			return
 		}
 
-		// 7️⃣ Create notification (DB)
-		payload, _ := json.Marshal(gin.H{
-			"job_id":  job.ID,
-			"title":   job.Title,
-			"company": job.Company,
+		var data any = page.Items
+		if auth.Role == string(models.Recruiter) {
+			if data, err = svc.Candidates(c.Request.Context(), page.Items); err != nil {
+				writeServiceError(c, err, "failed to fetch applications")
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": data,
+			"meta": pagination.Meta(q.Params, page.Total, page.NextCursor),
 		})
+	}
+}
+
+func GetApplicationByID(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		if err := tx.Create(&models.Notification{
-			UserID:   auth.UserID,
-			Type:     models.NotificationJobApplied,
-			TargetID: app.ID,
-			Payload:  payload,
-		}).Error; err != nil {
-			tx.Rollback()
-			c.JSON(500, gin.H{"error": "failed to notify"})
+		appID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid application id",
+			})
 			return
 		}
 
-		if err := tx.Commit().Error; err != nil {
-			c.JSON(500, gin.H{"error": "transaction failed"})
+		application, err := svc.Get(c.Request.Context(), auth, uint(appID))
+		if err != nil {
+			writeServiceError(c, err, "database error")
 			return
 		}
 
-		// 8️⃣ Push Redis notification (non-blocking)
-		redisPayload, _ := json.Marshal(gin.H{
-			"type":    models.NotificationJobApplied,
-			"job_id":  job.ID,
-			"title":   job.Title,
-			"company": job.Company,
-		})
-
-		_ = rdb.LPush(
-			context.Background(),
-			fmt.Sprintf("notifications:user:%d", auth.UserID),
-			redisPayload,
-		).Err()
+		if auth.Role == string(models.Recruiter) {
+			candidates, err := svc.Candidates(c.Request.Context(), []models.Application{application})
+			if err != nil {
+				writeServiceError(c, err, "database error")
+				return
+			}
+			c.JSON(http.StatusOK, candidates[0])
+			return
+		}
 
-		// 9️⃣ Respond
-		c.JSON(200, gin.H{
-			"message": "application confirmed",
-		})
+		c.JSON(http.StatusOK, application)
 	}
 }
 
-func BulkUpdateApplicationStatus(db *gorm.DB, redisClient *redis.Client) gin.HandlerFunc {
+// DownloadResume redirects to the resume submitted with the application.
+func DownloadResume(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		var req BulkStatusUpdateRequest
-		if err := c.ShouldBindJSON(&req); err != nil {
+		appID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
+				"error": "invalid application id",
 			})
 			return
 		}
 
-		// extracted from auth middleware
-		collegeID := c.GetUint("college_id")
+		resumeURL, err := svc.ResumeURL(c.Request.Context(), auth, uint(appID))
+		if err != nil {
+			writeServiceError(c, err, "database error")
+			return
+		}
 
-		var applications []models.Application
+		c.Redirect(http.StatusFound, resumeURL)
+	}
+}
 
-		err := db.
-			Joins("JOIN jobs ON jobs.id = applications.job_id").
-			Where("applications.id IN ?", req.ApplicationIDs).
-			Where("jobs.college_id = ?", collegeID).
-			Find(&applications).Error
+// ListIntents returns the student's pending intents, so an application
+// started in a closed tab can still be confirmed.
+func ListIntents(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
 
+		intents, err := svc.Intents(c.Request.Context(), auth)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "database error",
-			})
+			writeServiceError(c, err, "failed to fetch intents")
 			return
 		}
 
-		// Security check: prevent cross-college updates
-		if len(applications) != len(req.ApplicationIDs) {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": "one or more applications do not belong to your college",
-			})
+		c.JSON(http.StatusOK, gin.H{"data": intents})
+	}
+}
+
+func GetIntentMetrics(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var q IntentMetricsQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		// Status transition validation
-		for _, app := range applications {
-			if !isValidTransition(app.Status, req.NewStatus) {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error": "invalid status transition",
-				})
-				return
-			}
+		metrics, err := svc.IntentMetrics(c.Request.Context(), auth, q)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch intent metrics")
+			return
 		}
 
-		// Transaction
-		tx := db.Begin()
-		if tx.Error != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "failed to start transaction",
-			})
+		c.JSON(http.StatusOK, gin.H{"data": metrics})
+	}
+}
+
+// multipart framing allowed on top of the responses file
+const multipartSlack = 64 << 10
+
+// ReconcileRegistrations takes the job's registration form responses as
+// the multipart "responses" file, a CSV export.
+func ReconcileRegistrations(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var q ReconcileQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		if err := tx.
-			Model(&models.Application{}).
-			Where("id IN ?", req.ApplicationIDs).
-			Update("status", req.NewStatus).Error; err != nil {
-
-			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "failed to update application statuses",
-			})
+		// cut oversized uploads off while streaming
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxResponsesBytes+multipartSlack)
+		header, err := c.FormFile("responses")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": ErrResponsesTooLarge.Error()})
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": "responses file required"})
+			return
+		}
+		if header.Size > MaxResponsesBytes {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrResponsesTooLarge.Error()})
 			return
 		}
 
-		if err := tx.Commit().Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "transaction commit failed",
-			})
+		file, err := header.Open()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to open file"})
 			return
 		}
+		defer file.Close()
 
-		if err := enqueueApplicationStatusNotifications(
-			redisClient,
-			applications,
-			req.NewStatus,
-		); err != nil {
-			// IMPORTANT: do NOT fail the request
-			// log this instead
-			log.Println("failed to enqueue notifications:", err)
+		report, err := svc.Reconcile(audit.Context(c), auth, q, header.Filename, file)
+		if err != nil {
+			writeServiceError(c, err, "failed to reconcile registrations")
+			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"updated_count": len(req.ApplicationIDs),
-			"new_status":    req.NewStatus,
-		})
+		c.JSON(http.StatusCreated, report)
 	}
 }
 
-func ListApplications(db *gorm.DB) gin.HandlerFunc {
+func ListReconciliations(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		var q ApplicationListQuery
+		var q ReconciliationListQuery
 		if err := c.ShouldBindQuery(&q); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		applyDefaults(&q)
+		recs, err := svc.Reconciliations(c.Request.Context(), auth, q)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch reconciliations")
+			return
+		}
 
-		role := models.Role(c.GetString("role"))
-		userID := c.GetUint("user_id")
-		collegeID := c.GetUint("college_id")
+		c.JSON(http.StatusOK, gin.H{"data": recs})
+	}
+}
 
-		baseQuery := buildApplicationQuery(db, role, userID, collegeID, q)
+func GetReconciliation(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		var total int64
-		if err := baseQuery.Count(&total).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "failed to count applications",
-			})
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reconciliation id"})
 			return
 		}
 
-		var applications []models.Application
-		if err := baseQuery.
-			Limit(q.Limit).
-			Offset((q.Page - 1) * q.Limit).
-			Find(&applications).Error; err != nil {
-
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "failed to fetch applications",
-			})
+		report, err := svc.Reconciliation(c.Request.Context(), auth, uint(id))
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch reconciliation")
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"data": applications,
-			"meta": gin.H{
-				"page":  q.Page,
-				"limit": q.Limit,
-				"total": total,
-			},
-		})
+		c.JSON(http.StatusOK, report)
 	}
 }
 
-func GetApplicationByID(db *gorm.DB) gin.HandlerFunc {
+// WithdrawApplication lets a student back out of their application.
+func WithdrawApplication(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		idParam := c.Param("id")
-		appID, err := strconv.ParseUint(idParam, 10, 64)
-		if err != nil {
+		appID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || appID == 0 {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "invalid application id",
 			})
 			return
 		}
 
-		var application models.Application
-
-		err = db.
-			Preload("Job").
-			Preload("Student").
-			First(&application, appID).Error
+		// the body is optional
+		var req WithdrawRequest
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
+		resp, err := svc.Withdraw(audit.Context(c), auth, uint(appID), req)
 		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				c.JSON(http.StatusNotFound, gin.H{
-					"error": "application not found",
-				})
-				return
-			}
-
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "database error",
-			})
+			writeServiceError(c, err, "failed to withdraw application")
 			return
 		}
 
-		role := models.Role(c.GetString("role"))
-		userID := c.GetUint("user_id")
-		collegeID := c.GetUint("college_id")
+		c.JSON(http.StatusOK, resp)
+	}
+}
 
-		// Authorization check
-		switch role {
+func GetWithdrawalPolicy(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		case models.Student:
-			if application.StudentID != userID {
-				c.JSON(http.StatusForbidden, gin.H{
-					"error": "access denied",
-				})
-				return
-			}
+		resp, err := svc.WithdrawalPolicy(c.Request.Context(), auth)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch withdrawal policy")
+			return
+		}
 
-		case models.CollegeAdmin:
-			if application.CollegeID != collegeID {
-				c.JSON(http.StatusForbidden, gin.H{
-					"error": "access denied",
-				})
-				return
-			}
+		c.JSON(http.StatusOK, resp)
+	}
+}
 
-		case models.Admin:
-			// full access
+func SetWithdrawalPolicy(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		default:
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": "invalid role",
-			})
+		var req WithdrawalPolicyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		c.JSON(http.StatusOK, application)
+		policy, err := svc.SetWithdrawalPolicy(audit.Context(c), auth, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to update withdrawal policy")
+			return
+		}
+
+		c.JSON(http.StatusOK, policy)
+	}
+}
+
+var serviceErrorStatus = map[error]int{
+	ErrIntentNotFound:      http.StatusNotFound,
+	ErrIntentExpired:       http.StatusBadRequest,
+	ErrJobNotFound:         http.StatusNotFound,
+	ErrApplicationNotFound: http.StatusNotFound,
+	ErrAlreadyApplied:      http.StatusConflict,
+	ErrInvalidTransition:   http.StatusBadRequest,
+	ErrForeignApplications: http.StatusForbidden,
+	ErrForbidden:           http.StatusForbidden,
+	ErrResumeNotFound:      http.StatusNotFound,
+	ErrResumeUnavailable:   http.StatusNotFound,
+	ErrNotWithdrawable:     http.StatusConflict,
+	ErrWithdrawalClosed:    http.StatusConflict,
+	ErrWithdrawalLimit:     http.StatusConflict,
+	ErrNoCollegeContext:    http.StatusForbidden,
+
+	ErrInvalidResponses:       http.StatusBadRequest,
+	ErrNoMatchColumn:          http.StatusBadRequest,
+	ErrTooManyResponses:       http.StatusBadRequest,
+	ErrResponsesTooLarge:      http.StatusBadRequest,
+	ErrReconciliationNotFound: http.StatusNotFound,
+
+	pagination.ErrInvalidCursor: http.StatusBadRequest,
+}
+
+func writeServiceError(c *gin.Context, err error, fallback string) {
+	for target, status := range serviceErrorStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": target.Error()})
+			return
+		}
 	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
 }
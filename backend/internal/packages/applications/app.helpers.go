@@ -1,13 +1,10 @@
 package applications
 
 import (
-	"context"
-	"encoding/json"
 	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/pagination"
 	"time"
 
-	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
@@ -49,48 +46,100 @@ func allowedSortColumn(col string) string {
 }
 
 func applyDefaults(q *ApplicationListQuery) {
-	if q.Page <= 0 {
-		q.Page = 1
-	}
-	if q.Limit <= 0 || q.Limit > 100 {
-		q.Limit = 20
-	}
-	if q.SortBy == "" {
-		q.SortBy = "created_at"
-	}
+	q.Normalize(20, 100)
+	q.SortBy = allowedSortColumn(q.SortBy)
 	if q.SortDir != "asc" {
 		q.SortDir = "desc"
 	}
 }
 
-func buildApplicationQuery(
-	db *gorm.DB,
-	role models.Role,
-	userID uint,
-	collegeID uint,
-	q ApplicationListQuery,
-) *gorm.DB {
+// applicationKeyset orders by the requested column, ties broken by id.
+func applicationKeyset(q ApplicationListQuery) pagination.Keyset {
+	return pagination.Keyset{
+		Column:   "applications." + allowedSortColumn(q.SortBy),
+		IDColumn: "applications.id",
+		Desc:     q.SortDir == "desc",
+	}
+}
+
+// cursorSort tags cursors with the ordering they were produced under.
+func cursorSort(q ApplicationListQuery) string {
+	return q.SortBy + "_" + q.SortDir
+}
+
+// applicationSortValue is the sort column's value for app.
+func applicationSortValue(q ApplicationListQuery, app models.Application) any {
+	if q.SortBy == "status" {
+		return app.Status
+	}
+	return app.CreatedAt
+}
 
-	query := db.Model(&models.Application{}).
-		Preload("Job").
-		Preload("Student")
+// cursorValue decodes the sort value stored in a cursor.
+func cursorValue(q ApplicationListQuery, c pagination.Cursor) (any, error) {
+	if q.SortBy == "status" {
+		var status string
+		err := c.Scan(&status)
+		return status, err
+	}
+	var t time.Time
+	err := c.Scan(&t)
+	return t, err
+}
 
-	// Role scoping
-	switch role {
+// scopeApplications limits query to the applications the caller may see.
+func scopeApplications(query *gorm.DB, scope Scope) *gorm.DB {
+	switch scope.Role {
 	case models.Student:
-		query = query.Where("student_id = ?", userID)
+		return query.Where("applications.student_id = ?", scope.UserID)
 
 	case models.CollegeAdmin:
-		query = query.Where("college_id = ?", collegeID)
+		// the student's college: for a pooled drive each participating
+		// college sees only its own students
+		return query.Where("applications.college_id = ?", scope.CollegeID)
+
+	case models.Recruiter:
+		// granted per job and per college: a college shares only its own
+		// students' applications
+		return query.Where(`EXISTS (
+			SELECT 1 FROM recruiter_jobs
+			WHERE recruiter_jobs.recruiter_id = ?
+				AND recruiter_jobs.job_id = applications.job_id
+				AND recruiter_jobs.college_id = applications.college_id
+		)`, scope.UserID)
+
+	case models.Admin:
+		return query
+
+	default:
+		return query.Where("1 = 0")
 	}
+}
+
+func buildApplicationQuery(
+	db *gorm.DB,
+	scope Scope,
+	q ApplicationListQuery,
+) *gorm.DB {
+
+	query := scopeApplications(
+		db.Model(&models.Application{}).
+			Preload("Job").
+			Preload("Student"),
+		scope,
+	)
 
 	// Application-level filters
 	if q.Status != "" {
-		query = query.Where("status = ?", q.Status)
+		query = query.Where("applications.status = ?", q.Status)
 	}
 
 	if q.JobID != 0 {
-		query = query.Where("job_id = ?", q.JobID)
+		query = query.Where("applications.job_id = ?", q.JobID)
+	}
+
+	if q.RegistrationCheck != "" {
+		query = query.Where("applications.registration_check = ?", q.RegistrationCheck)
 	}
 
 	// Job-level filters (require join)
@@ -121,38 +170,7 @@ func buildApplicationQuery(
 	}
 
 	// Sorting
-	sortCol := allowedSortColumn(q.SortBy)
-	query = query.Order(sortCol + " " + q.SortDir)
+	query = query.Order(applicationKeyset(q).OrderBy())
 
 	return query
 }
-
-func enqueueApplicationStatusNotifications(
-	rdb *redis.Client,
-	applications []models.Application,
-	newStatus models.ApplicationStatus,
-) error {
-
-	ctx := context.Background()
-
-	for _, app := range applications {
-		payload, _ := json.Marshal(gin.H{
-			"application_id": app.ID,
-			"new_status":     newStatus,
-		})
-
-		msg, _ := json.Marshal(gin.H{
-			"user_id":    app.StudentID,
-			"type":       models.NotificationApplicationStatus,
-			"target_id":  app.ID,
-			"payload":    json.RawMessage(payload),
-			"created_at": time.Now(),
-		})
-
-		if err := rdb.LPush(ctx, "notifications:queue", msg).Err(); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
@@ -3,6 +3,7 @@ package applications
 import (
 	"iiitn-career-portal/internal/models"
 	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/packages/notifications"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
@@ -10,32 +11,91 @@ import (
 )
 
 func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB, redisClient *redis.Client) {
+	svc := NewService(NewGormRepository(db), notifications.New(db, redisClient))
+
 	applications := rg.Group("/applications")
+	// gin needs one wildcard name per position: :id is the intent here
 	applications.POST(
-		"/:intent_id/confirm",
+		"/:id/confirm",
 		authorization.RequireRole(string(models.Student)),
-		ConfirmApplication(db, redisClient),
+		ConfirmApplication(svc),
+	)
+	applications.POST(
+		"/:id/withdraw",
+		authorization.RequireRole(string(models.Student)),
+		WithdrawApplication(svc),
+	)
+	applications.GET(
+		"/withdrawal-policy",
+		authorization.RequireRole(
+			string(models.Student),
+			string(models.CollegeAdmin),
+		),
+		GetWithdrawalPolicy(svc),
+	)
+	applications.PUT(
+		"/withdrawal-policy",
+		authorization.RequireRole(string(models.CollegeAdmin)),
+		SetWithdrawalPolicy(svc),
+	)
+	applications.GET(
+		"/intents",
+		authorization.RequireRole(string(models.Student)),
+		ListIntents(svc),
+	)
+	applications.GET(
+		"/intents/metrics",
+		authorization.RequireRole(string(models.CollegeAdmin)),
+		GetIntentMetrics(svc),
+	)
+	applications.POST(
+		"/reconciliations",
+		authorization.RequireRole(string(models.CollegeAdmin)),
+		ReconcileRegistrations(svc),
+	)
+	applications.GET(
+		"/reconciliations",
+		authorization.RequireRole(string(models.CollegeAdmin)),
+		ListReconciliations(svc),
+	)
+	applications.GET(
+		"/reconciliations/:id",
+		authorization.RequireRole(string(models.CollegeAdmin)),
+		GetReconciliation(svc),
 	)
 	applications.PATCH(
 		"/status/bulk",
-		authorization.RequireRole(string(models.CollegeAdmin)),
-		BulkUpdateApplicationStatus(db, redisClient),
+		authorization.RequireRole(
+			string(models.CollegeAdmin),
+			string(models.Recruiter),
+		),
+		BulkUpdateApplicationStatus(svc),
 	)
 	applications.GET(
 		"",
 		authorization.RequireRole(
 			string(models.Student),
 			string(models.CollegeAdmin),
+			string(models.Recruiter),
 		),
-		ListApplications(db),
+		ListApplications(svc),
 	)
 	applications.GET(
 		"/:id",
 		authorization.RequireRole(
 			string(models.Student),
 			string(models.CollegeAdmin),
+			string(models.Recruiter),
 		),
-		GetApplicationByID(db),
+		GetApplicationByID(svc),
+	)
+	applications.GET(
+		"/:id/resume",
+		authorization.RequireRole(
+			string(models.Student),
+			string(models.CollegeAdmin),
+			string(models.Recruiter),
+		),
+		DownloadResume(svc),
 	)
-
 }
@@ -1,6 +1,15 @@
 package applications
 
-import "iiitn-career-portal/internal/models"
+import (
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/pagination"
+	"time"
+)
+
+// ConfirmRequest is the optional body of a confirmation.
+type ConfirmRequest struct {
+	ResumeID *uint `json:"resume_id"`
+}
 
 type BulkStatusUpdateRequest struct {
 	ApplicationIDs []uint                   `json:"application_ids" binding:"required,min=1"`
@@ -8,12 +17,14 @@ type BulkStatusUpdateRequest struct {
 }
 
 type ApplicationListQuery struct {
-	Page  int `form:"page"`
-	Limit int `form:"limit"`
+	pagination.Params
 
 	Status models.ApplicationStatus `form:"status"`
 	JobID  uint                     `form:"job_id"`
 
+	// VERIFIED or MISSING, from the latest reconciliation
+	RegistrationCheck models.RegistrationCheck `form:"registration_check"`
+
 	JobDomain models.JobDomain `form:"job_domain"`
 	JobType   models.JobType   `form:"job_type"`
 
@@ -21,4 +32,38 @@ type ApplicationListQuery struct {
 
 	SortBy  string `form:"sort_by"`  // created_at, status
 	SortDir string `form:"sort_dir"` // asc, desc
+
+	// decoded Params.Cursor; nil on the first page
+	After *pagination.Cursor `form:"-"`
+}
+
+// ApplicationPage is one page of applications. Total is nil when not
+// counted; NextCursor is empty on the last keyset page.
+type ApplicationPage struct {
+	Items      []models.Application
+	Total      *int64
+	NextCursor string
+}
+
+// CandidateResponse is an application as recruiters see it. Email and
+// LinkedIn are only set when the student shares contact details; CGPA is
+// left out when the student hides it.
+type CandidateResponse struct {
+	ApplicationID uint                     `json:"application_id"`
+	JobID         uint                     `json:"job_id"`
+	JobTitle      string                   `json:"job_title"`
+	CollegeID     uint                     `json:"college_id"`
+	Status        models.ApplicationStatus `json:"status"`
+
+	StudentID  uint     `json:"student_id"`
+	Name       string   `json:"name"`
+	Batch      int      `json:"batch"`
+	CGPA       *float32 `json:"cgpa,omitempty"`
+	Email      string   `json:"email,omitempty"`
+	LinkedinID string   `json:"linkedin_id,omitempty"`
+	ResumeURL  string   `json:"resume_url,omitempty"`
+	ResumeName string   `json:"resume_name,omitempty"`
+
+	AppliedAt time.Time `json:"applied_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,28 @@
+package applications
+
+import (
+	"iiitn-career-portal/internal/models"
+)
+
+// ApplicationEvent is the data of every application.* webhook. The
+// repository queues application.confirmed and application.status_changed
+// for the student's college in the transaction that makes the change.
+type ApplicationEvent struct {
+	ApplicationID uint                     `json:"application_id"`
+	JobID         uint                     `json:"job_id"`
+	StudentID     uint                     `json:"student_id"`
+	Status        models.ApplicationStatus `json:"status"`
+
+	// application.status_changed only
+	PreviousStatus models.ApplicationStatus `json:"previous_status,omitempty"`
+}
+
+func statusChangedEvent(app models.Application, status models.ApplicationStatus) ApplicationEvent {
+	return ApplicationEvent{
+		ApplicationID:  app.ID,
+		JobID:          app.JobID,
+		StudentID:      app.StudentID,
+		Status:         status,
+		PreviousStatus: app.Status,
+	}
+}
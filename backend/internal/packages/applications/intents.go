@@ -0,0 +1,146 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/packages/notifications"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	intentSweepInterval = 10 * time.Minute
+	// students are reminded this long before an intent expires
+	intentReminderWindow = 4 * time.Hour
+)
+
+// IntentResponse is a pending intent with what the student needs to
+// finish applying.
+type IntentResponse struct {
+	ID                  uint      `json:"id"`
+	JobID               uint      `json:"job_id"`
+	Title               string    `json:"title"`
+	Company             string    `json:"company"`
+	RegistrationFormURL *string   `json:"registration_form_url"`
+	ResumeID            *uint     `json:"resume_id"`
+	ExpiresAt           time.Time `json:"expires_at"`
+	CreatedAt           time.Time `json:"created_at"`
+	ConfirmURL          string    `json:"confirm_url" gorm:"-"`
+}
+
+// IntentReminder is an intent whose expiry reminder is due.
+type IntentReminder struct {
+	IntentID  uint
+	StudentID uint
+	JobID     uint
+	Title     string
+	Company   string
+	ExpiresAt time.Time
+}
+
+type IntentMetricsQuery struct {
+	JobID uint `form:"job_id"`
+}
+
+// IntentMetrics is how a college's intents for a job turned out.
+// ConversionRate is confirmed over confirmed plus expired, nil until one
+// of them happened; pending intents are left out.
+type IntentMetrics struct {
+	JobID          uint     `json:"job_id"`
+	Title          string   `json:"title"`
+	Company        string   `json:"company"`
+	Pending        int64    `json:"pending"`
+	Expired        int64    `json:"expired"`
+	Confirmed      int64    `json:"confirmed"`
+	ConversionRate *float64 `json:"conversion_rate"`
+}
+
+// Intents lists the student's unexpired intents, soonest to expire first.
+func (s *Service) Intents(ctx context.Context, auth *authorization.AuthContext) ([]IntentResponse, error) {
+	intents, err := s.repo.PendingIntents(ctx, auth.UserID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	for i := range intents {
+		intents[i].ConfirmURL = fmt.Sprintf("/api/applications/%d/confirm", intents[i].ID)
+	}
+	return intents, nil
+}
+
+// IntentMetrics reports intent-to-confirm conversion per job for the
+// admin's college, over its own students.
+func (s *Service) IntentMetrics(ctx context.Context, auth *authorization.AuthContext, q IntentMetricsQuery) ([]IntentMetrics, error) {
+	if auth.CollegeID == nil {
+		return nil, ErrNoCollegeContext
+	}
+	metrics, err := s.repo.IntentMetrics(ctx, *auth.CollegeID, q.JobID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	for i := range metrics {
+		if settled := metrics[i].Confirmed + metrics[i].Expired; settled > 0 {
+			rate := float64(metrics[i].Confirmed) / float64(settled)
+			metrics[i].ConversionRate = &rate
+		}
+	}
+	return metrics, nil
+}
+
+// SweepIntents reminds students of intents expiring within
+// intentReminderWindow, then deletes the expired ones. Each reminder is
+// claimed before it is sent, so it goes out at most once.
+func (s *Service) SweepIntents(ctx context.Context) error {
+	now := s.now()
+
+	due, err := s.repo.ClaimIntentReminders(ctx, now, now.Add(intentReminderWindow))
+	if err != nil {
+		return err
+	}
+	for _, r := range due {
+		if err := s.notifier.Push(ctx, r.StudentID, models.NotificationIntentExpiring, r.IntentID, gin.H{
+			"intent_id":  r.IntentID,
+			"job_id":     r.JobID,
+			"title":      r.Title,
+			"company":    r.Company,
+			"expires_at": r.ExpiresAt,
+		}); err != nil {
+			slog.ErrorContext(ctx, "failed to remind of expiring intent", "intent_id", r.IntentID, "error", err)
+		}
+	}
+
+	swept, err := s.repo.SweepIntents(ctx, now)
+	if err != nil {
+		return err
+	}
+	if swept > 0 {
+		slog.InfoContext(ctx, "expired intents swept", "count", swept)
+	}
+	return nil
+}
+
+// StartIntentSweeper runs SweepIntents every intentSweepInterval until ctx
+// is cancelled. Several instances may run side by side.
+func StartIntentSweeper(ctx context.Context, db *gorm.DB, rdb *redis.Client) {
+	svc := NewService(NewGormRepository(db), notifications.New(db, rdb))
+
+	ticker := time.NewTicker(intentSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.SweepIntents(ctx); err != nil {
+			slog.Error("intent sweep failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package applications
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxResponsesBytes caps an uploaded responses export.
+	MaxResponsesBytes = 5 << 20
+	maxResponseRows   = 5000
+)
+
+// ReconcileQuery names the job and, when the headers are not recognized
+// on their own, the columns holding emails and roll numbers.
+type ReconcileQuery struct {
+	JobID            uint   `form:"job_id" binding:"required"`
+	EmailColumn      string `form:"email_column"`
+	RollNumberColumn string `form:"roll_number_column"`
+}
+
+type ReconciliationListQuery struct {
+	JobID uint `form:"job_id"`
+}
+
+// ResponseRow is one response of the export, identified by its line.
+type ResponseRow struct {
+	Line       int    `json:"line"`
+	Email      string `json:"email,omitempty"`
+	RollNumber string `json:"roll_number,omitempty"`
+}
+
+// ReportEntry is a student of the college the reconciliation concerns.
+// Line is that of their response, if any; Error says why their intent
+// could not be confirmed.
+type ReportEntry struct {
+	StudentID     uint   `json:"student_id"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	RollNumber    string `json:"roll_number,omitempty"`
+	ApplicationID uint   `json:"application_id,omitempty"`
+	Line          int    `json:"line,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// ReconciliationReport is what an upload of registration form responses
+// did to a job's intents and applications, for the admin's college.
+type ReconciliationReport struct {
+	ID        uint      `json:"id"`
+	JobID     uint      `json:"job_id"`
+	FileName  string    `json:"file_name"`
+	TotalRows int       `json:"total_rows"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// pending intents of students who responded, now applications
+	Confirmed []ReportEntry `json:"confirmed"`
+	// applications whose student responded
+	Verified []ReportEntry `json:"verified"`
+	// applications without a response, flagged MISSING
+	Missing []ReportEntry `json:"missing"`
+	// students who responded without applying, or whose intent expired
+	NotApplied []ReportEntry `json:"not_applied"`
+	// responses matching no student of the college
+	Unmatched []ResponseRow `json:"unmatched"`
+	// responses whose email or roll number several students share
+	Ambiguous []ResponseRow `json:"ambiguous"`
+	// intents of students who responded that could not be confirmed
+	Failed []ReportEntry `json:"failed"`
+}
+
+// ReconciliationSummary is a past upload without its details.
+type ReconciliationSummary struct {
+	ID         uint      `json:"id"`
+	JobID      uint      `json:"job_id"`
+	FileName   string    `json:"file_name"`
+	UploadedBy uint      `json:"uploaded_by"`
+	TotalRows  int       `json:"total_rows"`
+	Confirmed  int       `json:"confirmed"`
+	Verified   int       `json:"verified"`
+	Missing    int       `json:"missing"`
+	Unmatched  int       `json:"unmatched"`
+	Ambiguous  int       `json:"ambiguous"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// StudentRef identifies a student of the college by what responses carry.
+type StudentRef struct {
+	ID         uint
+	Name       string
+	Email      string
+	RollNumber string
+}
+
+func (r StudentRef) entry() ReportEntry {
+	return ReportEntry{StudentID: r.ID, Name: r.Name, Email: r.Email, RollNumber: r.RollNumber}
+}
+
+// Reconcile matches a job's registration form responses, a CSV export,
+// against the college's students by email, then by roll number. Pending
+// intents of students who responded are confirmed; every application of
+// the college's students to the job that is not withdrawn is marked
+// VERIFIED or MISSING. The report is stored and returned.
+func (s *Service) Reconcile(ctx context.Context, auth *authorization.AuthContext, q ReconcileQuery, fileName string, file io.Reader) (ReconciliationReport, error) {
+	if auth.CollegeID == nil {
+		return ReconciliationReport{}, ErrNoCollegeContext
+	}
+	collegeID := *auth.CollegeID
+
+	job, err := s.repo.FindJob(ctx, q.JobID, collegeID)
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+
+	rows, err := parseResponses(file, q.EmailColumn, q.RollNumberColumn)
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+
+	var emails, rolls []string
+	for _, row := range rows {
+		if row.Email != "" {
+			emails = append(emails, row.Email)
+		}
+		if row.RollNumber != "" {
+			rolls = append(rolls, row.RollNumber)
+		}
+	}
+	students, err := s.repo.MatchStudents(ctx, collegeID, emails, rolls)
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+	intents, err := s.repo.JobIntents(ctx, job.ID, collegeID)
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+	apps, err := s.repo.JobApplications(ctx, job.ID, collegeID)
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+
+	report := ReconciliationReport{
+		JobID:      job.ID,
+		FileName:   fileName,
+		TotalRows:  len(rows),
+		Confirmed:  []ReportEntry{},
+		Verified:   []ReportEntry{},
+		Missing:    []ReportEntry{},
+		NotApplied: []ReportEntry{},
+		Unmatched:  []ResponseRow{},
+		Ambiguous:  []ResponseRow{},
+		Failed:     []ReportEntry{},
+	}
+
+	// the line of each responding student's first response
+	responded := matchResponses(rows, students, &report)
+
+	byStudent := map[uint]StudentRef{}
+	for _, st := range students {
+		byStudent[st.ID] = st
+	}
+	applied := map[uint]bool{}
+	for _, app := range apps {
+		applied[app.StudentID] = true
+	}
+
+	now := s.now()
+	var confirmed []models.Application
+	pending := map[uint]bool{}
+	for _, intent := range intents {
+		line, ok := responded[intent.StudentID]
+		if !ok || applied[intent.StudentID] || !now.Before(intent.ExpiresAt) {
+			continue
+		}
+		pending[intent.StudentID] = true
+
+		entry := byStudent[intent.StudentID].entry()
+		entry.Line = line
+		app, err := s.confirmIntent(ctx, intent)
+		if err != nil {
+			entry.Error = failureReason(err)
+			if entry.Error == "" {
+				slog.ErrorContext(ctx, "failed to confirm reconciled intent", "intent_id", intent.ID, "error", err)
+				entry.Error = "could not be confirmed"
+			}
+			report.Failed = append(report.Failed, entry)
+			continue
+		}
+		entry.ApplicationID = app.ID
+		report.Confirmed = append(report.Confirmed, entry)
+		confirmed = append(confirmed, app)
+	}
+
+	checks := map[models.RegistrationCheck][]uint{}
+	for _, app := range confirmed {
+		checks[models.RegistrationVerified] = append(checks[models.RegistrationVerified], app.ID)
+	}
+	for _, app := range apps {
+		// a withdrawn application needs no registration
+		if app.Status == models.Withdrawn {
+			continue
+		}
+		entry := ReportEntry{
+			StudentID:     app.StudentID,
+			Name:          app.Student.Name,
+			Email:         app.Student.Email,
+			ApplicationID: app.ID,
+		}
+		if line, ok := responded[app.StudentID]; ok {
+			entry.Line = line
+			entry.RollNumber = byStudent[app.StudentID].RollNumber
+			report.Verified = append(report.Verified, entry)
+			checks[models.RegistrationVerified] = append(checks[models.RegistrationVerified], app.ID)
+		} else {
+			report.Missing = append(report.Missing, entry)
+			checks[models.RegistrationMissing] = append(checks[models.RegistrationMissing], app.ID)
+		}
+	}
+
+	for _, st := range students {
+		line, ok := responded[st.ID]
+		if !ok || applied[st.ID] || pending[st.ID] {
+			continue
+		}
+		entry := st.entry()
+		entry.Line = line
+		report.NotApplied = append(report.NotApplied, entry)
+	}
+
+	rec := models.RegistrationReconciliation{
+		JobID:      job.ID,
+		CollegeID:  collegeID,
+		UploadedBy: auth.UserID,
+		FileName:   fileName,
+		TotalRows:  report.TotalRows,
+		Confirmed:  len(report.Confirmed),
+		Verified:   len(report.Verified),
+		Missing:    len(report.Missing),
+		Unmatched:  len(report.Unmatched),
+		Ambiguous:  len(report.Ambiguous),
+	}
+	if err := s.repo.SaveReconciliation(ctx, &rec, report, checks, now); err != nil {
+		return ReconciliationReport{}, err
+	}
+	report.ID = rec.ID
+	report.CreatedAt = rec.CreatedAt
+
+	for _, app := range confirmed {
+		s.announceConfirmed(ctx, app, job)
+	}
+
+	return report, nil
+}
+
+// confirmIntent confirms on the student's behalf. A chosen resume that is
+// gone falls back to the default rather than failing.
+func (s *Service) confirmIntent(ctx context.Context, intent models.ApplicationIntent) (models.Application, error) {
+	app, err := s.repo.ConfirmIntent(ctx, intent)
+	if errors.Is(err, ErrResumeUnavailable) {
+		intent.ResumeID = nil
+		app, err = s.repo.ConfirmIntent(ctx, intent)
+	}
+	return app, err
+}
+
+// failureReason is the message of a rule violation, empty otherwise.
+func failureReason(err error) string {
+	for target := range serviceErrorStatus {
+		if errors.Is(err, target) {
+			return target.Error()
+		}
+	}
+	return ""
+}
+
+// matchResponses finds the student behind each row, by email first, and
+// returns the line of each student's first response. Rows matching no one,
+// or only a value several students share, go to the report.
+func matchResponses(rows []ResponseRow, students []StudentRef, report *ReconciliationReport) map[uint]int {
+	// 0 marks a value shared by more than one student
+	index := func(m map[string]uint, key string, id uint) {
+		if prev, ok := m[key]; ok && prev != id {
+			id = 0
+		}
+		m[key] = id
+	}
+
+	byEmail := map[string]uint{}
+	byRoll := map[string]uint{}
+	for _, st := range students {
+		index(byEmail, strings.ToLower(st.Email), st.ID)
+		if st.RollNumber != "" {
+			index(byRoll, strings.ToLower(st.RollNumber), st.ID)
+		}
+	}
+
+	responded := map[uint]int{}
+	for _, row := range rows {
+		id, ok := byEmail[row.Email]
+		if !ok || id == 0 {
+			if rollID, found := byRoll[row.RollNumber]; found {
+				id, ok = rollID, true
+			}
+		}
+		if !ok || (row.Email == "" && row.RollNumber == "") {
+			report.Unmatched = append(report.Unmatched, row)
+			continue
+		}
+		if id == 0 {
+			report.Ambiguous = append(report.Ambiguous, row)
+			continue
+		}
+		if _, seen := responded[id]; !seen {
+			responded[id] = row.Line
+		}
+	}
+	return responded
+}
+
+// parseResponses reads a CSV export with a header row. Emails and roll
+// numbers come lowercased; rows with neither are kept so the report can
+// point at them.
+func parseResponses(file io.Reader, emailColumn, rollColumn string) ([]ResponseRow, error) {
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, ErrInvalidResponses
+	}
+	if len(header) > 0 {
+		// spreadsheet exports often start with a byte order mark
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	emailAt := findColumn(header, emailColumn, "email", "e-mail")
+	rollAt := findColumn(header, rollColumn, "roll")
+	if emailAt < 0 && rollAt < 0 {
+		return nil, ErrNoMatchColumn
+	}
+
+	var rows []ResponseRow
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidResponses
+		}
+		if len(rows) == maxResponseRows {
+			return nil, ErrTooManyResponses
+		}
+
+		line, _ := r.FieldPos(0)
+		row := ResponseRow{
+			Line:       line,
+			Email:      field(record, emailAt),
+			RollNumber: field(record, rollAt),
+		}
+		if row.Email == "" && row.RollNumber == "" && blank(record) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// findColumn returns the index of the named column, or else of the first
+// header containing one of the hints; -1 when there is none. A name that
+// is given but missing is not guessed around.
+func findColumn(header []string, name string, hints ...string) int {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if name != "" {
+			if h == name {
+				return i
+			}
+			continue
+		}
+		for _, hint := range hints {
+			if strings.Contains(h, hint) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(record[i]))
+}
+
+func blank(record []string) bool {
+	for _, f := range record {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Reconciliations lists the college's uploads, newest first; jobID 0
+// means every job.
+func (s *Service) Reconciliations(ctx context.Context, auth *authorization.AuthContext, q ReconciliationListQuery) ([]ReconciliationSummary, error) {
+	if auth.CollegeID == nil {
+		return nil, ErrNoCollegeContext
+	}
+	recs, err := s.repo.Reconciliations(ctx, *auth.CollegeID, q.JobID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ReconciliationSummary, len(recs))
+	for i, rec := range recs {
+		out[i] = ReconciliationSummary{
+			ID:         rec.ID,
+			JobID:      rec.JobID,
+			FileName:   rec.FileName,
+			UploadedBy: rec.UploadedBy,
+			TotalRows:  rec.TotalRows,
+			Confirmed:  rec.Confirmed,
+			Verified:   rec.Verified,
+			Missing:    rec.Missing,
+			Unmatched:  rec.Unmatched,
+			Ambiguous:  rec.Ambiguous,
+			CreatedAt:  rec.CreatedAt,
+		}
+	}
+	return out, nil
+}
+
+// Reconciliation returns the full report of one of the college's uploads.
+func (s *Service) Reconciliation(ctx context.Context, auth *authorization.AuthContext, id uint) (ReconciliationReport, error) {
+	if auth.CollegeID == nil {
+		return ReconciliationReport{}, ErrNoCollegeContext
+	}
+	return s.repo.FindReconciliation(ctx, id, *auth.CollegeID)
+}
@@ -0,0 +1,670 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/packages/webhooks"
+	"iiitn-career-portal/internal/pagination"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) FindIntent(ctx context.Context, intentID, studentID uint) (models.ApplicationIntent, error) {
+	var intent models.ApplicationIntent
+	err := r.db.WithContext(ctx).
+		Where("id = ?", intentID).
+		Where("student_id = ?", studentID).
+		First(&intent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return intent, ErrIntentNotFound
+	}
+	return intent, err
+}
+
+func (r *gormRepository) ExpireIntent(ctx context.Context, intent models.ApplicationIntent, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := expireIntents(tx, now, "id = ?", intent.ID)
+		return err
+	})
+}
+
+func (r *gormRepository) FindJob(ctx context.Context, jobID, collegeID uint) (models.Job, error) {
+	var job models.Job
+	err := r.db.WithContext(ctx).
+		Where("id = ?", jobID).
+		// its own job or a pooled drive the college takes part in
+		Where(`(college_id = ? OR EXISTS (
+			SELECT 1 FROM job_colleges
+			WHERE job_colleges.job_id = jobs.id
+				AND job_colleges.college_id = ?
+				AND job_colleges.is_visible
+		))`, collegeID, collegeID).
+		First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return job, ErrJobNotFound
+	}
+	return job, err
+}
+
+func (r *gormRepository) ConfirmIntent(ctx context.Context, intent models.ApplicationIntent) (models.Application, error) {
+	app := models.Application{
+		JobID:     intent.JobID,
+		StudentID: intent.StudentID,
+		CollegeID: intent.CollegeID,
+		Status:    models.Applied,
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the resume as it is when confirming
+		if err := snapshotResume(tx, intent, &app); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&app).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return ErrAlreadyApplied
+			}
+			return err
+		}
+		if err := tx.Delete(&intent).Error; err != nil {
+			return err
+		}
+		return webhooks.Enqueue(ctx, tx, app.CollegeID, models.WebhookApplicationConfirmed, ApplicationEvent{
+			ApplicationID: app.ID,
+			JobID:         app.JobID,
+			StudentID:     app.StudentID,
+			Status:        app.Status,
+		})
+	})
+
+	return app, err
+}
+
+// snapshotResume copies the chosen resume, or the default one, into the
+// application. Profiles from before named resumes only have a URL.
+func snapshotResume(tx *gorm.DB, intent models.ApplicationIntent, app *models.Application) error {
+	var resume models.StudentResume
+	if intent.ResumeID != nil {
+		err := tx.Where("id = ? AND user_id = ?", *intent.ResumeID, intent.StudentID).Take(&resume).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrResumeUnavailable
+		}
+		if err != nil {
+			return err
+		}
+	} else if err := tx.
+		Where("user_id = ? AND is_default", intent.StudentID).
+		Limit(1).
+		Find(&resume).Error; err != nil {
+		return err
+	}
+
+	if resume.ID != 0 {
+		app.ResumeSnapshotURL = resume.URL
+		app.ResumeName = resume.Name
+		return nil
+	}
+
+	var profile models.StudentProfile
+	if err := tx.Where("user_id = ?", intent.StudentID).Limit(1).Find(&profile).Error; err != nil {
+		return err
+	}
+	if profile.ResumeURL != nil {
+		app.ResumeSnapshotURL = *profile.ResumeURL
+	}
+	return nil
+}
+
+func (r *gormRepository) ScopedApplications(ctx context.Context, ids []uint, scope Scope) ([]models.Application, error) {
+	var apps []models.Application
+	err := scopeApplications(r.db.WithContext(ctx).Model(&models.Application{}), scope).
+		Where("applications.id IN ?", ids).
+		Find(&apps).Error
+	return apps, err
+}
+
+func (r *gormRepository) HasGrant(ctx context.Context, recruiterID, jobID, collegeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.RecruiterJob{}).
+		Where("recruiter_id = ? AND job_id = ? AND college_id = ?", recruiterID, jobID, collegeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormRepository) Profiles(ctx context.Context, studentIDs []uint) (map[uint]models.StudentProfile, error) {
+	var profiles []models.StudentProfile
+	if err := r.db.WithContext(ctx).
+		Where("user_id IN ?", studentIDs).
+		Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+
+	out := make(map[uint]models.StudentProfile, len(profiles))
+	for _, p := range profiles {
+		out[p.UserID] = p
+	}
+	return out, nil
+}
+
+func (r *gormRepository) UpdateStatuses(ctx context.Context, apps []models.Application, status models.ApplicationStatus) error {
+	ids := make([]uint, len(apps))
+	for i, app := range apps {
+		ids[i] = app.ID
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&models.Application{}).
+			Where("id IN ?", ids).
+			Update("status", status).Error; err != nil {
+			return err
+		}
+
+		for _, app := range apps {
+			if err := audit.RecordContext(ctx, tx, audit.Entry{
+				Action:     "application.status_change",
+				EntityType: "application",
+				EntityID:   app.ID,
+				CollegeID:  &app.CollegeID,
+				Diff: audit.Diff(
+					map[string]interface{}{"status": app.Status},
+					map[string]interface{}{"status": status},
+				),
+			}); err != nil {
+				return err
+			}
+			if err := webhooks.Enqueue(ctx, tx, app.CollegeID, models.WebhookApplicationStatusChanged, statusChangedEvent(app, status)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *gormRepository) List(ctx context.Context, scope Scope, q ApplicationListQuery) (ApplicationPage, error) {
+	query := buildApplicationQuery(r.db.WithContext(ctx), scope, q)
+
+	var page ApplicationPage
+	if q.WithTotal() {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return ApplicationPage{}, err
+		}
+		page.Total = &total
+	}
+
+	if !q.Keyset() {
+		query = query.Limit(q.Limit).Offset(q.Offset())
+	} else {
+		if q.After != nil {
+			value, err := cursorValue(q, *q.After)
+			if err != nil {
+				return ApplicationPage{}, err
+			}
+			cond, args := applicationKeyset(q).After(value, q.After.ID)
+			query = query.Where(cond, args...)
+		}
+		// one extra row tells whether there is a next page
+		query = query.Limit(q.Limit + 1)
+	}
+
+	var apps []models.Application
+	if err := query.Find(&apps).Error; err != nil {
+		return ApplicationPage{}, err
+	}
+
+	if q.Keyset() && len(apps) > q.Limit {
+		apps = apps[:q.Limit]
+		last := apps[len(apps)-1]
+		page.NextCursor = pagination.NewCursor(cursorSort(q), applicationSortValue(q, last), last.ID).Encode()
+	}
+	page.Items = apps
+
+	return page, nil
+}
+
+func (r *gormRepository) FindWithRelations(ctx context.Context, id uint) (models.Application, error) {
+	var app models.Application
+	err := r.db.WithContext(ctx).
+		Preload("Job").
+		Preload("Student").
+		First(&app, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return app, ErrApplicationNotFound
+	}
+	return app, err
+}
+
+func (r *gormRepository) FindCollege(ctx context.Context, id uint) (models.College, error) {
+	college := models.College{ID: id}
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Limit(1).
+		Find(&college).Error
+	return college, err
+}
+
+func (r *gormRepository) SetWithdrawalPolicy(ctx context.Context, collegeID uint, before, after WithdrawalPolicy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&models.College{}).
+			Where("id = ?", collegeID).
+			Updates(map[string]interface{}{
+				"withdrawal_cutoff_hours":    after.CutoffHours,
+				"max_withdrawals_per_season": after.MaxPerSeason,
+			}).Error; err != nil {
+			return err
+		}
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "college.withdrawal_policy",
+			EntityType: "college",
+			EntityID:   collegeID,
+			CollegeID:  &collegeID,
+			Diff: audit.Diff(
+				map[string]interface{}{"cutoff_hours": before.CutoffHours, "max_per_season": before.MaxPerSeason},
+				map[string]interface{}{"cutoff_hours": after.CutoffHours, "max_per_season": after.MaxPerSeason},
+			),
+		})
+	})
+}
+
+func (r *gormRepository) Withdraw(ctx context.Context, app models.Application, w Withdrawal) (int64, error) {
+	var used int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// one withdrawal per student at a time, so the limit holds
+		var student models.User
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", app.StudentID).
+			Take(&student).Error; err != nil {
+			return err
+		}
+
+		if err := countWithdrawals(tx, app.StudentID, w.SeasonStart).Count(&used).Error; err != nil {
+			return err
+		}
+		if w.Limit > 0 && used >= int64(w.Limit) {
+			return ErrWithdrawalLimit
+		}
+
+		res := tx.
+			Model(&models.Application{}).
+			Where("id = ? AND status = ?", app.ID, app.Status).
+			Updates(map[string]interface{}{
+				"status":       models.Withdrawn,
+				"withdrawn_at": w.At,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotWithdrawable
+		}
+		used++
+
+		after := map[string]interface{}{"status": models.Withdrawn}
+		if w.Reason != "" {
+			after["reason"] = w.Reason
+		}
+		if err := audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "application.withdraw",
+			EntityType: "application",
+			EntityID:   app.ID,
+			CollegeID:  &app.CollegeID,
+			Diff: audit.Diff(
+				map[string]interface{}{"status": app.Status},
+				after,
+			),
+		}); err != nil {
+			return err
+		}
+		return webhooks.Enqueue(ctx, tx, app.CollegeID, models.WebhookApplicationStatusChanged, statusChangedEvent(app, models.Withdrawn))
+	})
+
+	return used, err
+}
+
+func (r *gormRepository) CountWithdrawals(ctx context.Context, studentID uint, since time.Time) (int64, error) {
+	var count int64
+	err := countWithdrawals(r.db.WithContext(ctx), studentID, since).Count(&count).Error
+	return count, err
+}
+
+func countWithdrawals(db *gorm.DB, studentID uint, since time.Time) *gorm.DB {
+	return db.
+		Model(&models.Application{}).
+		Where("student_id = ? AND status = ?", studentID, models.Withdrawn).
+		Where("withdrawn_at >= ?", since)
+}
+
+func (r *gormRepository) PendingIntents(ctx context.Context, studentID uint, now time.Time) ([]IntentResponse, error) {
+	intents := []IntentResponse{}
+	err := r.db.WithContext(ctx).
+		Table("application_intents").
+		Select(`
+			application_intents.id,
+			application_intents.job_id,
+			jobs.title,
+			jobs.company,
+			jobs.registration_form_url,
+			application_intents.resume_id,
+			application_intents.expires_at,
+			application_intents.created_at
+		`).
+		Joins("JOIN jobs ON jobs.id = application_intents.job_id").
+		Where("application_intents.student_id = ?", studentID).
+		Where("application_intents.expires_at > ?", now).
+		Order("application_intents.expires_at, application_intents.id").
+		Scan(&intents).Error
+	return intents, err
+}
+
+func (r *gormRepository) ClaimIntentReminders(ctx context.Context, now, until time.Time) ([]IntentReminder, error) {
+	db := r.db.WithContext(ctx)
+
+	// the update claims the rows; a concurrent sweeper finds them reminded
+	var claimed []models.ApplicationIntent
+	if err := db.
+		Model(&claimed).
+		Clauses(clause.Returning{}).
+		Where("reminded_at IS NULL").
+		Where("expires_at > ? AND expires_at <= ?", now, until).
+		Update("reminded_at", now).Error; err != nil {
+		return nil, err
+	}
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+
+	jobIDs := make([]uint, len(claimed))
+	for i, intent := range claimed {
+		jobIDs[i] = intent.JobID
+	}
+	var jobs []models.Job
+	if err := db.Select("id, title, company").Where("id IN ?", jobIDs).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Job, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+
+	due := make([]IntentReminder, len(claimed))
+	for i, intent := range claimed {
+		due[i] = IntentReminder{
+			IntentID:  intent.ID,
+			StudentID: intent.StudentID,
+			JobID:     intent.JobID,
+			Title:     byID[intent.JobID].Title,
+			Company:   byID[intent.JobID].Company,
+			ExpiresAt: intent.ExpiresAt,
+		}
+	}
+	return due, nil
+}
+
+func (r *gormRepository) SweepIntents(ctx context.Context, now time.Time) (int64, error) {
+	var swept int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		swept, err = expireIntents(tx, now, "expires_at <= ?", now)
+		return err
+	})
+	return swept, err
+}
+
+// expireIntents deletes the intents matching the condition and adds them
+// to the expired counts of their job and college. Only rows this call
+// deleted are counted, so concurrent sweeps never count one twice.
+func expireIntents(tx *gorm.DB, now time.Time, query interface{}, args ...interface{}) (int64, error) {
+	var gone []models.ApplicationIntent
+	if err := tx.
+		Clauses(clause.Returning{}).
+		Where(query, args...).
+		Delete(&gone).Error; err != nil {
+		return 0, err
+	}
+
+	counts := map[[2]uint]int64{}
+	for _, intent := range gone {
+		counts[[2]uint{intent.JobID, intent.CollegeID}]++
+	}
+	for key, n := range counts {
+		stat := models.JobIntentStat{JobID: key[0], CollegeID: key[1], Expired: n, UpdatedAt: now}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "job_id"}, {Name: "college_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"expired":    gorm.Expr("job_intent_stats.expired + ?", n),
+				"updated_at": now,
+			}),
+		}).Create(&stat).Error; err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(gone)), nil
+}
+
+func (r *gormRepository) IntentMetrics(ctx context.Context, collegeID, jobID uint, now time.Time) ([]IntentMetrics, error) {
+	db := r.db.WithContext(ctx)
+	forJob := func(q *gorm.DB) *gorm.DB {
+		if jobID != 0 {
+			return q.Where("job_id = ?", jobID)
+		}
+		return q
+	}
+
+	byJob := map[uint]*IntentMetrics{}
+	metric := func(id uint) *IntentMetrics {
+		if m, ok := byJob[id]; ok {
+			return m
+		}
+		byJob[id] = &IntentMetrics{JobID: id}
+		return byJob[id]
+	}
+
+	// expired intents the sweeper has not got to yet count as expired
+	var intents []struct {
+		JobID   uint
+		Pending int64
+		Expired int64
+	}
+	if err := forJob(db.Table("application_intents")).
+		Select(`job_id,
+			SUM(CASE WHEN expires_at > ? THEN 1 ELSE 0 END) AS pending,
+			SUM(CASE WHEN expires_at <= ? THEN 1 ELSE 0 END) AS expired`, now, now).
+		Where("college_id = ?", collegeID).
+		Group("job_id").
+		Scan(&intents).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range intents {
+		m := metric(row.JobID)
+		m.Pending += row.Pending
+		m.Expired += row.Expired
+	}
+
+	var stats []models.JobIntentStat
+	if err := forJob(db.Where("college_id = ?", collegeID)).Find(&stats).Error; err != nil {
+		return nil, err
+	}
+	for _, stat := range stats {
+		metric(stat.JobID).Expired += stat.Expired
+	}
+
+	var apps []struct {
+		JobID     uint
+		Confirmed int64
+	}
+	if err := forJob(db.Model(&models.Application{})).
+		Select("job_id, COUNT(*) AS confirmed").
+		Where("college_id = ?", collegeID).
+		Group("job_id").
+		Scan(&apps).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range apps {
+		metric(row.JobID).Confirmed = row.Confirmed
+	}
+
+	out := make([]IntentMetrics, 0, len(byJob))
+	if len(byJob) == 0 {
+		return out, nil
+	}
+	ids := make([]uint, 0, len(byJob))
+	for id := range byJob {
+		ids = append(ids, id)
+	}
+	var jobs []models.Job
+	if err := db.Select("id, title, company").Where("id IN ?", ids).Order("id DESC").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		m := byJob[job.ID]
+		m.Title = job.Title
+		m.Company = job.Company
+		out = append(out, *m)
+	}
+	return out, nil
+}
+
+func (r *gormRepository) MatchStudents(ctx context.Context, collegeID uint, emails, rollNumbers []string) ([]StudentRef, error) {
+	students := []StudentRef{}
+	if len(emails) == 0 && len(rollNumbers) == 0 {
+		return students, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("users.id, users.name, users.email, COALESCE(student_profiles.roll_number, '') AS roll_number").
+		Joins("LEFT JOIN student_profiles ON student_profiles.user_id = users.id").
+		Where("users.college_id = ? AND users.role = ?", collegeID, models.Student).
+		// an empty list renders as IN (NULL) and matches nothing
+		Where("LOWER(users.email) IN ? OR LOWER(student_profiles.roll_number) IN ?", emails, rollNumbers).
+		Order("users.id").
+		Scan(&students).Error
+	return students, err
+}
+
+func (r *gormRepository) JobIntents(ctx context.Context, jobID, collegeID uint) ([]models.ApplicationIntent, error) {
+	var intents []models.ApplicationIntent
+	err := r.db.WithContext(ctx).
+		Where("job_id = ? AND college_id = ?", jobID, collegeID).
+		Order("id").
+		Find(&intents).Error
+	return intents, err
+}
+
+func (r *gormRepository) JobApplications(ctx context.Context, jobID, collegeID uint) ([]models.Application, error) {
+	var apps []models.Application
+	err := r.db.WithContext(ctx).
+		Preload("Student").
+		Where("job_id = ? AND college_id = ?", jobID, collegeID).
+		Order("id").
+		Find(&apps).Error
+	return apps, err
+}
+
+func (r *gormRepository) SaveReconciliation(
+	ctx context.Context,
+	rec *models.RegistrationReconciliation,
+	report ReconciliationReport,
+	checks map[models.RegistrationCheck][]uint,
+	at time.Time,
+) error {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	rec.Report = datatypes.JSON(raw)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for check, ids := range checks {
+			if len(ids) == 0 {
+				continue
+			}
+			if err := tx.
+				Model(&models.Application{}).
+				Where("id IN ?", ids).
+				Updates(map[string]interface{}{
+					"registration_check":      check,
+					"registration_checked_at": at,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(rec).Error; err != nil {
+			return err
+		}
+
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "job.registration_reconcile",
+			EntityType: "job",
+			EntityID:   rec.JobID,
+			CollegeID:  &rec.CollegeID,
+			Diff: audit.Created(map[string]interface{}{
+				"reconciliation_id": rec.ID,
+				"file_name":         rec.FileName,
+				"total_rows":        rec.TotalRows,
+				"confirmed":         rec.Confirmed,
+				"verified":          rec.Verified,
+				"missing":           rec.Missing,
+				"unmatched":         rec.Unmatched,
+				"ambiguous":         rec.Ambiguous,
+			}),
+		})
+	})
+}
+
+func (r *gormRepository) Reconciliations(ctx context.Context, collegeID, jobID uint) ([]models.RegistrationReconciliation, error) {
+	query := r.db.WithContext(ctx).
+		// the reports can be large; the list only needs the counts
+		Omit("report").
+		Where("college_id = ?", collegeID)
+	if jobID != 0 {
+		query = query.Where("job_id = ?", jobID)
+	}
+
+	var recs []models.RegistrationReconciliation
+	err := query.Order("id DESC").Find(&recs).Error
+	return recs, err
+}
+
+func (r *gormRepository) FindReconciliation(ctx context.Context, id, collegeID uint) (ReconciliationReport, error) {
+	var rec models.RegistrationReconciliation
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND college_id = ?", id, collegeID).
+		Take(&rec).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ReconciliationReport{}, ErrReconciliationNotFound
+	}
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+
+	var report ReconciliationReport
+	if err := json.Unmarshal(rec.Report, &report); err != nil {
+		return ReconciliationReport{}, err
+	}
+	report.ID = rec.ID
+	report.CreatedAt = rec.CreatedAt
+	return report, nil
+}
@@ -0,0 +1,355 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/pagination"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Rule violations. Their messages are what the API returns.
+var (
+	ErrIntentNotFound      = errors.New("application intent not found")
+	ErrIntentExpired       = errors.New("application intent expired")
+	ErrJobNotFound         = errors.New("job not found")
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrAlreadyApplied      = errors.New("application already exists")
+	ErrInvalidTransition   = errors.New("invalid status transition")
+	ErrForeignApplications = errors.New("one or more applications do not belong to your college")
+	ErrForbidden           = errors.New("access denied")
+	ErrResumeNotFound      = errors.New("no resume was submitted with this application")
+	ErrResumeUnavailable   = errors.New("selected resume not found")
+	ErrNotWithdrawable     = errors.New("application can no longer be withdrawn in its current status")
+	ErrWithdrawalClosed    = errors.New("the withdrawal window for this job has closed")
+	ErrWithdrawalLimit     = errors.New("withdrawal limit for this season reached")
+	ErrNoCollegeContext    = errors.New("no college context")
+
+	ErrInvalidResponses       = errors.New("responses must be a CSV file with a header row")
+	ErrNoMatchColumn          = errors.New("responses have no email or roll number column")
+	ErrTooManyResponses       = errors.New("too many responses in one file")
+	ErrResponsesTooLarge      = errors.New("responses file too large")
+	ErrReconciliationNotFound = errors.New("reconciliation not found")
+)
+
+// Repository is the persistence the application rules need. Status
+// changes record their audit entries and queue their webhook deliveries
+// in the same transaction.
+type Repository interface {
+	// FindIntent is scoped to the student; returns ErrIntentNotFound.
+	FindIntent(ctx context.Context, intentID, studentID uint) (models.ApplicationIntent, error)
+	// ExpireIntent deletes an intent that ran out by now, counting it as
+	// expired.
+	ExpireIntent(ctx context.Context, intent models.ApplicationIntent, now time.Time) error
+	// FindJob is scoped to the college, pooled drives included; returns
+	// ErrJobNotFound.
+	FindJob(ctx context.Context, jobID, collegeID uint) (models.Job, error)
+
+	// ConfirmIntent turns the intent into an APPLIED application and drops
+	// the intent atomically, snapshotting the intent's resume or else the
+	// student's default one. Returns ErrAlreadyApplied on a duplicate and
+	// ErrResumeUnavailable when the chosen resume is gone.
+	ConfirmIntent(ctx context.Context, intent models.ApplicationIntent) (models.Application, error)
+
+	// ScopedApplications loads the applications among ids the caller may
+	// see: for an admin those of the college's students, whichever college
+	// hosts the job; for a recruiter those of the granted jobs.
+	ScopedApplications(ctx context.Context, ids []uint, scope Scope) ([]models.Application, error)
+	UpdateStatuses(ctx context.Context, apps []models.Application, status models.ApplicationStatus) error
+
+	List(ctx context.Context, scope Scope, q ApplicationListQuery) (ApplicationPage, error)
+	// FindWithRelations returns ErrApplicationNotFound.
+	FindWithRelations(ctx context.Context, id uint) (models.Application, error)
+
+	// HasGrant reports whether the recruiter may see the college's
+	// applications to the job.
+	HasGrant(ctx context.Context, recruiterID, jobID, collegeID uint) (bool, error)
+	// Profiles returns the students' profiles by user id; students without
+	// one are missing from the map.
+	Profiles(ctx context.Context, studentIDs []uint) (map[uint]models.StudentProfile, error)
+
+	// FindCollege returns the college with its withdrawal policy; an
+	// unknown college comes back with only its id set.
+	FindCollege(ctx context.Context, id uint) (models.College, error)
+	SetWithdrawalPolicy(ctx context.Context, collegeID uint, before, after WithdrawalPolicy) error
+	// Withdraw moves the application to WITHDRAWN and returns the student's
+	// withdrawals this season, this one included. Returns
+	// ErrWithdrawalLimit once the limit is used up and ErrNotWithdrawable
+	// when the status changed meanwhile.
+	Withdraw(ctx context.Context, app models.Application, w Withdrawal) (int64, error)
+	CountWithdrawals(ctx context.Context, studentID uint, since time.Time) (int64, error)
+
+	// PendingIntents returns the student's intents not expired by now,
+	// soonest to expire first.
+	PendingIntents(ctx context.Context, studentID uint, now time.Time) ([]IntentResponse, error)
+	// ClaimIntentReminders marks the intents expiring after now and by
+	// until that were not reminded of yet, and returns them. An intent is
+	// claimed once, whichever sweeper gets to it.
+	ClaimIntentReminders(ctx context.Context, now, until time.Time) ([]IntentReminder, error)
+	// SweepIntents deletes the intents expired by now, counting them as
+	// expired for their job and college.
+	SweepIntents(ctx context.Context, now time.Time) (int64, error)
+	// IntentMetrics counts the college's pending and expired intents and
+	// applications per job, newest job first; jobID 0 means every job.
+	IntentMetrics(ctx context.Context, collegeID, jobID uint, now time.Time) ([]IntentMetrics, error)
+
+	// MatchStudents returns the college's students with one of the emails
+	// or roll numbers, compared case-insensitively.
+	MatchStudents(ctx context.Context, collegeID uint, emails, rollNumbers []string) ([]StudentRef, error)
+	// JobIntents and JobApplications return those of the college's
+	// students for the job; applications come with their student.
+	JobIntents(ctx context.Context, jobID, collegeID uint) ([]models.ApplicationIntent, error)
+	JobApplications(ctx context.Context, jobID, collegeID uint) ([]models.Application, error)
+	// SaveReconciliation stores the upload and its report and sets the
+	// applications' registration checks, audited, in one transaction.
+	SaveReconciliation(ctx context.Context, rec *models.RegistrationReconciliation, report ReconciliationReport, checks map[models.RegistrationCheck][]uint, at time.Time) error
+	// Reconciliations lists the college's uploads, newest first.
+	Reconciliations(ctx context.Context, collegeID, jobID uint) ([]models.RegistrationReconciliation, error)
+	// FindReconciliation returns ErrReconciliationNotFound.
+	FindReconciliation(ctx context.Context, id, collegeID uint) (ReconciliationReport, error)
+}
+
+type Notifier interface {
+	Push(ctx context.Context, userID uint, notifType models.NotificationType, targetID uint, payload gin.H) error
+	Enqueue(ctx context.Context, userID uint, notifType models.NotificationType, targetID uint, payload gin.H) error
+	PushToCollegeAdmins(ctx context.Context, collegeID uint, notifType models.NotificationType, targetID uint, payload gin.H) error
+}
+
+// Scope limits which applications a caller sees.
+type Scope struct {
+	Role      models.Role
+	UserID    uint
+	CollegeID uint
+}
+
+func scopeOf(auth *authorization.AuthContext) Scope {
+	s := Scope{Role: models.Role(auth.Role), UserID: auth.UserID}
+	if auth.CollegeID != nil {
+		s.CollegeID = *auth.CollegeID
+	}
+	return s
+}
+
+type Service struct {
+	repo     Repository
+	notifier Notifier
+	now      func() time.Time
+}
+
+func NewService(repo Repository, notifier Notifier) *Service {
+	return &Service{repo: repo, notifier: notifier, now: time.Now}
+}
+
+// Confirm finalizes a student's intent into an application. Expired
+// intents are removed and rejected. A resume chosen here replaces the one
+// picked when applying.
+func (s *Service) Confirm(ctx context.Context, auth *authorization.AuthContext, intentID uint, req ConfirmRequest) (models.Application, error) {
+	intent, err := s.repo.FindIntent(ctx, intentID, auth.UserID)
+	if err != nil {
+		return models.Application{}, err
+	}
+	if req.ResumeID != nil {
+		intent.ResumeID = req.ResumeID
+	}
+
+	if now := s.now(); now.After(intent.ExpiresAt) {
+		_ = s.repo.ExpireIntent(ctx, intent, now)
+		return models.Application{}, ErrIntentExpired
+	}
+
+	job, err := s.repo.FindJob(ctx, intent.JobID, intent.CollegeID)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	app, err := s.repo.ConfirmIntent(ctx, intent)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	s.announceConfirmed(ctx, app, job)
+	return app, nil
+}
+
+// announceConfirmed tells the student about a new application. Failures
+// are only logged.
+func (s *Service) announceConfirmed(ctx context.Context, app models.Application, job models.Job) {
+	if err := s.notifier.Push(ctx, app.StudentID, models.NotificationJobApplied, app.ID, gin.H{
+		"job_id":  job.ID,
+		"title":   job.Title,
+		"company": job.Company,
+	}); err != nil {
+		slog.ErrorContext(ctx, "failed to notify application", "application_id", app.ID, "error", err)
+	}
+}
+
+// BulkUpdateStatus moves applications of the admin's college, or of the
+// recruiter's jobs, to status. Either every application may make the
+// transition or none is changed.
+func (s *Service) BulkUpdateStatus(
+	ctx context.Context,
+	auth *authorization.AuthContext,
+	ids []uint,
+	status models.ApplicationStatus,
+) ([]models.Application, error) {
+	scope := scopeOf(auth)
+
+	apps, err := s.repo.ScopedApplications(ctx, ids, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// prevent cross-college (or ungranted) updates
+	if len(apps) != len(uniqueIDs(ids)) {
+		return nil, ErrForeignApplications
+	}
+
+	for _, app := range apps {
+		if !isValidTransition(app.Status, status) {
+			return nil, ErrInvalidTransition
+		}
+	}
+
+	if err := s.repo.UpdateStatuses(ctx, apps, status); err != nil {
+		return nil, err
+	}
+
+	// IMPORTANT: do NOT fail the request
+	for _, app := range apps {
+		if err := s.notifier.Enqueue(ctx, app.StudentID, models.NotificationApplicationStatus, app.ID, gin.H{
+			"application_id": app.ID,
+			"new_status":     status,
+		}); err != nil {
+			slog.ErrorContext(ctx, "failed to enqueue notifications", "error", err)
+			break
+		}
+	}
+	return apps, nil
+}
+
+func (s *Service) List(ctx context.Context, auth *authorization.AuthContext, q ApplicationListQuery) (ApplicationPage, ApplicationListQuery, error) {
+	applyDefaults(&q)
+
+	if q.Keyset() {
+		after, err := pagination.Decode(*q.Cursor, cursorSort(q))
+		if err == nil && after != nil {
+			_, err = cursorValue(q, *after)
+		}
+		if err != nil {
+			return ApplicationPage{}, q, pagination.ErrInvalidCursor
+		}
+		q.After = after
+	}
+
+	page, err := s.repo.List(ctx, scopeOf(auth), q)
+	return page, q, err
+}
+
+func (s *Service) Get(ctx context.Context, auth *authorization.AuthContext, id uint) (models.Application, error) {
+	app, err := s.repo.FindWithRelations(ctx, id)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	scope := scopeOf(auth)
+	if scope.Role == models.Recruiter {
+		ok, err := s.repo.HasGrant(ctx, scope.UserID, app.JobID, app.CollegeID)
+		if err != nil {
+			return models.Application{}, err
+		}
+		if !ok {
+			return models.Application{}, ErrForbidden
+		}
+		return app, nil
+	}
+
+	if !canView(scope, app) {
+		return models.Application{}, ErrForbidden
+	}
+
+	return app, nil
+}
+
+// ResumeURL returns the resume snapshot submitted with the application.
+func (s *Service) ResumeURL(ctx context.Context, auth *authorization.AuthContext, id uint) (string, error) {
+	app, err := s.Get(ctx, auth, id)
+	if err != nil {
+		return "", err
+	}
+	if app.ResumeSnapshotURL == "" {
+		return "", ErrResumeNotFound
+	}
+	return app.ResumeSnapshotURL, nil
+}
+
+// Candidates is what a recruiter sees of applications: the student's
+// privacy settings decide on contact details and CGPA.
+func (s *Service) Candidates(ctx context.Context, apps []models.Application) ([]CandidateResponse, error) {
+	ids := make([]uint, len(apps))
+	for i, app := range apps {
+		ids[i] = app.StudentID
+	}
+	profiles, err := s.repo.Profiles(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]CandidateResponse, len(apps))
+	for i, app := range apps {
+		out[i] = candidate(app, profiles[app.StudentID])
+	}
+	return out, nil
+}
+
+func candidate(app models.Application, profile models.StudentProfile) CandidateResponse {
+	c := CandidateResponse{
+		ApplicationID: app.ID,
+		JobID:         app.JobID,
+		JobTitle:      app.Job.Title,
+		CollegeID:     app.CollegeID,
+		Status:        app.Status,
+		StudentID:     app.StudentID,
+		Name:          app.Student.Name,
+		Batch:         profile.Batch,
+		AppliedAt:     app.CreatedAt,
+		UpdatedAt:     app.UpdatedAt,
+	}
+	if !profile.HideCGPAFromRecruiters {
+		c.CGPA = profile.CGPA
+	}
+	if profile.ShareContactWithRecruiters {
+		c.Email = app.Student.Email
+		c.LinkedinID = profile.LinkedinID
+	}
+	if app.ResumeSnapshotURL != "" {
+		c.ResumeURL = fmt.Sprintf("/api/applications/%d/resume", app.ID)
+		c.ResumeName = app.ResumeName
+	}
+	return c
+}
+
+func canView(scope Scope, app models.Application) bool {
+	switch scope.Role {
+	case models.Student:
+		return app.StudentID == scope.UserID
+	case models.CollegeAdmin:
+		return app.CollegeID == scope.CollegeID
+	case models.Admin:
+		return true
+	default:
+		return false
+	}
+}
+
+func uniqueIDs(ids []uint) map[uint]struct{} {
+	set := make(map[uint]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,951 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/pagination"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeRepo struct {
+	intents map[uint]models.ApplicationIntent
+	jobs    map[uint]models.Job
+	apps    map[uint]models.Application
+
+	// recruiter, job, college
+	grants   map[[3]uint]bool
+	profiles map[uint]models.StudentProfile
+	colleges map[uint]models.College
+
+	expiredIntents []uint
+	expiredAt      time.Time
+	confirmed      []models.ApplicationIntent
+	confirmErr     error
+	updatedTo      models.ApplicationStatus
+	updated        []uint
+	listScope      Scope
+	policySet      *WithdrawalPolicy
+	metrics        []IntentMetrics
+	// student -> withdrawal times
+	withdrawals map[uint][]time.Time
+
+	students []StudentRef
+	saved    []models.RegistrationReconciliation
+	checks   map[models.RegistrationCheck][]uint
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		intents:  map[uint]models.ApplicationIntent{},
+		jobs:     map[uint]models.Job{},
+		apps:     map[uint]models.Application{},
+		grants:   map[[3]uint]bool{},
+		profiles: map[uint]models.StudentProfile{},
+		colleges: map[uint]models.College{},
+
+		withdrawals: map[uint][]time.Time{},
+	}
+}
+
+func (r *fakeRepo) FindIntent(_ context.Context, id, studentID uint) (models.ApplicationIntent, error) {
+	intent, ok := r.intents[id]
+	if !ok || intent.StudentID != studentID {
+		return models.ApplicationIntent{}, ErrIntentNotFound
+	}
+	return intent, nil
+}
+
+func (r *fakeRepo) ExpireIntent(_ context.Context, intent models.ApplicationIntent, at time.Time) error {
+	r.expiredIntents = append(r.expiredIntents, intent.ID)
+	r.expiredAt = at
+	delete(r.intents, intent.ID)
+	return nil
+}
+
+func (r *fakeRepo) FindJob(_ context.Context, jobID, collegeID uint) (models.Job, error) {
+	job, ok := r.jobs[jobID]
+	if !ok || job.CollegeID != collegeID {
+		return models.Job{}, ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (r *fakeRepo) ConfirmIntent(_ context.Context, intent models.ApplicationIntent) (models.Application, error) {
+	if r.confirmErr != nil {
+		return models.Application{}, r.confirmErr
+	}
+	r.confirmed = append(r.confirmed, intent)
+	app := models.Application{
+		ID:        uint(len(r.apps) + 1),
+		JobID:     intent.JobID,
+		StudentID: intent.StudentID,
+		CollegeID: intent.CollegeID,
+		Status:    models.Applied,
+	}
+	r.apps[app.ID] = app
+	delete(r.intents, intent.ID)
+	return app, nil
+}
+
+func (r *fakeRepo) ScopedApplications(_ context.Context, ids []uint, scope Scope) ([]models.Application, error) {
+	var out []models.Application
+	seen := map[uint]bool{}
+	for _, id := range ids {
+		app, ok := r.apps[id]
+		if !ok || seen[id] {
+			continue
+		}
+		visible := app.CollegeID == scope.CollegeID
+		if scope.Role == models.Recruiter {
+			visible = r.grants[[3]uint{scope.UserID, app.JobID, app.CollegeID}]
+		}
+		if visible {
+			out = append(out, app)
+			seen[id] = true
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) HasGrant(_ context.Context, recruiterID, jobID, collegeID uint) (bool, error) {
+	return r.grants[[3]uint{recruiterID, jobID, collegeID}], nil
+}
+
+func (r *fakeRepo) Profiles(_ context.Context, ids []uint) (map[uint]models.StudentProfile, error) {
+	out := map[uint]models.StudentProfile{}
+	for _, id := range ids {
+		if p, ok := r.profiles[id]; ok {
+			out[id] = p
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) UpdateStatuses(_ context.Context, apps []models.Application, status models.ApplicationStatus) error {
+	r.updatedTo = status
+	for _, app := range apps {
+		r.updated = append(r.updated, app.ID)
+	}
+	return nil
+}
+
+func (r *fakeRepo) List(_ context.Context, scope Scope, _ ApplicationListQuery) (ApplicationPage, error) {
+	r.listScope = scope
+	return ApplicationPage{}, nil
+}
+
+func (r *fakeRepo) FindWithRelations(_ context.Context, id uint) (models.Application, error) {
+	app, ok := r.apps[id]
+	if !ok {
+		return app, ErrApplicationNotFound
+	}
+	return app, nil
+}
+
+func (r *fakeRepo) FindCollege(_ context.Context, id uint) (models.College, error) {
+	if college, ok := r.colleges[id]; ok {
+		return college, nil
+	}
+	return models.College{ID: id}, nil
+}
+
+func (r *fakeRepo) SetWithdrawalPolicy(_ context.Context, _ uint, _, after WithdrawalPolicy) error {
+	r.policySet = &after
+	return nil
+}
+
+func (r *fakeRepo) Withdraw(ctx context.Context, app models.Application, w Withdrawal) (int64, error) {
+	used, _ := r.CountWithdrawals(ctx, app.StudentID, w.SeasonStart)
+	if w.Limit > 0 && used >= int64(w.Limit) {
+		return 0, ErrWithdrawalLimit
+	}
+	app.Status = models.Withdrawn
+	app.WithdrawnAt = &w.At
+	r.apps[app.ID] = app
+	r.withdrawals[app.StudentID] = append(r.withdrawals[app.StudentID], w.At)
+	return used + 1, nil
+}
+
+func (r *fakeRepo) CountWithdrawals(_ context.Context, studentID uint, since time.Time) (int64, error) {
+	var n int64
+	for _, at := range r.withdrawals[studentID] {
+		if !at.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeRepo) PendingIntents(_ context.Context, studentID uint, now time.Time) ([]IntentResponse, error) {
+	out := []IntentResponse{}
+	for _, intent := range r.intents {
+		if intent.StudentID == studentID && intent.ExpiresAt.After(now) {
+			out = append(out, IntentResponse{ID: intent.ID, JobID: intent.JobID, Title: r.jobs[intent.JobID].Title, ExpiresAt: intent.ExpiresAt})
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) ClaimIntentReminders(_ context.Context, now, until time.Time) ([]IntentReminder, error) {
+	var due []IntentReminder
+	for id, intent := range r.intents {
+		if intent.RemindedAt != nil || !intent.ExpiresAt.After(now) || intent.ExpiresAt.After(until) {
+			continue
+		}
+		intent.RemindedAt = &now
+		r.intents[id] = intent
+		due = append(due, IntentReminder{IntentID: id, StudentID: intent.StudentID, JobID: intent.JobID, ExpiresAt: intent.ExpiresAt})
+	}
+	return due, nil
+}
+
+func (r *fakeRepo) SweepIntents(_ context.Context, now time.Time) (int64, error) {
+	var n int64
+	for id, intent := range r.intents {
+		if !intent.ExpiresAt.After(now) {
+			r.expiredIntents = append(r.expiredIntents, id)
+			delete(r.intents, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeRepo) IntentMetrics(context.Context, uint, uint, time.Time) ([]IntentMetrics, error) {
+	return r.metrics, nil
+}
+
+func (r *fakeRepo) MatchStudents(_ context.Context, _ uint, emails, rollNumbers []string) ([]StudentRef, error) {
+	var out []StudentRef
+	for _, st := range r.students {
+		if slices.Contains(emails, strings.ToLower(st.Email)) ||
+			(st.RollNumber != "" && slices.Contains(rollNumbers, strings.ToLower(st.RollNumber))) {
+			out = append(out, st)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) JobIntents(_ context.Context, jobID, collegeID uint) ([]models.ApplicationIntent, error) {
+	var out []models.ApplicationIntent
+	for _, intent := range r.intents {
+		if intent.JobID == jobID && intent.CollegeID == collegeID {
+			out = append(out, intent)
+		}
+	}
+	slices.SortFunc(out, func(a, b models.ApplicationIntent) int { return int(a.ID) - int(b.ID) })
+	return out, nil
+}
+
+func (r *fakeRepo) JobApplications(_ context.Context, jobID, collegeID uint) ([]models.Application, error) {
+	var out []models.Application
+	for _, app := range r.apps {
+		if app.JobID == jobID && app.CollegeID == collegeID {
+			out = append(out, app)
+		}
+	}
+	slices.SortFunc(out, func(a, b models.Application) int { return int(a.ID) - int(b.ID) })
+	return out, nil
+}
+
+func (r *fakeRepo) SaveReconciliation(_ context.Context, rec *models.RegistrationReconciliation, _ ReconciliationReport, checks map[models.RegistrationCheck][]uint, _ time.Time) error {
+	rec.ID = uint(len(r.saved) + 1)
+	r.saved = append(r.saved, *rec)
+	r.checks = checks
+	return nil
+}
+
+func (r *fakeRepo) Reconciliations(context.Context, uint, uint) ([]models.RegistrationReconciliation, error) {
+	return r.saved, nil
+}
+
+func (r *fakeRepo) FindReconciliation(context.Context, uint, uint) (ReconciliationReport, error) {
+	return ReconciliationReport{}, ErrReconciliationNotFound
+}
+
+type fakeNotifier struct {
+	pushed   []models.NotificationType
+	enqueued []uint
+	// college -> notification types sent to its admins
+	toAdmins map[uint][]models.NotificationType
+}
+
+func (n *fakeNotifier) Push(_ context.Context, _ uint, typ models.NotificationType, _ uint, _ gin.H) error {
+	n.pushed = append(n.pushed, typ)
+	return nil
+}
+
+func (n *fakeNotifier) Enqueue(_ context.Context, userID uint, _ models.NotificationType, _ uint, _ gin.H) error {
+	n.enqueued = append(n.enqueued, userID)
+	return nil
+}
+
+func (n *fakeNotifier) PushToCollegeAdmins(_ context.Context, collegeID uint, typ models.NotificationType, _ uint, _ gin.H) error {
+	if n.toAdmins == nil {
+		n.toAdmins = map[uint][]models.NotificationType{}
+	}
+	n.toAdmins[collegeID] = append(n.toAdmins[collegeID], typ)
+	return nil
+}
+
+func newTestService() (*Service, *fakeRepo, *fakeNotifier) {
+	repo := newFakeRepo()
+	notifier := &fakeNotifier{}
+	svc := NewService(repo, notifier)
+	svc.now = func() time.Time { return now }
+	return svc, repo, notifier
+}
+
+func authAs(role models.Role, userID, college uint) *authorization.AuthContext {
+	return &authorization.AuthContext{UserID: userID, Role: string(role), CollegeID: &college}
+}
+
+func TestValidTransitions(t *testing.T) {
+	all := []models.ApplicationStatus{
+		models.Applied, models.Shortlisted, models.Interview, models.Offered, models.Rejected,
+		models.Withdrawn,
+	}
+	allowed := map[[2]models.ApplicationStatus]bool{
+		{models.Applied, models.Shortlisted}:   true,
+		{models.Applied, models.Rejected}:      true,
+		{models.Shortlisted, models.Interview}: true,
+		{models.Shortlisted, models.Rejected}:  true,
+		{models.Interview, models.Offered}:     true,
+		{models.Interview, models.Rejected}:    true,
+	}
+
+	for _, from := range all {
+		for _, to := range all {
+			want := allowed[[2]models.ApplicationStatus{from, to}]
+			if got := isValidTransition(from, to); got != want {
+				t.Errorf("%s -> %s = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiresAt  time.Time
+		studentID  uint
+		jobMissing bool
+		confirmErr error
+		want       error
+	}{
+		{name: "fresh intent", expiresAt: now.Add(time.Hour), studentID: 5},
+		{name: "someone else's intent", expiresAt: now.Add(time.Hour), studentID: 6, want: ErrIntentNotFound},
+		{name: "expired intent", expiresAt: now.Add(-time.Second), studentID: 5, want: ErrIntentExpired},
+		{name: "job gone", expiresAt: now.Add(time.Hour), studentID: 5, jobMissing: true, want: ErrJobNotFound},
+		{name: "duplicate", expiresAt: now.Add(time.Hour), studentID: 5, confirmErr: ErrAlreadyApplied, want: ErrAlreadyApplied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, notifier := newTestService()
+			repo.intents[1] = models.ApplicationIntent{ID: 1, JobID: 3, StudentID: 5, CollegeID: 10, ExpiresAt: tt.expiresAt}
+			if !tt.jobMissing {
+				repo.jobs[3] = models.Job{ID: 3, CollegeID: 10, Title: "SDE", Company: "Acme"}
+			}
+			repo.confirmErr = tt.confirmErr
+
+			app, err := svc.Confirm(context.Background(), authAs(models.Student, tt.studentID, 10), 1, ConfirmRequest{})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+
+			switch {
+			case tt.want == nil:
+				if app.Status != models.Applied || app.StudentID != 5 {
+					t.Fatalf("app = %+v", app)
+				}
+				if len(notifier.pushed) != 1 || notifier.pushed[0] != models.NotificationJobApplied {
+					t.Fatalf("notifications = %v", notifier.pushed)
+				}
+			case errors.Is(tt.want, ErrIntentExpired):
+				if len(repo.expiredIntents) != 1 {
+					t.Fatal("expired intent should be deleted")
+				}
+				if !repo.expiredAt.Equal(now) {
+					t.Fatalf("expired at %v, want the service clock %v", repo.expiredAt, now)
+				}
+			default:
+				if len(notifier.pushed) != 0 {
+					t.Fatal("failed confirm must not notify")
+				}
+			}
+		})
+	}
+}
+
+func TestConfirmResumeChoice(t *testing.T) {
+	applyChoice, confirmChoice := uint(7), uint(8)
+
+	tests := []struct {
+		name string
+		req  ConfirmRequest
+		want uint
+	}{
+		{"chosen when applying", ConfirmRequest{}, applyChoice},
+		{"changed when confirming", ConfirmRequest{ResumeID: &confirmChoice}, confirmChoice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			repo.intents[1] = models.ApplicationIntent{ID: 1, JobID: 3, StudentID: 5, CollegeID: 10, ResumeID: &applyChoice, ExpiresAt: now.Add(time.Hour)}
+			repo.jobs[3] = models.Job{ID: 3, CollegeID: 10}
+
+			if _, err := svc.Confirm(context.Background(), authAs(models.Student, 5, 10), 1, tt.req); err != nil {
+				t.Fatal(err)
+			}
+			if got := repo.confirmed[0].ResumeID; got == nil || *got != tt.want {
+				t.Fatalf("confirmed with resume %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkUpdateStatus(t *testing.T) {
+	seed := func(repo *fakeRepo) {
+		repo.apps[1] = models.Application{ID: 1, StudentID: 21, CollegeID: 10, Status: models.Applied}
+		repo.apps[2] = models.Application{ID: 2, StudentID: 22, CollegeID: 10, Status: models.Applied}
+		repo.apps[3] = models.Application{ID: 3, StudentID: 23, CollegeID: 10, Status: models.Offered}
+		repo.apps[4] = models.Application{ID: 4, StudentID: 24, CollegeID: 11, Status: models.Applied}
+	}
+
+	tests := []struct {
+		name   string
+		ids    []uint
+		status models.ApplicationStatus
+		want   error
+	}{
+		{"valid batch", []uint{1, 2}, models.Shortlisted, nil},
+		{"duplicate ids", []uint{1, 1, 2}, models.Shortlisted, nil},
+		{"other college", []uint{1, 4}, models.Shortlisted, ErrForeignApplications},
+		{"unknown id", []uint{1, 99}, models.Shortlisted, ErrForeignApplications},
+		{"one invalid transition", []uint{1, 3}, models.Rejected, ErrInvalidTransition},
+		{"skipping a stage", []uint{1}, models.Offered, ErrInvalidTransition},
+		{"only students withdraw", []uint{1}, models.Withdrawn, ErrInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, notifier := newTestService()
+			seed(repo)
+
+			apps, err := svc.BulkUpdateStatus(context.Background(), authAs(models.CollegeAdmin, 1, 10), tt.ids, tt.status)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+
+			if tt.want != nil {
+				if len(repo.updated) != 0 || len(notifier.enqueued) != 0 {
+					t.Fatal("rejected batch must change nothing")
+				}
+				return
+			}
+
+			if repo.updatedTo != tt.status || len(repo.updated) != len(apps) || len(notifier.enqueued) != len(apps) {
+				t.Fatalf("updated %v to %s, enqueued %v", repo.updated, repo.updatedTo, notifier.enqueued)
+			}
+		})
+	}
+}
+
+func TestGetIsScoped(t *testing.T) {
+	tests := []struct {
+		name string
+		auth *authorization.AuthContext
+		want error
+	}{
+		{"owner", authAs(models.Student, 21, 10), nil},
+		{"other student", authAs(models.Student, 22, 10), ErrForbidden},
+		{"own college admin", authAs(models.CollegeAdmin, 1, 10), nil},
+		{"other college admin", authAs(models.CollegeAdmin, 2, 11), ErrForbidden},
+		{"super admin", authAs(models.Admin, 3, 0), nil},
+		{"alumni", authAs(models.Alumni, 4, 10), ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			repo.apps[1] = models.Application{ID: 1, StudentID: 21, CollegeID: 10}
+
+			if _, err := svc.Get(context.Background(), tt.auth, 1); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListScopeAndDefaults(t *testing.T) {
+	svc, repo, _ := newTestService()
+
+	_, q, err := svc.List(context.Background(), authAs(models.CollegeAdmin, 1, 10), ApplicationListQuery{
+		Params:  pagination.Params{Limit: 1000},
+		SortDir: "sideways",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q.Page != 1 || q.Limit != 20 || q.SortBy != "created_at" || q.SortDir != "desc" {
+		t.Fatalf("query = %+v", q)
+	}
+	if repo.listScope != (Scope{Role: models.CollegeAdmin, UserID: 1, CollegeID: 10}) {
+		t.Fatalf("scope = %+v", repo.listScope)
+	}
+}
+
+func TestRecruiterAccess(t *testing.T) {
+	ctx := context.Background()
+	recruiter := authAs(models.Recruiter, 7, 0)
+	recruiter.CollegeID = nil
+
+	svc, repo, _ := newTestService()
+	repo.apps[1] = models.Application{ID: 1, JobID: 3, StudentID: 21, CollegeID: 10, Status: models.Applied}
+	// same job, but a pooled college that did not share it
+	repo.apps[2] = models.Application{ID: 2, JobID: 3, StudentID: 22, CollegeID: 11, Status: models.Applied}
+	repo.grants[[3]uint{7, 3, 10}] = true
+
+	if _, err := svc.Get(ctx, recruiter, 1); err != nil {
+		t.Fatalf("granted application: %v", err)
+	}
+	if _, err := svc.Get(ctx, recruiter, 2); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("other college's application: err = %v, want ErrForbidden", err)
+	}
+
+	if _, err := svc.BulkUpdateStatus(ctx, recruiter, []uint{1, 2}, models.Shortlisted); !errors.Is(err, ErrForeignApplications) {
+		t.Fatalf("bulk with an ungranted application: err = %v", err)
+	}
+	apps, err := svc.BulkUpdateStatus(ctx, recruiter, []uint{1}, models.Shortlisted)
+	if err != nil || len(apps) != 1 || repo.updatedTo != models.Shortlisted {
+		t.Fatalf("bulk = %v, %v", apps, err)
+	}
+
+	if _, err := svc.ResumeURL(ctx, recruiter, 1); !errors.Is(err, ErrResumeNotFound) {
+		t.Fatalf("resume without snapshot: err = %v, want ErrResumeNotFound", err)
+	}
+}
+
+func TestCandidatesRespectPrivacy(t *testing.T) {
+	cgpa := float32(8.4)
+	app := func(id, student uint) models.Application {
+		return models.Application{
+			ID:                id,
+			StudentID:         student,
+			Student:           models.User{ID: student, Name: "S", Email: "s@example.com"},
+			ResumeSnapshotURL: "http://minio/resume.pdf",
+		}
+	}
+
+	svc, repo, _ := newTestService()
+	repo.profiles[21] = models.StudentProfile{UserID: 21, CGPA: &cgpa, LinkedinID: "in/s", Batch: 2026}
+	repo.profiles[22] = models.StudentProfile{UserID: 22, CGPA: &cgpa, LinkedinID: "in/s",
+		ShareContactWithRecruiters: true, HideCGPAFromRecruiters: true}
+
+	got, err := svc.Candidates(context.Background(), []models.Application{app(1, 21), app(2, 22), app(3, 23)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c := got[0]; c.Email != "" || c.LinkedinID != "" || c.CGPA == nil || c.Batch != 2026 {
+		t.Fatalf("default privacy = %+v", c)
+	}
+	if c := got[1]; c.Email == "" || c.LinkedinID == "" || c.CGPA != nil {
+		t.Fatalf("shared contact, hidden cgpa = %+v", c)
+	}
+	if c := got[2]; c.Email != "" || c.CGPA != nil || c.ResumeURL != "/api/applications/3/resume" {
+		t.Fatalf("no profile = %+v", c)
+	}
+}
+
+func TestWithdraw(t *testing.T) {
+	deadline := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+	ptr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		status  models.ApplicationStatus
+		student uint
+		// registration deadline relative to now; nil for none
+		deadline *time.Time
+		college  models.College
+		// earlier withdrawals by the student
+		earlier []time.Time
+		want    error
+		left    *int
+	}{
+		{name: "applied", status: models.Applied, left: ptr(1)},
+		{name: "shortlisted", status: models.Shortlisted, deadline: deadline(time.Hour), left: ptr(1)},
+		{name: "interviewing", status: models.Interview, want: ErrNotWithdrawable},
+		{name: "offered", status: models.Offered, want: ErrNotWithdrawable},
+		{name: "already withdrawn", status: models.Withdrawn, want: ErrNotWithdrawable},
+		{name: "someone else's", status: models.Applied, student: 99, want: ErrForbidden},
+		{name: "past the deadline", status: models.Applied, deadline: deadline(-time.Minute), want: ErrWithdrawalClosed},
+		{
+			name:     "within the grace after the deadline",
+			status:   models.Applied,
+			deadline: deadline(-time.Hour),
+			college:  models.College{WithdrawalCutoffHours: ptr(24)},
+			left:     ptr(1),
+		},
+		{
+			name:     "cutoff before the deadline",
+			status:   models.Applied,
+			deadline: deadline(time.Hour),
+			college:  models.College{WithdrawalCutoffHours: ptr(-2)},
+			want:     ErrWithdrawalClosed,
+		},
+		{
+			name:    "season limit reached",
+			status:  models.Applied,
+			earlier: []time.Time{now.Add(-48 * time.Hour), now.Add(-24 * time.Hour)},
+			want:    ErrWithdrawalLimit,
+		},
+		{
+			name:    "last season does not count",
+			status:  models.Applied,
+			earlier: []time.Time{time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), now.Add(-24 * time.Hour)},
+			left:    ptr(0),
+		},
+		{
+			name:    "no limit",
+			status:  models.Applied,
+			college: models.College{MaxWithdrawalsPerSeason: ptr(0)},
+			earlier: []time.Time{now.Add(-48 * time.Hour), now.Add(-24 * time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, notifier := newTestService()
+			tt.college.ID = 10
+			repo.colleges[10] = tt.college
+			repo.withdrawals[7] = tt.earlier
+			repo.apps[1] = models.Application{
+				ID:        1,
+				JobID:     5,
+				Job:       models.Job{ID: 5, RegistrationDeadline: tt.deadline},
+				StudentID: 7,
+				CollegeID: 10,
+				Status:    tt.status,
+			}
+			student := tt.student
+			if student == 0 {
+				student = 7
+			}
+
+			resp, err := svc.Withdraw(context.Background(), authAs(models.Student, student, 10), 1, WithdrawRequest{Reason: "took another offer"})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+
+			if tt.want != nil {
+				if repo.apps[1].Status != tt.status || len(notifier.toAdmins) != 0 {
+					t.Fatal("a refused withdrawal must change nothing")
+				}
+				return
+			}
+
+			if repo.apps[1].Status != models.Withdrawn || resp.Status != models.Withdrawn || !resp.WithdrawnAt.Equal(now) {
+				t.Fatalf("application %+v, response %+v", repo.apps[1], resp)
+			}
+			if got := notifier.toAdmins[10]; len(got) != 1 || got[0] != models.NotificationApplicationWithdrawn {
+				t.Fatalf("admins notified with %v", got)
+			}
+			if (resp.WithdrawalsLeft == nil) != (tt.left == nil) ||
+				(tt.left != nil && *resp.WithdrawalsLeft != *tt.left) {
+				t.Fatalf("withdrawals_left = %v, want %v", resp.WithdrawalsLeft, tt.left)
+			}
+		})
+	}
+}
+
+func TestSeasonStart(t *testing.T) {
+	tests := []struct {
+		at   time.Time
+		want time.Time
+	}{
+		{now, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := seasonStart(tt.at); !got.Equal(tt.want) {
+			t.Errorf("seasonStart(%s) = %s, want %s", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestWithdrawalPolicy(t *testing.T) {
+	svc, repo, _ := newTestService()
+	repo.withdrawals[7] = []time.Time{now.Add(-time.Hour)}
+
+	got, err := svc.WithdrawalPolicy(context.Background(), authAs(models.Student, 7, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.CutoffHours != DefaultWithdrawalCutoffHours || got.MaxPerSeason != DefaultMaxWithdrawalsPerSeason {
+		t.Fatalf("defaults = %+v", got.WithdrawalPolicy)
+	}
+	if got.WithdrawalsUsed == nil || *got.WithdrawalsUsed != 1 {
+		t.Fatalf("withdrawals_used = %v, want 1", got.WithdrawalsUsed)
+	}
+
+	admin, err := svc.WithdrawalPolicy(context.Background(), authAs(models.CollegeAdmin, 1, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if admin.WithdrawalsUsed != nil {
+		t.Fatal("admins have no withdrawals of their own")
+	}
+
+	cutoff, limit := -12, 0
+	set, err := svc.SetWithdrawalPolicy(context.Background(), authAs(models.CollegeAdmin, 1, 10), WithdrawalPolicyRequest{
+		CutoffHours:  &cutoff,
+		MaxPerSeason: &limit,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set != (WithdrawalPolicy{CutoffHours: -12}) || repo.policySet == nil || *repo.policySet != set {
+		t.Fatalf("stored %+v, returned %+v", repo.policySet, set)
+	}
+}
+
+func TestIntents(t *testing.T) {
+	svc, repo, _ := newTestService()
+	repo.jobs[3] = models.Job{ID: 3, CollegeID: 10, Title: "SDE"}
+	repo.intents[1] = models.ApplicationIntent{ID: 1, JobID: 3, StudentID: 5, ExpiresAt: now.Add(time.Hour)}
+	repo.intents[2] = models.ApplicationIntent{ID: 2, JobID: 3, StudentID: 5, ExpiresAt: now.Add(-time.Hour)}
+	repo.intents[3] = models.ApplicationIntent{ID: 3, JobID: 3, StudentID: 6, ExpiresAt: now.Add(time.Hour)}
+
+	intents, err := svc.Intents(context.Background(), authAs(models.Student, 5, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(intents) != 1 || intents[0].ID != 1 || intents[0].ConfirmURL != "/api/applications/1/confirm" {
+		t.Fatalf("intents = %+v", intents)
+	}
+}
+
+func TestSweepIntents(t *testing.T) {
+	svc, repo, notifier := newTestService()
+	reminded := now.Add(-time.Hour)
+	repo.intents[1] = models.ApplicationIntent{ID: 1, StudentID: 5, ExpiresAt: now.Add(time.Hour)}
+	repo.intents[2] = models.ApplicationIntent{ID: 2, StudentID: 6, ExpiresAt: now.Add(time.Hour), RemindedAt: &reminded}
+	repo.intents[3] = models.ApplicationIntent{ID: 3, StudentID: 7, ExpiresAt: now.Add(intentReminderWindow + time.Minute)}
+	repo.intents[4] = models.ApplicationIntent{ID: 4, StudentID: 8, ExpiresAt: now.Add(-time.Minute)}
+
+	if err := svc.SweepIntents(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.pushed) != 1 || notifier.pushed[0] != models.NotificationIntentExpiring {
+		t.Fatalf("notifications = %v, want one expiry reminder", notifier.pushed)
+	}
+	if len(repo.expiredIntents) != 1 || repo.expiredIntents[0] != 4 || len(repo.intents) != 3 {
+		t.Fatalf("swept %v, left %d", repo.expiredIntents, len(repo.intents))
+	}
+
+	// a reminder goes out once
+	if err := svc.SweepIntents(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.pushed) != 1 {
+		t.Fatalf("notifications = %v after a second sweep", notifier.pushed)
+	}
+}
+
+func TestIntentMetricsConversion(t *testing.T) {
+	svc, repo, _ := newTestService()
+	repo.metrics = []IntentMetrics{
+		{JobID: 1, Pending: 2, Expired: 1, Confirmed: 3},
+		{JobID: 2, Pending: 4},
+	}
+
+	metrics, err := svc.IntentMetrics(context.Background(), authAs(models.CollegeAdmin, 1, 10), IntentMetricsQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate := metrics[0].ConversionRate; rate == nil || *rate != 0.75 {
+		t.Fatalf("conversion = %v, want 0.75", rate)
+	}
+	if metrics[1].ConversionRate != nil {
+		t.Fatal("only pending intents have no conversion yet")
+	}
+}
+
+func TestParseResponses(t *testing.T) {
+	tests := []struct {
+		name        string
+		csv         string
+		emailColumn string
+		want        []ResponseRow
+		err         error
+	}{
+		{
+			name: "detected columns",
+			csv:  "\ufeffTimestamp,Email Address,Roll No\n1,A@Example.com ,bt21cse001\n\n2,,BT21CSE002\n",
+			want: []ResponseRow{
+				{Line: 2, Email: "a@example.com", RollNumber: "bt21cse001"},
+				{Line: 4, RollNumber: "bt21cse002"},
+			},
+		},
+		{
+			name:        "named column",
+			csv:         "Mail,Institute mail\nx@gmail.com,a@example.com\n",
+			emailColumn: "institute mail",
+			want:        []ResponseRow{{Line: 2, Email: "a@example.com"}},
+		},
+		{name: "nothing to match on", csv: "Name,Branch\nA,CSE\n", err: ErrNoMatchColumn},
+		{name: "named column missing", csv: "Email\na@example.com\n", emailColumn: "college email", err: ErrNoMatchColumn},
+		{name: "empty", csv: "", err: ErrInvalidResponses},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseResponses(strings.NewReader(tt.csv), tt.emailColumn, "")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if !slices.Equal(rows, tt.want) {
+				t.Fatalf("rows = %+v, want %+v", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	svc, repo, notifier := newTestService()
+	repo.jobs[3] = models.Job{ID: 3, CollegeID: 10, Title: "SDE", Company: "Acme"}
+	repo.students = []StudentRef{
+		{ID: 5, Name: "Pending", Email: "pending@example.com"},
+		{ID: 6, Name: "Applied", Email: "applied@example.com", RollNumber: "BT21CSE006"},
+		{ID: 7, Name: "Silent", Email: "silent@example.com"},
+		{ID: 8, Name: "Stray", Email: "stray@example.com"},
+		{ID: 9, Name: "Withdrawn", Email: "withdrawn@example.com"},
+	}
+	repo.intents[1] = models.ApplicationIntent{ID: 1, JobID: 3, StudentID: 5, CollegeID: 10, ExpiresAt: now.Add(time.Hour)}
+	repo.apps[100] = models.Application{ID: 100, JobID: 3, StudentID: 6, CollegeID: 10, Status: models.Applied}
+	repo.apps[101] = models.Application{ID: 101, JobID: 3, StudentID: 7, CollegeID: 10, Status: models.Shortlisted}
+	repo.apps[102] = models.Application{ID: 102, JobID: 3, StudentID: 9, CollegeID: 10, Status: models.Withdrawn}
+
+	csv := "Email,Roll Number\n" +
+		"pending@example.com,\n" +
+		",bt21cse006\n" +
+		"stray@example.com,\n" +
+		"outsider@gmail.com,\n"
+	report, err := svc.Reconcile(context.Background(), authAs(models.CollegeAdmin, 1, 10), ReconcileQuery{JobID: 3}, "responses.csv", strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(entries []ReportEntry) []uint {
+		var out []uint
+		for _, e := range entries {
+			out = append(out, e.StudentID)
+		}
+		return out
+	}
+	if got := ids(report.Confirmed); !slices.Equal(got, []uint{5}) || report.Confirmed[0].ApplicationID == 0 {
+		t.Fatalf("confirmed = %+v", report.Confirmed)
+	}
+	if got := ids(report.Verified); !slices.Equal(got, []uint{6}) || report.Verified[0].Line != 3 {
+		t.Fatalf("verified = %+v", report.Verified)
+	}
+	if got := ids(report.Missing); !slices.Equal(got, []uint{7}) {
+		t.Fatalf("missing = %v", got)
+	}
+	if got := ids(report.NotApplied); !slices.Equal(got, []uint{8}) {
+		t.Fatalf("not applied = %v", got)
+	}
+	if len(report.Unmatched) != 1 || report.Unmatched[0].Line != 5 {
+		t.Fatalf("unmatched = %+v", report.Unmatched)
+	}
+
+	confirmedID := report.Confirmed[0].ApplicationID
+	if got := repo.checks[models.RegistrationVerified]; !slices.Equal(got, []uint{confirmedID, 100}) {
+		t.Fatalf("verified applications = %v", got)
+	}
+	if got := repo.checks[models.RegistrationMissing]; !slices.Equal(got, []uint{101}) {
+		t.Fatalf("missing applications = %v", got)
+	}
+	if len(repo.saved) != 1 || report.ID != repo.saved[0].ID || repo.saved[0].Unmatched != 1 {
+		t.Fatalf("saved = %+v", repo.saved)
+	}
+	if len(notifier.pushed) != 1 || notifier.pushed[0] != models.NotificationJobApplied {
+		t.Fatalf("notifications = %v", notifier.pushed)
+	}
+}
+
+func TestReconcileAmbiguousResponses(t *testing.T) {
+	svc, repo, _ := newTestService()
+	repo.jobs[3] = models.Job{ID: 3, CollegeID: 10}
+	// roll numbers entered with different case by two profiles
+	repo.students = []StudentRef{
+		{ID: 5, Email: "first@example.com", RollNumber: "BT21CSE005"},
+		{ID: 6, Email: "second@example.com", RollNumber: "bt21cse005"},
+		{ID: 7, Email: "third@example.com", RollNumber: "BT21CSE007"},
+	}
+	repo.apps[100] = models.Application{ID: 100, JobID: 3, StudentID: 5, CollegeID: 10, Status: models.Applied}
+	repo.apps[101] = models.Application{ID: 101, JobID: 3, StudentID: 6, CollegeID: 10, Status: models.Applied}
+
+	csv := "Email,Roll Number\n" +
+		",BT21CSE005\n" +
+		"second@example.com,bt21cse005\n" +
+		",bt21cse007\n"
+	report, err := svc.Reconcile(context.Background(), authAs(models.CollegeAdmin, 1, 10), ReconcileQuery{JobID: 3}, "r.csv", strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the shared roll number alone matches nobody; the email still does
+	if len(report.Ambiguous) != 1 || report.Ambiguous[0].Line != 2 {
+		t.Fatalf("ambiguous = %+v", report.Ambiguous)
+	}
+	if len(report.Verified) != 1 || report.Verified[0].StudentID != 6 || report.Verified[0].Line != 3 {
+		t.Fatalf("verified = %+v", report.Verified)
+	}
+	if len(report.Missing) != 1 || report.Missing[0].StudentID != 5 {
+		t.Fatalf("missing = %+v", report.Missing)
+	}
+	if rec := repo.saved[0]; rec.Ambiguous != 1 || rec.TotalRows != 3 {
+		t.Fatalf("saved counts: ambiguous %d, total_rows %d; want 1 and 3", rec.Ambiguous, rec.TotalRows)
+	}
+	if len(report.NotApplied) != 1 || report.NotApplied[0].StudentID != 7 || len(report.Unmatched) != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestReconcileConfirmFailure(t *testing.T) {
+	svc, repo, notifier := newTestService()
+	repo.jobs[3] = models.Job{ID: 3, CollegeID: 10}
+	repo.students = []StudentRef{{ID: 5, Email: "pending@example.com"}}
+	repo.intents[1] = models.ApplicationIntent{ID: 1, JobID: 3, StudentID: 5, CollegeID: 10, ExpiresAt: now.Add(time.Hour)}
+	repo.confirmErr = ErrAlreadyApplied
+
+	report, err := svc.Reconcile(context.Background(), authAs(models.CollegeAdmin, 1, 10), ReconcileQuery{JobID: 3}, "r.csv", strings.NewReader("email\npending@example.com\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].Error != ErrAlreadyApplied.Error() || len(report.Confirmed) != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	if len(notifier.pushed) != 0 {
+		t.Fatal("a failed confirm must not notify")
+	}
+}
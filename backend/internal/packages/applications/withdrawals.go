@@ -0,0 +1,211 @@
+package applications
+
+import (
+	"context"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Defaults for colleges that have not set a withdrawal policy.
+const (
+	DefaultWithdrawalCutoffHours   = 0
+	DefaultMaxWithdrawalsPerSeason = 2
+)
+
+// placement seasons run from July to June, like the academic year
+const seasonStartMonth = time.July
+
+// a student may only back out before being interviewed
+var withdrawableStatuses = []models.ApplicationStatus{
+	models.Applied,
+	models.Shortlisted,
+}
+
+// WithdrawRequest is the optional body of a withdrawal.
+type WithdrawRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// WithdrawResponse reports the withdrawal; WithdrawalsLeft is nil when
+// the college sets no limit.
+type WithdrawResponse struct {
+	ApplicationID   uint                     `json:"application_id"`
+	Status          models.ApplicationStatus `json:"status"`
+	WithdrawnAt     time.Time                `json:"withdrawn_at"`
+	WithdrawalsLeft *int                     `json:"withdrawals_left"`
+}
+
+// WithdrawalPolicy is a college's rules for withdrawing applications.
+// CutoffHours is relative to the job's registration deadline, negative
+// closing withdrawals before it; MaxPerSeason 0 means no limit.
+type WithdrawalPolicy struct {
+	CutoffHours  int `json:"cutoff_hours"`
+	MaxPerSeason int `json:"max_per_season"`
+}
+
+type WithdrawalPolicyRequest struct {
+	CutoffHours  *int `json:"cutoff_hours" binding:"required,min=-720,max=720"`
+	MaxPerSeason *int `json:"max_per_season" binding:"required,min=0,max=50"`
+}
+
+// WithdrawalPolicyResponse also tells a student how many withdrawals
+// they made this season.
+type WithdrawalPolicyResponse struct {
+	WithdrawalPolicy
+	SeasonStart     time.Time `json:"season_start"`
+	WithdrawalsUsed *int64    `json:"withdrawals_used,omitempty"`
+}
+
+// Withdrawal is a student backing out of an application.
+type Withdrawal struct {
+	At     time.Time
+	Reason string
+	// earlier withdrawals since SeasonStart count toward Limit, 0 for none
+	SeasonStart time.Time
+	Limit       int
+}
+
+// policyOf fills in the defaults for the college's unset rules.
+func policyOf(college models.College) WithdrawalPolicy {
+	p := WithdrawalPolicy{
+		CutoffHours:  DefaultWithdrawalCutoffHours,
+		MaxPerSeason: DefaultMaxWithdrawalsPerSeason,
+	}
+	if college.WithdrawalCutoffHours != nil {
+		p.CutoffHours = *college.WithdrawalCutoffHours
+	}
+	if college.MaxWithdrawalsPerSeason != nil {
+		p.MaxPerSeason = *college.MaxWithdrawalsPerSeason
+	}
+	return p
+}
+
+// seasonStart is the start of the placement season t falls in.
+func seasonStart(t time.Time) time.Time {
+	t = t.UTC()
+	year := t.Year()
+	if t.Month() < seasonStartMonth {
+		year--
+	}
+	return time.Date(year, seasonStartMonth, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// withdrawalCloses is when the job stops accepting withdrawals; zero
+// when it has no registration deadline.
+func withdrawalCloses(job models.Job, p WithdrawalPolicy) time.Time {
+	if job.RegistrationDeadline == nil {
+		return time.Time{}
+	}
+	return job.RegistrationDeadline.Add(time.Duration(p.CutoffHours) * time.Hour)
+}
+
+// Withdraw moves the student's own application to WITHDRAWN. Only
+// applications not yet past shortlisting can be withdrawn, before the
+// college's cutoff and within its per-season limit. The college's admins
+// are notified.
+func (s *Service) Withdraw(ctx context.Context, auth *authorization.AuthContext, id uint, req WithdrawRequest) (WithdrawResponse, error) {
+	app, err := s.repo.FindWithRelations(ctx, id)
+	if err != nil {
+		return WithdrawResponse{}, err
+	}
+	if app.StudentID != auth.UserID {
+		return WithdrawResponse{}, ErrForbidden
+	}
+	if !slices.Contains(withdrawableStatuses, app.Status) {
+		return WithdrawResponse{}, ErrNotWithdrawable
+	}
+
+	college, err := s.repo.FindCollege(ctx, app.CollegeID)
+	if err != nil {
+		return WithdrawResponse{}, err
+	}
+	policy := policyOf(college)
+
+	now := s.now()
+	if closes := withdrawalCloses(app.Job, policy); !closes.IsZero() && !now.Before(closes) {
+		return WithdrawResponse{}, ErrWithdrawalClosed
+	}
+
+	used, err := s.repo.Withdraw(ctx, app, Withdrawal{
+		At:          now,
+		Reason:      req.Reason,
+		SeasonStart: seasonStart(now),
+		Limit:       policy.MaxPerSeason,
+	})
+	if err != nil {
+		return WithdrawResponse{}, err
+	}
+
+	// IMPORTANT: do NOT fail the request
+	if err := s.notifier.PushToCollegeAdmins(ctx, app.CollegeID, models.NotificationApplicationWithdrawn, app.ID, gin.H{
+		"application_id":  app.ID,
+		"job_id":          app.JobID,
+		"title":           app.Job.Title,
+		"company":         app.Job.Company,
+		"student_id":      app.StudentID,
+		"student_name":    app.Student.Name,
+		"previous_status": app.Status,
+		"reason":          req.Reason,
+	}); err != nil {
+		slog.ErrorContext(ctx, "failed to notify withdrawal", "application_id", app.ID, "error", err)
+	}
+
+	resp := WithdrawResponse{
+		ApplicationID: app.ID,
+		Status:        models.Withdrawn,
+		WithdrawnAt:   now,
+	}
+	if policy.MaxPerSeason > 0 {
+		left := max(policy.MaxPerSeason-int(used), 0)
+		resp.WithdrawalsLeft = &left
+	}
+	return resp, nil
+}
+
+// WithdrawalPolicy returns the rules of the caller's college; students
+// also get their withdrawals of the current season.
+func (s *Service) WithdrawalPolicy(ctx context.Context, auth *authorization.AuthContext) (WithdrawalPolicyResponse, error) {
+	if auth.CollegeID == nil {
+		return WithdrawalPolicyResponse{}, ErrNoCollegeContext
+	}
+	college, err := s.repo.FindCollege(ctx, *auth.CollegeID)
+	if err != nil {
+		return WithdrawalPolicyResponse{}, err
+	}
+
+	resp := WithdrawalPolicyResponse{
+		WithdrawalPolicy: policyOf(college),
+		SeasonStart:      seasonStart(s.now()),
+	}
+	if auth.Role == string(models.Student) {
+		used, err := s.repo.CountWithdrawals(ctx, auth.UserID, resp.SeasonStart)
+		if err != nil {
+			return WithdrawalPolicyResponse{}, err
+		}
+		resp.WithdrawalsUsed = &used
+	}
+	return resp, nil
+}
+
+// SetWithdrawalPolicy replaces the rules of the admin's college. They
+// apply to withdrawals from then on.
+func (s *Service) SetWithdrawalPolicy(ctx context.Context, auth *authorization.AuthContext, req WithdrawalPolicyRequest) (WithdrawalPolicy, error) {
+	if auth.CollegeID == nil {
+		return WithdrawalPolicy{}, ErrNoCollegeContext
+	}
+	college, err := s.repo.FindCollege(ctx, *auth.CollegeID)
+	if err != nil {
+		return WithdrawalPolicy{}, err
+	}
+
+	after := WithdrawalPolicy{CutoffHours: *req.CutoffHours, MaxPerSeason: *req.MaxPerSeason}
+	if err := s.repo.SetWithdrawalPolicy(ctx, college.ID, policyOf(college), after); err != nil {
+		return WithdrawalPolicy{}, err
+	}
+	return after, nil
+}
@@ -0,0 +1,234 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// all-zero hash that starts every chain
+var genesisHash = strings.Repeat("0", 64)
+
+// base for per-chain advisory locks, so appends to one chain serialize
+const chainLockBase int64 = 7_241_200_000
+
+type Entry struct {
+	Action     string
+	EntityType string
+	EntityID   uint
+
+	// college whose chain the entry joins; nil for platform actions
+	CollegeID *uint
+
+	Diff map[string]Change
+}
+
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Diff keeps only the keys of after whose value differs from before.
+func Diff(before, after map[string]interface{}) map[string]Change {
+	out := map[string]Change{}
+	for k, a := range after {
+		b := before[k]
+		if canonical(b) != canonical(a) {
+			out[k] = Change{Before: b, After: a}
+		}
+	}
+	return out
+}
+
+// Created is the diff of a freshly created entity.
+func Created(after map[string]interface{}) map[string]Change {
+	return Diff(map[string]interface{}{}, after)
+}
+
+// Actor is who performed an audited change and from where.
+type Actor struct {
+	UserID    uint
+	Role      string
+	RequestID string
+	IP        string
+}
+
+// ActorFrom reads the actor of an authenticated request.
+func ActorFrom(c *gin.Context) (Actor, error) {
+	auth, ok := c.MustGet("auth").(*authorization.AuthContext)
+	if !ok {
+		return Actor{}, errors.New("audit: missing auth context")
+	}
+
+	requestID := c.GetString("request_id")
+	if requestID == "" {
+		requestID = c.GetHeader("X-Request-ID")
+	}
+
+	return Actor{
+		UserID:    auth.UserID,
+		Role:      auth.Role,
+		RequestID: requestID,
+		IP:        c.ClientIP(),
+	}, nil
+}
+
+type actorKey struct{}
+
+// WithActor attaches the actor to ctx so code below the HTTP layer
+// (services, repositories) can record entries with RecordContext.
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// Context is the request context of c carrying its actor, if any.
+func Context(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if _, ok := c.Get("auth"); !ok {
+		return ctx
+	}
+	if actor, err := ActorFrom(c); err == nil {
+		ctx = WithActor(ctx, actor)
+	}
+	return ctx
+}
+
+// Record appends an entry for the authenticated actor. Pass the caller's
+// transaction so the entry commits (or rolls back) with the change.
+func Record(tx *gorm.DB, c *gin.Context, e Entry) error {
+	actor, err := ActorFrom(c)
+	if err != nil {
+		return err
+	}
+	return RecordAs(tx, actor, e)
+}
+
+// RecordContext is Record for callers holding a context from Context or
+// WithActor instead of the gin request.
+func RecordContext(ctx context.Context, tx *gorm.DB, e Entry) error {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	if !ok {
+		return errors.New("audit: no actor in context")
+	}
+	return RecordAs(tx, actor, e)
+}
+
+func RecordAs(tx *gorm.DB, actor Actor, e Entry) error {
+	diffJSON, err := json.Marshal(e.Diff)
+	if err != nil {
+		return err
+	}
+
+	var chainKey uint
+	if e.CollegeID != nil {
+		chainKey = *e.CollegeID
+	}
+
+	log := models.AuditLog{
+		ChainKey:   chainKey,
+		ActorID:    actor.UserID,
+		ActorRole:  actor.Role,
+		CollegeID:  e.CollegeID,
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityID:   strconv.FormatUint(uint64(e.EntityID), 10),
+		Diff:       diffJSON,
+		RequestID:  truncate(actor.RequestID, 64),
+		IP:         truncate(actor.IP, 64),
+		// Postgres keeps microseconds; hash what will be stored
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	return tx.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			"SELECT pg_advisory_xact_lock(?)",
+			chainLockBase+int64(chainKey),
+		).Error; err != nil {
+			return err
+		}
+
+		var prev models.AuditLog
+		err := tx.
+			Select("hash").
+			Where("chain_key = ?", chainKey).
+			Order("id DESC").
+			Take(&prev).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			log.PrevHash = genesisHash
+		case err != nil:
+			return err
+		default:
+			log.PrevHash = prev.Hash
+		}
+
+		log.Hash = computeHash(log)
+
+		return tx.Create(&log).Error
+	})
+}
+
+func computeHash(l models.AuditLog) string {
+	collegeID := ""
+	if l.CollegeID != nil {
+		collegeID = strconv.FormatUint(uint64(*l.CollegeID), 10)
+	}
+
+	parts := []string{
+		l.PrevHash,
+		strconv.FormatUint(uint64(l.ChainKey), 10),
+		strconv.FormatUint(uint64(l.ActorID), 10),
+		l.ActorRole,
+		collegeID,
+		l.Action,
+		l.EntityType,
+		l.EntityID,
+		canonicalRaw(l.Diff),
+		l.RequestID,
+		l.IP,
+		l.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonical renders a value as compact JSON with sorted keys. jsonb
+// reorders keys on storage, so hashes are always taken over this form.
+func canonical(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return canonicalRaw(b)
+}
+
+func canonicalRaw(raw []byte) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ListQuery struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+
+	Action     string `form:"action"`
+	EntityType string `form:"entity_type"`
+	EntityID   string `form:"entity_id"`
+	ActorID    uint   `form:"actor_id"`
+	RequestID  string `form:"request_id"`
+
+	// super admin only; college admins are pinned to their college
+	CollegeID *uint `form:"college_id"`
+
+	From *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+func ListAuditLogs(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var q ListQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if q.Page <= 0 {
+			q.Page = 1
+		}
+		if q.Limit <= 0 || q.Limit > 100 {
+			q.Limit = 50
+		}
+
+		query := db.Model(&models.AuditLog{})
+
+		switch models.Role(auth.Role) {
+		case models.CollegeAdmin:
+			query = query.Where("college_id = ?", auth.CollegeID)
+		case models.Admin:
+			if q.CollegeID != nil {
+				query = query.Where("college_id = ?", *q.CollegeID)
+			}
+		default:
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+
+		if q.Action != "" {
+			query = query.Where("action = ?", q.Action)
+		}
+		if q.EntityType != "" {
+			query = query.Where("entity_type = ?", q.EntityType)
+		}
+		if q.EntityID != "" {
+			query = query.Where("entity_id = ?", q.EntityID)
+		}
+		if q.ActorID != 0 {
+			query = query.Where("actor_id = ?", q.ActorID)
+		}
+		if q.RequestID != "" {
+			query = query.Where("request_id = ?", q.RequestID)
+		}
+		if q.From != nil {
+			query = query.Where("created_at >= ?", *q.From)
+		}
+		if q.To != nil {
+			query = query.Where("created_at < ?", *q.To)
+		}
+
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count audit logs"})
+			return
+		}
+
+		var logs []models.AuditLog
+		if err := query.
+			Order("id DESC").
+			Limit(q.Limit).
+			Offset((q.Page - 1) * q.Limit).
+			Find(&logs).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch audit logs"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": logs,
+			"meta": gin.H{
+				"page":  q.Page,
+				"limit": q.Limit,
+				"total": total,
+			},
+		})
+	}
+}
+
+// VerifyChain recomputes a chain end to end and reports the first entry
+// whose hash or link does not match.
+func VerifyChain(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var chainKey uint
+		switch models.Role(auth.Role) {
+		case models.CollegeAdmin:
+			chainKey = *auth.CollegeID
+		case models.Admin:
+			var q struct {
+				CollegeID uint `form:"college_id"`
+			}
+			_ = c.ShouldBindQuery(&q)
+			chainKey = q.CollegeID
+		default:
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+
+		prevHash := genesisHash
+		var checked int64
+		var brokenAt *uint
+
+		var batch []models.AuditLog
+		err := db.
+			Where("chain_key = ?", chainKey).
+			Order("id ASC").
+			FindInBatches(&batch, 500, func(tx *gorm.DB, _ int) error {
+				for _, l := range batch {
+					if brokenAt != nil {
+						return nil
+					}
+					if l.PrevHash != prevHash || computeHash(l) != l.Hash {
+						id := l.ID
+						brokenAt = &id
+						return nil
+					}
+					prevHash = l.Hash
+					checked++
+				}
+				return nil
+			}).Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify audit chain"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"chain_key":     chainKey,
+			"valid":         brokenAt == nil,
+			"checked_count": checked,
+			"broken_at_id":  brokenAt,
+			"head_hash":     prevHash,
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	logs := rg.Group("/audit-logs")
+	logs.Use(authorization.RequireRole(
+		string(models.Admin),
+		string(models.CollegeAdmin),
+	))
+	{
+		logs.GET("", ListAuditLogs(db))
+		logs.GET("/verify", VerifyChain(db))
+	}
+}
@@ -1,16 +1,12 @@
 package auth
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"iiitn-career-portal/internal/config"
 	"iiitn-career-portal/internal/models"
 	"iiitn-career-portal/internal/packages/authorization"
 	"iiitn-career-portal/internal/packages/keycloak"
-	"io"
-	"log"
-	"net/http"
-	"net/url"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -19,30 +15,33 @@ import (
 	"gorm.io/gorm"
 )
 
-type TokenResponse struct {
-	AccessToken string `json:"access_token"`
-}
-
-func GeneratePortalJWT(user models.User, secret string) (string, error) {
+func GeneratePortalJWT(user models.User, secret string, ttl time.Duration) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id":    user.ID,
 		"role":       user.Role,
 		"college_id": user.CollegeID,
-		"exp":        time.Now().Add(24 * time.Hour).Unix(),
+		"exp":        time.Now().Add(ttl).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secret))
 }
 
-func Signup(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
+type SignupRequest struct {
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required,min=8"`
+	Name      string `json:"name" binding:"required"`
+	CollegeID uint   `json:"college_id" binding:"required"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+func Signup(db *gorm.DB, cfg config.Config, kc *keycloak.Client) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req struct {
-			Email     string `json:"email" binding:"required,email"`
-			Password  string `json:"password" binding:"required,min=8"`
-			Name      string `json:"name" binding:"required"`
-			CollegeID uint   `json:"college_id" binding:"required"`
-		}
+		var req SignupRequest
 
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(400, gin.H{"error": err.Error()})
@@ -70,8 +69,8 @@ func Signup(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 		}
 
 		// 4️⃣ Create Keycloak user
-		kcUserID, err := keycloak.CreateUser(
-			cfg,
+		kcUserID, err := kc.CreateUser(
+			c.Request.Context(),
 			req.Email,
 			req.Password,
 			req.Name,
@@ -84,12 +83,13 @@ func Signup(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 		// Ensure cleanup on ANY failure below
 		defer func() {
 			if err != nil {
-				keycloak.DeleteUser(cfg, kcUserID)
+				// must run even if the client went away
+				kc.DeleteUser(context.WithoutCancel(c.Request.Context()), kcUserID)
 			}
 		}()
 
 		// 5️⃣ Assign role
-		err = keycloak.AssignRealmRole(cfg, kcUserID, "student")
+		err = kc.AssignRealmRole(c.Request.Context(), kcUserID, "student")
 		if err != nil {
 			c.JSON(500, gin.H{"error": "role assignment failed"})
 			return
@@ -128,12 +128,9 @@ func Signup(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 	}
 }
 
-func Login(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
+func Login(db *gorm.DB, cfg config.Config, kc *keycloak.Client) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req struct {
-			Email    string `json:"email" binding:"required,email"`
-			Password string `json:"password" binding:"required"`
-		}
+		var req LoginRequest
 
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(400, gin.H{"error": err.Error()})
@@ -141,16 +138,16 @@ func Login(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 		}
 
 		// 1️⃣ Get token from Keycloak
-		tokenResp, err := keycloak.PasswordGrant(cfg, req.Email, req.Password)
+		tokenResp, err := kc.PasswordGrant(c.Request.Context(), req.Email, req.Password)
 		if err != nil {
 			c.JSON(401, gin.H{"error": "invalid credentials"})
 			return
 		}
 
 		// 2️⃣ Verify token
-		claims, err := keycloak.VerifyAccessToken(cfg, tokenResp.AccessToken)
+		claims, err := kc.VerifyAccessToken(c.Request.Context(), tokenResp.AccessToken)
 		if err != nil {
-			log.Println(err)
+			slog.WarnContext(c.Request.Context(), "keycloak token failed verification", "error", err)
 			c.JSON(401, gin.H{"error": "invalid token"})
 			return
 		}
@@ -164,45 +161,30 @@ func Login(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 		}
 
 		// 4️⃣ Issue portal JWT
-		portalToken, err := GeneratePortalJWT(user, cfg.JWTSecret)
+		portalToken, err := GeneratePortalJWT(user, cfg.JWTSecret, sessionTTL(cfg))
 		if err != nil {
 			c.JSON(500, gin.H{"error": "login failed"})
 			return
 		}
 
 		// 5️⃣ Set cookie
-		c.SetCookie(
-			"portal_token",
-			portalToken,
-			86400,
-			"/",
-			"",
-			false,
-			true, // HttpOnly
-		)
+		setSessionCookie(c, cfg, portalToken)
 
 		c.JSON(200, gin.H{"message": "login successful"})
 	}
 }
 
-func SSOLogin(cfg config.Config) gin.HandlerFunc {
+func SSOLogin(cfg config.Config, kc *keycloak.Client) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		q := url.Values{}
-		q.Set("client_id", cfg.ClientID)
-		q.Set("response_type", "code")
-		q.Set("scope", "openid email profile")
-		q.Set("redirect_uri", cfg.BackendBaseURL+"/api/auth/sso/callback")
-
-		authURL := cfg.BaseURL +
-			"/realms/" + cfg.Realm +
-			"/protocol/openid-connect/auth?" +
-			q.Encode()
-
-		c.Redirect(302, authURL)
+		c.Redirect(302, kc.AuthURL(ssoRedirectURI(cfg)))
 	}
 }
 
-func SSOCallback(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
+func ssoRedirectURI(cfg config.Config) string {
+	return cfg.BackendBaseURL + "/api/auth/sso/callback"
+}
+
+func SSOCallback(db *gorm.DB, cfg config.Config, kc *keycloak.Client) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		code := c.Query("code")
 		if code == "" {
@@ -211,14 +193,14 @@ func SSOCallback(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 		}
 
 		// 1️⃣ Exchange code for token
-		token, err := exchangeCode(cfg, code)
+		token, err := kc.ExchangeCode(c.Request.Context(), code, ssoRedirectURI(cfg))
 		if err != nil {
 			c.JSON(401, gin.H{"error": "sso exchange failed"})
 			return
 		}
 
 		// 2️⃣ Verify token (same verifier you already trust)
-		claims, err := keycloak.VerifyAccessToken(cfg, token.AccessToken)
+		claims, err := kc.VerifyAccessToken(c.Request.Context(), token.AccessToken)
 		if err != nil {
 			c.JSON(401, gin.H{"error": "invalid token"})
 			return
@@ -233,65 +215,24 @@ func SSOCallback(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 		}
 
 		// 4️⃣ Issue portal JWT
-		portalToken, err := GeneratePortalJWT(user, cfg.JWTSecret)
+		portalToken, err := GeneratePortalJWT(user, cfg.JWTSecret, sessionTTL(cfg))
 		if err != nil {
 			c.JSON(500, gin.H{"error": "login failed"})
 			return
 		}
 
 		// 5️⃣ Set cookie
-		c.SetCookie(
-			"portal_token",
-			portalToken,
-			86400,
-			"/",
-			"",
-			false,
-			true,
-		)
+		setSessionCookie(c, cfg, portalToken)
 
 		// 6️⃣ Redirect to frontend
 		c.Redirect(302, cfg.FrontendURL)
 	}
 }
 
-func exchangeCode(cfg config.Config, code string) (*TokenResponse, error) {
-	data := url.Values{}
-	data.Set("grant_type", "authorization_code")
-	data.Set("code", code)
-	data.Set("client_id", cfg.ClientID)
-	data.Set("client_secret", cfg.ClientSecret)
-	data.Set("redirect_uri", cfg.BackendBaseURL+"/api/auth/sso/callback")
-
-	resp, err := http.PostForm(
-		cfg.BaseURL+"/realms/"+cfg.Realm+
-			"/protocol/openid-connect/token",
-		data,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	// log.Println("[SSO TOKEN EXCHANGE]", resp.StatusCode, string(body))
-
-	if resp.StatusCode != 200 {
-		return nil, errors.New("code exchange failed")
-	}
-
-	var token TokenResponse
-	if err := json.Unmarshal(body, &token); err != nil {
-		return nil, err
-	}
-
-	return &token, nil
-}
-
 func Me(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 1️⃣ Read cookie
-		tokenStr, err := c.Cookie("portal_token")
+		tokenStr, err := c.Cookie(sessionCookie)
 		if err != nil {
 			c.JSON(401, gin.H{"error": "unauthenticated"})
 			return
@@ -318,11 +259,12 @@ func Me(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 			Email     string
 			Role      string
 			CollegeID uint
+			CompanyID *uint
 		}
 
 		err = db.
 			Model(&models.User{}).
-			Select("id, name, email, role, college_id").
+			Select("id, name, email, role, college_id, company_id").
 			Where("id = ?", authCtx.UserID).
 			First(&user).Error
 
@@ -346,6 +288,7 @@ func Me(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
 			"email":            user.Email,
 			"role":             user.Role,
 			"college_id":       user.CollegeID,
+			"company_id":       user.CompanyID,
 			"profile_complete": profileComplete,
 		})
 	}
@@ -2,19 +2,30 @@ package auth
 
 import (
 	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/packages/keycloak"
+	"iiitn-career-portal/internal/packages/ratelimit"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func RegisterRoutes(rg *gin.RouterGroup, cfg config.Config, db *gorm.DB) {
+func RegisterRoutes(rg *gin.RouterGroup, cfg config.Config, db *gorm.DB, kc *keycloak.Client, limiter *ratelimit.Limiter) {
+
+	loginLimit := limiter.Middleware(
+		ratelimit.PolicyFromConfig(cfg, "login_ip", "20/1m", ratelimit.ByIP),
+		ratelimit.PolicyFromConfig(cfg, "login_email", "5/15m", ratelimit.ByJSONField("email")),
+	)
+	signupLimit := limiter.Middleware(
+		ratelimit.PolicyFromConfig(cfg, "signup_ip", "5/1h", ratelimit.ByIP),
+		ratelimit.PolicyFromConfig(cfg, "signup_email", "3/1h", ratelimit.ByJSONField("email")),
+	)
 
 	auth := rg.Group("/auth")
 	{
-		auth.POST("/signup", Signup(db, cfg))
-		auth.POST("/login", Login(db, cfg))
-		auth.GET("/sso/login", SSOLogin(cfg))
-		auth.GET("/sso/callback", SSOCallback(db, cfg))
+		auth.POST("/signup", signupLimit, Signup(db, cfg, kc))
+		auth.POST("/login", loginLimit, Login(db, cfg, kc))
+		auth.GET("/sso/login", SSOLogin(cfg, kc))
+		auth.GET("/sso/callback", SSOCallback(db, cfg, kc))
 		auth.GET("/me", Me(db, cfg))
 	}
 }
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"iiitn-career-portal/internal/config"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const sessionCookie = "portal_token"
+
+// sessionTTL falls back to the default for zero-value configs (tests).
+func sessionTTL(cfg config.Config) time.Duration {
+	if cfg.SessionTTL <= 0 {
+		return config.DefaultSessionTTL
+	}
+	return cfg.SessionTTL
+}
+
+// setSessionCookie writes the HttpOnly session cookie with the
+// per-environment domain, Secure and SameSite settings. It lives exactly
+// as long as the JWT inside it.
+func setSessionCookie(c *gin.Context, cfg config.Config, token string) {
+	c.SetSameSite(sameSite(cfg.Cookie.SameSite))
+	c.SetCookie(
+		sessionCookie,
+		token,
+		int(sessionTTL(cfg).Seconds()),
+		"/",
+		cfg.Cookie.Domain,
+		cfg.Cookie.Secure,
+		true, // HttpOnly
+	)
+}
+
+func sameSite(mode string) http.SameSite {
+	switch mode {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
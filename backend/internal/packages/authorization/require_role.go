@@ -1,6 +1,7 @@
 package authorization
 
 import (
+	"iiitn-career-portal/internal/models"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -26,6 +27,16 @@ func RequireRole(allowedRoles ...string) gin.HandlerFunc {
 			return
 		}
 
+		// recruiters belong to a company, never to a college; a recruiter
+		// session carrying one would pass college-scoped checks
+		if user.Role == string(models.Recruiter) && user.CollegeID != nil {
+			c.AbortWithStatusJSON(
+				http.StatusForbidden,
+				gin.H{"error": "insufficient permissions"},
+			)
+			return
+		}
+
 		for _, role := range allowedRoles {
 			if user.Role == role {
 				c.Next()
@@ -0,0 +1,60 @@
+package experiences
+
+import (
+	"iiitn-career-portal/internal/models"
+	"time"
+)
+
+type Round struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type CreateExperienceRequest struct {
+	Company    string                      `json:"company" binding:"required"`
+	Role       string                      `json:"role" binding:"required"`
+	Year       int                         `json:"year" binding:"required"`
+	Rounds     []Round                     `json:"rounds" binding:"dive"`
+	Questions  []string                    `json:"questions"`
+	Difficulty models.ExperienceDifficulty `json:"difficulty" binding:"required"`
+	Verdict    models.ExperienceVerdict    `json:"verdict" binding:"required"`
+	Content    string                      `json:"content"`
+}
+
+type ExperienceListQuery struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+
+	Company string `form:"company"`
+	Year    int    `form:"year"`
+}
+
+type CreateCommentRequest struct {
+	ParentID *uint  `json:"parent_id"`
+	Content  string `json:"content" binding:"required"`
+}
+
+type ExperienceResponse struct {
+	ID           uint                        `json:"id"`
+	AuthorID     uint                        `json:"author_id"`
+	AuthorName   string                      `json:"author_name"`
+	Company      string                      `json:"company"`
+	Role         string                      `json:"role"`
+	Year         int                         `json:"year"`
+	Rounds       []Round                     `json:"rounds"`
+	Questions    []string                    `json:"questions"`
+	Difficulty   models.ExperienceDifficulty `json:"difficulty"`
+	Verdict      models.ExperienceVerdict    `json:"verdict"`
+	Content      string                      `json:"content"`
+	CommentCount int64                       `json:"comment_count"`
+	CreatedAt    time.Time                   `json:"created_at"`
+}
+
+type CommentResponse struct {
+	ID         uint      `json:"id"`
+	ParentID   *uint     `json:"parent_id"`
+	AuthorID   uint      `json:"author_id"`
+	AuthorName string    `json:"author_name"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,365 @@
+package experiences
+
+import (
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/packages/notifications"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func CreateExperience(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req CreateExperienceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		company := strings.TrimSpace(req.Company)
+		role := strings.TrimSpace(req.Role)
+		if company == "" || role == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "company and role are required"})
+			return
+		}
+
+		if req.Year < 2000 || req.Year > time.Now().Year() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid year"})
+			return
+		}
+
+		if !isValidDifficulty(req.Difficulty) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid difficulty"})
+			return
+		}
+
+		if !isValidVerdict(req.Verdict) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid verdict"})
+			return
+		}
+
+		placed, err := isPlaced(db, auth.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+			return
+		}
+		if !placed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "only placed students can post experiences"})
+			return
+		}
+
+		if req.Rounds == nil {
+			req.Rounds = []Round{}
+		}
+		if req.Questions == nil {
+			req.Questions = []string{}
+		}
+
+		roundsJSON, _ := json.Marshal(req.Rounds)
+		questionsJSON, _ := json.Marshal(req.Questions)
+
+		exp := models.Experience{
+			AuthorID:   auth.UserID,
+			CollegeID:  *auth.CollegeID,
+			Company:    company,
+			Role:       role,
+			Year:       req.Year,
+			Rounds:     roundsJSON,
+			Questions:  questionsJSON,
+			Difficulty: req.Difficulty,
+			Verdict:    req.Verdict,
+			Content:    req.Content,
+		}
+
+		if err := db.Create(&exp).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create experience"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"id":      exp.ID,
+			"message": "experience posted",
+		})
+	}
+}
+
+func ListExperiences(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var q ExperienceListQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		applyDefaults(&q)
+
+		query := experienceQuery(db, auth.CollegeID)
+
+		if company := strings.TrimSpace(q.Company); company != "" {
+			query = query.Where("LOWER(experiences.company) = LOWER(?)", company)
+		}
+		if q.Year > 0 {
+			query = query.Where("experiences.year = ?", q.Year)
+		}
+
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count experiences"})
+			return
+		}
+
+		var rows []experienceRow
+		if err := selectExperienceRows(query).
+			Order("experiences.created_at DESC").
+			Limit(q.Limit).
+			Offset((q.Page - 1) * q.Limit).
+			Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch experiences"})
+			return
+		}
+
+		data := make([]ExperienceResponse, 0, len(rows))
+		for _, r := range rows {
+			data = append(data, toExperienceResponse(r))
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": data,
+			"meta": gin.H{
+				"page":  q.Page,
+				"limit": q.Limit,
+				"total": total,
+			},
+		})
+	}
+}
+
+func GetExperienceByID(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		expID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || expID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid experience id"})
+			return
+		}
+
+		var rows []experienceRow
+		if err := selectExperienceRows(experienceQuery(db, auth.CollegeID)).
+			Where("experiences.id = ?", expID).
+			Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch experience"})
+			return
+		}
+
+		if len(rows) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "experience not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, toExperienceResponse(rows[0]))
+	}
+}
+
+func DeleteExperience(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		exp, ok := loadExperience(c, db, auth)
+		if !ok {
+			return
+		}
+
+		// author or their college admin (moderation)
+		if exp.AuthorID != auth.UserID && auth.Role != string(models.CollegeAdmin) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.
+				Where("experience_id = ?", exp.ID).
+				Delete(&models.ExperienceComment{}).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&exp).Error
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete experience"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "experience deleted"})
+	}
+}
+
+func ListComments(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		exp, ok := loadExperience(c, db, auth)
+		if !ok {
+			return
+		}
+
+		page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+		if page < 1 {
+			page = 1
+		}
+		if limit < 1 || limit > 100 {
+			limit = 50
+		}
+
+		query := db.
+			Table("experience_comments").
+			Joins("JOIN users ON users.id = experience_comments.author_id").
+			Where("experience_comments.experience_id = ?", exp.ID)
+
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count comments"})
+			return
+		}
+
+		comments := []CommentResponse{}
+		if err := query.
+			Select(`
+				experience_comments.id,
+				experience_comments.parent_id,
+				experience_comments.author_id,
+				users.name AS author_name,
+				experience_comments.content,
+				experience_comments.created_at
+			`).
+			Order("experience_comments.created_at ASC").
+			Limit(limit).
+			Offset((page - 1) * limit).
+			Scan(&comments).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch comments"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": comments,
+			"meta": gin.H{
+				"page":  page,
+				"limit": limit,
+				"total": total,
+			},
+		})
+	}
+}
+
+func CreateComment(db *gorm.DB, rdb *redis.Client) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		exp, ok := loadExperience(c, db, auth)
+		if !ok {
+			return
+		}
+
+		var req CreateCommentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		content := strings.TrimSpace(req.Content)
+		if content == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "content is required"})
+			return
+		}
+
+		var parent models.ExperienceComment
+		if req.ParentID != nil {
+			if err := db.
+				Where("id = ?", *req.ParentID).
+				Where("experience_id = ?", exp.ID).
+				First(&parent).Error; err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid parent_id"})
+				return
+			}
+		}
+
+		comment := models.ExperienceComment{
+			ExperienceID: exp.ID,
+			ParentID:     req.ParentID,
+			AuthorID:     auth.UserID,
+			Content:      content,
+		}
+
+		if err := db.Create(&comment).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add comment"})
+			return
+		}
+
+		// notify the post author and, for replies, the parent's author
+		recipients := map[uint]bool{exp.AuthorID: true}
+		if req.ParentID != nil {
+			recipients[parent.AuthorID] = true
+		}
+		delete(recipients, auth.UserID)
+
+		for userID := range recipients {
+			if err := notifications.Push(
+				db,
+				rdb,
+				userID,
+				models.NotificationDiscussionUpdate,
+				exp.ID,
+				gin.H{
+					"experience_id": exp.ID,
+					"comment_id":    comment.ID,
+					"company":       exp.Company,
+					"is_reply":      req.ParentID != nil,
+				},
+			); err != nil {
+				slog.ErrorContext(c.Request.Context(), "failed to notify discussion update", "experience_id", exp.ID, "error", err)
+			}
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"id":      comment.ID,
+			"message": "comment added",
+		})
+	}
+}
+
+func loadExperience(c *gin.Context, db *gorm.DB, auth *authorization.AuthContext) (models.Experience, bool) {
+	var exp models.Experience
+
+	expID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || expID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid experience id"})
+		return exp, false
+	}
+
+	if err := db.
+		Where("id = ?", expID).
+		Where("college_id = ?", auth.CollegeID).
+		First(&exp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "experience not found"})
+			return exp, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+		return exp, false
+	}
+
+	return exp, true
+}
@@ -0,0 +1,37 @@
+package experiences
+
+import (
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB, rc *redis.Client) {
+	experiences := rg.Group("/experiences")
+	experiences.Use(authorization.RequireRole(
+		string(models.Student),
+		string(models.Alumni),
+		string(models.CollegeAdmin),
+	))
+	{
+		experiences.GET("", ListExperiences(db))
+		experiences.GET("/:id", GetExperienceByID(db))
+		experiences.DELETE("/:id", DeleteExperience(db))
+
+		experiences.GET("/:id/comments", ListComments(db))
+		experiences.POST("/:id/comments", CreateComment(db, rc))
+
+		// Students and alumni (placed only, checked in handler)
+		experiences.POST(
+			"",
+			authorization.RequireRole(
+				string(models.Student),
+				string(models.Alumni),
+			),
+			CreateExperience(db),
+		)
+	}
+}
@@ -0,0 +1,115 @@
+package experiences
+
+import (
+	"encoding/json"
+	"iiitn-career-portal/internal/models"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func isValidDifficulty(d models.ExperienceDifficulty) bool {
+	switch d {
+	case models.DifficultyEasy, models.DifficultyMedium, models.DifficultyHard:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidVerdict(v models.ExperienceVerdict) bool {
+	switch v {
+	case models.VerdictSelected, models.VerdictRejected, models.VerdictPending:
+		return true
+	default:
+		return false
+	}
+}
+
+func applyDefaults(q *ExperienceListQuery) {
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.Limit <= 0 || q.Limit > 50 {
+		q.Limit = 10
+	}
+}
+
+// isPlaced reports whether the student holds at least one offer.
+func isPlaced(db *gorm.DB, studentID uint) (bool, error) {
+	var count int64
+	err := db.Model(&models.Application{}).
+		Where("student_id = ?", studentID).
+		Where("status = ?", models.Offered).
+		Count(&count).Error
+	return count > 0, err
+}
+
+type experienceRow struct {
+	ID           uint
+	AuthorID     uint
+	AuthorName   string
+	Company      string
+	Role         string
+	Year         int
+	Rounds       datatypes.JSON
+	Questions    datatypes.JSON
+	Difficulty   models.ExperienceDifficulty
+	Verdict      models.ExperienceVerdict
+	Content      string
+	CommentCount int64
+	CreatedAt    time.Time
+}
+
+// experienceQuery is the college-scoped listing query.
+func experienceQuery(db *gorm.DB, collegeID *uint) *gorm.DB {
+	return db.
+		Table("experiences").
+		Joins("JOIN users ON users.id = experiences.author_id").
+		Where("experiences.college_id = ?", collegeID)
+}
+
+func selectExperienceRows(query *gorm.DB) *gorm.DB {
+	return query.Select(`
+		experiences.id,
+		experiences.author_id,
+		users.name AS author_name,
+		experiences.company,
+		experiences.role,
+		experiences.year,
+		experiences.rounds,
+		experiences.questions,
+		experiences.difficulty,
+		experiences.verdict,
+		experiences.content,
+		experiences.created_at,
+		(
+			SELECT COUNT(*) FROM experience_comments
+			WHERE experience_comments.experience_id = experiences.id
+		) AS comment_count
+	`)
+}
+
+func toExperienceResponse(r experienceRow) ExperienceResponse {
+	rounds := []Round{}
+	questions := []string{}
+	_ = json.Unmarshal(r.Rounds, &rounds)
+	_ = json.Unmarshal(r.Questions, &questions)
+
+	return ExperienceResponse{
+		ID:           r.ID,
+		AuthorID:     r.AuthorID,
+		AuthorName:   r.AuthorName,
+		Company:      r.Company,
+		Role:         r.Role,
+		Year:         r.Year,
+		Rounds:       rounds,
+		Questions:    questions,
+		Difficulty:   r.Difficulty,
+		Verdict:      r.Verdict,
+		Content:      r.Content,
+		CommentCount: r.CommentCount,
+		CreatedAt:    r.CreatedAt,
+	}
+}
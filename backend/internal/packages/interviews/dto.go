@@ -0,0 +1,45 @@
+package interviews
+
+import "time"
+
+type SlotInput struct {
+	StartsAt        time.Time `json:"starts_at" binding:"required"`
+	DurationMinutes int       `json:"duration_minutes" binding:"required,min=5,max=480"`
+	Venue           *string   `json:"venue"`
+	MeetingLink     *string   `json:"meeting_link"`
+	Panel           []string  `json:"panel"`
+}
+
+type CreateSlotsRequest struct {
+	Slots []SlotInput `json:"slots" binding:"required,min=1,max=200,dive"`
+}
+
+type AssignSlotRequest struct {
+	// nil unassigns the slot
+	ApplicationID *uint `json:"application_id"`
+	// an occupied slot is only given to someone else when set
+	Replace bool `json:"replace"`
+}
+
+type RescheduleRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type SlotResponse struct {
+	ID              uint      `json:"id"`
+	JobID           uint      `json:"job_id"`
+	Company         string    `json:"company"`
+	Title           string    `json:"title"`
+	StartsAt        time.Time `json:"starts_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Venue           *string   `json:"venue"`
+	MeetingLink     *string   `json:"meeting_link"`
+	Panel           []string  `json:"panel"`
+
+	ApplicationID *uint   `json:"application_id"`
+	StudentID     *uint   `json:"student_id,omitempty"`
+	StudentName   *string `json:"student_name,omitempty"`
+
+	RescheduleReason      *string    `json:"reschedule_reason"`
+	RescheduleRequestedAt *time.Time `json:"reschedule_requested_at"`
+}
@@ -0,0 +1,208 @@
+package interviews
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type slotRow struct {
+	ID                    uint
+	JobID                 uint
+	Company               string
+	Title                 string
+	StartsAt              time.Time
+	DurationMinutes       int
+	Venue                 *string
+	MeetingLink           *string
+	Panel                 datatypes.JSON
+	ApplicationID         *uint
+	StudentID             *uint
+	StudentName           *string
+	RescheduleReason      *string
+	RescheduleRequestedAt *time.Time
+	UpdatedAt             time.Time
+}
+
+// takesPart matches jobs the college posted or joined as a pooled
+// drive it still shows its students; the two placeholders are both the
+// college id. Slots of a pooled job are shared, applicants stay with
+// their own college.
+const takesPart = `(jobs.college_id = ? OR EXISTS (
+	SELECT 1 FROM job_colleges
+	WHERE job_colleges.job_id = jobs.id
+		AND job_colleges.college_id = ?
+		AND job_colleges.is_visible
+))`
+
+// slotQuery joins everything a slot listing or calendar entry needs.
+func slotQuery(db *gorm.DB) *gorm.DB {
+	return db.
+		Table("interview_slots").
+		Select(`
+			interview_slots.id,
+			interview_slots.job_id,
+			jobs.company,
+			jobs.title,
+			interview_slots.starts_at,
+			interview_slots.duration_minutes,
+			interview_slots.venue,
+			interview_slots.meeting_link,
+			interview_slots.panel,
+			interview_slots.application_id,
+			applications.student_id,
+			users.name AS student_name,
+			interview_slots.reschedule_reason,
+			interview_slots.reschedule_requested_at,
+			interview_slots.updated_at
+		`).
+		Joins("JOIN jobs ON jobs.id = interview_slots.job_id").
+		Joins("LEFT JOIN applications ON applications.id = interview_slots.application_id").
+		Joins("LEFT JOIN users ON users.id = applications.student_id").
+		Order("interview_slots.starts_at ASC")
+}
+
+func toSlotResponses(rows []slotRow) []SlotResponse {
+	out := make([]SlotResponse, 0, len(rows))
+	for _, r := range rows {
+		var panel []string
+		_ = json.Unmarshal(r.Panel, &panel)
+
+		out = append(out, SlotResponse{
+			ID:                    r.ID,
+			JobID:                 r.JobID,
+			Company:               r.Company,
+			Title:                 r.Title,
+			StartsAt:              r.StartsAt,
+			DurationMinutes:       r.DurationMinutes,
+			Venue:                 r.Venue,
+			MeetingLink:           r.MeetingLink,
+			Panel:                 panel,
+			ApplicationID:         r.ApplicationID,
+			StudentID:             r.StudentID,
+			StudentName:           r.StudentName,
+			RescheduleReason:      r.RescheduleReason,
+			RescheduleRequestedAt: r.RescheduleRequestedAt,
+		})
+	}
+	return out
+}
+
+func isValidMeetingLink(s string) bool {
+	u, err := url.ParseRequestURI(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
+
+func newFeedToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// -------- ICS (RFC 5545) --------
+
+const icsTimeLayout = "20060102T150405Z"
+
+// buildICS stamps the events with now, when the calendar was generated.
+func buildICS(name string, rows []slotRow, now time.Time) string {
+	var b strings.Builder
+
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//IIITN Career Portal//Interviews//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText(name))
+
+	stamp := now.UTC().Format(icsTimeLayout)
+
+	for _, r := range rows {
+		start := r.StartsAt.UTC()
+		end := start.Add(time.Duration(r.DurationMinutes) * time.Minute)
+
+		var panel []string
+		_ = json.Unmarshal(r.Panel, &panel)
+
+		var desc []string
+		if r.MeetingLink != nil {
+			desc = append(desc, "Meeting link: "+*r.MeetingLink)
+		}
+		if len(panel) > 0 {
+			desc = append(desc, "Panel: "+strings.Join(panel, ", "))
+		}
+
+		location := ""
+		if r.Venue != nil {
+			location = *r.Venue
+		} else if r.MeetingLink != nil {
+			location = *r.MeetingLink
+		}
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, fmt.Sprintf("UID:interview-slot-%d@iiitn-career-portal", r.ID))
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		writeICSLine(&b, "LAST-MODIFIED:"+r.UpdatedAt.UTC().Format(icsTimeLayout))
+		writeICSLine(&b, "DTSTART:"+start.Format(icsTimeLayout))
+		writeICSLine(&b, "DTEND:"+end.Format(icsTimeLayout))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText("Interview: "+r.Company+" - "+r.Title))
+		if location != "" {
+			writeICSLine(&b, "LOCATION:"+escapeICSText(location))
+		}
+		if len(desc) > 0 {
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(strings.Join(desc, "\n")))
+		}
+		if r.MeetingLink != nil {
+			writeICSLine(&b, "URL:"+*r.MeetingLink)
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+
+	return b.String()
+}
+
+func escapeICSText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// writeICSLine folds content lines at 75 octets as the RFC requires,
+// without splitting multi-byte characters.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := 75
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+
+		// continuation lines lose one octet to the leading space
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
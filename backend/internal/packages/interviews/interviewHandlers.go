@@ -0,0 +1,646 @@
+package interviews
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/packages/notifications"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// errSlotTaken aborts an assignment racing another one.
+var errSlotTaken = errors.New("slot taken")
+
+// -------- College admin --------
+
+func CreateSlots(db *gorm.DB, now func() time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		job, ok := loadCollegeJob(c, db, auth)
+		if !ok {
+			return
+		}
+
+		var req CreateSlotsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		slots := make([]models.InterviewSlot, 0, len(req.Slots))
+		for _, in := range req.Slots {
+			if !in.StartsAt.After(now()) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "starts_at must be in the future"})
+				return
+			}
+			if in.Venue == nil && in.MeetingLink == nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "venue or meeting_link required"})
+				return
+			}
+			if in.MeetingLink != nil && !isValidMeetingLink(strings.TrimSpace(*in.MeetingLink)) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "meeting_link must be a valid URL"})
+				return
+			}
+
+			panelJSON, _ := json.Marshal(in.Panel)
+
+			slots = append(slots, models.InterviewSlot{
+				JobID:           job.ID,
+				CollegeID:       *auth.CollegeID,
+				StartsAt:        in.StartsAt,
+				DurationMinutes: in.DurationMinutes,
+				Venue:           in.Venue,
+				MeetingLink:     in.MeetingLink,
+				Panel:           panelJSON,
+			})
+		}
+
+		if err := db.Create(&slots).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create slots"})
+			return
+		}
+
+		ids := make([]uint, 0, len(slots))
+		for _, s := range slots {
+			ids = append(ids, s.ID)
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"ids":     ids,
+			"message": "slots created",
+		})
+	}
+}
+
+func ListJobSlots(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		job, ok := loadCollegeJob(c, db, auth)
+		if !ok {
+			return
+		}
+
+		// free slots and those held by the college's own applicants
+		var rows []slotRow
+		if err := slotQuery(db).
+			Where("interview_slots.job_id = ?", job.ID).
+			Where("(interview_slots.application_id IS NULL OR applications.college_id = ?)", auth.CollegeID).
+			Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch slots"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": toSlotResponses(rows)})
+	}
+}
+
+// AutoAssignSlots pairs every INTERVIEW-stage applicant of the caller's
+// college without a slot with the earliest free upcoming slot, in
+// application order.
+func AutoAssignSlots(db *gorm.DB, rdb *redis.Client, now func() time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		job, ok := loadCollegeJob(c, db, auth)
+		if !ok {
+			return
+		}
+
+		var (
+			assigned   []models.InterviewSlot
+			unassigned int
+		)
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var apps []models.Application
+			if err := tx.
+				Where("job_id = ?", job.ID).
+				Where("college_id = ?", auth.CollegeID).
+				Where("status = ?", models.Interview).
+				Where("id NOT IN (?)", tx.Model(&models.InterviewSlot{}).
+					Select("application_id").
+					Where("application_id IS NOT NULL")).
+				Order("id ASC").
+				Find(&apps).Error; err != nil {
+				return err
+			}
+
+			var free []models.InterviewSlot
+			if err := tx.
+				Where("job_id = ?", job.ID).
+				Where("application_id IS NULL").
+				Where("starts_at > ?", now()).
+				Order("starts_at ASC").
+				Limit(len(apps)).
+				Find(&free).Error; err != nil {
+				return err
+			}
+
+			next := 0
+			for _, slot := range free {
+				if next == len(apps) {
+					break
+				}
+				appID := apps[next].ID
+
+				// another college of a pooled drive may have taken it
+				res := tx.Model(&slot).
+					Where("application_id IS NULL").
+					Updates(map[string]interface{}{
+						"application_id":          appID,
+						"reschedule_reason":       nil,
+						"reschedule_requested_at": nil,
+					})
+				if res.Error != nil {
+					return res.Error
+				}
+				if res.RowsAffected == 0 {
+					continue
+				}
+				slot.ApplicationID = &appID
+				assigned = append(assigned, slot)
+				next++
+			}
+
+			unassigned = len(apps) - next
+			return nil
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to assign slots"})
+			return
+		}
+
+		for _, slot := range assigned {
+			notifyScheduled(db, rdb, job, slot)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"assigned_count":   len(assigned),
+			"unassigned_count": unassigned,
+		})
+	}
+}
+
+func AssignSlot(db *gorm.DB, rdb *redis.Client) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		slot, ok := loadCollegeSlot(c, db, auth)
+		if !ok {
+			return
+		}
+
+		var req AssignSlotRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if req.ApplicationID != nil {
+			var app models.Application
+			if err := db.
+				Where("id = ?", *req.ApplicationID).
+				Where("job_id = ?", slot.JobID).
+				Where("college_id = ?", auth.CollegeID).
+				First(&app).Error; err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "application does not belong to this job"})
+				return
+			}
+
+			if app.Status != models.Interview {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "application is not in INTERVIEW stage"})
+				return
+			}
+		}
+
+		// the applicant losing the slot, if any
+		var displaced *uint
+		if slot.ApplicationID != nil && (req.ApplicationID == nil || *req.ApplicationID != *slot.ApplicationID) {
+			displaced = slot.ApplicationID
+		}
+		if displaced != nil {
+			// pooled drives share slots; other colleges' applicants are off limits
+			var holder models.Application
+			if err := db.Select("college_id").First(&holder, *displaced).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+				return
+			}
+			if holder.CollegeID != *auth.CollegeID {
+				c.JSON(http.StatusConflict, gin.H{"error": "slot is held by another college's applicant"})
+				return
+			}
+		}
+		if displaced != nil && req.ApplicationID != nil && !req.Replace {
+			c.JSON(http.StatusConflict, gin.H{"error": "slot is already assigned; set replace to give it to another applicant"})
+			return
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// an applicant holds at most one slot: moving them frees the old one
+			if req.ApplicationID != nil {
+				if err := tx.Model(&models.InterviewSlot{}).
+					Where("application_id = ?", *req.ApplicationID).
+					Where("id <> ?", slot.ID).
+					Update("application_id", nil).Error; err != nil {
+					return err
+				}
+			}
+
+			// only if nobody assigned the slot in the meantime
+			update := tx.Model(&slot)
+			if slot.ApplicationID == nil {
+				update = update.Where("application_id IS NULL")
+			} else {
+				update = update.Where("application_id = ?", *slot.ApplicationID)
+			}
+			res := update.Updates(map[string]interface{}{
+				"application_id":          req.ApplicationID,
+				"reschedule_reason":       nil,
+				"reschedule_requested_at": nil,
+			})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return errSlotTaken
+			}
+
+			if displaced == nil {
+				return nil
+			}
+			return audit.Record(tx, c, audit.Entry{
+				Action:     "interview.slot_reassign",
+				EntityType: "interview_slot",
+				EntityID:   slot.ID,
+				CollegeID:  &slot.CollegeID,
+				Diff: audit.Diff(
+					map[string]interface{}{"application_id": displaced},
+					map[string]interface{}{"application_id": req.ApplicationID},
+				),
+			})
+		})
+		if errors.Is(err, errSlotTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": "slot was changed meanwhile, reload and retry"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to assign slot"})
+			return
+		}
+
+		if displaced != nil {
+			notifyCancelled(db, rdb, slot.Job, slot, *displaced)
+		}
+		if req.ApplicationID != nil {
+			slot.ApplicationID = req.ApplicationID
+			notifyScheduled(db, rdb, slot.Job, slot)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "slot updated"})
+	}
+}
+
+func DeleteSlot(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		slot, ok := loadCollegeSlot(c, db, auth)
+		if !ok {
+			return
+		}
+
+		if slot.CollegeID != *auth.CollegeID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "only the college that created the slot can delete it"})
+			return
+		}
+
+		if slot.ApplicationID != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "unassign the slot before deleting it"})
+			return
+		}
+
+		if err := db.Delete(&slot).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete slot"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "slot deleted"})
+	}
+}
+
+// -------- Student --------
+
+func GetMySlots(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var rows []slotRow
+		if err := slotQuery(db).
+			Where("applications.student_id = ?", auth.UserID).
+			Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch interviews"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": toSlotResponses(rows)})
+	}
+}
+
+func RequestReschedule(db *gorm.DB, rdb *redis.Client, now func() time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		slotID, err := strconv.ParseUint(c.Param("slot_id"), 10, 64)
+		if err != nil || slotID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid slot id"})
+			return
+		}
+
+		var req RescheduleRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		reason := strings.TrimSpace(req.Reason)
+		if reason == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required"})
+			return
+		}
+
+		var slot models.InterviewSlot
+		if err := db.
+			Joins("JOIN applications ON applications.id = interview_slots.application_id").
+			Where("interview_slots.id = ?", slotID).
+			Where("applications.student_id = ?", auth.UserID).
+			Preload("Job").
+			First(&slot).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "interview slot not found"})
+			return
+		}
+
+		requestedAt := now()
+		if !slot.StartsAt.After(requestedAt) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "interview already started"})
+			return
+		}
+
+		if err := db.Model(&slot).Updates(map[string]interface{}{
+			"reschedule_reason":       reason,
+			"reschedule_requested_at": requestedAt,
+		}).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to request reschedule"})
+			return
+		}
+
+		// the student's own college schedules them, even on a shared slot
+		collegeID := slot.CollegeID
+		if auth.CollegeID != nil {
+			collegeID = *auth.CollegeID
+		}
+
+		if err := notifications.PushToCollegeAdmins(
+			db,
+			rdb,
+			collegeID,
+			models.NotificationInterviewRescheduleRequest,
+			slot.ID,
+			gin.H{
+				"slot_id":    slot.ID,
+				"job_id":     slot.JobID,
+				"company":    slot.Job.Company,
+				"student_id": auth.UserID,
+				"reason":     reason,
+			},
+		); err != nil {
+			slog.ErrorContext(c.Request.Context(), "failed to notify reschedule request", "slot_id", slot.ID, "error", err)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "reschedule requested"})
+	}
+}
+
+// -------- Calendar --------
+
+// DownloadSlotICS serves a single slot to its student or the college admin.
+func DownloadSlotICS(db *gorm.DB, now func() time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		slotID, err := strconv.ParseUint(c.Param("slot_id"), 10, 64)
+		if err != nil || slotID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid slot id"})
+			return
+		}
+
+		query := slotQuery(db).Where("interview_slots.id = ?", slotID)
+
+		switch models.Role(auth.Role) {
+		case models.Student:
+			query = query.Where("applications.student_id = ?", auth.UserID)
+		case models.CollegeAdmin:
+			query = query.Where("(applications.college_id = ? OR interview_slots.college_id = ?)", auth.CollegeID, auth.CollegeID)
+		default:
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+
+		var rows []slotRow
+		if err := query.Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch slot"})
+			return
+		}
+		if len(rows) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "interview slot not found"})
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%d.ics"`, slotID))
+		c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(buildICS("Interview", rows, now())))
+	}
+}
+
+// GetFeedURL returns the student's personal calendar feed URL, creating
+// the token on first use. Pass ?rotate=true to invalidate the old URL.
+func GetFeedURL(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var feed models.CalendarFeed
+		err := db.Where("student_id = ?", auth.UserID).First(&feed).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) || c.Query("rotate") == "true" {
+			token, err := newFeedToken()
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create feed"})
+				return
+			}
+
+			feed.StudentID = auth.UserID
+			feed.Token = token
+			if err := db.Save(&feed).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create feed"})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"feed_url": cfg.BackendBaseURL + "/api/calendar/" + feed.Token + ".ics",
+		})
+	}
+}
+
+// CalendarFeed is public: the unguessable token in the path is the auth.
+func CalendarFeed(db *gorm.DB, now func() time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+		var feed models.CalendarFeed
+		if err := db.Where("token = ?", token).First(&feed).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "feed not found"})
+			return
+		}
+
+		var rows []slotRow
+		if err := slotQuery(db).
+			Where("applications.student_id = ?", feed.StudentID).
+			Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch interviews"})
+			return
+		}
+
+		c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(buildICS("Placement interviews", rows, now())))
+	}
+}
+
+// -------- loaders --------
+
+func loadCollegeJob(c *gin.Context, db *gorm.DB, auth *authorization.AuthContext) (models.Job, bool) {
+	var job models.Job
+
+	jobID, err := strconv.ParseUint(c.Param("job_id"), 10, 64)
+	if err != nil || jobID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid job id"})
+		return job, false
+	}
+
+	if err := db.
+		Where("id = ?", jobID).
+		Where(takesPart, auth.CollegeID, auth.CollegeID).
+		First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+			return job, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+		return job, false
+	}
+
+	return job, true
+}
+
+func loadCollegeSlot(c *gin.Context, db *gorm.DB, auth *authorization.AuthContext) (models.InterviewSlot, bool) {
+	var slot models.InterviewSlot
+
+	slotID, err := strconv.ParseUint(c.Param("slot_id"), 10, 64)
+	if err != nil || slotID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid slot id"})
+		return slot, false
+	}
+
+	if err := db.
+		Preload("Job").
+		Joins("JOIN jobs ON jobs.id = interview_slots.job_id").
+		Where("interview_slots.id = ?", slotID).
+		Where(takesPart, auth.CollegeID, auth.CollegeID).
+		First(&slot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "interview slot not found"})
+			return slot, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+		return slot, false
+	}
+
+	return slot, true
+}
+
+// notifyCancelled tells the applicant who lost a slot that their
+// interview is off until they get a new one.
+func notifyCancelled(db *gorm.DB, rdb *redis.Client, job models.Job, slot models.InterviewSlot, applicationID uint) {
+	var studentID uint
+	if err := db.Model(&models.Application{}).
+		Select("student_id").
+		Where("id = ?", applicationID).
+		Scan(&studentID).Error; err != nil {
+		slog.Error("failed to resolve interview student", "slot_id", slot.ID, "error", err)
+		return
+	}
+
+	if err := notifications.Push(
+		db,
+		rdb,
+		studentID,
+		models.NotificationInterviewCancelled,
+		slot.ID,
+		gin.H{
+			"slot_id":        slot.ID,
+			"job_id":         job.ID,
+			"company":        job.Company,
+			"title":          job.Title,
+			"starts_at":      slot.StartsAt,
+			"application_id": applicationID,
+		},
+	); err != nil {
+		slog.Error("failed to notify interview cancellation", "slot_id", slot.ID, "error", err)
+	}
+}
+
+func notifyScheduled(db *gorm.DB, rdb *redis.Client, job models.Job, slot models.InterviewSlot) {
+	var studentID uint
+	if err := db.Model(&models.Application{}).
+		Select("student_id").
+		Where("id = ?", *slot.ApplicationID).
+		Scan(&studentID).Error; err != nil {
+		slog.Error("failed to resolve interview student", "slot_id", slot.ID, "error", err)
+		return
+	}
+
+	if err := notifications.Push(
+		db,
+		rdb,
+		studentID,
+		models.NotificationInterviewScheduled,
+		slot.ID,
+		gin.H{
+			"slot_id":          slot.ID,
+			"job_id":           job.ID,
+			"company":          job.Company,
+			"title":            job.Title,
+			"starts_at":        slot.StartsAt,
+			"duration_minutes": slot.DurationMinutes,
+			"venue":            slot.Venue,
+			"meeting_link":     slot.MeetingLink,
+		},
+	); err != nil {
+		slog.Error("failed to notify interview slot", "slot_id", slot.ID, "error", err)
+	}
+}
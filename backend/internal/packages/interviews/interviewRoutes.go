@@ -0,0 +1,54 @@
+package interviews
+
+import (
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB, rc *redis.Client, cfg config.Config) {
+	now := time.Now
+
+	interviews := rg.Group("/interviews")
+	{
+		// College admin only
+		admin := interviews.Group("")
+		admin.Use(authorization.RequireRole(string(models.CollegeAdmin)))
+		{
+			admin.POST("/jobs/:job_id/slots", CreateSlots(db, now))
+			admin.GET("/jobs/:job_id/slots", ListJobSlots(db))
+			admin.POST("/jobs/:job_id/slots/auto-assign", AutoAssignSlots(db, rc, now))
+			admin.PUT("/slots/:slot_id/assign", AssignSlot(db, rc))
+			admin.DELETE("/slots/:slot_id", DeleteSlot(db))
+		}
+
+		// Student only
+		student := interviews.Group("")
+		student.Use(authorization.RequireRole(string(models.Student)))
+		{
+			student.GET("/me", GetMySlots(db))
+			student.POST("/slots/:slot_id/reschedule", RequestReschedule(db, rc, now))
+			student.GET("/feed", GetFeedURL(db, cfg))
+		}
+
+		interviews.GET(
+			"/slots/:slot_id/ics",
+			authorization.RequireRole(
+				string(models.Student),
+				string(models.CollegeAdmin),
+			),
+			DownloadSlotICS(db, now),
+		)
+	}
+}
+
+// RegisterPublicRoutes mounts the token-authenticated ICS feed, which
+// calendar clients fetch without the portal cookie.
+func RegisterPublicRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	rg.GET("/calendar/:token", CalendarFeed(db, time.Now))
+}
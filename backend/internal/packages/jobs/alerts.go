@@ -0,0 +1,109 @@
+package jobs
+
+import (
+	"context"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/notifications"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	savedSearchAlertInterval = time.Minute
+	// daily searches get at most one digest per period
+	savedSearchDigestPeriod = 24 * time.Hour
+	// new jobs matched per query, bounding one worker transaction
+	savedSearchMatchBatch = 100
+)
+
+// AlertJob is a job in a saved-search alert.
+type AlertJob struct {
+	JobID   uint   `json:"job_id"`
+	Title   string `json:"title"`
+	Company string `json:"company"`
+}
+
+// SavedSearchAlert is what one saved search has to tell its student.
+type SavedSearchAlert struct {
+	SearchID  uint
+	StudentID uint
+	Name      string
+	Frequency models.AlertFrequency
+	Jobs      []AlertJob
+}
+
+// SendSavedSearchAlerts matches new jobs against saved searches, then
+// alerts: instant searches once per job, daily ones with a digest of
+// everything matched since their last one.
+func (s *Service) SendSavedSearchAlerts(ctx context.Context) error {
+	for {
+		n, err := s.repo.MatchPendingJobs(ctx, savedSearchMatchBatch)
+		if err != nil {
+			return err
+		}
+		if n < savedSearchMatchBatch {
+			break
+		}
+	}
+
+	now := s.now()
+	alerts, err := s.repo.ClaimSavedSearchAlerts(ctx, now, now.Add(-savedSearchDigestPeriod))
+	if err != nil {
+		return err
+	}
+
+	for _, alert := range alerts {
+		if alert.Frequency == models.AlertDaily {
+			s.pushAlert(ctx, alert, models.NotificationSavedSearchDigest, alert.SearchID, gin.H{
+				"saved_search_id": alert.SearchID,
+				"saved_search":    alert.Name,
+				"jobs":            alert.Jobs,
+			})
+			continue
+		}
+		for _, job := range alert.Jobs {
+			s.pushAlert(ctx, alert, models.NotificationSavedSearchMatch, job.JobID, gin.H{
+				"job_id":          job.JobID,
+				"title":           job.Title,
+				"company":         job.Company,
+				"saved_search_id": alert.SearchID,
+				"saved_search":    alert.Name,
+			})
+		}
+	}
+	return nil
+}
+
+// pushAlert logs a failed push; the match is already claimed, so one
+// student's failure does not hold up the others.
+func (s *Service) pushAlert(ctx context.Context, alert SavedSearchAlert, typ models.NotificationType, targetID uint, payload gin.H) {
+	if err := s.notifier.Push(ctx, alert.StudentID, typ, targetID, payload); err != nil {
+		slog.ErrorContext(ctx, "failed to send saved search alert", "saved_search_id", alert.SearchID, "error", err)
+	}
+}
+
+// StartSavedSearchAlerts runs SendSavedSearchAlerts every
+// savedSearchAlertInterval until ctx is cancelled. Several instances may
+// run side by side.
+func StartSavedSearchAlerts(ctx context.Context, db *gorm.DB, rdb *redis.Client) {
+	svc := NewService(NewGormRepository(db), notifications.New(db, rdb))
+
+	ticker := time.NewTicker(savedSearchAlertInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.SendSavedSearchAlerts(ctx); err != nil {
+			slog.Error("saved search alerts failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
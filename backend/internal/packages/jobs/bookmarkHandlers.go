@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/pagination"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func BookmarkJob(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		jobID, ok := parseJobID(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.Bookmark(c.Request.Context(), auth, jobID); err != nil {
+			writeServiceError(c, err, "failed to bookmark job")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "job bookmarked"})
+	}
+}
+
+func UnbookmarkJob(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		jobID, ok := parseJobID(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.Unbookmark(c.Request.Context(), auth, jobID); err != nil {
+			writeServiceError(c, err, "failed to remove bookmark")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "bookmark removed"})
+	}
+}
+
+func GetBookmarkedJobs(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var params pagination.Params
+		if err := c.ShouldBindQuery(&params); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pagination parameters"})
+			return
+		}
+
+		page, q, err := svc.Bookmarks(c.Request.Context(), auth, ListQuery{Params: params})
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch bookmarked jobs")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": page.Items,
+			"meta": pagination.Meta(q.Params, page.Total, page.NextCursor),
+		})
+	}
+}
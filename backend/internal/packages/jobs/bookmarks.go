@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/pagination"
+	"time"
+)
+
+// bookmarks list in the only order: most recently bookmarked first
+const bookmarkSort = "bookmarked"
+
+var bookmarkKeyset = pagination.Keyset{
+	Column:   "job_bookmarks.created_at",
+	IDColumn: "jobs.id",
+	Desc:     true,
+}
+
+// BookmarkedJob is a listed job together with when the student
+// bookmarked it, which is what the list is ordered by.
+type BookmarkedJob struct {
+	JobListItem
+	BookmarkedAt time.Time `json:"bookmarked_at"`
+}
+
+// BookmarkPage is one page of bookmarks. Total is nil when not counted;
+// NextCursor is empty on the last keyset page and in offset mode.
+type BookmarkPage struct {
+	Items      []BookmarkedJob `json:"items"`
+	Total      *int64          `json:"total"`
+	NextCursor string          `json:"next_cursor"`
+}
+
+// Bookmark saves a job the student can see. Bookmarking twice is a no-op.
+func (s *Service) Bookmark(ctx context.Context, auth *authorization.AuthContext, jobID uint) error {
+	if auth.CollegeID == nil {
+		return ErrJobNotFound
+	}
+
+	job, err := s.repo.FindActiveJob(ctx, jobID, *auth.CollegeID)
+	if err != nil {
+		return err
+	}
+
+	// CollegeID is the student's, which differs from the job's for a
+	// pooled drive
+	return s.repo.AddBookmark(ctx, models.JobBookmark{
+		JobID:     job.ID,
+		StudentID: auth.UserID,
+		CollegeID: *auth.CollegeID,
+	})
+}
+
+func (s *Service) Unbookmark(ctx context.Context, auth *authorization.AuthContext, jobID uint) error {
+	return s.repo.RemoveBookmark(ctx, jobID, auth.UserID)
+}
+
+// Bookmarks returns one page of the student's bookmarked jobs that are
+// still open to them.
+func (s *Service) Bookmarks(ctx context.Context, auth *authorization.AuthContext, q ListQuery) (BookmarkPage, ListQuery, error) {
+	q.Normalize(10, 50)
+	q.Sort = bookmarkSort
+
+	if auth.CollegeID == nil {
+		return BookmarkPage{Items: []BookmarkedJob{}}, q, nil
+	}
+	q.CollegeID = auth.CollegeID
+	q.StudentID = auth.UserID
+
+	if q.Keyset() {
+		after, err := pagination.Decode(*q.Cursor, bookmarkSort)
+		if err == nil && after != nil {
+			err = after.Scan(new(time.Time))
+		}
+		if err != nil {
+			return BookmarkPage{}, q, invalid(pagination.ErrInvalidCursor.Error())
+		}
+		q.After = after
+	}
+
+	page, err := s.repo.BookmarkedJobs(ctx, q)
+	return page, q, err
+}
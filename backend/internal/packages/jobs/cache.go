@@ -0,0 +1,180 @@
+package jobs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/metrics"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/pagination"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Listing and detail reads are cached per college. Keys embed the
+// college's cache version; every job mutation bumps it, so older entries
+// are never read again and just expire.
+//
+//	jobs:ver:<college>                            version counter
+//	jobs_list:<college>:<version>:<query hash>    JobPage
+//	jobs_detail:<college>:<version>:<job id>      JobDetailResponse
+
+const (
+	listCache   = "jobs_list"
+	detailCache = "jobs_detail"
+
+	defaultCacheTTL = time.Minute
+)
+
+var errCacheMiss = errors.New("cache miss")
+
+// Cache is the key-value store behind the read-through cache.
+type Cache interface {
+	// Get returns errCacheMiss for absent keys.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Incr(ctx context.Context, key string) error
+}
+
+type redisCache struct {
+	rdb *redis.Client
+}
+
+func NewRedisCache(rdb *redis.Client) Cache {
+	return &redisCache{rdb: rdb}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	b, err := c.rdb.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, errCacheMiss
+	}
+	return b, err
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.rdb.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *redisCache) Incr(ctx context.Context, key string) error {
+	return c.rdb.Incr(ctx, key).Err()
+}
+
+func versionKey(collegeID uint) string {
+	return fmt.Sprintf("jobs:ver:%d", collegeID)
+}
+
+// listCacheKey hashes the normalized query. The search term is
+// lowercased since the listing matches it case-insensitively.
+func listCacheKey(q ListQuery) string {
+	f := q.Filter
+	f.Q = strings.ToLower(strings.TrimSpace(f.Q))
+
+	canonical, _ := json.Marshal(struct {
+		Params pagination.Params
+		Filter JobFilter
+		Sort   string
+	}{q.Params, f, q.Sort})
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:16])
+}
+
+// cached returns the value stored under name/suffix for the college's
+// current version, or loads and stores it. Concurrent misses on the same
+// key share one load. Cache failures fall back to load.
+func cached[T any](
+	ctx context.Context,
+	s *Service,
+	name string,
+	collegeID uint,
+	suffix string,
+	load func(context.Context) (T, error),
+) (T, error) {
+	if s.cache == nil {
+		return load(ctx)
+	}
+
+	version, err := s.cache.Get(ctx, versionKey(collegeID))
+	if errors.Is(err, errCacheMiss) {
+		version, err = []byte("0"), nil
+	}
+	if err != nil {
+		metrics.ObserveCache(name, metrics.CacheError)
+		slog.WarnContext(ctx, "job cache unavailable", "cache", name, "error", err)
+		return load(ctx)
+	}
+
+	key := fmt.Sprintf("%s:%d:%s:%s", name, collegeID, version, suffix)
+
+	raw, err := s.cache.Get(ctx, key)
+	switch {
+	case err == nil:
+		var v T
+		if json.Unmarshal(raw, &v) == nil {
+			metrics.ObserveCache(name, metrics.CacheHit)
+			return v, nil
+		}
+		metrics.ObserveCache(name, metrics.CacheMiss)
+	case errors.Is(err, errCacheMiss):
+		metrics.ObserveCache(name, metrics.CacheMiss)
+	default:
+		metrics.ObserveCache(name, metrics.CacheError)
+		return load(ctx)
+	}
+
+	// the shared load must not be cancelled by whichever caller started it
+	v, err, _ := s.flight.Do(key, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+
+		v, err := load(ctx)
+		if err != nil {
+			return v, err
+		}
+		if raw, err := json.Marshal(v); err == nil {
+			if err := s.cache.Set(ctx, key, raw, s.cacheTTL); err != nil {
+				slog.WarnContext(ctx, "failed to fill job cache", "cache", name, "error", err)
+			}
+		}
+		return v, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+// invalidate bumps the colleges' cache versions after a job mutation. On
+// failure the old entries live out their TTL.
+func (s *Service) invalidate(ctx context.Context, collegeIDs ...uint) {
+	if s.cache == nil {
+		return
+	}
+	for _, collegeID := range collegeIDs {
+		if err := s.cache.Incr(ctx, versionKey(collegeID)); err != nil {
+			slog.WarnContext(ctx, "failed to invalidate job cache",
+				"college_id", collegeID, "error", err)
+		}
+	}
+}
+
+// invalidateJob invalidates every college that lists the job: its host
+// and, for a pooled drive, the participating colleges.
+func (s *Service) invalidateJob(ctx context.Context, job models.Job) {
+	if s.cache == nil {
+		return
+	}
+	pool, err := s.repo.Pool(ctx, job.ID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load job pool for invalidation",
+			"job_id", job.ID, "error", err)
+	}
+	s.invalidate(ctx, append([]uint{job.CollegeID}, poolCollegeIDs(pool)...)...)
+}
@@ -0,0 +1,188 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memCache struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMemCache() *memCache {
+	return &memCache{data: map[string][]byte{}}
+}
+
+func (c *memCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.data[key]
+	if !ok {
+		return nil, errCacheMiss
+	}
+	return v, nil
+}
+
+func (c *memCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *memCache) Incr(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = append(c.data[key], '+') // any change is a new version
+	return nil
+}
+
+func newCachedService() (*Service, *fakeRepo) {
+	svc, repo, _ := newTestService()
+	WithCache(newMemCache(), 0)(svc)
+	repo.jobs[1] = openJob(1, 10, "[2026]")
+	repo.listed = []JobListItem{{ID: 1, Title: "SDE Intern"}, {ID: 2, Title: "Analyst"}}
+	return svc, repo
+}
+
+func TestListIsCachedPerCollegeAndQuery(t *testing.T) {
+	svc, repo := newCachedService()
+	ctx := context.Background()
+
+	list := func(college uint, f JobFilter) {
+		t.Helper()
+		if _, _, err := svc.List(ctx, student(5, college), ListQuery{Filter: f}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list(10, JobFilter{Q: "sde"})
+	list(10, JobFilter{Q: "  SDE "}) // same normalized query
+	if repo.listCalls != 1 {
+		t.Fatalf("ListJobs calls = %d, want 1", repo.listCalls)
+	}
+
+	list(10, JobFilter{Q: "analyst"})
+	list(11, JobFilter{Q: "sde"})
+	if repo.listCalls != 3 {
+		t.Fatalf("ListJobs calls = %d, want 3", repo.listCalls)
+	}
+}
+
+func TestMutationsInvalidateTheCollege(t *testing.T) {
+	svc, repo := newCachedService()
+	ctx := context.Background()
+
+	listBoth := func() {
+		t.Helper()
+		for _, college := range []uint{10, 11} {
+			if _, _, err := svc.List(ctx, student(5, college), ListQuery{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	listBoth()
+	if err := svc.Update(ctx, collegeAdmin(1, 10), 1, UpdateJobRequest{Title: strPtr("SDE")}); err != nil {
+		t.Fatal(err)
+	}
+	listBoth()
+	if repo.listCalls != 3 {
+		t.Fatalf("ListJobs calls = %d, want 3 (college 10 reloaded, 11 still cached)", repo.listCalls)
+	}
+
+	if err := svc.Delete(ctx, collegeAdmin(1, 10), 1); err != nil {
+		t.Fatal(err)
+	}
+	listBoth()
+	if repo.listCalls != 4 {
+		t.Fatalf("ListJobs calls = %d, want 4", repo.listCalls)
+	}
+}
+
+func TestCachedListKeepsFlagsPerStudent(t *testing.T) {
+	svc, repo := newCachedService()
+	ctx := context.Background()
+	repo.bookmarked[[2]uint{2, 5}] = true
+	repo.applied[[2]uint{1, 6}] = true
+
+	a, _, err := svc.List(ctx, student(5, 10), ListQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := svc.List(ctx, student(6, 10), ListQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin, _, err := svc.List(ctx, collegeAdmin(5, 10), ListQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if repo.listCalls != 1 {
+		t.Fatalf("ListJobs calls = %d, want 1", repo.listCalls)
+	}
+	if a.Items[0].HasApplied || !a.Items[1].IsBookmarked {
+		t.Fatalf("student 5 flags = %+v", a)
+	}
+	if !b.Items[0].HasApplied || b.Items[1].IsBookmarked {
+		t.Fatalf("student 6 flags = %+v", b)
+	}
+	if admin.Items[1].IsBookmarked {
+		t.Fatal("admin got a student's flags")
+	}
+}
+
+func TestConcurrentMissesShareOneLoad(t *testing.T) {
+	svc, repo := newCachedService()
+	repo.listGate = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := uint(0); i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := svc.List(context.Background(), student(i+1, 10), ListQuery{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	// let every request reach the in-flight load before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(repo.listGate)
+	wg.Wait()
+
+	if repo.listCalls != 1 {
+		t.Fatalf("ListJobs calls = %d, want 1", repo.listCalls)
+	}
+}
+
+func TestDetailIsCached(t *testing.T) {
+	svc, repo := newCachedService()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Get(ctx, student(5, 10), 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	delete(repo.jobs, 1)
+	if _, err := svc.Get(ctx, student(5, 10), 1); err != nil {
+		t.Fatal("second read should have been served from cache")
+	}
+
+	// not-found is not cached
+	if _, err := svc.Get(ctx, student(5, 10), 99); err != ErrJobNotFound {
+		t.Fatalf("err = %v", err)
+	}
+	repo.jobs[99] = openJob(99, 10, "[2026]")
+	if _, err := svc.Get(ctx, student(5, 10), 99); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/webhooks"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// JobEvent is the data of every job.* webhook. The repository queues
+// job.created, job.updated and job.deleted for the host college and every
+// pooled college in the transaction that makes the change.
+type JobEvent struct {
+	ID                   uint             `json:"id"`
+	Company              string           `json:"company"`
+	Title                string           `json:"title"`
+	JobType              models.JobType   `json:"job_type"`
+	Domain               models.JobDomain `json:"domain"`
+	EligibleBatches      json.RawMessage  `json:"eligible_batches"`
+	CTC                  *float64         `json:"ctc"`
+	Stipend              *float64         `json:"stipend"`
+	RegistrationDeadline *time.Time       `json:"registration_deadline"`
+	IsActive             bool             `json:"is_active"`
+
+	// job.updated only: the fields the update set
+	Changed []string `json:"changed,omitempty"`
+}
+
+func jobEventOf(job models.Job) JobEvent {
+	return JobEvent{
+		ID:                   job.ID,
+		Company:              job.Company,
+		Title:                job.Title,
+		JobType:              job.JobType,
+		Domain:               job.Domain,
+		EligibleBatches:      json.RawMessage(job.EligibleBatches),
+		CTC:                  job.CTC,
+		Stipend:              job.Stipend,
+		RegistrationDeadline: job.RegistrationDeadline,
+		IsActive:             job.IsActive,
+	}
+}
+
+// jobUpdatedEvent is the job as it is after the update, listing the
+// fields the update set.
+func jobUpdatedEvent(job models.Job, updates map[string]interface{}) JobEvent {
+	data := jobEventOf(job)
+	for field := range updates {
+		data.Changed = append(data.Changed, field)
+	}
+	sort.Strings(data.Changed)
+	return data
+}
+
+// publishJobEvent queues event for the job's host and for every college
+// the job is pooled with, inside tx.
+func publishJobEvent(ctx context.Context, tx *gorm.DB, job models.Job, event models.WebhookEvent, data JobEvent) error {
+	if err := webhooks.Enqueue(ctx, tx, job.CollegeID, event, data); err != nil {
+		return err
+	}
+
+	var pool []models.JobCollege
+	if err := tx.Where("job_id = ?", job.ID).Order("college_id").Find(&pool).Error; err != nil {
+		return err
+	}
+	return publishToPool(ctx, tx, pool, event, data)
+}
+
+// publishToPool queues event for each pool entry's college, carrying the
+// batches that college opened the job to.
+func publishToPool(ctx context.Context, tx *gorm.DB, pool []models.JobCollege, event models.WebhookEvent, data JobEvent) error {
+	for _, entry := range pool {
+		entryData := data
+		entryData.EligibleBatches = json.RawMessage(entry.EligibleBatches)
+		if err := webhooks.Enqueue(ctx, tx, entry.CollegeID, event, entryData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// poolChanges splits a pool replacement into the entries that joined and
+// the ones that left.
+func poolChanges(before, after []models.JobCollege) (added, removed []models.JobCollege) {
+	was := map[uint]bool{}
+	for _, entry := range before {
+		was[entry.CollegeID] = true
+	}
+	is := map[uint]bool{}
+	for _, entry := range after {
+		is[entry.CollegeID] = true
+		if !was[entry.CollegeID] {
+			added = append(added, entry)
+		}
+	}
+	for _, entry := range before {
+		if !is[entry.CollegeID] {
+			removed = append(removed, entry)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,125 @@
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// JobFilter is the filter set accepted by GetJobs. Saved searches persist
+// the same struct so alerts match exactly what the listing would return.
+type JobFilter struct {
+	Q          string  `json:"q,omitempty" form:"q"`
+	JobType    string  `json:"job_type,omitempty" form:"job_type"`
+	Domain     string  `json:"domain,omitempty" form:"domain"`
+	MinCTC     float64 `json:"min_ctc,omitempty" form:"min_ctc"`
+	MaxCTC     float64 `json:"max_ctc,omitempty" form:"max_ctc"`
+	MinStipend float64 `json:"min_stipend,omitempty" form:"min_stipend"`
+	MaxStipend float64 `json:"max_stipend,omitempty" form:"max_stipend"`
+	Batch      int     `json:"batch,omitempty" form:"batch"`
+}
+
+func parseJobFilter(c *gin.Context) JobFilter {
+	minCTC, _ := strconv.ParseFloat(c.Query("min_ctc"), 64)
+	maxCTC, _ := strconv.ParseFloat(c.Query("max_ctc"), 64)
+	minStipend, _ := strconv.ParseFloat(c.Query("min_stipend"), 64)
+	maxStipend, _ := strconv.ParseFloat(c.Query("max_stipend"), 64)
+
+	batch, _ := strconv.Atoi(c.Query("batch"))
+
+	return JobFilter{
+		Q:          strings.TrimSpace(c.Query("q")),
+		JobType:    c.Query("job_type"),
+		Domain:     c.Query("domain"),
+		MinCTC:     minCTC,
+		MaxCTC:     maxCTC,
+		MinStipend: minStipend,
+		MaxStipend: maxStipend,
+		Batch:      batch,
+	}
+}
+
+// activeJobsQuery is the base listing query: the active jobs a college's
+// students see, i.e. its own and the pooled drives it takes part in and
+// has not hidden. The college's job_colleges row (if any) is joined so
+// filters can read its eligible batches.
+func activeJobsQuery(db *gorm.DB, collegeID *uint) *gorm.DB {
+	return db.
+		Table("jobs").
+		Joins("LEFT JOIN job_colleges ON job_colleges.job_id = jobs.id AND job_colleges.college_id = ?", collegeID).
+		Where("(jobs.college_id = ? OR job_colleges.is_visible)", collegeID).
+		Where("jobs.is_active = true")
+}
+
+func applyJobFilter(query *gorm.DB, f JobFilter) *gorm.DB {
+	// -------- Search (temporary DB search) --------
+	if f.Q != "" {
+		query = query.Where(
+			"(jobs.title ILIKE ? OR jobs.company ILIKE ? OR jobs.description ILIKE ?)",
+			"%"+f.Q+"%",
+			"%"+f.Q+"%",
+			"%"+f.Q+"%",
+		)
+	}
+
+	// -------- Filters --------
+	if f.JobType != "" {
+		query = query.Where("jobs.job_type = ?", f.JobType)
+	}
+	if f.Domain != "" {
+		query = query.Where("jobs.domain = ?", f.Domain)
+	}
+	if f.MinCTC > 0 {
+		query = query.Where("jobs.ctc >= ?", f.MinCTC)
+	}
+	if f.MaxCTC > 0 {
+		query = query.Where("jobs.ctc <= ?", f.MaxCTC)
+	}
+	if f.MinStipend > 0 {
+		query = query.Where("jobs.stipend >= ?", f.MinStipend)
+	}
+	if f.MaxStipend > 0 {
+		query = query.Where("jobs.stipend <= ?", f.MaxStipend)
+	}
+	if f.Batch > 0 {
+		// a pooled drive's batches are the viewing college's own
+		query = query.Where(
+			"COALESCE(job_colleges.eligible_batches, jobs.eligible_batches) @> ?",
+			fmt.Sprintf("[%d]", f.Batch),
+		)
+	}
+
+	return query
+}
+
+// jobListColumns project a jobs query onto JobListItem. They take the
+// student the is_bookmarked / has_applied flags are for, twice.
+const jobListColumns = `
+		jobs.id,
+		jobs.company,
+		jobs.title,
+		jobs.job_type,
+		jobs.domain,
+		jobs.ctc,
+		jobs.stipend,
+		jobs.description,
+		jobs.created_at,
+		jobs.registration_deadline,
+		jobs.college_id AS host_college_id,
+		EXISTS (
+			SELECT 1 FROM job_bookmarks
+			WHERE job_bookmarks.job_id = jobs.id AND job_bookmarks.student_id = ?
+		) AS is_bookmarked,
+		EXISTS (
+			SELECT 1 FROM applications
+			WHERE applications.job_id = jobs.id AND applications.student_id = ?
+		) AS has_applied`
+
+// selectJobListItems projects a jobs query onto JobListItem, including the
+// is_bookmarked / has_applied flags for the given student.
+func selectJobListItems(query *gorm.DB, studentID uint) *gorm.DB {
+	return query.Select(jobListColumns, studentID, studentID)
+}
@@ -1,6 +1,8 @@
 package jobs
 
 import (
+	"encoding/json"
+	"errors"
 	"iiitn-career-portal/internal/models"
 	"net/url"
 )
@@ -35,3 +37,73 @@ func isValidURL(s string) bool {
 	u, err := url.ParseRequestURI(s)
 	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
 }
+
+const maxSavedSearchesPerStudent = 20
+
+func validateJobFilter(f JobFilter) error {
+	if f.JobType != "" && !isValidJobType(models.JobType(f.JobType)) {
+		return errors.New("invalid job_type")
+	}
+	if f.Domain != "" && !isValidDomain(models.JobDomain(f.Domain)) {
+		return errors.New("invalid domain")
+	}
+	if f.MinCTC < 0 || f.MaxCTC < 0 || f.MinStipend < 0 || f.MaxStipend < 0 {
+		return errors.New("ctc and stipend bounds must be positive")
+	}
+	if f.Batch < 0 {
+		return errors.New("invalid batch")
+	}
+	return nil
+}
+
+func isValidAlertFrequency(f models.AlertFrequency) bool {
+	return f == models.AlertInstant || f == models.AlertDaily
+}
+
+func toSavedSearchResponse(s models.SavedSearch) (SavedSearchResponse, error) {
+	var filter JobFilter
+	if err := json.Unmarshal(s.Filters, &filter); err != nil {
+		return SavedSearchResponse{}, err
+	}
+
+	return SavedSearchResponse{
+		ID:             s.ID,
+		Name:           s.Name,
+		Filters:        filter,
+		NotifyOnMatch:  s.NotifyOnMatch,
+		Frequency:      s.Frequency,
+		LastNotifiedAt: s.LastNotifiedAt,
+		CreatedAt:      s.CreatedAt,
+	}, nil
+}
+
+// poolAuditValue is the audited form of a pool: one entry per college.
+func poolAuditValue(pool []models.JobCollege) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(pool))
+	for i, entry := range pool {
+		out[i] = map[string]interface{}{
+			"college_id":       entry.CollegeID,
+			"eligible_batches": entry.EligibleBatches,
+			"is_visible":       entry.IsVisible,
+		}
+	}
+	return out
+}
+
+// jobAuditFields maps a job onto the column names UpdateJob writes, so
+// audit diffs line up key for key.
+func jobAuditFields(job models.Job) map[string]interface{} {
+	return map[string]interface{}{
+		"title":                 job.Title,
+		"company":               job.Company,
+		"job_type":              job.JobType,
+		"domain":                job.Domain,
+		"eligible_batches":      job.EligibleBatches,
+		"ctc":                   job.CTC,
+		"stipend":               job.Stipend,
+		"registration_form_url": job.RegistrationFormURL,
+		"registration_deadline": job.RegistrationDeadline,
+		"description":           job.Description,
+		"is_active":             job.IsActive,
+	}
+}
@@ -8,15 +8,19 @@ import (
 )
 
 type CreateJobRequest struct {
-	Company             string           `json:"company" binding:"required"`
-	Title               string           `json:"title" binding:"required"`
-	JobType             models.JobType   `json:"job_type" binding:"required"`
-	Domain              models.JobDomain `json:"domain" binding:"required"`
-	EligibleBatches     []int            `json:"eligible_batches" binding:"required"`
-	CTC                 *float64         `json:"ctc"`
-	Stipend             *float64         `json:"stipend"`
-	Description         string           `json:"description"`
-	RegistrationFormURL *string          `json:"registration_form_url" binding:"required"`
+	Company              string           `json:"company" binding:"required"`
+	Title                string           `json:"title" binding:"required"`
+	JobType              models.JobType   `json:"job_type" binding:"required"`
+	Domain               models.JobDomain `json:"domain" binding:"required"`
+	EligibleBatches      []int            `json:"eligible_batches" binding:"required"`
+	CTC                  *float64         `json:"ctc"`
+	Stipend              *float64         `json:"stipend"`
+	Description          string           `json:"description"`
+	RegistrationFormURL  *string          `json:"registration_form_url" binding:"required"`
+	RegistrationDeadline *time.Time       `json:"registration_deadline"`
+
+	// other colleges the drive is pooled with
+	Pool []PoolCollegeRequest `json:"pool"`
 }
 
 type JobListItem struct {
@@ -29,20 +33,32 @@ type JobListItem struct {
 	Stipend     *float64  `json:"stipend"`
 	Description string    `json:"description"`
 	CreatedAt   time.Time `json:"created_at"`
+
+	RegistrationDeadline *time.Time `json:"registration_deadline"`
+
+	// the college that posted the job; differs from the viewer's for a
+	// pooled drive
+	HostCollegeID uint `json:"host_college_id"`
+
+	// per-student flags, always false for admins
+	IsBookmarked bool `json:"is_bookmarked"`
+	HasApplied   bool `json:"has_applied"`
 }
 
 type JobDetailResponse struct {
-	ID                  uint      `json:"id"`
-	Company             string    `json:"company"`
-	Title               string    `json:"title"`
-	JobType             string    `json:"job_type"`
-	Domain              string    `json:"domain"`
-	EligibleBatches     []int     `json:"eligible_batches"`
-	CTC                 *float64  `json:"ctc"`
-	Stipend             *float64  `json:"stipend"`
-	Description         string    `json:"description"`
-	RegistrationFormURL *string   `json:"registration_form_url"`
-	CreatedAt           time.Time `json:"created_at"`
+	ID                   uint       `json:"id"`
+	Company              string     `json:"company"`
+	Title                string     `json:"title"`
+	JobType              string     `json:"job_type"`
+	Domain               string     `json:"domain"`
+	EligibleBatches      []int      `json:"eligible_batches"`
+	CTC                  *float64   `json:"ctc"`
+	Stipend              *float64   `json:"stipend"`
+	Description          string     `json:"description"`
+	RegistrationFormURL  *string    `json:"registration_form_url"`
+	RegistrationDeadline *time.Time `json:"registration_deadline"`
+	HostCollegeID        uint       `json:"host_college_id"`
+	CreatedAt            time.Time  `json:"created_at"`
 }
 
 type UpdateJobRequest struct {
@@ -55,8 +71,66 @@ type UpdateJobRequest struct {
 	CTC     *float64 `json:"ctc"`
 	Stipend *float64 `json:"stipend"`
 
-	RegistrationFormURL *string `json:"registration_form_url"`
-	Description         *string `json:"description"`
+	RegistrationFormURL  *string    `json:"registration_form_url"`
+	RegistrationDeadline *time.Time `json:"registration_deadline"`
+	Description          *string    `json:"description"`
 
 	IsActive *bool `json:"is_active"`
 }
+
+// PoolCollegeRequest adds a college to a pooled drive. Visible defaults
+// to true.
+type PoolCollegeRequest struct {
+	CollegeID       uint  `json:"college_id" binding:"required"`
+	EligibleBatches []int `json:"eligible_batches" binding:"required"`
+	Visible         *bool `json:"is_visible"`
+}
+
+// SetPoolRequest replaces the set of participating colleges; an empty
+// list makes the job single-college again.
+type SetPoolRequest struct {
+	Colleges []PoolCollegeRequest `json:"colleges"`
+}
+
+type UpdatePoolEntryRequest struct {
+	EligibleBatches *[]int `json:"eligible_batches"`
+	Visible         *bool  `json:"is_visible"`
+}
+
+type PoolEntryResponse struct {
+	CollegeID       uint      `json:"college_id"`
+	EligibleBatches []int     `json:"eligible_batches"`
+	IsVisible       bool      `json:"is_visible"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ApplyRequest is the optional body of an apply. Without a resume_id the
+// student's default resume at confirmation is submitted.
+type ApplyRequest struct {
+	ResumeID *uint `json:"resume_id"`
+}
+
+// SavedSearchRequest: Frequency is INSTANT (the default) or DAILY.
+type SavedSearchRequest struct {
+	Name          string                `json:"name" binding:"required"`
+	Filters       JobFilter             `json:"filters"`
+	NotifyOnMatch *bool                 `json:"notify_on_match"`
+	Frequency     models.AlertFrequency `json:"frequency"`
+}
+
+type UpdateSavedSearchRequest struct {
+	Name          *string                `json:"name"`
+	Filters       *JobFilter             `json:"filters"`
+	NotifyOnMatch *bool                  `json:"notify_on_match"`
+	Frequency     *models.AlertFrequency `json:"frequency"`
+}
+
+type SavedSearchResponse struct {
+	ID             uint                  `json:"id"`
+	Name           string                `json:"name"`
+	Filters        JobFilter             `json:"filters"`
+	NotifyOnMatch  bool                  `json:"notify_on_match"`
+	Frequency      models.AlertFrequency `json:"frequency"`
+	LastNotifiedAt *time.Time            `json:"last_notified_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
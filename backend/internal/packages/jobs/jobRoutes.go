@@ -1,47 +1,104 @@
 package jobs
 
 import (
+	"iiitn-career-portal/internal/config"
 	"iiitn-career-portal/internal/models"
 	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/packages/notifications"
+	"iiitn-career-portal/internal/packages/ratelimit"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB, rc *redis.Client) {
+func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB, rc *redis.Client, cfg config.Config, limiter *ratelimit.Limiter) {
+	var opts []Option
+	if rc != nil && cfg.JobCacheTTL > 0 {
+		opts = append(opts, WithCache(NewRedisCache(rc), cfg.JobCacheTTL))
+	}
+	svc := NewService(NewGormRepository(db), notifications.New(db, rc), opts...)
+
+	applyLimit := limiter.Middleware(
+		ratelimit.PolicyFromConfig(cfg, "apply_user", "30/1h", ratelimit.ByUser),
+		ratelimit.PolicyFromConfig(cfg, "apply_ip", "120/1h", ratelimit.ByIP),
+	)
+
 	jobs := rg.Group("/jobs")
 	{
 		// Accessible to ALL authenticated users
-		jobs.GET("", GetJobs(db))
-		jobs.GET("/:id", GetJobByID(db))
+		jobs.GET("", GetJobs(svc))
+		jobs.GET(
+			"/bookmarked",
+			authorization.RequireRole(string(models.Student)),
+			GetBookmarkedJobs(svc),
+		)
+		jobs.GET("/:id", GetJobByID(svc))
 
 		// College admin only
 		jobs.POST(
 			"",
 			authorization.RequireRole(string(models.CollegeAdmin)),
-			CreateJob(db),
+			CreateJob(svc),
 		)
 		jobs.PATCH(
 			"/:id",
 			authorization.RequireRole(
 				string(models.CollegeAdmin),
 			),
-			UpdateJob(db),
+			UpdateJob(svc),
 		)
 		jobs.DELETE(
 			"/:id",
 			authorization.RequireRole(
 				string(models.CollegeAdmin),
 			),
-			DeleteJob(db),
+			DeleteJob(svc),
+		)
+
+		// Pooled drives: the host sets the colleges, each college admin
+		// manages its own entry
+		jobs.GET(
+			"/:id/pool",
+			authorization.RequireRole(string(models.CollegeAdmin)),
+			GetJobPool(svc),
+		)
+		jobs.PUT(
+			"/:id/pool",
+			authorization.RequireRole(string(models.CollegeAdmin)),
+			SetJobPool(svc),
+		)
+		jobs.PATCH(
+			"/:id/pool/:college_id",
+			authorization.RequireRole(string(models.CollegeAdmin)),
+			UpdateJobPoolEntry(svc),
 		)
 
 		// Student only
 		jobs.POST(
 			"/:id/apply",
 			authorization.RequireRole(string(models.Student)),
-			ApplyJobIntent(db, rc),
+			applyLimit,
+			ApplyJobIntent(svc),
+		)
+		jobs.POST(
+			"/:id/bookmark",
+			authorization.RequireRole(string(models.Student)),
+			BookmarkJob(svc),
 		)
+		jobs.DELETE(
+			"/:id/bookmark",
+			authorization.RequireRole(string(models.Student)),
+			UnbookmarkJob(svc),
+		)
+
+		saved := jobs.Group("/saved-searches")
+		saved.Use(authorization.RequireRole(string(models.Student)))
+		{
+			saved.GET("", ListSavedSearches(svc))
+			saved.POST("", CreateSavedSearch(svc))
+			saved.PATCH("/:search_id", UpdateSavedSearch(svc))
+			saved.DELETE("/:search_id", DeleteSavedSearch(svc))
+		}
 	}
 }
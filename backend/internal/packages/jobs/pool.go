@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+)
+
+// A pooled drive is a job posted by one college (the host) and published
+// to others. The host edits the job and picks the participating colleges;
+// each participant's admin then owns its eligible batches and visibility.
+// Applications carry the student's college, so every admin only ever
+// sees their own students' applications.
+
+const maxPoolColleges = 50
+
+var ErrNotInPool = errors.New("college is not part of this drive")
+
+// buildPool validates the requested participants of a job hosted by
+// hostCollegeID.
+func (s *Service) buildPool(ctx context.Context, hostCollegeID uint, reqs []PoolCollegeRequest) ([]models.JobCollege, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	if len(reqs) > maxPoolColleges {
+		return nil, invalid(fmt.Sprintf("a drive can be pooled with at most %d colleges", maxPoolColleges))
+	}
+
+	pool := make([]models.JobCollege, 0, len(reqs))
+	ids := make([]uint, 0, len(reqs))
+	seen := map[uint]bool{}
+
+	for _, req := range reqs {
+		if req.CollegeID == hostCollegeID {
+			return nil, invalid("pool cannot include the posting college")
+		}
+		if seen[req.CollegeID] {
+			return nil, invalid(fmt.Sprintf("college %d listed twice", req.CollegeID))
+		}
+		seen[req.CollegeID] = true
+
+		if len(req.EligibleBatches) == 0 {
+			return nil, invalid(fmt.Sprintf("eligible_batches required for college %d", req.CollegeID))
+		}
+		batchesJSON, err := json.Marshal(req.EligibleBatches)
+		if err != nil {
+			return nil, err
+		}
+
+		visible := true
+		if req.Visible != nil {
+			visible = *req.Visible
+		}
+
+		pool = append(pool, models.JobCollege{
+			CollegeID:       req.CollegeID,
+			EligibleBatches: batchesJSON,
+			IsVisible:       visible,
+		})
+		ids = append(ids, req.CollegeID)
+	}
+
+	count, err := s.repo.CountColleges(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if count != int64(len(ids)) {
+		return nil, invalid("unknown college in pool")
+	}
+
+	return pool, nil
+}
+
+// GetPool lists the participating colleges: all of them for the host's
+// admins, only their own entry for a participant's.
+func (s *Service) GetPool(ctx context.Context, auth *authorization.AuthContext, id uint) ([]PoolEntryResponse, error) {
+	if auth.CollegeID == nil {
+		return nil, ErrForbidden
+	}
+
+	job, err := s.repo.FindJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := s.repo.Pool(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.CollegeID == *auth.CollegeID {
+		return poolResponse(pool)
+	}
+	for _, entry := range pool {
+		if entry.CollegeID == *auth.CollegeID {
+			return poolResponse([]models.JobCollege{entry})
+		}
+	}
+	return nil, ErrJobNotFound
+}
+
+// SetPool replaces the colleges a job is pooled with. Only the host may
+// do so.
+func (s *Service) SetPool(ctx context.Context, auth *authorization.AuthContext, id uint, req SetPoolRequest) ([]PoolEntryResponse, error) {
+	job, err := s.mutableJob(ctx, auth, id)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := s.buildPool(ctx, job.CollegeID, req.Colleges)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pool {
+		pool[i].JobID = job.ID
+	}
+
+	before, err := s.repo.Pool(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplacePool(ctx, job, pool); err != nil {
+		return nil, err
+	}
+
+	// colleges that left the pool must stop listing it too
+	s.invalidate(ctx, append(append([]uint{job.CollegeID}, poolCollegeIDs(before)...), poolCollegeIDs(pool)...)...)
+
+	return poolResponse(pool)
+}
+
+// UpdatePoolEntry changes one participant's eligible batches or
+// visibility. The participant's admins and the host's may do so.
+func (s *Service) UpdatePoolEntry(
+	ctx context.Context,
+	auth *authorization.AuthContext,
+	id, collegeID uint,
+	req UpdatePoolEntryRequest,
+) (PoolEntryResponse, error) {
+	job, err := s.repo.FindJob(ctx, id)
+	if err != nil {
+		return PoolEntryResponse{}, err
+	}
+	if auth.CollegeID == nil ||
+		(*auth.CollegeID != job.CollegeID && *auth.CollegeID != collegeID) {
+		return PoolEntryResponse{}, ErrForbidden
+	}
+
+	pool, err := s.repo.Pool(ctx, job.ID)
+	if err != nil {
+		return PoolEntryResponse{}, err
+	}
+	var entry *models.JobCollege
+	for i := range pool {
+		if pool[i].CollegeID == collegeID {
+			entry = &pool[i]
+		}
+	}
+	if entry == nil {
+		return PoolEntryResponse{}, ErrNotInPool
+	}
+
+	updated := *entry
+	updates := map[string]interface{}{}
+	if req.EligibleBatches != nil {
+		if len(*req.EligibleBatches) == 0 {
+			return PoolEntryResponse{}, invalid("eligible_batches cannot be empty")
+		}
+		batchesJSON, err := json.Marshal(*req.EligibleBatches)
+		if err != nil {
+			return PoolEntryResponse{}, err
+		}
+		updated.EligibleBatches = batchesJSON
+		updates["eligible_batches"] = updated.EligibleBatches
+	}
+	if req.Visible != nil {
+		updated.IsVisible = *req.Visible
+		updates["is_visible"] = updated.IsVisible
+	}
+	if len(updates) == 0 {
+		return PoolEntryResponse{}, invalid("no fields to update")
+	}
+
+	if err := s.repo.UpdatePoolEntry(ctx, *entry, updates); err != nil {
+		return PoolEntryResponse{}, err
+	}
+	s.invalidate(ctx, collegeID)
+
+	updated.UpdatedAt = s.now()
+
+	resp, err := poolResponse([]models.JobCollege{updated})
+	if err != nil {
+		return PoolEntryResponse{}, err
+	}
+	return resp[0], nil
+}
+
+func poolCollegeIDs(pool []models.JobCollege) []uint {
+	ids := make([]uint, len(pool))
+	for i, entry := range pool {
+		ids[i] = entry.CollegeID
+	}
+	return ids
+}
+
+func poolResponse(pool []models.JobCollege) ([]PoolEntryResponse, error) {
+	out := make([]PoolEntryResponse, len(pool))
+	for i, entry := range pool {
+		var batches []int
+		if err := json.Unmarshal(entry.EligibleBatches, &batches); err != nil {
+			return nil, err
+		}
+		out[i] = PoolEntryResponse{
+			CollegeID:       entry.CollegeID,
+			EligibleBatches: batches,
+			IsVisible:       entry.IsVisible,
+			UpdatedAt:       entry.UpdatedAt,
+		}
+	}
+	return out, nil
+}
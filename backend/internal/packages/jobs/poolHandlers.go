@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetJobPool(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		jobID, ok := parseJobID(c)
+		if !ok {
+			return
+		}
+
+		pool, err := svc.GetPool(c.Request.Context(), auth, jobID)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch pool")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": pool})
+	}
+}
+
+func SetJobPool(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		jobID, ok := parseJobID(c)
+		if !ok {
+			return
+		}
+
+		var req SetPoolRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		pool, err := svc.SetPool(audit.Context(c), auth, jobID, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to update pool")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": pool})
+	}
+}
+
+func UpdateJobPoolEntry(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		jobID, ok := parseJobID(c)
+		if !ok {
+			return
+		}
+
+		collegeID, err := strconv.ParseUint(c.Param("college_id"), 10, 64)
+		if err != nil || collegeID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid college id"})
+			return
+		}
+
+		var req UpdatePoolEntryRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		entry, err := svc.UpdatePoolEntry(audit.Context(c), auth, jobID, uint(collegeID), req)
+		if err != nil {
+			writeServiceError(c, err, "failed to update pool entry")
+			return
+		}
+
+		c.JSON(http.StatusOK, entry)
+	}
+}
@@ -0,0 +1,175 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"testing"
+)
+
+func pooledJob(repo *fakeRepo) {
+	repo.jobs[1] = openJob(1, 10, "[2026]")
+	repo.pools[1] = []models.JobCollege{
+		{JobID: 1, CollegeID: 11, EligibleBatches: []byte("[2027]"), IsVisible: true},
+		{JobID: 1, CollegeID: 12, EligibleBatches: []byte("[2026]"), IsVisible: false},
+	}
+}
+
+func TestPooledApplyUsesTheCollegesBatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		batch int
+		auth  uint // student's college
+		want  error
+	}{
+		{"host college, host batch", 2026, 10, nil},
+		{"participant, own batch", 2027, 11, nil},
+		{"participant, host batch only", 2026, 11, ErrNotEligible},
+		{"participant hid the drive", 2026, 12, ErrJobNotFound},
+		{"not in the pool", 2026, 13, ErrJobNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			pooledJob(repo)
+			repo.profiles[5] = models.StudentProfile{UserID: 5, Batch: tt.batch, ProfileComplete: true}
+
+			_, intent, err := svc.ApplyIntent(context.Background(), student(5, tt.auth), 1, ApplyRequest{})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if err == nil && intent.CollegeID != tt.auth {
+				t.Fatalf("intent college = %d, want the student's %d", intent.CollegeID, tt.auth)
+			}
+		})
+	}
+}
+
+func TestPoolValidation(t *testing.T) {
+	entry := func(college uint) PoolCollegeRequest {
+		return PoolCollegeRequest{CollegeID: college, EligibleBatches: []int{2026}}
+	}
+
+	tests := []struct {
+		name string
+		pool []PoolCollegeRequest
+		want string
+	}{
+		{"valid", []PoolCollegeRequest{entry(11), entry(12)}, ""},
+		{"host college", []PoolCollegeRequest{entry(10)}, "pool cannot include the posting college"},
+		{"duplicate", []PoolCollegeRequest{entry(11), entry(11)}, "college 11 listed twice"},
+		{"no batches", []PoolCollegeRequest{{CollegeID: 11}}, "eligible_batches required for college 11"},
+		{"unknown college", []PoolCollegeRequest{entry(99)}, "unknown college in pool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			repo.jobs[1] = openJob(1, 10, "[2026]")
+
+			pool, err := svc.SetPool(context.Background(), collegeAdmin(1, 10), 1, SetPoolRequest{Colleges: tt.pool})
+
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(pool) != 2 || !pool[0].IsVisible || len(repo.pools[1]) != 2 {
+					t.Fatalf("pool = %+v", pool)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) || verr.Error() != tt.want {
+				t.Fatalf("err = %v, want validation error %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoolPermissions(t *testing.T) {
+	ctx := context.Background()
+	hide := false
+
+	svc, repo, _ := newTestService()
+	pooledJob(repo)
+
+	if _, err := svc.SetPool(ctx, collegeAdmin(2, 11), 1, SetPoolRequest{}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("participant set pool: err = %v, want ErrForbidden", err)
+	}
+
+	if _, err := svc.UpdatePoolEntry(ctx, collegeAdmin(2, 11), 1, 12, UpdatePoolEntryRequest{Visible: &hide}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("other participant's entry: err = %v, want ErrForbidden", err)
+	}
+	if _, err := svc.UpdatePoolEntry(ctx, collegeAdmin(3, 13), 1, 13, UpdatePoolEntryRequest{Visible: &hide}); !errors.Is(err, ErrNotInPool) {
+		t.Fatalf("college outside the pool: err = %v, want ErrNotInPool", err)
+	}
+
+	entry, err := svc.UpdatePoolEntry(ctx, collegeAdmin(2, 11), 1, 11, UpdatePoolEntryRequest{
+		EligibleBatches: &[]int{2026, 2027},
+		Visible:         &hide,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.IsVisible || len(entry.EligibleBatches) != 2 || repo.pools[1][0].IsVisible {
+		t.Fatalf("entry = %+v, stored = %+v", entry, repo.pools[1][0])
+	}
+
+	own, err := svc.GetPool(ctx, collegeAdmin(2, 11), 1)
+	if err != nil || len(own) != 1 || own[0].CollegeID != 11 {
+		t.Fatalf("participant view = %+v, %v", own, err)
+	}
+	all, err := svc.GetPool(ctx, collegeAdmin(1, 10), 1)
+	if err != nil || len(all) != 2 {
+		t.Fatalf("host view = %+v, %v", all, err)
+	}
+	if _, err := svc.GetPool(ctx, collegeAdmin(3, 13), 1); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("outsider view: err = %v, want ErrJobNotFound", err)
+	}
+}
+
+func TestPoolMutationsInvalidateParticipants(t *testing.T) {
+	svc, repo := newCachedService()
+	pooledJob(repo)
+	ctx := context.Background()
+
+	listAll := func() {
+		t.Helper()
+		for _, college := range []uint{10, 11, 12} {
+			if _, _, err := svc.List(ctx, student(5, college), ListQuery{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	listAll()
+	if err := svc.Update(ctx, collegeAdmin(1, 10), 1, UpdateJobRequest{Title: strPtr("SDE")}); err != nil {
+		t.Fatal(err)
+	}
+	listAll()
+	if repo.listCalls != 6 {
+		t.Fatalf("ListJobs calls = %d, want 6 (every pooled college reloaded)", repo.listCalls)
+	}
+
+	// college 12 leaves the pool and must stop serving its cached page
+	if _, err := svc.SetPool(ctx, collegeAdmin(1, 10), 1, SetPoolRequest{
+		Colleges: []PoolCollegeRequest{{CollegeID: 11, EligibleBatches: []int{2027}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	listAll()
+	if repo.listCalls != 9 {
+		t.Fatalf("ListJobs calls = %d, want 9", repo.listCalls)
+	}
+
+	visible := true
+	if _, err := svc.UpdatePoolEntry(ctx, collegeAdmin(2, 11), 1, 11, UpdatePoolEntryRequest{Visible: &visible}); err != nil {
+		t.Fatal(err)
+	}
+	listAll()
+	if repo.listCalls != 10 {
+		t.Fatalf("ListJobs calls = %d, want 10 (only college 11 reloaded)", repo.listCalls)
+	}
+}
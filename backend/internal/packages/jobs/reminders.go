@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"context"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/notifications"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	bookmarkReminderInterval = 15 * time.Minute
+	bookmarkReminderWindow   = 24 * time.Hour
+)
+
+// BookmarkReminder is a bookmark whose deadline reminder is due.
+type BookmarkReminder struct {
+	BookmarkID           uint
+	StudentID            uint
+	JobID                uint
+	Title                string
+	Company              string
+	RegistrationDeadline time.Time
+}
+
+// SendBookmarkReminders reminds students about bookmarked jobs whose
+// registration closes within bookmarkReminderWindow and which they have
+// not applied to yet. Each reminder is claimed before it is sent, so it
+// goes out at most once.
+func (s *Service) SendBookmarkReminders(ctx context.Context) error {
+	now := s.now()
+
+	due, err := s.repo.ClaimBookmarkReminders(ctx, now, now.Add(bookmarkReminderWindow))
+	if err != nil {
+		return err
+	}
+
+	for _, d := range due {
+		if err := s.notifier.Push(ctx, d.StudentID, models.NotificationBookmarkReminder, d.JobID, gin.H{
+			"job_id":                d.JobID,
+			"title":                 d.Title,
+			"company":               d.Company,
+			"registration_deadline": d.RegistrationDeadline,
+		}); err != nil {
+			slog.ErrorContext(ctx, "failed to send bookmark reminder", "bookmark_id", d.BookmarkID, "error", err)
+		}
+	}
+	return nil
+}
+
+// notifyBookmarkersClosed tells the students who bookmarked a job that was
+// just deactivated, and had not applied, that it is gone.
+func (s *Service) notifyBookmarkersClosed(ctx context.Context, job models.Job) {
+	students, err := s.repo.UnappliedBookmarkers(ctx, job.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load bookmarkers", "job_id", job.ID, "error", err)
+		return
+	}
+
+	for _, id := range students {
+		if err := s.notifier.Enqueue(ctx, id, models.NotificationBookmarkClosed, job.ID, gin.H{
+			"job_id":  job.ID,
+			"title":   job.Title,
+			"company": job.Company,
+		}); err != nil {
+			slog.ErrorContext(ctx, "failed to enqueue bookmark closed notification", "job_id", job.ID, "student_id", id, "error", err)
+			continue
+		}
+	}
+}
+
+// StartBookmarkReminders runs SendBookmarkReminders every
+// bookmarkReminderInterval until ctx is cancelled. Several instances may
+// run side by side.
+func StartBookmarkReminders(ctx context.Context, db *gorm.DB, rdb *redis.Client) {
+	svc := NewService(NewGormRepository(db), notifications.New(db, rdb))
+
+	ticker := time.NewTicker(bookmarkReminderInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.SendBookmarkReminders(ctx); err != nil {
+			slog.Error("bookmark reminders failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
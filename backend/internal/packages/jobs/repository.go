@@ -0,0 +1,666 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/pagination"
+	"log/slog"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) CreateJob(ctx context.Context, job *models.Job, pool []models.JobCollege) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+
+		// matched against saved searches by the alert worker
+		if err := tx.Create(&models.PendingJobAlert{JobID: job.ID}).Error; err != nil {
+			return err
+		}
+
+		fields := jobAuditFields(*job)
+		if len(pool) > 0 {
+			for i := range pool {
+				pool[i].JobID = job.ID
+			}
+			if err := tx.Create(&pool).Error; err != nil {
+				return err
+			}
+			fields["pool"] = poolAuditValue(pool)
+		}
+
+		if err := audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "job.create",
+			EntityType: "job",
+			EntityID:   job.ID,
+			CollegeID:  &job.CollegeID,
+			Diff:       audit.Created(fields),
+		}); err != nil {
+			return err
+		}
+		return publishJobEvent(ctx, tx, *job, models.WebhookJobCreated, jobEventOf(*job))
+	})
+}
+
+func (r *gormRepository) UpdateJob(ctx context.Context, job models.Job, updates map[string]interface{}) error {
+	before := jobAuditFields(job)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&job).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "job.update",
+			EntityType: "job",
+			EntityID:   job.ID,
+			CollegeID:  &job.CollegeID,
+			Diff:       audit.Diff(before, updates),
+		}); err != nil {
+			return err
+		}
+
+		var updated models.Job
+		if err := tx.First(&updated, job.ID).Error; err != nil {
+			return err
+		}
+		return publishJobEvent(ctx, tx, updated, models.WebhookJobUpdated, jobUpdatedEvent(updated, updates))
+	})
+}
+
+func (r *gormRepository) DeactivateJob(ctx context.Context, job models.Job) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&job).
+			Update("is_active", false).Error; err != nil {
+			return err
+		}
+		if err := audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "job.delete",
+			EntityType: "job",
+			EntityID:   job.ID,
+			CollegeID:  &job.CollegeID,
+			Diff: audit.Diff(
+				map[string]interface{}{"is_active": job.IsActive},
+				map[string]interface{}{"is_active": false},
+			),
+		}); err != nil {
+			return err
+		}
+
+		deleted := job
+		deleted.IsActive = false
+		return publishJobEvent(ctx, tx, deleted, models.WebhookJobDeleted, jobEventOf(deleted))
+	})
+}
+
+func (r *gormRepository) FindJob(ctx context.Context, id uint) (models.Job, error) {
+	var job models.Job
+	err := r.db.WithContext(ctx).First(&job, id).Error
+	return job, notFound(err)
+}
+
+func (r *gormRepository) FindActiveJob(ctx context.Context, id, collegeID uint) (models.Job, error) {
+	db := r.db.WithContext(ctx)
+
+	var job models.Job
+	if err := activeJobsQuery(db, &collegeID).
+		Select("jobs.*").
+		Where("jobs.id = ?", id).
+		Take(&job).Error; err != nil {
+		return job, notFound(err)
+	}
+
+	if job.CollegeID != collegeID {
+		var entry models.JobCollege
+		if err := db.
+			Where("job_id = ? AND college_id = ?", id, collegeID).
+			Take(&entry).Error; err != nil {
+			return models.Job{}, notFound(err)
+		}
+		job.EligibleBatches = entry.EligibleBatches
+	}
+
+	return job, nil
+}
+
+func (r *gormRepository) Pool(ctx context.Context, jobID uint) ([]models.JobCollege, error) {
+	var pool []models.JobCollege
+	err := r.db.WithContext(ctx).
+		Where("job_id = ?", jobID).
+		Order("college_id").
+		Find(&pool).Error
+	return pool, err
+}
+
+func (r *gormRepository) ReplacePool(ctx context.Context, job models.Job, pool []models.JobCollege) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before []models.JobCollege
+		if err := tx.Where("job_id = ?", job.ID).Order("college_id").Find(&before).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("job_id = ?", job.ID).Delete(&models.JobCollege{}).Error; err != nil {
+			return err
+		}
+		if len(pool) > 0 {
+			if err := tx.Create(&pool).Error; err != nil {
+				return err
+			}
+		}
+		if err := audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "job.pool_update",
+			EntityType: "job",
+			EntityID:   job.ID,
+			CollegeID:  &job.CollegeID,
+			Diff: audit.Diff(
+				map[string]interface{}{"pool": poolAuditValue(before)},
+				map[string]interface{}{"pool": poolAuditValue(pool)},
+			),
+		}); err != nil {
+			return err
+		}
+
+		// a closed job was already announced as deleted to everyone
+		if !job.IsActive {
+			return nil
+		}
+		added, removed := poolChanges(before, pool)
+		if err := publishToPool(ctx, tx, added, models.WebhookJobCreated, jobEventOf(job)); err != nil {
+			return err
+		}
+		gone := jobEventOf(job)
+		gone.IsActive = false
+		return publishToPool(ctx, tx, removed, models.WebhookJobDeleted, gone)
+	})
+}
+
+func (r *gormRepository) UpdatePoolEntry(ctx context.Context, entry models.JobCollege, updates map[string]interface{}) error {
+	before := map[string]interface{}{
+		"eligible_batches": entry.EligibleBatches,
+		"is_visible":       entry.IsVisible,
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.JobCollege{}).
+			Where("job_id = ? AND college_id = ?", entry.JobID, entry.CollegeID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "job.pool_entry_update",
+			EntityType: "job",
+			EntityID:   entry.JobID,
+			CollegeID:  &entry.CollegeID,
+			Diff:       audit.Diff(before, updates),
+		})
+	})
+}
+
+func (r *gormRepository) CountColleges(ctx context.Context, ids []uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.College{}).
+		Where("id IN ?", ids).
+		Count(&count).Error
+	return count, err
+}
+
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrJobNotFound
+	}
+	return err
+}
+
+func (r *gormRepository) ListJobs(ctx context.Context, q ListQuery) (JobPage, error) {
+	query := applyJobFilter(activeJobsQuery(r.db.WithContext(ctx), q.CollegeID), q.Filter)
+
+	var page JobPage
+	if q.WithTotal() {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return JobPage{}, err
+		}
+		page.Total = &total
+	}
+
+	keyset := jobSorts[q.Sort]
+	query = query.Order(keyset.OrderBy())
+
+	if !q.Keyset() {
+		query = query.Limit(q.Limit).Offset(q.Offset())
+	} else {
+		if q.After != nil {
+			value, err := jobCursorValue(q.Sort, *q.After)
+			if err != nil {
+				return JobPage{}, err
+			}
+			cond, args := keyset.After(value, q.After.ID)
+			query = query.Where(cond, args...)
+		}
+		// one extra row tells whether there is a next page
+		query = query.Limit(q.Limit + 1)
+	}
+
+	var jobs []JobListItem
+	if err := selectJobListItems(query, q.StudentID).Scan(&jobs).Error; err != nil {
+		return JobPage{}, err
+	}
+
+	if q.Keyset() && len(jobs) > q.Limit {
+		jobs = jobs[:q.Limit]
+		last := jobs[len(jobs)-1]
+		page.NextCursor = pagination.NewCursor(q.Sort, jobSortValue(q.Sort, last), last.ID).Encode()
+	}
+	page.Items = jobs
+
+	return page, nil
+}
+
+func (r *gormRepository) StudentJobFlags(ctx context.Context, studentID uint, jobIDs []uint) (map[uint]bool, map[uint]bool, error) {
+	db := r.db.WithContext(ctx)
+
+	var bookmarkedIDs, appliedIDs []uint
+	if err := db.Table("job_bookmarks").
+		Where("student_id = ? AND job_id IN ?", studentID, jobIDs).
+		Pluck("job_id", &bookmarkedIDs).Error; err != nil {
+		return nil, nil, err
+	}
+	if err := db.Table("applications").
+		Where("student_id = ? AND job_id IN ?", studentID, jobIDs).
+		Pluck("job_id", &appliedIDs).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return idSet(bookmarkedIDs), idSet(appliedIDs), nil
+}
+
+func idSet(ids []uint) map[uint]bool {
+	set := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func (r *gormRepository) StudentProfile(ctx context.Context, userID uint) (models.StudentProfile, error) {
+	var profile models.StudentProfile
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		First(&profile).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return profile, ErrProfileMissing
+	}
+	return profile, err
+}
+
+func (r *gormRepository) HasApplied(ctx context.Context, jobID, studentID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Application{}).
+		Where("job_id = ? AND student_id = ?", jobID, studentID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormRepository) HasResume(ctx context.Context, resumeID, studentID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.StudentResume{}).
+		Where("id = ? AND user_id = ?", resumeID, studentID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormRepository) UpsertIntent(ctx context.Context, intent *models.ApplicationIntent) error {
+	return r.db.WithContext(ctx).
+		Where("job_id = ? AND student_id = ?", intent.JobID, intent.StudentID).
+		// a map, so applying again without a resume clears the earlier
+		// choice; the refreshed intent gets a fresh expiry reminder
+		Assign(map[string]interface{}{
+			"college_id":  intent.CollegeID,
+			"resume_id":   intent.ResumeID,
+			"expires_at":  intent.ExpiresAt,
+			"reminded_at": nil,
+		}).
+		FirstOrCreate(intent).Error
+}
+
+func (r *gormRepository) AddBookmark(ctx context.Context, bookmark models.JobBookmark) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&bookmark).Error
+}
+
+func (r *gormRepository) RemoveBookmark(ctx context.Context, jobID, studentID uint) error {
+	return r.db.WithContext(ctx).
+		Where("job_id = ? AND student_id = ?", jobID, studentID).
+		Delete(&models.JobBookmark{}).Error
+}
+
+func (r *gormRepository) BookmarkedJobs(ctx context.Context, q ListQuery) (BookmarkPage, error) {
+	query := activeJobsQuery(r.db.WithContext(ctx), q.CollegeID).
+		Joins("JOIN job_bookmarks ON job_bookmarks.job_id = jobs.id").
+		Where("job_bookmarks.student_id = ?", q.StudentID)
+
+	var page BookmarkPage
+	if q.WithTotal() {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return BookmarkPage{}, err
+		}
+		page.Total = &total
+	}
+
+	query = query.Order(bookmarkKeyset.OrderBy())
+
+	if !q.Keyset() {
+		query = query.Limit(q.Limit).Offset(q.Offset())
+	} else {
+		if q.After != nil {
+			var after time.Time
+			if err := q.After.Scan(&after); err != nil {
+				return BookmarkPage{}, err
+			}
+			cond, args := bookmarkKeyset.After(after, q.After.ID)
+			query = query.Where(cond, args...)
+		}
+		// one extra row tells whether there is a next page
+		query = query.Limit(q.Limit + 1)
+	}
+
+	jobs := []BookmarkedJob{}
+	if err := query.
+		Select(jobListColumns+", job_bookmarks.created_at AS bookmarked_at", q.StudentID, q.StudentID).
+		Scan(&jobs).Error; err != nil {
+		return BookmarkPage{}, err
+	}
+
+	if q.Keyset() && len(jobs) > q.Limit {
+		jobs = jobs[:q.Limit]
+		last := jobs[len(jobs)-1]
+		page.NextCursor = pagination.NewCursor(bookmarkSort, last.BookmarkedAt, last.ID).Encode()
+	}
+	page.Items = jobs
+
+	return page, nil
+}
+
+func (r *gormRepository) ResetBookmarkReminders(ctx context.Context, jobID uint) error {
+	return r.db.WithContext(ctx).
+		Model(&models.JobBookmark{}).
+		Where("job_id = ?", jobID).
+		Update("reminded_at", nil).Error
+}
+
+func (r *gormRepository) ClaimBookmarkReminders(ctx context.Context, now, until time.Time) ([]BookmarkReminder, error) {
+	db := r.db.WithContext(ctx)
+
+	due := db.
+		Table("job_bookmarks").
+		Select("job_bookmarks.id").
+		Joins("JOIN jobs ON jobs.id = job_bookmarks.job_id").
+		Where("jobs.is_active = true").
+		// a pooled drive the student's college has since hidden
+		Where(`(jobs.college_id = job_bookmarks.college_id OR EXISTS (
+			SELECT 1 FROM job_colleges
+			WHERE job_colleges.job_id = jobs.id
+				AND job_colleges.college_id = job_bookmarks.college_id
+				AND job_colleges.is_visible
+		))`).
+		Where("jobs.registration_deadline > ?", now).
+		Where("jobs.registration_deadline <= ?", until).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM applications
+			WHERE applications.job_id = jobs.id AND applications.student_id = job_bookmarks.student_id
+		)`)
+
+	// the update claims the rows; a concurrent worker finds them reminded
+	var claimed []models.JobBookmark
+	if err := db.
+		Model(&claimed).
+		Clauses(clause.Returning{}).
+		Where("reminded_at IS NULL").
+		Where("id IN (?)", due).
+		Update("reminded_at", now).Error; err != nil {
+		return nil, err
+	}
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+
+	jobIDs := make([]uint, len(claimed))
+	for i, b := range claimed {
+		jobIDs[i] = b.JobID
+	}
+	var jobs []models.Job
+	if err := db.Select("id, title, company, registration_deadline").Where("id IN ?", jobIDs).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Job, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+
+	reminders := make([]BookmarkReminder, 0, len(claimed))
+	for _, b := range claimed {
+		job := byID[b.JobID]
+		r := BookmarkReminder{
+			BookmarkID: b.ID,
+			StudentID:  b.StudentID,
+			JobID:      b.JobID,
+			Title:      job.Title,
+			Company:    job.Company,
+		}
+		if job.RegistrationDeadline != nil {
+			r.RegistrationDeadline = *job.RegistrationDeadline
+		}
+		reminders = append(reminders, r)
+	}
+	return reminders, nil
+}
+
+func (r *gormRepository) UnappliedBookmarkers(ctx context.Context, jobID uint) ([]uint, error) {
+	var students []uint
+	err := r.db.WithContext(ctx).
+		Model(&models.JobBookmark{}).
+		Where("job_id = ?", jobID).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM applications
+			WHERE applications.job_id = job_bookmarks.job_id AND applications.student_id = job_bookmarks.student_id
+		)`).
+		Order("student_id").
+		Pluck("student_id", &students).Error
+	return students, err
+}
+
+func (r *gormRepository) CountSavedSearches(ctx context.Context, studentID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.SavedSearch{}).
+		Where("student_id = ?", studentID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *gormRepository) CreateSavedSearch(ctx context.Context, search *models.SavedSearch) error {
+	return r.db.WithContext(ctx).Create(search).Error
+}
+
+func (r *gormRepository) SavedSearches(ctx context.Context, studentID uint) ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	err := r.db.WithContext(ctx).
+		Where("student_id = ?", studentID).
+		Order("created_at DESC").
+		Find(&searches).Error
+	return searches, err
+}
+
+func (r *gormRepository) FindSavedSearch(ctx context.Context, id, studentID uint) (models.SavedSearch, error) {
+	var search models.SavedSearch
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND student_id = ?", id, studentID).
+		First(&search).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return search, ErrSavedSearchNotFound
+	}
+	return search, err
+}
+
+func (r *gormRepository) UpdateSavedSearch(ctx context.Context, search models.SavedSearch, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&search).Updates(updates).Error
+}
+
+func (r *gormRepository) DeleteSavedSearch(ctx context.Context, search models.SavedSearch) error {
+	return r.db.WithContext(ctx).Delete(&search).Error
+}
+
+// MatchPendingJobs matches up to limit queued jobs at once. Each saved
+// search runs one query over the whole batch, through applyJobFilter, so
+// a search matches exactly the jobs GetJobs with the same filters would
+// list. For a pooled drive that covers every participating college. The
+// queue rows are deleted in the same transaction the matches are stored
+// in; a concurrent worker skips them.
+func (r *gormRepository) MatchPendingJobs(ctx context.Context, limit int) (int, error) {
+	var matched int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var queued []models.PendingJobAlert
+		if err := tx.
+			Clauses(clause.Returning{}).
+			Where("job_id IN (?)", tx.Model(&models.PendingJobAlert{}).Select("job_id").Order("job_id").Limit(limit)).
+			Delete(&queued).Error; err != nil {
+			return err
+		}
+		jobIDs := make([]uint, len(queued))
+		for i, q := range queued {
+			jobIDs[i] = q.JobID
+		}
+		matched = len(jobIDs)
+		if matched == 0 {
+			return nil
+		}
+
+		var searches []models.SavedSearch
+		if err := tx.
+			Where("college_id IN (?) OR college_id IN (?)",
+				tx.Model(&models.Job{}).Select("college_id").Where("id IN ?", jobIDs),
+				tx.Model(&models.JobCollege{}).Select("college_id").Where("job_id IN ?", jobIDs)).
+			Where("notify_on_match = true").
+			Find(&searches).Error; err != nil {
+			return err
+		}
+
+		var matches []models.SavedSearchMatch
+		for _, search := range searches {
+			// filters are validated on save; a row that still does not
+			// decode is skipped, but loudly, so its owner can be helped
+			var filter JobFilter
+			if err := json.Unmarshal(search.Filters, &filter); err != nil {
+				slog.ErrorContext(ctx, "skipping saved search with unreadable filters", "saved_search_id", search.ID, "error", err)
+				continue
+			}
+
+			var ids []uint
+			if err := applyJobFilter(activeJobsQuery(tx, &search.CollegeID), filter).
+				Where("jobs.id IN ?", jobIDs).
+				Pluck("jobs.id", &ids).Error; err != nil {
+				return err
+			}
+			for _, id := range ids {
+				matches = append(matches, models.SavedSearchMatch{SavedSearchID: search.ID, JobID: id})
+			}
+		}
+
+		if len(matches) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&matches).Error
+	})
+	return matched, err
+}
+
+// ClaimSavedSearchAlerts takes the waiting matches of instant searches and
+// of daily searches last notified before digestDue, newest job first, and
+// stamps those searches notified. Claimed matches are deleted, so each
+// goes out at most once. Jobs deactivated since matching are dropped.
+func (r *gormRepository) ClaimSavedSearchAlerts(ctx context.Context, now, digestDue time.Time) ([]SavedSearchAlert, error) {
+	var alerts []SavedSearchAlert
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var claimed []models.SavedSearchMatch
+		if err := tx.
+			Clauses(clause.Returning{}).
+			Where("saved_search_id IN (?)", tx.Model(&models.SavedSearch{}).
+				Select("id").
+				Where("frequency = ? OR last_notified_at IS NULL OR last_notified_at <= ?", models.AlertInstant, digestDue)).
+			Delete(&claimed).Error; err != nil {
+			return err
+		}
+		if len(claimed) == 0 {
+			return nil
+		}
+
+		bySearch := map[uint][]uint{}
+		var searchIDs, jobIDs []uint
+		for _, m := range claimed {
+			if _, ok := bySearch[m.SavedSearchID]; !ok {
+				searchIDs = append(searchIDs, m.SavedSearchID)
+			}
+			bySearch[m.SavedSearchID] = append(bySearch[m.SavedSearchID], m.JobID)
+			jobIDs = append(jobIDs, m.JobID)
+		}
+
+		if err := tx.
+			Model(&models.SavedSearch{}).
+			Where("id IN ?", searchIDs).
+			Update("last_notified_at", now).Error; err != nil {
+			return err
+		}
+
+		var searches []models.SavedSearch
+		if err := tx.Where("id IN ?", searchIDs).Order("id").Find(&searches).Error; err != nil {
+			return err
+		}
+		var jobs []AlertJob
+		if err := tx.
+			Model(&models.Job{}).
+			Select("id AS job_id, title, company").
+			Where("id IN ? AND is_active = true", jobIDs).
+			Order("id DESC").
+			Scan(&jobs).Error; err != nil {
+			return err
+		}
+
+		for _, search := range searches {
+			alert := SavedSearchAlert{
+				SearchID:  search.ID,
+				StudentID: search.StudentID,
+				Name:      search.Name,
+				Frequency: search.Frequency,
+			}
+			for _, job := range jobs {
+				if slices.Contains(bySearch[search.ID], job.JobID) {
+					alert.Jobs = append(alert.Jobs, job)
+				}
+			}
+			if len(alert.Jobs) > 0 {
+				alerts = append(alerts, alert)
+			}
+		}
+		return nil
+	})
+	return alerts, err
+}
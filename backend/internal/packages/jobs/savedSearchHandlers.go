@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateSavedSearch(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req SavedSearchRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		search, err := svc.CreateSavedSearch(c.Request.Context(), auth, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to save search")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"id":      search.ID,
+			"message": "search saved",
+		})
+	}
+}
+
+func ListSavedSearches(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		searches, err := svc.ListSavedSearches(c.Request.Context(), auth)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch saved searches")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": searches})
+	}
+}
+
+func UpdateSavedSearch(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		searchID, ok := parseSavedSearchID(c)
+		if !ok {
+			return
+		}
+
+		var req UpdateSavedSearchRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		if err := svc.UpdateSavedSearch(c.Request.Context(), auth, searchID, req); err != nil {
+			writeServiceError(c, err, "failed to update saved search")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "saved search updated"})
+	}
+}
+
+func DeleteSavedSearch(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		searchID, ok := parseSavedSearchID(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.DeleteSavedSearch(c.Request.Context(), auth, searchID); err != nil {
+			writeServiceError(c, err, "failed to delete saved search")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "saved search deleted"})
+	}
+}
+
+func parseSavedSearchID(c *gin.Context) (uint, bool) {
+	searchID, err := strconv.ParseUint(c.Param("search_id"), 10, 64)
+	if err != nil || searchID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid search id"})
+		return 0, false
+	}
+	return uint(searchID), true
+}
@@ -0,0 +1,141 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"strings"
+)
+
+var (
+	ErrSavedSearchNotFound = errors.New("saved search not found")
+	ErrSavedSearchLimit    = errors.New("saved search limit reached")
+)
+
+// ListSavedSearches returns the student's saved searches, newest first.
+func (s *Service) ListSavedSearches(ctx context.Context, auth *authorization.AuthContext) ([]SavedSearchResponse, error) {
+	searches, err := s.repo.SavedSearches(ctx, auth.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]SavedSearchResponse, 0, len(searches))
+	for _, search := range searches {
+		resp, err := toSavedSearchResponse(search)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resp)
+	}
+	return out, nil
+}
+
+// CreateSavedSearch stores a search for the student's college. Its
+// filters are validated here so that the matcher can always decode them.
+func (s *Service) CreateSavedSearch(ctx context.Context, auth *authorization.AuthContext, req SavedSearchRequest) (models.SavedSearch, error) {
+	if auth.CollegeID == nil {
+		return models.SavedSearch{}, ErrForbidden
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return models.SavedSearch{}, invalid("name is required")
+	}
+
+	req.Filters.Q = strings.TrimSpace(req.Filters.Q)
+	if err := validateJobFilter(req.Filters); err != nil {
+		return models.SavedSearch{}, invalid(err.Error())
+	}
+
+	if req.Frequency == "" {
+		req.Frequency = models.AlertInstant
+	}
+	if !isValidAlertFrequency(req.Frequency) {
+		return models.SavedSearch{}, invalid("invalid frequency")
+	}
+
+	count, err := s.repo.CountSavedSearches(ctx, auth.UserID)
+	if err != nil {
+		return models.SavedSearch{}, err
+	}
+	if count >= maxSavedSearchesPerStudent {
+		return models.SavedSearch{}, ErrSavedSearchLimit
+	}
+
+	filtersJSON, err := json.Marshal(req.Filters)
+	if err != nil {
+		return models.SavedSearch{}, err
+	}
+
+	notify := true
+	if req.NotifyOnMatch != nil {
+		notify = *req.NotifyOnMatch
+	}
+
+	search := models.SavedSearch{
+		StudentID:     auth.UserID,
+		CollegeID:     *auth.CollegeID,
+		Name:          name,
+		Filters:       filtersJSON,
+		NotifyOnMatch: notify,
+		Frequency:     req.Frequency,
+	}
+	if err := s.repo.CreateSavedSearch(ctx, &search); err != nil {
+		return models.SavedSearch{}, err
+	}
+
+	return search, nil
+}
+
+func (s *Service) UpdateSavedSearch(ctx context.Context, auth *authorization.AuthContext, id uint, req UpdateSavedSearchRequest) error {
+	search, err := s.repo.FindSavedSearch(ctx, id, auth.UserID)
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return invalid("name cannot be empty")
+		}
+		updates["name"] = name
+	}
+	if req.Filters != nil {
+		req.Filters.Q = strings.TrimSpace(req.Filters.Q)
+		if err := validateJobFilter(*req.Filters); err != nil {
+			return invalid(err.Error())
+		}
+		filtersJSON, err := json.Marshal(req.Filters)
+		if err != nil {
+			return err
+		}
+		updates["filters"] = filtersJSON
+	}
+	if req.NotifyOnMatch != nil {
+		updates["notify_on_match"] = *req.NotifyOnMatch
+	}
+	if req.Frequency != nil {
+		if !isValidAlertFrequency(*req.Frequency) {
+			return invalid("invalid frequency")
+		}
+		updates["frequency"] = *req.Frequency
+	}
+
+	if len(updates) == 0 {
+		return invalid("no fields to update")
+	}
+
+	return s.repo.UpdateSavedSearch(ctx, search, updates)
+}
+
+func (s *Service) DeleteSavedSearch(ctx context.Context, auth *authorization.AuthContext, id uint) error {
+	search, err := s.repo.FindSavedSearch(ctx, id, auth.UserID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteSavedSearch(ctx, search)
+}
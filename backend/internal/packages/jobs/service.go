@@ -0,0 +1,538 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/pagination"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/singleflight"
+)
+
+// how long a student has to confirm an application after being sent to
+// the registration form
+const intentTTL = 24 * time.Hour
+
+// Rule violations. Their messages are what the API returns.
+var (
+	ErrJobNotFound        = errors.New("job not found")
+	ErrForbidden          = errors.New("access denied")
+	ErrRegistrationClosed = errors.New("registration closed")
+	ErrProfileMissing     = errors.New("complete profile first")
+	ErrProfileIncomplete  = errors.New("complete profile before applying")
+	ErrNotEligible        = errors.New("you are not eligible for this job")
+	ErrAlreadyApplied     = errors.New("already applied")
+	ErrResumeNotFound     = errors.New("resume not found")
+)
+
+// ValidationError is a bad request; the message is returned as is.
+type ValidationError struct {
+	msg string
+}
+
+func (e *ValidationError) Error() string { return e.msg }
+
+func invalid(msg string) error { return &ValidationError{msg: msg} }
+
+// Repository is the persistence the job rules need. Mutations record
+// their audit entry and queue their webhook deliveries in the same
+// transaction.
+type Repository interface {
+	// CreateJob stores the job together with its pool, if any, and queues
+	// it for saved-search matching.
+	CreateJob(ctx context.Context, job *models.Job, pool []models.JobCollege) error
+	UpdateJob(ctx context.Context, job models.Job, updates map[string]interface{}) error
+	DeactivateJob(ctx context.Context, job models.Job) error
+
+	// FindJob ignores scoping; FindActiveJob is college-scoped and active only.
+	// Both return ErrJobNotFound. For a pooled drive FindActiveJob returns
+	// the participating college's eligible batches.
+	FindJob(ctx context.Context, id uint) (models.Job, error)
+	FindActiveJob(ctx context.Context, id, collegeID uint) (models.Job, error)
+	// ListJobs returns one page in q's mode, counting the total only
+	// when asked to.
+	ListJobs(ctx context.Context, q ListQuery) (JobPage, error)
+	// StudentJobFlags reports which of jobIDs the student bookmarked and
+	// applied to.
+	StudentJobFlags(ctx context.Context, studentID uint, jobIDs []uint) (bookmarked, applied map[uint]bool, err error)
+
+	// Pool lists the colleges a job is pooled with, by college id.
+	Pool(ctx context.Context, jobID uint) ([]models.JobCollege, error)
+	ReplacePool(ctx context.Context, job models.Job, pool []models.JobCollege) error
+	UpdatePoolEntry(ctx context.Context, entry models.JobCollege, updates map[string]interface{}) error
+	CountColleges(ctx context.Context, ids []uint) (int64, error)
+
+	// StudentProfile returns ErrProfileMissing when there is none.
+	StudentProfile(ctx context.Context, userID uint) (models.StudentProfile, error)
+	HasApplied(ctx context.Context, jobID, studentID uint) (bool, error)
+	// HasResume reports whether the resume is one of the student's.
+	HasResume(ctx context.Context, resumeID, studentID uint) (bool, error)
+	UpsertIntent(ctx context.Context, intent *models.ApplicationIntent) error
+
+	// AddBookmark ignores a bookmark the student already has.
+	AddBookmark(ctx context.Context, bookmark models.JobBookmark) error
+	RemoveBookmark(ctx context.Context, jobID, studentID uint) error
+	// BookmarkedJobs returns one page of the student's bookmarks of jobs
+	// that are active and visible to q.CollegeID.
+	BookmarkedJobs(ctx context.Context, q ListQuery) (BookmarkPage, error)
+	ResetBookmarkReminders(ctx context.Context, jobID uint) error
+	// ClaimBookmarkReminders marks the unreminded bookmarks whose job
+	// closes in (now, until] reminded, skipping students who applied or
+	// no longer see the job, and returns them.
+	ClaimBookmarkReminders(ctx context.Context, now, until time.Time) ([]BookmarkReminder, error)
+	// UnappliedBookmarkers returns the students who bookmarked the job
+	// without applying to it.
+	UnappliedBookmarkers(ctx context.Context, jobID uint) ([]uint, error)
+
+	CountSavedSearches(ctx context.Context, studentID uint) (int64, error)
+	CreateSavedSearch(ctx context.Context, search *models.SavedSearch) error
+	// SavedSearches lists the student's searches, newest first.
+	SavedSearches(ctx context.Context, studentID uint) ([]models.SavedSearch, error)
+	// FindSavedSearch returns ErrSavedSearchNotFound unless the search is
+	// the student's.
+	FindSavedSearch(ctx context.Context, id, studentID uint) (models.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, search models.SavedSearch, updates map[string]interface{}) error
+	DeleteSavedSearch(ctx context.Context, search models.SavedSearch) error
+
+	// MatchPendingJobs matches up to limit jobs queued by CreateJob
+	// against the alerting saved searches, with the same SQL as ListJobs,
+	// and returns how many jobs it took off the queue.
+	MatchPendingJobs(ctx context.Context, limit int) (int, error)
+	// ClaimSavedSearchAlerts takes the matches that are due, so that each
+	// is sent at most once, and marks their searches notified at now.
+	ClaimSavedSearchAlerts(ctx context.Context, now, digestDue time.Time) ([]SavedSearchAlert, error)
+}
+
+type Notifier interface {
+	Push(ctx context.Context, userID uint, notifType models.NotificationType, targetID uint, payload gin.H) error
+	// Enqueue hands the notification to the background worker, for fan-out.
+	Enqueue(ctx context.Context, userID uint, notifType models.NotificationType, targetID uint, payload gin.H) error
+}
+
+type ListQuery struct {
+	pagination.Params
+
+	CollegeID *uint
+	StudentID uint
+	Filter    JobFilter
+	Sort      string
+
+	// decoded Params.Cursor; nil on the first page
+	After *pagination.Cursor
+}
+
+// JobPage is one page of the listing. Total is nil when not counted;
+// NextCursor is empty on the last keyset page and in offset mode.
+type JobPage struct {
+	Items      []JobListItem `json:"items"`
+	Total      *int64        `json:"total"`
+	NextCursor string        `json:"next_cursor"`
+}
+
+type Service struct {
+	repo     Repository
+	notifier Notifier
+	now      func() time.Time
+
+	cache    Cache
+	cacheTTL time.Duration
+	flight   singleflight.Group
+}
+
+type Option func(*Service)
+
+// WithCache enables read-through caching of listings and job details.
+// ttl <= 0 uses the default of one minute.
+func WithCache(c Cache, ttl time.Duration) Option {
+	return func(s *Service) {
+		s.cache = c
+		s.cacheTTL = ttl
+		if ttl <= 0 {
+			s.cacheTTL = defaultCacheTTL
+		}
+	}
+}
+
+func NewService(repo Repository, notifier Notifier, opts ...Option) *Service {
+	s := &Service{repo: repo, notifier: notifier, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Service) Create(ctx context.Context, auth *authorization.AuthContext, req CreateJobRequest) (models.Job, error) {
+	if auth.CollegeID == nil {
+		return models.Job{}, ErrForbidden
+	}
+	if err := s.validateCreate(&req); err != nil {
+		return models.Job{}, err
+	}
+
+	pool, err := s.buildPool(ctx, *auth.CollegeID, req.Pool)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	batchesJSON, err := json.Marshal(req.EligibleBatches)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	job := models.Job{
+		CollegeID:            *auth.CollegeID,
+		Company:              strings.TrimSpace(req.Company),
+		Title:                req.Title,
+		JobType:              req.JobType,
+		Domain:               req.Domain,
+		EligibleBatches:      batchesJSON,
+		CTC:                  req.CTC,
+		Stipend:              req.Stipend,
+		Description:          req.Description,
+		RegistrationFormURL:  req.RegistrationFormURL,
+		RegistrationDeadline: req.RegistrationDeadline,
+		IsActive:             true,
+	}
+
+	if err := s.repo.CreateJob(ctx, &job, pool); err != nil {
+		return models.Job{}, err
+	}
+	s.invalidate(ctx, append([]uint{job.CollegeID}, poolCollegeIDs(pool)...)...)
+
+	return job, nil
+}
+
+func (s *Service) validateCreate(req *CreateJobRequest) error {
+	if strings.TrimSpace(req.Company) == "" {
+		return invalid("company is required")
+	}
+	if !isValidJobType(req.JobType) {
+		return invalid("invalid job_type")
+	}
+	if !isValidDomain(req.Domain) {
+		return invalid("invalid domain")
+	}
+	if len(req.EligibleBatches) == 0 {
+		return invalid("eligible_batches required")
+	}
+	if req.CTC == nil && req.Stipend == nil {
+		return invalid("ctc or stipend required")
+	}
+	if req.RegistrationFormURL == nil {
+		return invalid("registration_form_url is required")
+	}
+
+	url := strings.TrimSpace(*req.RegistrationFormURL)
+	if url == "" {
+		return invalid("registration_form_url cannot be empty")
+	}
+	if !isValidURL(url) {
+		return invalid("registration_form_url must be a valid URL")
+	}
+	req.RegistrationFormURL = &url
+
+	if req.RegistrationDeadline != nil && !req.RegistrationDeadline.After(s.now()) {
+		return invalid("registration_deadline must be in the future")
+	}
+
+	return nil
+}
+
+func (s *Service) List(ctx context.Context, auth *authorization.AuthContext, q ListQuery) (JobPage, ListQuery, error) {
+	q.Normalize(10, 50)
+	if _, ok := jobSorts[q.Sort]; !ok {
+		q.Sort = "latest"
+	}
+	q.CollegeID = auth.CollegeID
+	q.StudentID = auth.UserID
+
+	if q.Keyset() {
+		after, err := pagination.Decode(*q.Cursor, q.Sort)
+		if err == nil && after != nil {
+			_, err = jobCursorValue(q.Sort, *after)
+		}
+		if err != nil {
+			return JobPage{}, q, invalid(pagination.ErrInvalidCursor.Error())
+		}
+		q.After = after
+	}
+
+	// the page itself is shared by the whole college; the student's own
+	// flags are added on top so it can be cached
+	shared := q
+	shared.StudentID = 0
+	load := func(ctx context.Context) (JobPage, error) {
+		return s.repo.ListJobs(ctx, shared)
+	}
+
+	var (
+		page JobPage
+		err  error
+	)
+	if q.CollegeID == nil {
+		page, err = load(ctx)
+	} else {
+		page, err = cached(ctx, s, listCache, *q.CollegeID, listCacheKey(shared), load)
+	}
+	if err != nil {
+		return JobPage{}, q, err
+	}
+
+	// copy: a cached page may be shared with concurrent requests
+	items := make([]JobListItem, len(page.Items))
+	copy(items, page.Items)
+	page.Items = items
+
+	if auth.Role == string(models.Student) && len(items) > 0 {
+		if err := s.markStudentFlags(ctx, auth.UserID, items); err != nil {
+			return JobPage{}, q, err
+		}
+	}
+
+	return page, q, nil
+}
+
+func (s *Service) markStudentFlags(ctx context.Context, studentID uint, items []JobListItem) error {
+	ids := make([]uint, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	bookmarked, applied, err := s.repo.StudentJobFlags(ctx, studentID, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		items[i].IsBookmarked = bookmarked[items[i].ID]
+		items[i].HasApplied = applied[items[i].ID]
+	}
+	return nil
+}
+
+func (s *Service) Get(ctx context.Context, auth *authorization.AuthContext, id uint) (JobDetailResponse, error) {
+	if auth.CollegeID == nil {
+		return JobDetailResponse{}, ErrJobNotFound
+	}
+
+	collegeID := *auth.CollegeID
+	return cached(ctx, s, detailCache, collegeID, strconv.FormatUint(uint64(id), 10),
+		func(ctx context.Context) (JobDetailResponse, error) {
+			return s.loadDetail(ctx, id, collegeID)
+		})
+}
+
+func (s *Service) loadDetail(ctx context.Context, id, collegeID uint) (JobDetailResponse, error) {
+	job, err := s.repo.FindActiveJob(ctx, id, collegeID)
+	if err != nil {
+		return JobDetailResponse{}, err
+	}
+
+	var batches []int
+	if err := json.Unmarshal(job.EligibleBatches, &batches); err != nil {
+		return JobDetailResponse{}, err
+	}
+
+	return JobDetailResponse{
+		ID:                   job.ID,
+		Company:              job.Company,
+		Title:                job.Title,
+		JobType:              string(job.JobType),
+		Domain:               string(job.Domain),
+		EligibleBatches:      batches,
+		CTC:                  job.CTC,
+		Stipend:              job.Stipend,
+		Description:          job.Description,
+		RegistrationFormURL:  job.RegistrationFormURL,
+		RegistrationDeadline: job.RegistrationDeadline,
+		HostCollegeID:        job.CollegeID,
+		CreatedAt:            job.CreatedAt,
+	}, nil
+}
+
+// ApplyIntent checks that the student may apply and records an intent
+// they confirm after filling the registration form, along with the resume
+// they chose, if any.
+func (s *Service) ApplyIntent(ctx context.Context, auth *authorization.AuthContext, jobID uint, req ApplyRequest) (models.Job, models.ApplicationIntent, error) {
+	if auth.CollegeID == nil {
+		return models.Job{}, models.ApplicationIntent{}, ErrJobNotFound
+	}
+
+	job, err := s.repo.FindActiveJob(ctx, jobID, *auth.CollegeID)
+	if err != nil {
+		return models.Job{}, models.ApplicationIntent{}, err
+	}
+
+	if job.RegistrationDeadline != nil && s.now().After(*job.RegistrationDeadline) {
+		return job, models.ApplicationIntent{}, ErrRegistrationClosed
+	}
+
+	var batches []int
+	if err := json.Unmarshal(job.EligibleBatches, &batches); err != nil {
+		return job, models.ApplicationIntent{}, err
+	}
+
+	profile, err := s.repo.StudentProfile(ctx, auth.UserID)
+	if err != nil {
+		return job, models.ApplicationIntent{}, err
+	}
+	// filled in against the college's required fields by the profile service
+	if !profile.ProfileComplete {
+		return job, models.ApplicationIntent{}, ErrProfileIncomplete
+	}
+	if !containsInt(batches, profile.Batch) {
+		return job, models.ApplicationIntent{}, ErrNotEligible
+	}
+
+	applied, err := s.repo.HasApplied(ctx, job.ID, auth.UserID)
+	if err != nil {
+		return job, models.ApplicationIntent{}, err
+	}
+	if applied {
+		return job, models.ApplicationIntent{}, ErrAlreadyApplied
+	}
+
+	if req.ResumeID != nil {
+		ok, err := s.repo.HasResume(ctx, *req.ResumeID, auth.UserID)
+		if err != nil {
+			return job, models.ApplicationIntent{}, err
+		}
+		if !ok {
+			return job, models.ApplicationIntent{}, ErrResumeNotFound
+		}
+	}
+
+	// create or refresh intent
+	intent := models.ApplicationIntent{
+		JobID:     job.ID,
+		StudentID: auth.UserID,
+		CollegeID: *auth.CollegeID,
+		ResumeID:  req.ResumeID,
+		ExpiresAt: s.now().Add(intentTTL),
+	}
+	if err := s.repo.UpsertIntent(ctx, &intent); err != nil {
+		return job, models.ApplicationIntent{}, err
+	}
+
+	_ = s.notifier.Push(ctx, auth.UserID, models.NotificationJobApplyIntent, intent.ID, gin.H{
+		"job_id":  job.ID,
+		"title":   job.Title,
+		"company": job.Company,
+	})
+
+	return job, intent, nil
+}
+
+func (s *Service) Update(ctx context.Context, auth *authorization.AuthContext, id uint, req UpdateJobRequest) error {
+	job, err := s.mutableJob(ctx, auth, id)
+	if err != nil {
+		return err
+	}
+
+	if req.RegistrationDeadline != nil && !req.RegistrationDeadline.After(s.now()) {
+		return invalid("registration_deadline must be in the future")
+	}
+
+	updates := jobUpdates(req)
+	if len(updates) == 0 {
+		return invalid("no fields to update")
+	}
+
+	if err := s.repo.UpdateJob(ctx, job, updates); err != nil {
+		return err
+	}
+	s.invalidateJob(ctx, job)
+
+	active := job.IsActive
+	if req.IsActive != nil {
+		active = *req.IsActive
+	}
+
+	// a moved deadline deserves a fresh reminder, unless the job is closed
+	if req.RegistrationDeadline != nil && active {
+		_ = s.repo.ResetBookmarkReminders(ctx, job.ID)
+	}
+	if job.IsActive && req.IsActive != nil && !*req.IsActive {
+		s.notifyBookmarkersClosed(ctx, job)
+	}
+
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, auth *authorization.AuthContext, id uint) error {
+	job, err := s.mutableJob(ctx, auth, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeactivateJob(ctx, job); err != nil {
+		return err
+	}
+	s.invalidateJob(ctx, job)
+
+	// deleting an already inactive job tells nobody again
+	if job.IsActive {
+		s.notifyBookmarkersClosed(ctx, job)
+	}
+
+	return nil
+}
+
+func (s *Service) mutableJob(ctx context.Context, auth *authorization.AuthContext, id uint) (models.Job, error) {
+	job, err := s.repo.FindJob(ctx, id)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	var collegeID uint
+	if auth.CollegeID != nil {
+		collegeID = *auth.CollegeID
+	}
+	if !canMutateJob(models.Role(auth.Role), job, collegeID) {
+		return models.Job{}, ErrForbidden
+	}
+
+	return job, nil
+}
+
+func jobUpdates(req UpdateJobRequest) map[string]interface{} {
+	updates := map[string]interface{}{}
+
+	if req.Title != nil {
+		updates["title"] = *req.Title
+	}
+	if req.JobType != nil {
+		updates["job_type"] = *req.JobType
+	}
+	if req.Domain != nil {
+		updates["domain"] = *req.Domain
+	}
+	if req.EligibleBatches != nil {
+		updates["eligible_batches"] = *req.EligibleBatches
+	}
+	if req.CTC != nil {
+		updates["ctc"] = req.CTC
+	}
+	if req.Stipend != nil {
+		updates["stipend"] = req.Stipend
+	}
+	if req.RegistrationFormURL != nil {
+		updates["registration_form_url"] = req.RegistrationFormURL
+	}
+	if req.RegistrationDeadline != nil {
+		updates["registration_deadline"] = req.RegistrationDeadline
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	return updates
+}
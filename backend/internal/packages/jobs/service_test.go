@@ -0,0 +1,875 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/pagination"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeRepo struct {
+	jobs     map[uint]models.Job
+	profiles map[uint]models.StudentProfile
+	applied  map[[2]uint]bool
+	intents  []models.ApplicationIntent
+	resumes  map[[2]uint]bool // resume, student
+	alerts   []SavedSearchAlert
+	listed   []JobListItem
+	pools    map[uint][]models.JobCollege
+	colleges map[uint]bool
+	searches map[uint]models.SavedSearch
+
+	mu         sync.Mutex
+	listCalls  int
+	listGate   chan struct{}
+	bookmarked map[[2]uint]bool // job, student
+	// bookmark -> reminded
+	reminded map[uint]bool
+
+	created     []models.Job
+	updates     map[string]interface{}
+	deactivated []uint
+	resets      []uint
+	// jobs queued for saved-search matching
+	pending    int
+	matchCalls int
+	digestDue  time.Time
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		jobs:     map[uint]models.Job{},
+		profiles: map[uint]models.StudentProfile{},
+		applied:  map[[2]uint]bool{},
+		resumes:  map[[2]uint]bool{},
+		pools:    map[uint][]models.JobCollege{},
+		colleges: map[uint]bool{10: true, 11: true, 12: true},
+		searches: map[uint]models.SavedSearch{},
+
+		bookmarked: map[[2]uint]bool{},
+		reminded:   map[uint]bool{},
+	}
+}
+
+func (r *fakeRepo) CreateJob(_ context.Context, job *models.Job, pool []models.JobCollege) error {
+	job.ID = uint(len(r.jobs) + 100)
+	r.jobs[job.ID] = *job
+	r.created = append(r.created, *job)
+	for i := range pool {
+		pool[i].JobID = job.ID
+	}
+	if len(pool) > 0 {
+		r.pools[job.ID] = pool
+	}
+	return nil
+}
+
+func (r *fakeRepo) UpdateJob(_ context.Context, _ models.Job, updates map[string]interface{}) error {
+	r.updates = updates
+	return nil
+}
+
+func (r *fakeRepo) DeactivateJob(_ context.Context, job models.Job) error {
+	r.deactivated = append(r.deactivated, job.ID)
+	return nil
+}
+
+func (r *fakeRepo) FindJob(_ context.Context, id uint) (models.Job, error) {
+	job, ok := r.jobs[id]
+	if !ok {
+		return job, ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (r *fakeRepo) FindActiveJob(_ context.Context, id, collegeID uint) (models.Job, error) {
+	job, ok := r.jobs[id]
+	if !ok || !job.IsActive {
+		return models.Job{}, ErrJobNotFound
+	}
+	if job.CollegeID == collegeID {
+		return job, nil
+	}
+	for _, entry := range r.pools[id] {
+		if entry.CollegeID == collegeID && entry.IsVisible {
+			job.EligibleBatches = entry.EligibleBatches
+			return job, nil
+		}
+	}
+	return models.Job{}, ErrJobNotFound
+}
+
+func (r *fakeRepo) Pool(_ context.Context, jobID uint) ([]models.JobCollege, error) {
+	return append([]models.JobCollege(nil), r.pools[jobID]...), nil
+}
+
+func (r *fakeRepo) ReplacePool(_ context.Context, job models.Job, pool []models.JobCollege) error {
+	r.pools[job.ID] = pool
+	return nil
+}
+
+func (r *fakeRepo) UpdatePoolEntry(_ context.Context, entry models.JobCollege, updates map[string]interface{}) error {
+	pool := r.pools[entry.JobID]
+	for i := range pool {
+		if pool[i].CollegeID != entry.CollegeID {
+			continue
+		}
+		if v, ok := updates["eligible_batches"]; ok {
+			pool[i].EligibleBatches = v.(datatypes.JSON)
+		}
+		if v, ok := updates["is_visible"]; ok {
+			pool[i].IsVisible = v.(bool)
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) CountColleges(_ context.Context, ids []uint) (int64, error) {
+	var n int64
+	for _, id := range ids {
+		if r.colleges[id] {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeRepo) ListJobs(_ context.Context, q ListQuery) (JobPage, error) {
+	r.mu.Lock()
+	r.listCalls++
+	gate := r.listGate
+	r.mu.Unlock()
+
+	if gate != nil {
+		<-gate
+	}
+	total := int64(len(r.listed))
+	return JobPage{Items: r.listed, Total: &total}, nil
+}
+
+func (r *fakeRepo) StudentJobFlags(_ context.Context, studentID uint, jobIDs []uint) (map[uint]bool, map[uint]bool, error) {
+	bookmarked, applied := map[uint]bool{}, map[uint]bool{}
+	for _, id := range jobIDs {
+		bookmarked[id] = r.bookmarked[[2]uint{id, studentID}]
+		applied[id] = r.applied[[2]uint{id, studentID}]
+	}
+	return bookmarked, applied, nil
+}
+
+func (r *fakeRepo) StudentProfile(_ context.Context, userID uint) (models.StudentProfile, error) {
+	p, ok := r.profiles[userID]
+	if !ok {
+		return p, ErrProfileMissing
+	}
+	return p, nil
+}
+
+func (r *fakeRepo) HasApplied(_ context.Context, jobID, studentID uint) (bool, error) {
+	return r.applied[[2]uint{jobID, studentID}], nil
+}
+
+func (r *fakeRepo) HasResume(_ context.Context, resumeID, studentID uint) (bool, error) {
+	return r.resumes[[2]uint{resumeID, studentID}], nil
+}
+
+func (r *fakeRepo) UpsertIntent(_ context.Context, intent *models.ApplicationIntent) error {
+	intent.ID = uint(len(r.intents) + 1)
+	r.intents = append(r.intents, *intent)
+	return nil
+}
+
+func (r *fakeRepo) AddBookmark(_ context.Context, bookmark models.JobBookmark) error {
+	r.bookmarked[[2]uint{bookmark.JobID, bookmark.StudentID}] = true
+	return nil
+}
+
+func (r *fakeRepo) RemoveBookmark(_ context.Context, jobID, studentID uint) error {
+	delete(r.bookmarked, [2]uint{jobID, studentID})
+	return nil
+}
+
+func (r *fakeRepo) BookmarkedJobs(_ context.Context, q ListQuery) (BookmarkPage, error) {
+	page := BookmarkPage{Items: []BookmarkedJob{}}
+	for key := range r.bookmarked {
+		if key[1] == q.StudentID {
+			page.Items = append(page.Items, BookmarkedJob{JobListItem: JobListItem{ID: key[0], IsBookmarked: true}})
+		}
+	}
+	return page, nil
+}
+
+func (r *fakeRepo) ResetBookmarkReminders(_ context.Context, jobID uint) error {
+	r.resets = append(r.resets, jobID)
+	return nil
+}
+
+// bookmarks are numbered by job*100 + student
+func (r *fakeRepo) ClaimBookmarkReminders(_ context.Context, now, until time.Time) ([]BookmarkReminder, error) {
+	var due []BookmarkReminder
+	for key := range r.bookmarked {
+		job, id := r.jobs[key[0]], key[0]*100+key[1]
+		if r.reminded[id] || r.applied[key] || !job.IsActive || job.RegistrationDeadline == nil ||
+			!job.RegistrationDeadline.After(now) || job.RegistrationDeadline.After(until) {
+			continue
+		}
+		r.reminded[id] = true
+		due = append(due, BookmarkReminder{BookmarkID: id, StudentID: key[1], JobID: key[0]})
+	}
+	return due, nil
+}
+
+func (r *fakeRepo) UnappliedBookmarkers(_ context.Context, jobID uint) ([]uint, error) {
+	var out []uint
+	for key := range r.bookmarked {
+		if key[0] == jobID && !r.applied[key] {
+			out = append(out, key[1])
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+func (r *fakeRepo) CountSavedSearches(_ context.Context, studentID uint) (int64, error) {
+	var n int64
+	for _, search := range r.searches {
+		if search.StudentID == studentID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeRepo) CreateSavedSearch(_ context.Context, search *models.SavedSearch) error {
+	search.ID = uint(len(r.searches) + 1)
+	r.searches[search.ID] = *search
+	return nil
+}
+
+func (r *fakeRepo) SavedSearches(_ context.Context, studentID uint) ([]models.SavedSearch, error) {
+	var out []models.SavedSearch
+	for _, search := range r.searches {
+		if search.StudentID == studentID {
+			out = append(out, search)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) FindSavedSearch(_ context.Context, id, studentID uint) (models.SavedSearch, error) {
+	search, ok := r.searches[id]
+	if !ok || search.StudentID != studentID {
+		return models.SavedSearch{}, ErrSavedSearchNotFound
+	}
+	return search, nil
+}
+
+func (r *fakeRepo) UpdateSavedSearch(_ context.Context, search models.SavedSearch, updates map[string]interface{}) error {
+	if v, ok := updates["name"]; ok {
+		search.Name = v.(string)
+	}
+	r.searches[search.ID] = search
+	return nil
+}
+
+func (r *fakeRepo) DeleteSavedSearch(_ context.Context, search models.SavedSearch) error {
+	delete(r.searches, search.ID)
+	return nil
+}
+
+func (r *fakeRepo) MatchPendingJobs(_ context.Context, limit int) (int, error) {
+	n := min(limit, r.pending)
+	r.pending -= n
+	r.matchCalls++
+	return n, nil
+}
+
+func (r *fakeRepo) ClaimSavedSearchAlerts(_ context.Context, _, digestDue time.Time) ([]SavedSearchAlert, error) {
+	r.digestDue = digestDue
+	alerts := r.alerts
+	r.alerts = nil
+	return alerts, nil
+}
+
+type sentNotification struct {
+	userID uint
+	typ    models.NotificationType
+}
+
+type fakeNotifier struct {
+	sent     []sentNotification
+	enqueued []sentNotification
+	// Enqueue fails for these users
+	failFor map[uint]bool
+}
+
+func (n *fakeNotifier) Push(_ context.Context, userID uint, typ models.NotificationType, _ uint, _ gin.H) error {
+	n.sent = append(n.sent, sentNotification{userID, typ})
+	return nil
+}
+
+func (n *fakeNotifier) Enqueue(_ context.Context, userID uint, typ models.NotificationType, _ uint, _ gin.H) error {
+	if n.failFor[userID] {
+		return errors.New("queue unavailable")
+	}
+	n.enqueued = append(n.enqueued, sentNotification{userID, typ})
+	return nil
+}
+
+func newTestService() (*Service, *fakeRepo, *fakeNotifier) {
+	repo := newFakeRepo()
+	notifier := &fakeNotifier{}
+	svc := NewService(repo, notifier)
+	svc.now = func() time.Time { return now }
+	return svc, repo, notifier
+}
+
+func collegeID(id uint) *uint { return &id }
+
+func student(id, college uint) *authorization.AuthContext {
+	return &authorization.AuthContext{UserID: id, Role: string(models.Student), CollegeID: collegeID(college)}
+}
+
+func collegeAdmin(id, college uint) *authorization.AuthContext {
+	return &authorization.AuthContext{UserID: id, Role: string(models.CollegeAdmin), CollegeID: collegeID(college)}
+}
+
+func openJob(id, college uint, batches string) models.Job {
+	return models.Job{
+		ID:              id,
+		CollegeID:       college,
+		Title:           "SDE Intern",
+		Company:         "Acme",
+		EligibleBatches: []byte(batches),
+		IsActive:        true,
+	}
+}
+
+func TestApplyIntentRules(t *testing.T) {
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		job     models.Job
+		profile *models.StudentProfile
+		applied bool
+		auth    *authorization.AuthContext
+		want    error
+	}{
+		{
+			name:    "eligible",
+			job:     openJob(1, 10, "[2026,2027]"),
+			profile: &models.StudentProfile{UserID: 5, Batch: 2026, ProfileComplete: true},
+			auth:    student(5, 10),
+		},
+		{
+			name:    "other college",
+			job:     openJob(1, 11, "[2026]"),
+			profile: &models.StudentProfile{UserID: 5, Batch: 2026, ProfileComplete: true},
+			auth:    student(5, 10),
+			want:    ErrJobNotFound,
+		},
+		{
+			name: "inactive job",
+			job: func() models.Job {
+				j := openJob(1, 10, "[2026]")
+				j.IsActive = false
+				return j
+			}(),
+			profile: &models.StudentProfile{UserID: 5, Batch: 2026, ProfileComplete: true},
+			auth:    student(5, 10),
+			want:    ErrJobNotFound,
+		},
+		{
+			name: "deadline passed",
+			job: func() models.Job {
+				j := openJob(1, 10, "[2026]")
+				j.RegistrationDeadline = &past
+				return j
+			}(),
+			profile: &models.StudentProfile{UserID: 5, Batch: 2026, ProfileComplete: true},
+			auth:    student(5, 10),
+			want:    ErrRegistrationClosed,
+		},
+		{
+			name: "no profile",
+			job:  openJob(1, 10, "[2026]"),
+			auth: student(5, 10),
+			want: ErrProfileMissing,
+		},
+		{
+			name:    "incomplete profile",
+			job:     openJob(1, 10, "[2026]"),
+			profile: &models.StudentProfile{UserID: 5, Batch: 2026},
+			auth:    student(5, 10),
+			want:    ErrProfileIncomplete,
+		},
+		{
+			name:    "batch not eligible",
+			job:     openJob(1, 10, "[2027]"),
+			profile: &models.StudentProfile{UserID: 5, Batch: 2026, ProfileComplete: true},
+			auth:    student(5, 10),
+			want:    ErrNotEligible,
+		},
+		{
+			name:    "already applied",
+			job:     openJob(1, 10, "[2026]"),
+			profile: &models.StudentProfile{UserID: 5, Batch: 2026, ProfileComplete: true},
+			applied: true,
+			auth:    student(5, 10),
+			want:    ErrAlreadyApplied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, notifier := newTestService()
+			repo.jobs[tt.job.ID] = tt.job
+			if tt.profile != nil {
+				repo.profiles[tt.profile.UserID] = *tt.profile
+			}
+			repo.applied[[2]uint{tt.job.ID, tt.auth.UserID}] = tt.applied
+
+			_, intent, err := svc.ApplyIntent(context.Background(), tt.auth, tt.job.ID, ApplyRequest{})
+
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if len(repo.intents) != 0 || len(notifier.sent) != 0 {
+					t.Fatal("rejected apply must not create an intent or notify")
+				}
+				return
+			}
+
+			if !intent.ExpiresAt.Equal(now.Add(intentTTL)) {
+				t.Fatalf("intent expires at %v, want %v", intent.ExpiresAt, now.Add(intentTTL))
+			}
+			if intent.CollegeID != 10 || intent.StudentID != 5 {
+				t.Fatalf("intent = %+v", intent)
+			}
+			if len(notifier.sent) != 1 || notifier.sent[0].typ != models.NotificationJobApplyIntent {
+				t.Fatalf("notifications = %+v", notifier.sent)
+			}
+		})
+	}
+}
+
+func TestApplyIntentResumeChoice(t *testing.T) {
+	svc, repo, _ := newTestService()
+	repo.jobs[1] = openJob(1, 10, "[2026]")
+	repo.profiles[5] = models.StudentProfile{UserID: 5, Batch: 2026, ProfileComplete: true}
+	repo.resumes[[2]uint{3, 5}] = true
+	repo.resumes[[2]uint{4, 6}] = true
+	own, foreign := uint(3), uint(4)
+
+	if _, _, err := svc.ApplyIntent(context.Background(), student(5, 10), 1, ApplyRequest{ResumeID: &foreign}); !errors.Is(err, ErrResumeNotFound) {
+		t.Fatalf("another student's resume: err = %v", err)
+	}
+	if len(repo.intents) != 0 {
+		t.Fatal("intent created with a foreign resume")
+	}
+
+	_, intent, err := svc.ApplyIntent(context.Background(), student(5, 10), 1, ApplyRequest{ResumeID: &own})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if intent.ResumeID == nil || *intent.ResumeID != 3 {
+		t.Fatalf("intent resume = %v, want 3", intent.ResumeID)
+	}
+}
+
+func TestCreateValidation(t *testing.T) {
+	url := "https://forms.example/apply"
+	blank := "  "
+	bad := "not a url"
+	past := now.Add(-time.Minute)
+	ctc := 12.0
+
+	valid := func() CreateJobRequest {
+		return CreateJobRequest{
+			Company:             "Acme",
+			Title:               "SDE",
+			JobType:             models.JobFTE,
+			Domain:              models.DomainBackend,
+			EligibleBatches:     []int{2026},
+			CTC:                 &ctc,
+			RegistrationFormURL: &url,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*CreateJobRequest)
+		want   string
+	}{
+		{"valid", func(*CreateJobRequest) {}, ""},
+		{"blank company", func(r *CreateJobRequest) { r.Company = " " }, "company is required"},
+		{"bad job type", func(r *CreateJobRequest) { r.JobType = "PART_TIME" }, "invalid job_type"},
+		{"bad domain", func(r *CreateJobRequest) { r.Domain = "SALES" }, "invalid domain"},
+		{"no batches", func(r *CreateJobRequest) { r.EligibleBatches = nil }, "eligible_batches required"},
+		{"no pay", func(r *CreateJobRequest) { r.CTC = nil }, "ctc or stipend required"},
+		{"no form", func(r *CreateJobRequest) { r.RegistrationFormURL = nil }, "registration_form_url is required"},
+		{"blank form", func(r *CreateJobRequest) { r.RegistrationFormURL = &blank }, "registration_form_url cannot be empty"},
+		{"bad form", func(r *CreateJobRequest) { r.RegistrationFormURL = &bad }, "registration_form_url must be a valid URL"},
+		{"past deadline", func(r *CreateJobRequest) { r.RegistrationDeadline = &past }, "registration_deadline must be in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			req := valid()
+			tt.mutate(&req)
+
+			job, err := svc.Create(context.Background(), collegeAdmin(1, 10), req)
+
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if job.CollegeID != 10 || !job.IsActive || len(repo.created) != 1 {
+					t.Fatalf("job = %+v", job)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) || verr.Error() != tt.want {
+				t.Fatalf("err = %v, want validation error %q", err, tt.want)
+			}
+			if len(repo.created) != 0 {
+				t.Fatal("invalid job was stored")
+			}
+		})
+	}
+}
+
+func TestSendSavedSearchAlerts(t *testing.T) {
+	svc, repo, notifier := newTestService()
+	repo.pending = savedSearchMatchBatch + 1
+	jobs := []AlertJob{{JobID: 2, Title: "SDE"}, {JobID: 1, Title: "SRE"}}
+	repo.alerts = []SavedSearchAlert{
+		{SearchID: 7, StudentID: 21, Name: "backend", Frequency: models.AlertInstant, Jobs: jobs},
+		{SearchID: 8, StudentID: 22, Name: "fte", Frequency: models.AlertDaily, Jobs: jobs},
+	}
+
+	if err := svc.SendSavedSearchAlerts(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if repo.pending != 0 || repo.matchCalls != 2 {
+		t.Fatalf("%d jobs left in %d batches", repo.pending, repo.matchCalls)
+	}
+	if !repo.digestDue.Equal(now.Add(-savedSearchDigestPeriod)) {
+		t.Fatalf("digests due before %v", repo.digestDue)
+	}
+	want := []sentNotification{
+		{21, models.NotificationSavedSearchMatch},
+		{21, models.NotificationSavedSearchMatch},
+		{22, models.NotificationSavedSearchDigest},
+	}
+	if !slices.Equal(notifier.sent, want) {
+		t.Fatalf("notifications = %+v, want %+v", notifier.sent, want)
+	}
+
+	// claimed matches are not sent again
+	if err := svc.SendSavedSearchAlerts(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.sent) != len(want) {
+		t.Fatalf("notifications = %+v after a second run", notifier.sent)
+	}
+}
+
+func TestSavedSearchRules(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	var verr *ValidationError
+
+	noCollege := &authorization.AuthContext{UserID: 5, Role: string(models.Student)}
+	if _, err := svc.CreateSavedSearch(ctx, noCollege, SavedSearchRequest{Name: "any"}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("without a college: err = %v", err)
+	}
+
+	bad := SavedSearchRequest{Name: "bad", Filters: JobFilter{JobType: "GIG"}}
+	if _, err := svc.CreateSavedSearch(ctx, student(5, 10), bad); !errors.As(err, &verr) {
+		t.Fatalf("invalid filters: err = %v, want validation error", err)
+	}
+
+	search, err := svc.CreateSavedSearch(ctx, student(5, 10), SavedSearchRequest{Name: " backend "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if search.Name != "backend" || search.CollegeID != 10 || search.Frequency != models.AlertInstant || !search.NotifyOnMatch {
+		t.Fatalf("saved %+v", search)
+	}
+
+	// another student's search does not exist for them
+	name := "mine now"
+	if err := svc.UpdateSavedSearch(ctx, student(6, 10), search.ID, UpdateSavedSearchRequest{Name: &name}); !errors.Is(err, ErrSavedSearchNotFound) {
+		t.Fatalf("foreign update: err = %v", err)
+	}
+	if err := svc.DeleteSavedSearch(ctx, student(6, 10), search.ID); !errors.Is(err, ErrSavedSearchNotFound) {
+		t.Fatalf("foreign delete: err = %v", err)
+	}
+
+	for i := len(repo.searches); i < maxSavedSearchesPerStudent; i++ {
+		if _, err := svc.CreateSavedSearch(ctx, student(5, 10), SavedSearchRequest{Name: "more"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := svc.CreateSavedSearch(ctx, student(5, 10), SavedSearchRequest{Name: "one too many"}); !errors.Is(err, ErrSavedSearchLimit) {
+		t.Fatalf("over the limit: err = %v", err)
+	}
+}
+
+func TestMutationsAreCollegeScoped(t *testing.T) {
+	title := "Renamed"
+
+	tests := []struct {
+		name string
+		auth *authorization.AuthContext
+		want error
+	}{
+		{"own college admin", collegeAdmin(1, 10), nil},
+		{"other college admin", collegeAdmin(2, 11), ErrForbidden},
+		{"student", student(3, 10), ErrForbidden},
+		{"admin without college", &authorization.AuthContext{UserID: 4, Role: string(models.Admin)}, ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			repo.jobs[1] = openJob(1, 10, "[2026]")
+
+			if err := svc.Update(context.Background(), tt.auth, 1, UpdateJobRequest{Title: &title}); !errors.Is(err, tt.want) {
+				t.Fatalf("update err = %v, want %v", err, tt.want)
+			}
+			if err := svc.Delete(context.Background(), tt.auth, 1); !errors.Is(err, tt.want) {
+				t.Fatalf("delete err = %v, want %v", err, tt.want)
+			}
+
+			if tt.want == nil && (repo.updates["title"] != title || len(repo.deactivated) != 1) {
+				t.Fatalf("updates = %v, deactivated = %v", repo.updates, repo.deactivated)
+			}
+			if tt.want != nil && (repo.updates != nil || len(repo.deactivated) != 0) {
+				t.Fatal("forbidden mutation reached the repository")
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	svc, repo, _ := newTestService()
+	repo.jobs[1] = openJob(1, 10, "[2026]")
+
+	err := svc.Update(context.Background(), collegeAdmin(1, 10), 1, UpdateJobRequest{})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("empty update: err = %v, want validation error", err)
+	}
+
+	if err := svc.Update(context.Background(), collegeAdmin(1, 10), 99, UpdateJobRequest{}); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("missing job: err = %v", err)
+	}
+
+	deadline := now.Add(48 * time.Hour)
+	if err := svc.Update(context.Background(), collegeAdmin(1, 10), 1, UpdateJobRequest{RegistrationDeadline: &deadline}); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.resets) != 1 || repo.resets[0] != 1 {
+		t.Fatalf("moving the deadline should reset bookmark reminders, got %v", repo.resets)
+	}
+
+	past := now.Add(-time.Hour)
+	err = svc.Update(context.Background(), collegeAdmin(1, 10), 1, UpdateJobRequest{RegistrationDeadline: &past})
+	if !errors.As(err, &verr) {
+		t.Fatalf("past deadline: err = %v, want validation error", err)
+	}
+
+	// a closed job has nothing to remind about
+	closed := openJob(2, 10, "[2026]")
+	closed.IsActive = false
+	repo.jobs[2] = closed
+	if err := svc.Update(context.Background(), collegeAdmin(1, 10), 2, UpdateJobRequest{RegistrationDeadline: &deadline}); err != nil {
+		t.Fatal(err)
+	}
+	inactive := false
+	if err := svc.Update(context.Background(), collegeAdmin(1, 10), 1, UpdateJobRequest{RegistrationDeadline: &deadline, IsActive: &inactive}); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.resets) != 1 {
+		t.Fatalf("closed jobs reset reminders: %v", repo.resets)
+	}
+}
+
+func TestListNormalizesPaging(t *testing.T) {
+	svc, _, _ := newTestService()
+
+	_, q, err := svc.List(context.Background(), student(5, 10), ListQuery{
+		Params: pagination.Params{Page: -1, Limit: 500},
+		Sort:   "newest",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Page != 1 || q.Limit != 10 || q.Sort != "latest" || *q.CollegeID != 10 || q.StudentID != 5 {
+		t.Fatalf("query = %+v", q)
+	}
+}
+
+func TestListRejectsForeignCursors(t *testing.T) {
+	svc, _, _ := newTestService()
+
+	for name, cursor := range map[string]string{
+		"garbage":    "not-a-cursor",
+		"other sort": pagination.NewCursor("latest", now, 3).Encode(),
+		"bad value":  pagination.NewCursor("ctc_desc", "lots", 3).Encode(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := svc.List(context.Background(), student(5, 10), ListQuery{
+				Params: pagination.Params{Cursor: &cursor},
+				Sort:   "ctc_desc",
+			})
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("err = %v, want a validation error", err)
+			}
+		})
+	}
+}
+
+func TestBookmarks(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	repo.jobs[1] = openJob(1, 10, "[2026]")
+
+	if err := svc.Bookmark(ctx, student(5, 11), 1); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("another college's job: err = %v", err)
+	}
+	noCollege := &authorization.AuthContext{UserID: 5, Role: string(models.Student)}
+	if err := svc.Bookmark(ctx, noCollege, 1); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("without a college: err = %v", err)
+	}
+
+	if err := svc.Bookmark(ctx, student(5, 10), 1); err != nil {
+		t.Fatal(err)
+	}
+	page, q, err := svc.Bookmarks(ctx, student(5, 10), ListQuery{Params: pagination.Params{Limit: 500}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Limit != 10 || len(page.Items) != 1 || page.Items[0].ID != 1 {
+		t.Fatalf("limit %d, bookmarks %+v", q.Limit, page.Items)
+	}
+
+	foreign := pagination.NewCursor("latest", now, 1).Encode()
+	_, _, err = svc.Bookmarks(ctx, student(5, 10), ListQuery{Params: pagination.Params{Cursor: &foreign}})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("listing cursor: err = %v, want validation error", err)
+	}
+
+	if err := svc.Unbookmark(ctx, student(5, 10), 1); err != nil {
+		t.Fatal(err)
+	}
+	if page, _, _ := svc.Bookmarks(ctx, student(5, 10), ListQuery{}); len(page.Items) != 0 {
+		t.Fatalf("bookmarks after removal: %+v", page.Items)
+	}
+}
+
+func TestSendBookmarkReminders(t *testing.T) {
+	svc, repo, notifier := newTestService()
+	closing := func(id uint, deadline time.Time) {
+		job := openJob(id, 10, "[2026]")
+		job.RegistrationDeadline = &deadline
+		repo.jobs[id] = job
+	}
+	closing(1, now.Add(time.Hour))
+	closing(2, now.Add(bookmarkReminderWindow+time.Hour))
+	repo.bookmarked[[2]uint{1, 5}] = true
+	repo.bookmarked[[2]uint{1, 6}] = true
+	repo.applied[[2]uint{1, 6}] = true
+	repo.bookmarked[[2]uint{2, 5}] = true
+
+	for range 2 {
+		if err := svc.SendBookmarkReminders(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []sentNotification{{5, models.NotificationBookmarkReminder}}
+	if !slices.Equal(notifier.sent, want) {
+		t.Fatalf("notifications = %+v, want %+v", notifier.sent, want)
+	}
+}
+
+func TestDeactivationNotifiesBookmarkers(t *testing.T) {
+	inactive, title := false, "Renamed"
+
+	tests := []struct {
+		name       string
+		active     bool
+		deactivate func(*Service) error
+		want       int
+	}{
+		{"delete", true, func(s *Service) error {
+			return s.Delete(context.Background(), collegeAdmin(1, 10), 1)
+		}, 1},
+		{"delete an inactive job", false, func(s *Service) error {
+			return s.Delete(context.Background(), collegeAdmin(1, 10), 1)
+		}, 0},
+		{"update is_active", true, func(s *Service) error {
+			return s.Update(context.Background(), collegeAdmin(1, 10), 1, UpdateJobRequest{IsActive: &inactive})
+		}, 1},
+		{"other update", true, func(s *Service) error {
+			return s.Update(context.Background(), collegeAdmin(1, 10), 1, UpdateJobRequest{Title: &title})
+		}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, notifier := newTestService()
+			job := openJob(1, 10, "[2026]")
+			job.IsActive = tt.active
+			repo.jobs[1] = job
+			repo.bookmarked[[2]uint{1, 5}] = true
+			repo.bookmarked[[2]uint{1, 6}] = true
+			repo.applied[[2]uint{1, 6}] = true
+
+			if err := tt.deactivate(svc); err != nil {
+				t.Fatal(err)
+			}
+			if len(notifier.enqueued) != tt.want {
+				t.Fatalf("notifications = %+v, want %d", notifier.enqueued, tt.want)
+			}
+			if tt.want > 0 && notifier.enqueued[0] != (sentNotification{5, models.NotificationBookmarkClosed}) {
+				t.Fatalf("notified %+v", notifier.enqueued[0])
+			}
+		})
+	}
+}
+
+func TestDeactivationNotifiesPastAFailure(t *testing.T) {
+	svc, repo, notifier := newTestService()
+	repo.jobs[1] = openJob(1, 10, "[2026]")
+	repo.bookmarked[[2]uint{1, 5}] = true
+	repo.bookmarked[[2]uint{1, 7}] = true
+	notifier.failFor = map[uint]bool{5: true}
+
+	if err := svc.Delete(context.Background(), collegeAdmin(1, 10), 1); err != nil {
+		t.Fatal(err)
+	}
+	if want := []sentNotification{{7, models.NotificationBookmarkClosed}}; !slices.Equal(notifier.enqueued, want) {
+		t.Fatalf("notifications = %+v, want %+v", notifier.enqueued, want)
+	}
+}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"iiitn-career-portal/internal/pagination"
+	"time"
+)
+
+// jobSorts are the listing orders by ?sort= value. Jobs without a CTC or
+// stipend sort last in both directions.
+var jobSorts = map[string]pagination.Keyset{
+	"latest":       {Column: "jobs.created_at", IDColumn: "jobs.id", Desc: true},
+	"ctc_asc":      {Column: "jobs.ctc", IDColumn: "jobs.id", Nullable: true},
+	"ctc_desc":     {Column: "jobs.ctc", IDColumn: "jobs.id", Desc: true, Nullable: true},
+	"stipend_asc":  {Column: "jobs.stipend", IDColumn: "jobs.id", Nullable: true},
+	"stipend_desc": {Column: "jobs.stipend", IDColumn: "jobs.id", Desc: true, Nullable: true},
+}
+
+// jobSortValue is the value of the sort column for item; nil for NULL.
+func jobSortValue(sort string, item JobListItem) any {
+	var v *float64
+	switch sort {
+	case "ctc_asc", "ctc_desc":
+		v = item.CTC
+	case "stipend_asc", "stipend_desc":
+		v = item.Stipend
+	default:
+		return item.CreatedAt
+	}
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// jobCursorValue decodes the sort value stored in a cursor.
+func jobCursorValue(sort string, c pagination.Cursor) (any, error) {
+	if sort == "latest" {
+		var t time.Time
+		if c.IsNull() {
+			return nil, pagination.ErrInvalidCursor
+		}
+		if err := c.Scan(&t); err != nil {
+			return nil, err
+		}
+		return t, nil
+	}
+
+	if c.IsNull() {
+		return nil, nil
+	}
+	var f float64
+	if err := c.Scan(&f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -1,41 +1,272 @@
 package keycloak
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"iiitn-career-portal/internal/config"
+
+	"github.com/coreos/go-oidc"
 )
 
-func getAdminToken(cfg config.Config) (string, error) {
+// refresh the admin token this long before Keycloak says it expires
+const adminTokenLeeway = 30 * time.Second
+
+// Client is a long-lived Keycloak client. It discovers the realm once and
+// keeps the verifier (go-oidc caches the JWKS and refetches on unknown
+// key ids) and the client-credentials admin token between calls.
+// Create one at startup and share it.
+type Client struct {
+	baseURL      string
+	realm        string
+	clientID     string
+	clientSecret string
+
+	http *http.Client
+
+	verifierMu sync.Mutex
+	verifier   *oidc.IDTokenVerifier
+
+	tokenMu       sync.Mutex
+	adminToken    string
+	adminTokenExp time.Time
+	now           func() time.Time
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default client (10s timeout), e.g. to point
+// at an httptest server.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+func New(cfg config.Config, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(cfg.BaseURL, "/"),
+		realm:        cfg.Realm,
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		http: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.http = instrument(c.http)
+	return c
+}
+
+// Ping fetches the realm's discovery document.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, c.realmURL()+"/.well-known/openid-configuration", nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("discovery: status=%d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *Client) realmURL() string {
+	return c.baseURL + "/realms/" + c.realm
+}
+
+func (c *Client) adminURL() string {
+	return c.baseURL + "/admin/realms/" + c.realm
+}
+
+func (c *Client) tokenURL() string {
+	return c.realmURL() + "/protocol/openid-connect/token"
+}
+
+// AuthURL is the browser-facing authorization endpoint for SSO.
+func (c *Client) AuthURL(redirectURI string) string {
+	q := url.Values{}
+	q.Set("client_id", c.clientID)
+	q.Set("response_type", "code")
+	q.Set("scope", "openid email profile")
+	q.Set("redirect_uri", redirectURI)
+
+	return c.realmURL() + "/protocol/openid-connect/auth?" + q.Encode()
+}
+
+// getVerifier discovers the realm on first use. A failed discovery is not
+// cached, so the next call retries.
+func (c *Client) getVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	c.verifierMu.Lock()
+	defer c.verifierMu.Unlock()
+
+	if c.verifier != nil {
+		return c.verifier, nil
+	}
+
+	provider, err := oidc.NewProvider(
+		oidc.ClientContext(ctx, c.http),
+		c.realmURL(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The key set outlives this request, so it must not inherit ctx's
+	// deadline; it still goes through our http client.
+	keySet := oidc.NewRemoteKeySet(
+		oidc.ClientContext(context.Background(), c.http),
+		c.jwksURL(provider),
+	)
+
+	// strict: signature, expiry, issuer, audience == client id
+	c.verifier = oidc.NewVerifier(c.realmURL(), keySet, &oidc.Config{
+		ClientID: c.clientID,
+	})
+
+	return c.verifier, nil
+}
+
+func (c *Client) jwksURL(provider *oidc.Provider) string {
+	var meta struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := provider.Claims(&meta); err != nil || meta.JWKSURI == "" {
+		return c.realmURL() + "/protocol/openid-connect/certs"
+	}
+	return meta.JWKSURI
+}
+
+func (c *Client) getAdminToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.adminToken != "" && c.now().Before(c.adminTokenExp) {
+		return c.adminToken, nil
+	}
+
 	data := url.Values{}
 	data.Set("grant_type", "client_credentials")
-	data.Set("client_id", cfg.ClientID)
-	data.Set("client_secret", cfg.ClientSecret)
+	data.Set("client_id", c.clientID)
+	data.Set("client_secret", c.clientSecret)
+
+	token, err := c.postToken(ctx, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to get keycloak admin token: %w", err)
+	}
 
-	tokenURL := cfg.BaseURL +
-		"/realms/" + cfg.Realm +
-		"/protocol/openid-connect/token"
+	c.adminToken = token.AccessToken
+	c.adminTokenExp = c.now().
+		Add(time.Duration(token.ExpiresIn)*time.Second - adminTokenLeeway)
 
-	resp, err := http.PostForm(tokenURL, data)
+	return c.adminToken, nil
+}
+
+// forgetAdminToken drops a token Keycloak rejected (e.g. revoked early).
+func (c *Client) forgetAdminToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.adminToken == token {
+		c.adminToken = ""
+	}
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+var errTokenRejected = errors.New("token request rejected")
+
+func (c *Client) postToken(ctx context.Context, data url.Values) (*TokenResponse, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		c.tokenURL(),
+		strings.NewReader(data.Encode()),
+	)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	defer resp.Body.Close()
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	if resp.StatusCode != 200 {
-		return "", errors.New("failed to get keycloak admin token")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
 
-	var res struct {
-		AccessToken string `json:"access_token"`
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status=%d body=%s", errTokenRejected, resp.StatusCode, body)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return "", err
+	var token TokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, err
 	}
 
-	return res.AccessToken, nil
+	return &token, nil
+}
+
+// adminDo sends an authenticated admin API request, retrying once with a
+// fresh token if the cached one is rejected.
+func (c *Client) adminDo(ctx context.Context, method, path string, body []byte) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := c.getAdminToken(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.adminURL()+path, reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			c.forgetAdminToken(token)
+			continue
+		}
+
+		return resp, respBody, nil
+	}
 }
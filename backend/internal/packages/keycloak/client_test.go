@@ -0,0 +1,118 @@
+package keycloak_test
+
+import (
+	"context"
+	"errors"
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/packages/keycloak"
+	"iiitn-career-portal/internal/testutil/fakekeycloak"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newClient(t *testing.T) (*keycloak.Client, *fakekeycloak.Server) {
+	t.Helper()
+
+	kc := fakekeycloak.Start(t)
+	cfg := config.Config{
+		BaseURL:      kc.URL,
+		Realm:        fakekeycloak.Realm,
+		ClientID:     fakekeycloak.ClientID,
+		ClientSecret: fakekeycloak.ClientSecret,
+	}
+
+	return keycloak.New(cfg, keycloak.WithHTTPClient(kc.Client())), kc
+}
+
+func TestVerifierIsDiscoveredOnce(t *testing.T) {
+	client, kc := newClient(t)
+	ctx := context.Background()
+	kc.AddUser("asha@iiitn.ac.in", "pw", "Asha", "student")
+
+	for i := 0; i < 3; i++ {
+		tok, err := client.PasswordGrant(ctx, "asha@iiitn.ac.in", "pw")
+		if err != nil {
+			t.Fatalf("password grant: %v", err)
+		}
+		claims, err := client.VerifyAccessToken(ctx, tok.AccessToken)
+		if err != nil {
+			t.Fatalf("verify: %v", err)
+		}
+		if claims.Email != "asha@iiitn.ac.in" {
+			t.Fatalf("email claim = %q", claims.Email)
+		}
+	}
+
+	if n := kc.Hits("discovery"); n != 1 {
+		t.Fatalf("discovery requests = %d, want 1", n)
+	}
+	if n := kc.Hits("certs"); n != 1 {
+		t.Fatalf("jwks requests = %d, want 1", n)
+	}
+}
+
+func TestVerifyRejectsBadTokens(t *testing.T) {
+	client, kc := newClient(t)
+	ctx := context.Background()
+
+	cases := map[string]jwt.MapClaims{
+		"expired": {
+			"iss": kc.Issuer(), "aud": fakekeycloak.ClientID, "sub": "u1",
+			"exp": time.Now().Add(-time.Minute).Unix(),
+		},
+		"wrong audience": {
+			"iss": kc.Issuer(), "aud": "someone-else", "sub": "u1",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		},
+		"wrong issuer": {
+			"iss": "https://evil.example/realms/portal", "aud": fakekeycloak.ClientID, "sub": "u1",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		},
+	}
+
+	for name, claims := range cases {
+		if _, err := client.VerifyAccessToken(ctx, kc.SignToken(claims)); !errors.Is(err, keycloak.ErrInvalidToken) {
+			t.Errorf("%s: err = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
+
+func TestAdminTokenIsReused(t *testing.T) {
+	client, kc := newClient(t)
+	ctx := context.Background()
+
+	for _, email := range []string{"a@iiitn.ac.in", "b@iiitn.ac.in"} {
+		id, err := client.CreateUser(ctx, email, "password1", "Test")
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		if err := client.AssignRealmRole(ctx, id, "student"); err != nil {
+			t.Fatalf("assign role: %v", err)
+		}
+	}
+
+	if n := kc.Hits("token:client_credentials"); n != 1 {
+		t.Fatalf("admin token requests = %d, want 1", n)
+	}
+
+	// a revoked token is replaced transparently
+	kc.RevokeAdminTokens()
+	if _, err := client.CreateUser(ctx, "c@iiitn.ac.in", "password1", "Test"); err != nil {
+		t.Fatalf("create user after revocation: %v", err)
+	}
+	if n := kc.Hits("token:client_credentials"); n != 2 {
+		t.Fatalf("admin token requests = %d, want 2", n)
+	}
+}
+
+func TestPasswordGrantInvalidCredentials(t *testing.T) {
+	client, kc := newClient(t)
+	kc.AddUser("asha@iiitn.ac.in", "pw", "Asha", "student")
+
+	_, err := client.PasswordGrant(context.Background(), "asha@iiitn.ac.in", "nope")
+	if !errors.Is(err, keycloak.ErrInvalidCredentials) {
+		t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+	}
+}
@@ -0,0 +1,39 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+)
+
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+func (c *Client) PasswordGrant(ctx context.Context, email, password string) (*TokenResponse, error) {
+	data := url.Values{}
+	data.Set("grant_type", "password")
+	data.Set("client_id", c.clientID)
+	data.Set("client_secret", c.clientSecret)
+	data.Set("username", email)
+	data.Set("password", password)
+
+	token, err := c.postToken(ctx, data)
+	if errors.Is(err, errTokenRejected) {
+		slog.InfoContext(ctx, "keycloak: password grant rejected", "error", err)
+		return nil, ErrInvalidCredentials
+	}
+	return token, err
+}
+
+// ExchangeCode trades an SSO authorization code for tokens. redirectURI
+// must match the one sent to AuthURL.
+func (c *Client) ExchangeCode(ctx context.Context, code, redirectURI string) (*TokenResponse, error) {
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("client_id", c.clientID)
+	data.Set("client_secret", c.clientSecret)
+	data.Set("redirect_uri", redirectURI)
+
+	return c.postToken(ctx, data)
+}
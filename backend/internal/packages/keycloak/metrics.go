@@ -0,0 +1,53 @@
+package keycloak
+
+import (
+	"iiitn-career-portal/internal/metrics"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// instrument returns a copy of hc whose calls are timed per operation.
+// The caller's client is left untouched.
+func instrument(hc *http.Client) *http.Client {
+	next := hc.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	out := *hc
+	out.Transport = timedTransport{next: next}
+	return &out
+}
+
+type timedTransport struct {
+	next http.RoundTripper
+}
+
+func (t timedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+	metrics.ObserveKeycloak(operationOf(req), time.Since(start), failed)
+	return resp, err
+}
+
+// operationOf keeps label cardinality fixed: ids never reach the label.
+func operationOf(req *http.Request) string {
+	p := req.URL.Path
+	switch {
+	case strings.Contains(p, "/.well-known/"):
+		return "discovery"
+	case strings.HasSuffix(p, "/certs"):
+		return "jwks"
+	case strings.HasSuffix(p, "/token"):
+		return "token"
+	case strings.HasSuffix(p, "/userinfo"):
+		return "userinfo"
+	case strings.Contains(p, "/admin/realms/"):
+		return "admin_" + strings.ToLower(req.Method)
+	default:
+		return "other"
+	}
+}
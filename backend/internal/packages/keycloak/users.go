@@ -1,22 +1,16 @@
 package keycloak
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
-
-	"iiitn-career-portal/internal/config"
 )
 
-func CreateUser(cfg config.Config, email, password, name string) (string, error) {
-	token, err := getAdminToken(cfg)
-	if err != nil {
-		return "", err
-	}
-
+func (c *Client) CreateUser(ctx context.Context, email, password, name string) (string, error) {
 	payload := map[string]interface{}{
 		"username":        email,
 		"email":           email,
@@ -35,30 +29,16 @@ func CreateUser(cfg config.Config, email, password, name string) (string, error)
 
 	body, _ := json.Marshal(payload)
 
-	req, _ := http.NewRequest(
-		"POST",
-		cfg.BaseURL+"/admin/realms/"+cfg.Realm+"/users",
-		bytes.NewBuffer(body),
-	)
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
+	resp, respBody, err := c.adminDo(ctx, http.MethodPost, "/users", body)
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
 
-	// if resp.StatusCode != 201 {
-	// 	return "", errors.New("failed to create keycloak user")
-	// }
-	if resp.StatusCode != 201 {
-		body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
 		return "", fmt.Errorf(
 			"keycloak user creation failed: status=%d body=%s",
 			resp.StatusCode,
-			string(body),
+			string(respBody),
 		)
 	}
 
@@ -67,18 +47,14 @@ func CreateUser(cfg config.Config, email, password, name string) (string, error)
 	return parts[len(parts)-1], nil
 }
 
-func DeleteUser(cfg config.Config, userID string) {
-	token, err := getAdminToken(cfg)
+// DeleteUser is best effort; it is used to undo a half-finished signup.
+func (c *Client) DeleteUser(ctx context.Context, userID string) {
+	resp, _, err := c.adminDo(ctx, http.MethodDelete, "/users/"+url.PathEscape(userID), nil)
 	if err != nil {
+		slog.WarnContext(ctx, "keycloak: delete user failed", "keycloak_user_id", userID, "error", err)
 		return
 	}
-
-	req, _ := http.NewRequest(
-		"DELETE",
-		cfg.BaseURL+"/admin/realms/"+cfg.Realm+"/users/"+userID,
-		nil,
-	)
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	http.DefaultClient.Do(req)
+	if resp.StatusCode != http.StatusNoContent {
+		slog.WarnContext(ctx, "keycloak: delete user failed", "keycloak_user_id", userID, "status", resp.StatusCode)
+	}
 }
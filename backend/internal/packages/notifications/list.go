@@ -0,0 +1,56 @@
+package notifications
+
+import (
+	"encoding/json"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/pagination"
+	"time"
+)
+
+// the only order: newest first
+const listSort = "latest"
+
+var listKeyset = pagination.Keyset{
+	Column:   "notifications.created_at",
+	IDColumn: "notifications.id",
+	Desc:     true,
+}
+
+type ListQuery struct {
+	pagination.Params
+
+	Unread bool `form:"unread"`
+
+	// decoded Params.Cursor; nil on the first page
+	After *pagination.Cursor `form:"-"`
+}
+
+// Item is a stored notification as the API returns it; the same shape
+// as the real-time message on the user's Redis list.
+type Item struct {
+	ID        uint                    `json:"id"`
+	Type      models.NotificationType `json:"type"`
+	TargetID  uint                    `json:"target_id"`
+	Payload   json.RawMessage         `json:"payload"`
+	IsRead    bool                    `json:"is_read"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+func itemOf(n models.Notification) Item {
+	return Item{
+		ID:        n.ID,
+		Type:      n.Type,
+		TargetID:  n.TargetID,
+		Payload:   json.RawMessage(n.Payload),
+		IsRead:    n.IsRead,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+// Page is one page of notifications. Total is nil when not counted;
+// NextCursor is empty on the last keyset page.
+type Page struct {
+	Items      []Item
+	Total      *int64
+	NextCursor string
+}
@@ -0,0 +1,37 @@
+package notifications
+
+import (
+	"errors"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/pagination"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ListNotifications(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var q ListQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+			return
+		}
+
+		page, q, err := svc.List(c.Request.Context(), auth.UserID, q)
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch notifications"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": page.Items,
+			"meta": pagination.Meta(q.Params, page.Total, page.NextCursor),
+		})
+	}
+}
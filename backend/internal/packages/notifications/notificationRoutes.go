@@ -0,0 +1,13 @@
+package notifications
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB, rdb *redis.Client) {
+	svc := New(db, rdb)
+
+	rg.GET("/notifications", ListNotifications(svc))
+}
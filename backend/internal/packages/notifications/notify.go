@@ -0,0 +1,36 @@
+package notifications
+
+import (
+	"context"
+	"iiitn-career-portal/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Push stores the notification and mirrors it onto the user's Redis list.
+// db may be a transaction. The Redis push is best-effort.
+func Push(
+	db *gorm.DB,
+	rdb *redis.Client,
+	userID uint,
+	notifType models.NotificationType,
+	targetID uint,
+	payload gin.H,
+) error {
+	return New(db, rdb).Push(context.Background(), userID, notifType, targetID, payload)
+}
+
+// PushToCollegeAdmins fans a notification out to every college admin of
+// the given college.
+func PushToCollegeAdmins(
+	db *gorm.DB,
+	rdb *redis.Client,
+	collegeID uint,
+	notifType models.NotificationType,
+	targetID uint,
+	payload gin.H,
+) error {
+	return New(db, rdb).PushToCollegeAdmins(context.Background(), collegeID, notifType, targetID, payload)
+}
@@ -0,0 +1,111 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/pagination"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) Create(ctx context.Context, n *models.Notification) error {
+	return r.db.WithContext(ctx).Create(n).Error
+}
+
+func (r *gormRepository) CollegeAdminIDs(ctx context.Context, collegeID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("college_id = ?", collegeID).
+		Where("role = ?", models.CollegeAdmin).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *gormRepository) List(ctx context.Context, userID uint, q ListQuery) (Page, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("user_id = ?", userID)
+	if q.Unread {
+		query = query.Where("is_read = ?", false)
+	}
+
+	var page Page
+	if q.WithTotal() {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return Page{}, err
+		}
+		page.Total = &total
+	}
+
+	query = query.Order(listKeyset.OrderBy())
+
+	if !q.Keyset() {
+		query = query.Limit(q.Limit).Offset(q.Offset())
+	} else {
+		if q.After != nil {
+			var after time.Time
+			if err := q.After.Scan(&after); err != nil {
+				return Page{}, err
+			}
+			cond, args := listKeyset.After(after, q.After.ID)
+			query = query.Where(cond, args...)
+		}
+		// one extra row tells whether there is a next page
+		query = query.Limit(q.Limit + 1)
+	}
+
+	var rows []models.Notification
+	if err := query.Find(&rows).Error; err != nil {
+		return Page{}, err
+	}
+
+	if q.Keyset() && len(rows) > q.Limit {
+		rows = rows[:q.Limit]
+		last := rows[len(rows)-1]
+		page.NextCursor = pagination.NewCursor(listSort, last.CreatedAt, last.ID).Encode()
+	}
+
+	page.Items = make([]Item, len(rows))
+	for i, n := range rows {
+		page.Items[i] = itemOf(n)
+	}
+
+	return page, nil
+}
+
+type redisQueue struct {
+	rdb *redis.Client
+}
+
+// NewRedisQueue pushes onto Redis lists. A nil client drops messages,
+// which keeps notifications working (DB only) when Redis is absent.
+func NewRedisQueue(rdb *redis.Client) Queue {
+	return &redisQueue{rdb: rdb}
+}
+
+var errNoRedis = errors.New("redis not configured")
+
+func (q *redisQueue) LPush(ctx context.Context, key string, msg []byte) error {
+	if q.rdb == nil {
+		return errNoRedis
+	}
+	return q.rdb.LPush(ctx, key, msg).Err()
+}
+
+// New wires the service to Postgres and Redis.
+func New(db *gorm.DB, rdb *redis.Client) *Service {
+	return NewService(NewGormRepository(db), NewRedisQueue(rdb))
+}
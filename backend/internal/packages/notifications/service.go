@@ -0,0 +1,140 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/pagination"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Repository stores notifications and resolves recipients.
+type Repository interface {
+	Create(ctx context.Context, n *models.Notification) error
+	CollegeAdminIDs(ctx context.Context, collegeID uint) ([]uint, error)
+	// List returns the user's notifications, newest first.
+	List(ctx context.Context, userID uint, q ListQuery) (Page, error)
+}
+
+// Queue is the real-time side channel (a Redis list per user, plus the
+// shared worker queue).
+type Queue interface {
+	LPush(ctx context.Context, key string, msg []byte) error
+}
+
+// WorkerQueueKey is the Redis list Enqueue feeds; exported for metrics.
+const WorkerQueueKey = "notifications:queue"
+
+type Service struct {
+	repo  Repository
+	queue Queue
+	now   func() time.Time
+}
+
+func NewService(repo Repository, queue Queue) *Service {
+	return &Service{repo: repo, queue: queue, now: time.Now}
+}
+
+// Push stores the notification and mirrors it onto the user's list. The
+// queue push is best-effort: the stored row is the source of truth.
+func (s *Service) Push(
+	ctx context.Context,
+	userID uint,
+	notifType models.NotificationType,
+	targetID uint,
+	payload gin.H,
+) error {
+	payloadJSON, _ := json.Marshal(payload)
+
+	if err := s.repo.Create(ctx, &models.Notification{
+		UserID:   userID,
+		Type:     notifType,
+		TargetID: targetID,
+		Payload:  payloadJSON,
+	}); err != nil {
+		return err
+	}
+
+	msg, _ := json.Marshal(gin.H{
+		"type":       notifType,
+		"target_id":  targetID,
+		"payload":    json.RawMessage(payloadJSON),
+		"is_read":    false,
+		"created_at": s.now(),
+	})
+
+	_ = s.queue.LPush(ctx, userKey(userID), msg)
+
+	return nil
+}
+
+// PushToCollegeAdmins fans a notification out to every college admin of
+// the given college.
+func (s *Service) PushToCollegeAdmins(
+	ctx context.Context,
+	collegeID uint,
+	notifType models.NotificationType,
+	targetID uint,
+	payload gin.H,
+) error {
+	adminIDs, err := s.repo.CollegeAdminIDs(ctx, collegeID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range adminIDs {
+		if err := s.Push(ctx, id, notifType, targetID, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Enqueue hands a notification to the background worker instead of
+// storing it directly.
+func (s *Service) Enqueue(
+	ctx context.Context,
+	userID uint,
+	notifType models.NotificationType,
+	targetID uint,
+	payload gin.H,
+) error {
+	payloadJSON, _ := json.Marshal(payload)
+
+	msg, _ := json.Marshal(gin.H{
+		"user_id":    userID,
+		"type":       notifType,
+		"target_id":  targetID,
+		"payload":    json.RawMessage(payloadJSON),
+		"created_at": s.now(),
+	})
+
+	return s.queue.LPush(ctx, WorkerQueueKey, msg)
+}
+
+// List returns one page of the user's notifications.
+func (s *Service) List(ctx context.Context, userID uint, q ListQuery) (Page, ListQuery, error) {
+	q.Normalize(20, 100)
+
+	if q.Keyset() {
+		after, err := pagination.Decode(*q.Cursor, listSort)
+		if err == nil && after != nil {
+			err = after.Scan(new(time.Time))
+		}
+		if err != nil {
+			return Page{}, q, pagination.ErrInvalidCursor
+		}
+		q.After = after
+	}
+
+	page, err := s.repo.List(ctx, userID, q)
+	return page, q, err
+}
+
+func userKey(userID uint) string {
+	return fmt.Sprintf("notifications:user:%d", userID)
+}
@@ -0,0 +1,137 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeRepo struct {
+	created []models.Notification
+	admins  map[uint][]uint
+	err     error
+}
+
+func (r *fakeRepo) Create(_ context.Context, n *models.Notification) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.created = append(r.created, *n)
+	return nil
+}
+
+func (r *fakeRepo) List(context.Context, uint, ListQuery) (Page, error) {
+	return Page{}, nil
+}
+
+func (r *fakeRepo) CollegeAdminIDs(_ context.Context, collegeID uint) ([]uint, error) {
+	return r.admins[collegeID], nil
+}
+
+type pushed struct {
+	key string
+	msg map[string]interface{}
+}
+
+type fakeQueue struct {
+	pushed []pushed
+	err    error
+}
+
+func (q *fakeQueue) LPush(_ context.Context, key string, msg []byte) error {
+	if q.err != nil {
+		return q.err
+	}
+	var m map[string]interface{}
+	_ = json.Unmarshal(msg, &m)
+	q.pushed = append(q.pushed, pushed{key, m})
+	return nil
+}
+
+func newTestService() (*Service, *fakeRepo, *fakeQueue) {
+	repo := &fakeRepo{admins: map[uint][]uint{}}
+	queue := &fakeQueue{}
+	svc := NewService(repo, queue)
+	svc.now = func() time.Time { return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC) }
+	return svc, repo, queue
+}
+
+func TestPushStoresAndMirrors(t *testing.T) {
+	svc, repo, queue := newTestService()
+
+	err := svc.Push(context.Background(), 7, models.NotificationJobApplied, 3, gin.H{"job_id": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.created) != 1 || repo.created[0].UserID != 7 || repo.created[0].TargetID != 3 {
+		t.Fatalf("stored = %+v", repo.created)
+	}
+	if string(repo.created[0].Payload) != `{"job_id":3}` {
+		t.Fatalf("payload = %s", repo.created[0].Payload)
+	}
+	if len(queue.pushed) != 1 || queue.pushed[0].key != "notifications:user:7" {
+		t.Fatalf("pushed = %+v", queue.pushed)
+	}
+	if queue.pushed[0].msg["type"] != string(models.NotificationJobApplied) || queue.pushed[0].msg["is_read"] != false {
+		t.Fatalf("message = %v", queue.pushed[0].msg)
+	}
+}
+
+func TestPushToleratesQueueFailure(t *testing.T) {
+	svc, repo, queue := newTestService()
+	queue.err = errors.New("redis down")
+
+	if err := svc.Push(context.Background(), 7, models.NotificationJobApplied, 3, nil); err != nil {
+		t.Fatalf("queue failure should be ignored, got %v", err)
+	}
+	if len(repo.created) != 1 {
+		t.Fatal("notification not stored")
+	}
+}
+
+func TestPushFailsWhenStoreFails(t *testing.T) {
+	svc, repo, queue := newTestService()
+	repo.err = errors.New("db down")
+
+	if err := svc.Push(context.Background(), 7, models.NotificationJobApplied, 3, nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(queue.pushed) != 0 {
+		t.Fatal("unstored notification was pushed")
+	}
+}
+
+func TestPushToCollegeAdmins(t *testing.T) {
+	svc, repo, _ := newTestService()
+	repo.admins[10] = []uint{1, 2}
+	repo.admins[11] = []uint{3}
+
+	if err := svc.PushToCollegeAdmins(context.Background(), 10, models.NotificationInterviewRescheduleRequest, 9, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.created) != 2 || repo.created[0].UserID != 1 || repo.created[1].UserID != 2 {
+		t.Fatalf("stored = %+v", repo.created)
+	}
+}
+
+func TestEnqueueGoesToWorkerQueue(t *testing.T) {
+	svc, repo, queue := newTestService()
+
+	if err := svc.Enqueue(context.Background(), 7, models.NotificationApplicationStatus, 3, gin.H{"new_status": "SHORTLISTED"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.created) != 0 {
+		t.Fatal("enqueue must not store directly")
+	}
+	if len(queue.pushed) != 1 || queue.pushed[0].key != WorkerQueueKey || queue.pushed[0].msg["user_id"] != float64(7) {
+		t.Fatalf("pushed = %+v", queue.pushed)
+	}
+}
@@ -4,25 +4,51 @@ import (
 	"bufio"
 	"encoding/binary"
 	"errors"
+	"iiitn-career-portal/internal/config"
 	"io"
+	"log/slog"
 	"net"
 	"strings"
 	"time"
 )
 
-func scanForVirus(r io.Reader) error {
-	const (
-		clamdAddr = "localhost:3310"
-		timeout   = 30 * time.Second
-	)
+const (
+	defaultClamdAddr   = "localhost:3310"
+	defaultScanTimeout = 30 * time.Second
+)
+
+// newScanner builds the clamd scanner from config. A disabled scanner
+// accepts everything; config validation refuses that in production.
+func newScanner(cfg config.ScannerConfig) Scanner {
+	if !cfg.Enabled {
+		slog.Warn("virus scanning disabled: resumes are stored unscanned")
+		return scannerFunc(func(io.Reader) error { return nil })
+	}
+
+	s := clamdScanner{addr: cfg.Addr, timeout: cfg.Timeout}
+	if s.addr == "" {
+		s.addr = defaultClamdAddr
+	}
+	if s.timeout <= 0 {
+		s.timeout = defaultScanTimeout
+	}
+	return s
+}
+
+// clamdScanner streams the file to clamd with INSTREAM.
+type clamdScanner struct {
+	addr    string
+	timeout time.Duration
+}
 
-	conn, err := net.DialTimeout("tcp", clamdAddr, timeout)
+func (s clamdScanner) Scan(r io.Reader) error {
+	conn, err := net.DialTimeout("tcp", s.addr, s.timeout)
 	if err != nil {
 		return errors.New("virus scanner unavailable")
 	}
 	defer conn.Close()
 
-	_ = conn.SetDeadline(time.Now().Add(timeout))
+	_ = conn.SetDeadline(time.Now().Add(s.timeout))
 
 	writer := bufio.NewWriter(conn)
 
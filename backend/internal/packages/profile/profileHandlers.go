@@ -1,17 +1,12 @@
 package profile
 
 import (
-	"context"
-	"fmt"
-	"iiitn-career-portal/internal/config"
-	"iiitn-career-portal/internal/models"
+	"errors"
 	"iiitn-career-portal/internal/packages/authorization"
+	"mime/multipart"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
-	"gorm.io/gorm"
 )
 
 type UpdateProfileRequest struct {
@@ -19,49 +14,89 @@ type UpdateProfileRequest struct {
 	Batch      *int     `json:"batch"`
 	CGPA       *float32 `json:"cgpa"`
 	LinkedinID *string  `json:"linkedin_id"`
+
+	Branch            *string            `json:"branch"`
+	RollNumber        *string            `json:"roll_number"`
+	TenthPercentage   *float32           `json:"tenth_percentage"`
+	TwelfthPercentage *float32           `json:"twelfth_percentage"`
+	Skills            *[]string          `json:"skills"`
+	CodingProfiles    *map[string]string `json:"coding_profiles"`
+
+	ShareContactWithRecruiters *bool `json:"share_contact_with_recruiters"`
+	HideCGPAFromRecruiters     *bool `json:"hide_cgpa_from_recruiters"`
 }
 
-func GetProfile(db *gorm.DB) gin.HandlerFunc {
+func GetProfile(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		// 1️⃣ Fetch user
-		var user models.User
-		if err := db.
-			Select("id, name, email, role, college_id").
-			Where("id = ?", auth.UserID).
-			First(&user).Error; err != nil {
-			c.JSON(404, gin.H{"error": "user not found"})
+		view, err := svc.Get(c.Request.Context(), auth)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch profile")
 			return
 		}
 
-		// 2️⃣ Fetch student profile (optional)
-		var profile models.StudentProfile
-		err := db.Where("user_id = ?", auth.UserID).First(&profile).Error
+		profileResp := gin.H{
+			"batch":            nil,
+			"cgpa":             nil,
+			"resume_url":       nil,
+			"linkedin_id":      nil,
+			"profile_complete": false,
 
-		var profileResp gin.H
-		if err == gorm.ErrRecordNotFound {
-			profileResp = gin.H{
-				"batch":            nil,
-				"cgpa":             nil,
-				"resume_url":       nil,
-				"linkedin_id":      nil,
-				"profile_complete": false,
-			}
-		} else if err != nil {
-			c.JSON(500, gin.H{"error": "failed to fetch profile"})
-			return
-		} else {
+			"branch":             nil,
+			"roll_number":        nil,
+			"tenth_percentage":   nil,
+			"twelfth_percentage": nil,
+			"skills":             []string{},
+			"coding_profiles":    map[string]string{},
+
+			"share_contact_with_recruiters": false,
+			"hide_cgpa_from_recruiters":     false,
+		}
+		if profile := view.Profile; profile != nil {
 			profileResp = gin.H{
 				"batch":            profile.Batch,
 				"cgpa":             profile.CGPA,
 				"resume_url":       profile.ResumeURL,
 				"linkedin_id":      profile.LinkedinID,
 				"profile_complete": profile.ProfileComplete,
+
+				"branch":             profile.Branch,
+				"roll_number":        profile.RollNumber,
+				"tenth_percentage":   profile.TenthPercentage,
+				"twelfth_percentage": profile.TwelfthPercentage,
+				"skills":             skillsOf(*profile),
+				"coding_profiles":    codingProfilesOf(*profile),
+
+				"share_contact_with_recruiters": profile.ShareContactWithRecruiters,
+				"hide_cgpa_from_recruiters":     profile.HideCGPAFromRecruiters,
 			}
 		}
 
-		// 3️⃣ Respond
+		projects := make([]ProjectResponse, len(view.Sections.Projects))
+		for i, p := range view.Sections.Projects {
+			projects[i] = projectResponse(p)
+		}
+		internships := make([]InternshipResponse, len(view.Sections.Internships))
+		for i, in := range view.Sections.Internships {
+			internships[i] = internshipResponse(in)
+		}
+		certifications := make([]CertificationResponse, len(view.Sections.Certifications))
+		for i, cert := range view.Sections.Certifications {
+			certifications[i] = certificationResponse(cert)
+		}
+		resumes := make([]ResumeResponse, len(view.Resumes))
+		for i, r := range view.Resumes {
+			resumes[i] = resumeResponse(r)
+		}
+		profileResp["resumes"] = resumes
+		profileResp["projects"] = projects
+		profileResp["internships"] = internships
+		profileResp["certifications"] = certifications
+		profileResp["required_fields"] = view.RequiredFields
+		profileResp["missing_fields"] = view.MissingFields
+
+		user := view.User
 		c.JSON(200, gin.H{
 			"id":         user.ID,
 			"name":       user.Name,
@@ -73,84 +108,18 @@ func GetProfile(db *gorm.DB) gin.HandlerFunc {
 	}
 }
 
-func UpdateProfile(db *gorm.DB) gin.HandlerFunc {
+func UpdateProfile(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		// 🔒 Only students can update student profile
-		if auth.Role != string(models.Student) {
-			c.JSON(403, gin.H{"error": "profile not applicable for this role"})
-			return
-		}
-
 		var req UpdateProfileRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(400, gin.H{"error": "invalid request"})
 			return
 		}
 
-		// Validate inputs
-		if req.CGPA != nil && (*req.CGPA < 0 || *req.CGPA > 10) {
-			c.JSON(400, gin.H{"error": "invalid cgpa"})
-			return
-		}
-		if req.Batch != nil && *req.Batch < 2000 {
-			c.JSON(400, gin.H{"error": "invalid batch"})
-			return
-		}
-
-		tx := db.Begin()
-
-		// 1️⃣ Update user name
-		if req.Name != nil {
-			if err := tx.
-				Model(&models.User{}).
-				Where("id = ?", auth.UserID).
-				Update("name", *req.Name).Error; err != nil {
-				tx.Rollback()
-				c.JSON(500, gin.H{"error": "failed to update name"})
-				return
-			}
-		}
-
-		// 2️⃣ Upsert student profile
-		var profile models.StudentProfile
-		err := tx.Where("user_id = ?", auth.UserID).
-			First(&profile).Error
-
-		if err == gorm.ErrRecordNotFound {
-			profile = models.StudentProfile{
-				UserID: auth.UserID,
-			}
-		} else if err != nil {
-			tx.Rollback()
-			c.JSON(500, gin.H{"error": "failed to load profile"})
-			return
-		}
-
-		if req.Batch != nil {
-			profile.Batch = *req.Batch
-		}
-		if req.CGPA != nil {
-			profile.CGPA = req.CGPA
-		}
-		if req.LinkedinID != nil {
-			profile.LinkedinID = *req.LinkedinID
-		}
-
-		// 3️⃣ Compute profile completeness
-		profile.ProfileComplete =
-			profile.Batch != 0 &&
-				profile.ResumeURL != nil
-
-		if err := tx.Save(&profile).Error; err != nil {
-			tx.Rollback()
-			c.JSON(500, gin.H{"error": "failed to update profile"})
-			return
-		}
-
-		if err := tx.Commit().Error; err != nil {
-			c.JSON(500, gin.H{"error": "transaction failed"})
+		if err := svc.Update(c.Request.Context(), auth, req); err != nil {
+			writeServiceError(c, err, "failed to update profile")
 			return
 		}
 
@@ -158,117 +127,90 @@ func UpdateProfile(db *gorm.DB) gin.HandlerFunc {
 	}
 }
 
-func uploadResume(db *gorm.DB, cfg config.Config) gin.HandlerFunc {
+const multipartSlack = 64 << 10
+
+func uploadResume(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.MustGet("auth").(*authorization.AuthContext)
 
-		file, err := c.FormFile("resume")
-		if err != nil {
-			c.JSON(400, gin.H{"error": "resume file required"})
-			return
-		}
-
-		// size limit: 2MB
-		if file.Size > 2*1024*1024 {
-			c.JSON(400, gin.H{"error": "resume too large"})
+		file, size, ok := resumeFile(c, svc)
+		if !ok {
 			return
 		}
+		defer file.Close()
 
-		f, err := file.Open()
+		resumeURL, err := svc.UploadResume(c.Request.Context(), auth, file, size)
 		if err != nil {
-			c.JSON(500, gin.H{"error": "failed to open file"})
-			return
-		}
-		defer f.Close()
-
-		// detect MIME
-		header := make([]byte, 512)
-		_, _ = f.Read(header)
-		contentType := http.DetectContentType(header)
-
-		if contentType != "application/pdf" {
-			c.JSON(400, gin.H{"error": "only PDF resumes allowed"})
-			return
-		}
-
-		_, _ = f.Seek(0, 0)
-
-		// virus scan hook
-		if err := scanForVirus(f); err != nil {
-			c.JSON(400, gin.H{"error": "virus detected"})
+			writeServiceError(c, err, "failed to save resume")
 			return
 		}
 
-		_, _ = f.Seek(0, 0)
-
-		// MinIO client
-		minioClient, err := minio.New(cfg.MinioEndpoint, &minio.Options{
-			Creds:  credentials.NewStaticV4(cfg.MinioAccessKey, cfg.MinioSecretKey, ""),
-			Secure: cfg.MinioUseSSL,
+		c.JSON(200, gin.H{
+			"message":    "resume uploaded successfully",
+			"resume_url": resumeURL,
 		})
-		if err != nil {
-			c.JSON(500, gin.H{"error": "storage unavailable"})
-			return
-		}
-
-		objectPath := fmt.Sprintf(
-			"resumes/%d/%d/resume.pdf",
-			*auth.CollegeID,
-			auth.UserID,
-		)
-
-		// upload (overwrite-safe)
-		_, err = minioClient.PutObject(
-			context.Background(),
-			cfg.MinioBucket,
-			objectPath,
-			f,
-			file.Size,
-			minio.PutObjectOptions{
-				ContentType: "application/pdf",
-			},
-		)
-		if err != nil {
-			c.JSON(500, gin.H{"error": "upload failed"})
-			return
-		}
-
-		resumeURL := fmt.Sprintf(
-			"%s/%s/%s",
-			cfg.MinioPublicURL,
-			cfg.MinioBucket,
-			objectPath,
-		)
+	}
+}
 
-		// DB transaction
-		tx := db.Begin()
+// resumeFile opens the "resume" part of a multipart upload. It answers
+// the request itself when there is none.
+func resumeFile(c *gin.Context, svc *Service) (multipart.File, int64, bool) {
+	// cap the whole body so oversized uploads are cut off while
+	// streaming instead of being spooled to disk first; the slack
+	// covers the multipart framing
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, svc.MaxResumeSize()+multipartSlack)
+
+	header, err := c.FormFile("resume")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(400, gin.H{"error": ErrResumeTooLarge.Error()})
+			return nil, 0, false
+		}
+		c.JSON(400, gin.H{"error": "resume file required"})
+		return nil, 0, false
+	}
 
-		if err := tx.Model(&models.StudentProfile{}).
-			Where("user_id = ?", auth.UserID).
-			Updates(map[string]interface{}{
-				"resume_url":       resumeURL,
-				"profile_complete": true,
-			}).Error; err != nil {
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(500, gin.H{"error": "failed to open file"})
+		return nil, 0, false
+	}
+	return file, header.Size, true
+}
 
-			tx.Rollback()
+var serviceErrorStatus = map[error]int{
+	ErrUserNotFound:     http.StatusNotFound,
+	ErrNotStudent:       http.StatusForbidden,
+	ErrInvalidCGPA:      http.StatusBadRequest,
+	ErrInvalidBatch:     http.StatusBadRequest,
+	ErrResumeTooLarge:   http.StatusBadRequest,
+	ErrResumeNotPDF:     http.StatusBadRequest,
+	ErrVirusDetected:    http.StatusBadRequest,
+	ErrStorageFailed:    http.StatusInternalServerError,
+	ErrNoCollegeContext: http.StatusForbidden,
+	ErrInvalidScore:     http.StatusBadRequest,
+	ErrRollNumberTaken:  http.StatusConflict,
+	ErrSectionNotFound:  http.StatusNotFound,
+	ErrTooManyEntries:   http.StatusConflict,
+	ErrResumeNotFound:   http.StatusNotFound,
+	ErrResumeNameTaken:  http.StatusConflict,
+	ErrTooManyResumes:   http.StatusConflict,
+}
 
-			// cleanup MinIO on DB failure
-			_ = minioClient.RemoveObject(
-				context.Background(),
-				cfg.MinioBucket,
-				objectPath,
-				minio.RemoveObjectOptions{},
-			)
+func writeServiceError(c *gin.Context, err error, fallback string) {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": verr.Error()})
+		return
+	}
 
-			c.JSON(500, gin.H{"error": "failed to save resume"})
+	for target, status := range serviceErrorStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": target.Error()})
 			return
 		}
-
-		tx.Commit()
-
-		c.JSON(200, gin.H{
-			"message":    "resume uploaded successfully",
-			"resume_url": resumeURL,
-		})
 	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
 }
@@ -10,11 +10,50 @@ import (
 )
 
 func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB, cfg config.Config) {
+	svc := NewService(
+		NewGormRepository(db),
+		NewMinioStorage(cfg),
+		newScanner(cfg.Scanner),
+		WithMaxResumeSize(cfg.Upload.MaxResumeBytes),
+		WithMaxResumes(cfg.Upload.MaxResumes),
+	)
+
 	profile := rg.Group("/profile")
-	profile.Use(authorization.RequireRole(string(models.Student)))
+
+	// Required fields: set by the college admin, readable by its students
+	profile.GET(
+		"/requirements",
+		authorization.RequireRole(string(models.Student), string(models.CollegeAdmin)),
+		GetRequirements(svc),
+	)
+	profile.PUT(
+		"/requirements",
+		authorization.RequireRole(string(models.CollegeAdmin)),
+		SetRequirements(svc),
+	)
+
+	// Student only
+	own := profile.Group("", authorization.RequireRole(string(models.Student)))
 	{
-		profile.GET("", GetProfile(db))
-		profile.PATCH("", UpdateProfile(db))
-		profile.POST("/resume", uploadResume(db, cfg))
+		own.GET("", GetProfile(svc))
+		own.PATCH("", UpdateProfile(svc))
+		own.POST("/resume", uploadResume(svc))
+
+		own.GET("/resumes", ListResumes(svc))
+		own.POST("/resumes", AddResume(svc))
+		own.PATCH("/resumes/:id", UpdateResume(svc))
+		own.DELETE("/resumes/:id", DeleteResume(svc))
+
+		own.POST("/projects", AddProject(svc))
+		own.PUT("/projects/:id", UpdateProject(svc))
+		own.DELETE("/projects/:id", DeleteProject(svc))
+
+		own.POST("/internships", AddInternship(svc))
+		own.PUT("/internships/:id", UpdateInternship(svc))
+		own.DELETE("/internships/:id", DeleteInternship(svc))
+
+		own.POST("/certifications", AddCertification(svc))
+		own.PUT("/certifications/:id", UpdateCertification(svc))
+		own.DELETE("/certifications/:id", DeleteCertification(svc))
 	}
 }
@@ -0,0 +1,358 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/audit"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) FindUser(ctx context.Context, userID uint) (models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).
+		Select("id, name, email, role, college_id").
+		Where("id = ?", userID).
+		First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return user, ErrUserNotFound
+	}
+	return user, err
+}
+
+func (r *gormRepository) FindProfile(ctx context.Context, userID uint) (*models.StudentProfile, error) {
+	var profile models.StudentProfile
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		First(&profile).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *gormRepository) SaveProfile(ctx context.Context, profile *models.StudentProfile, name *string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if name != nil {
+			if err := tx.
+				Model(&models.User{}).
+				Where("id = ?", profile.UserID).
+				Update("name", *name).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(profile).Error
+	})
+}
+
+func (r *gormRepository) RollNumberTaken(ctx context.Context, collegeID uint, rollNumber string, userID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.StudentProfile{}).
+		Joins("JOIN users ON users.id = student_profiles.user_id").
+		Where("users.college_id = ? AND LOWER(student_profiles.roll_number) = LOWER(?) AND student_profiles.user_id <> ?",
+			collegeID, rollNumber, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormRepository) Sections(ctx context.Context, userID uint) (Sections, error) {
+	var out Sections
+	db := r.db.WithContext(ctx)
+	if err := db.Where("user_id = ?", userID).Order("start_date DESC NULLS LAST, id").Find(&out.Projects).Error; err != nil {
+		return Sections{}, err
+	}
+	if err := db.Where("user_id = ?", userID).Order("start_date DESC NULLS LAST, id").Find(&out.Internships).Error; err != nil {
+		return Sections{}, err
+	}
+	if err := db.Where("user_id = ?", userID).Order("issued_on DESC NULLS LAST, id").Find(&out.Certifications).Error; err != nil {
+		return Sections{}, err
+	}
+	return out, nil
+}
+
+func (r *gormRepository) SectionCounts(ctx context.Context, userIDs []uint) (map[uint]SectionCounts, error) {
+	counts := make(map[uint]SectionCounts, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	type row struct {
+		UserID uint
+		N      int
+	}
+	count := func(model interface{}, set func(*SectionCounts, int)) error {
+		var rows []row
+		if err := r.db.WithContext(ctx).
+			Model(model).
+			Select("user_id, COUNT(*) AS n").
+			Where("user_id IN ?", userIDs).
+			Group("user_id").
+			Scan(&rows).Error; err != nil {
+			return err
+		}
+		for _, rw := range rows {
+			c := counts[rw.UserID]
+			set(&c, rw.N)
+			counts[rw.UserID] = c
+		}
+		return nil
+	}
+
+	if err := count(&models.StudentProject{}, func(c *SectionCounts, n int) { c.Projects = n }); err != nil {
+		return nil, err
+	}
+	if err := count(&models.StudentInternship{}, func(c *SectionCounts, n int) { c.Internships = n }); err != nil {
+		return nil, err
+	}
+	if err := count(&models.StudentCertification{}, func(c *SectionCounts, n int) { c.Certifications = n }); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *gormRepository) CreateProject(ctx context.Context, project *models.StudentProject) error {
+	return r.db.WithContext(ctx).Create(project).Error
+}
+
+func (r *gormRepository) UpdateProject(ctx context.Context, project models.StudentProject) error {
+	return updateOwned(ctx, r.db, &project, project.ID, project.UserID)
+}
+
+func (r *gormRepository) DeleteProject(ctx context.Context, id, userID uint) error {
+	return deleteOwned[models.StudentProject](ctx, r.db, id, userID)
+}
+
+func (r *gormRepository) CreateInternship(ctx context.Context, internship *models.StudentInternship) error {
+	return r.db.WithContext(ctx).Create(internship).Error
+}
+
+func (r *gormRepository) UpdateInternship(ctx context.Context, internship models.StudentInternship) error {
+	return updateOwned(ctx, r.db, &internship, internship.ID, internship.UserID)
+}
+
+func (r *gormRepository) DeleteInternship(ctx context.Context, id, userID uint) error {
+	return deleteOwned[models.StudentInternship](ctx, r.db, id, userID)
+}
+
+func (r *gormRepository) CreateCertification(ctx context.Context, cert *models.StudentCertification) error {
+	return r.db.WithContext(ctx).Create(cert).Error
+}
+
+func (r *gormRepository) UpdateCertification(ctx context.Context, cert models.StudentCertification) error {
+	return updateOwned(ctx, r.db, &cert, cert.ID, cert.UserID)
+}
+
+func (r *gormRepository) DeleteCertification(ctx context.Context, id, userID uint) error {
+	return deleteOwned[models.StudentCertification](ctx, r.db, id, userID)
+}
+
+// updateOwned overwrites every column of the student's entry but its
+// keys and creation time.
+func updateOwned[T any](ctx context.Context, db *gorm.DB, row *T, id, userID uint) error {
+	res := db.WithContext(ctx).
+		Model(row).
+		Where("id = ? AND user_id = ?", id, userID).
+		Select("*").
+		Omit("id", "user_id", "created_at").
+		Updates(row)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrSectionNotFound
+	}
+	return nil
+}
+
+func deleteOwned[T any](ctx context.Context, db *gorm.DB, id, userID uint) error {
+	res := db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(new(T))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrSectionNotFound
+	}
+	return nil
+}
+
+func (r *gormRepository) RequiredFields(ctx context.Context, collegeID uint) ([]byte, error) {
+	var college models.College
+	err := r.db.WithContext(ctx).
+		Select("id, profile_required_fields").
+		Where("id = ?", collegeID).
+		Take(&college).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return college.ProfileRequiredFields, err
+}
+
+func (r *gormRepository) SetRequiredFields(ctx context.Context, collegeID uint, before, after []string) error {
+	raw, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&models.College{}).
+			Where("id = ?", collegeID).
+			Update("profile_required_fields", datatypes.JSON(raw)).Error; err != nil {
+			return err
+		}
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "college.profile_requirements",
+			EntityType: "college",
+			EntityID:   collegeID,
+			CollegeID:  &collegeID,
+			Diff: audit.Diff(
+				map[string]interface{}{"required_fields": before},
+				map[string]interface{}{"required_fields": after},
+			),
+		})
+	})
+}
+
+func (r *gormRepository) CollegeProfiles(ctx context.Context, collegeID uint) ([]models.StudentProfile, error) {
+	var profiles []models.StudentProfile
+	err := r.db.WithContext(ctx).
+		Joins("JOIN users ON users.id = student_profiles.user_id").
+		Where("users.college_id = ?", collegeID).
+		Find(&profiles).Error
+	return profiles, err
+}
+
+func (r *gormRepository) SetComplete(ctx context.Context, userIDs []uint, complete bool) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.StudentProfile{}).
+		Where("user_id IN ?", userIDs).
+		Update("profile_complete", complete).Error
+}
+
+func (r *gormRepository) Resumes(ctx context.Context, userID uint) ([]models.StudentResume, error) {
+	var resumes []models.StudentResume
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at, id").
+		Find(&resumes).Error
+	return resumes, err
+}
+
+func (r *gormRepository) SaveResumes(ctx context.Context, userID uint, profile *models.StudentProfile, save []*models.StudentResume, remove []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(remove) > 0 {
+			if err := tx.
+				Where("id IN ? AND user_id = ?", remove, userID).
+				Delete(&models.StudentResume{}).Error; err != nil {
+				return err
+			}
+		}
+		for _, resume := range save {
+			if resume.UserID != userID {
+				return ErrResumeNotFound
+			}
+			if err := tx.Save(resume).Error; err != nil {
+				if errors.Is(err, gorm.ErrDuplicatedKey) {
+					return ErrResumeNameTaken
+				}
+				return err
+			}
+		}
+		if profile == nil {
+			return nil
+		}
+		return tx.Save(profile).Error
+	})
+}
+
+func (r *gormRepository) ResumeInUse(ctx context.Context, url string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Application{}).
+		Where("resume_snapshot_url = ?", url).
+		Count(&count).Error
+	return count > 0, err
+}
+
+type minioStorage struct {
+	cfg config.Config
+}
+
+// NewMinioStorage stores objects in the configured bucket. The client is
+// created per call, as before, so a MinIO outage only fails uploads.
+func NewMinioStorage(cfg config.Config) Storage {
+	return &minioStorage{cfg: cfg}
+}
+
+// PingStorage checks that MinIO answers and the resume bucket exists.
+func PingStorage(ctx context.Context, cfg config.Config) error {
+	client, err := (&minioStorage{cfg: cfg}).client()
+	if err != nil {
+		return err
+	}
+
+	ok, err := client.BucketExists(ctx, cfg.MinioBucket)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("bucket %q does not exist", cfg.MinioBucket)
+	}
+	return nil
+}
+
+func (m *minioStorage) client() (*minio.Client, error) {
+	return minio.New(m.cfg.MinioEndpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(m.cfg.MinioAccessKey, m.cfg.MinioSecretKey, ""),
+		Secure: m.cfg.MinioUseSSL,
+	})
+}
+
+func (m *minioStorage) Put(ctx context.Context, path string, r io.Reader, size int64, contentType string) (string, error) {
+	client, err := m.client()
+	if err != nil {
+		return "", err
+	}
+
+	// upload (overwrite-safe)
+	if _, err := client.PutObject(ctx, m.cfg.MinioBucket, path, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	}); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s/%s", m.cfg.MinioPublicURL, m.cfg.MinioBucket, path), nil
+}
+
+func (m *minioStorage) Remove(ctx context.Context, path string) error {
+	client, err := m.client()
+	if err != nil {
+		return err
+	}
+	return client.RemoveObject(ctx, m.cfg.MinioBucket, path, minio.RemoveObjectOptions{})
+}
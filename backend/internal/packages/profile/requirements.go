@@ -0,0 +1,159 @@
+package profile
+
+import (
+	"encoding/json"
+	"iiitn-career-portal/internal/models"
+)
+
+// Profile fields a college can require before its students may apply.
+const (
+	FieldBatch             = "batch"
+	FieldCGPA              = "cgpa"
+	FieldResume            = "resume"
+	FieldLinkedin          = "linkedin"
+	FieldBranch            = "branch"
+	FieldRollNumber        = "roll_number"
+	FieldTenthPercentage   = "tenth_percentage"
+	FieldTwelfthPercentage = "twelfth_percentage"
+	FieldSkills            = "skills"
+	FieldCodingProfiles    = "coding_profiles"
+	FieldProjects          = "projects"
+	FieldInternships       = "internships"
+	FieldCertifications    = "certifications"
+)
+
+// RequirableFields lists every field in the order they are reported.
+var RequirableFields = []string{
+	FieldBatch, FieldCGPA, FieldResume, FieldLinkedin, FieldBranch,
+	FieldRollNumber, FieldTenthPercentage, FieldTwelfthPercentage,
+	FieldSkills, FieldCodingProfiles, FieldProjects, FieldInternships,
+	FieldCertifications,
+}
+
+// colleges that never configured anything keep the original rule
+var defaultRequiredFields = []string{FieldBatch, FieldResume}
+
+// SectionCounts is how many entries of each section a student has.
+type SectionCounts struct {
+	Projects       int
+	Internships    int
+	Certifications int
+}
+
+type RequirementsRequest struct {
+	RequiredFields []string `json:"required_fields" binding:"required"`
+}
+
+type RequirementsResponse struct {
+	RequiredFields  []string `json:"required_fields"`
+	AvailableFields []string `json:"available_fields"`
+}
+
+// RequirementsUpdate also reports how the college's profiles came out
+// under the new rule.
+type RequirementsUpdate struct {
+	RequiredFields     []string `json:"required_fields"`
+	CompleteProfiles   int      `json:"complete_profiles"`
+	IncompleteProfiles int      `json:"incomplete_profiles"`
+}
+
+// requiredFieldsOf decodes a college's stored requirements; null means
+// the default.
+func requiredFieldsOf(raw []byte) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return append([]string(nil), defaultRequiredFields...), nil
+	}
+	var fields []string
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// normalizeRequired validates the fields and returns them deduplicated in
+// RequirableFields order.
+func normalizeRequired(fields []string) ([]string, error) {
+	want := map[string]bool{}
+	for _, f := range fields {
+		if !isRequirable(f) {
+			return nil, invalid("unknown profile field " + f)
+		}
+		want[f] = true
+	}
+
+	out := []string{}
+	for _, f := range RequirableFields {
+		if want[f] {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func isRequirable(field string) bool {
+	for _, f := range RequirableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// missingFields lists the required fields the profile leaves empty.
+func missingFields(p models.StudentProfile, counts SectionCounts, required []string) []string {
+	missing := []string{}
+	for _, f := range required {
+		if !hasField(p, counts, f) {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+func hasField(p models.StudentProfile, counts SectionCounts, field string) bool {
+	switch field {
+	case FieldBatch:
+		return p.Batch != 0
+	case FieldCGPA:
+		return p.CGPA != nil
+	case FieldResume:
+		return p.ResumeURL != nil
+	case FieldLinkedin:
+		return p.LinkedinID != ""
+	case FieldBranch:
+		return p.Branch != ""
+	case FieldRollNumber:
+		return p.RollNumber != ""
+	case FieldTenthPercentage:
+		return p.TenthPercentage != nil
+	case FieldTwelfthPercentage:
+		return p.TwelfthPercentage != nil
+	case FieldSkills:
+		return len(skillsOf(p)) > 0
+	case FieldCodingProfiles:
+		return len(codingProfilesOf(p)) > 0
+	case FieldProjects:
+		return counts.Projects > 0
+	case FieldInternships:
+		return counts.Internships > 0
+	case FieldCertifications:
+		return counts.Certifications > 0
+	}
+	return false
+}
+
+func skillsOf(p models.StudentProfile) []string {
+	skills := []string{}
+	if len(p.Skills) > 0 {
+		_ = json.Unmarshal(p.Skills, &skills)
+	}
+	return skills
+}
+
+func codingProfilesOf(p models.StudentProfile) map[string]string {
+	links := map[string]string{}
+	if len(p.CodingProfiles) > 0 {
+		_ = json.Unmarshal(p.CodingProfiles, &links)
+	}
+	return links
+}
@@ -0,0 +1,97 @@
+package profile
+
+import (
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ListResumes(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		resumes, err := svc.Resumes(c.Request.Context(), auth)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch resumes")
+			return
+		}
+
+		out := make([]ResumeResponse, len(resumes))
+		for i, r := range resumes {
+			out[i] = resumeResponse(r)
+		}
+		c.JSON(http.StatusOK, gin.H{"data": out})
+	}
+}
+
+// AddResume takes a multipart upload: the "resume" file, its "name" and
+// an optional "default" flag.
+func AddResume(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		file, size, ok := resumeFile(c, svc)
+		if !ok {
+			return
+		}
+		defer file.Close()
+
+		var req ResumeUpload
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		resume, err := svc.AddResume(c.Request.Context(), auth, req, file, size)
+		if err != nil {
+			writeServiceError(c, err, "failed to save resume")
+			return
+		}
+
+		c.JSON(http.StatusCreated, resumeResponse(resume))
+	}
+}
+
+func UpdateResume(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := entryID(c)
+		if !ok {
+			return
+		}
+
+		var req UpdateResumeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		resume, err := svc.UpdateResume(c.Request.Context(), auth, id, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to update resume")
+			return
+		}
+
+		c.JSON(http.StatusOK, resumeResponse(resume))
+	}
+}
+
+func DeleteResume(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := entryID(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.DeleteResume(c.Request.Context(), auth, id); err != nil {
+			writeServiceError(c, err, "failed to delete resume")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "resume deleted"})
+	}
+}
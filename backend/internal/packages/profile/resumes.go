@@ -0,0 +1,341 @@
+package profile
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// name given to a resume uploaded through the single-resume endpoint
+const legacyResumeName = "Resume"
+
+const maxResumeNameLength = 60
+
+// ResumeUpload names a new resume version; the file comes separately.
+type ResumeUpload struct {
+	Name    string `form:"name"`
+	Default bool   `form:"default"`
+}
+
+type UpdateResumeRequest struct {
+	Name      *string `json:"name"`
+	IsDefault *bool   `json:"is_default"`
+}
+
+type ResumeResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Size      int64     `json:"size"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func resumeResponse(r models.StudentResume) ResumeResponse {
+	return ResumeResponse{
+		ID:        r.ID,
+		Name:      r.Name,
+		URL:       r.URL,
+		Size:      r.Size,
+		IsDefault: r.IsDefault,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func (s *Service) Resumes(ctx context.Context, auth *authorization.AuthContext) ([]models.StudentResume, error) {
+	return s.repo.Resumes(ctx, auth.UserID)
+}
+
+// AddResume stores a new named resume. The first one, or one uploaded
+// with Default set, becomes the default the profile points at.
+func (s *Service) AddResume(ctx context.Context, auth *authorization.AuthContext, req ResumeUpload, file io.ReadSeeker, size int64) (models.StudentResume, error) {
+	name, err := text("name", req.Name, maxResumeNameLength, true)
+	if err != nil {
+		return models.StudentResume{}, err
+	}
+	resumes, err := s.repo.Resumes(ctx, auth.UserID)
+	if err != nil {
+		return models.StudentResume{}, err
+	}
+	if nameTaken(resumes, name, 0) {
+		return models.StudentResume{}, ErrResumeNameTaken
+	}
+	if len(resumes) >= s.maxResumes {
+		return models.StudentResume{}, ErrTooManyResumes
+	}
+
+	objectPath, resumeURL, err := s.storeResume(ctx, auth, file, size)
+	if err != nil {
+		return models.StudentResume{}, err
+	}
+
+	resume := models.StudentResume{
+		UserID:     auth.UserID,
+		Name:       name,
+		URL:        resumeURL,
+		ObjectPath: objectPath,
+		Size:       size,
+		IsDefault:  req.Default || len(resumes) == 0,
+	}
+	save := []*models.StudentResume{&resume}
+
+	var profile *models.StudentProfile
+	if resume.IsDefault {
+		if previous := defaultResume(resumes); previous != nil {
+			previous.IsDefault = false
+			save = append(save, previous)
+		}
+		profile, err = s.profileWithResume(ctx, auth, &resume.URL)
+	}
+	if err == nil {
+		err = s.repo.SaveResumes(ctx, auth.UserID, profile, save, nil)
+	}
+	if err != nil {
+		// cleanup storage on DB failure
+		_ = s.storage.Remove(context.WithoutCancel(ctx), objectPath)
+		return models.StudentResume{}, err
+	}
+
+	return resume, nil
+}
+
+// UploadResume replaces the file of the default resume, creating one
+// when the student has none, and points the profile at it. The stored
+// object is removed if the update fails.
+func (s *Service) UploadResume(ctx context.Context, auth *authorization.AuthContext, file io.ReadSeeker, size int64) (string, error) {
+	resumes, err := s.repo.Resumes(ctx, auth.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	objectPath, resumeURL, err := s.storeResume(ctx, auth, file, size)
+	if err != nil {
+		return "", err
+	}
+
+	resume := defaultResume(resumes)
+	var replaced models.StudentResume
+	if resume == nil {
+		resume = &models.StudentResume{UserID: auth.UserID, Name: legacyResumeName, IsDefault: true}
+	} else {
+		replaced = *resume
+	}
+	resume.URL = resumeURL
+	resume.ObjectPath = objectPath
+	resume.Size = size
+
+	profile, err := s.profileWithResume(ctx, auth, &resumeURL)
+	if err == nil {
+		err = s.repo.SaveResumes(ctx, auth.UserID, profile, []*models.StudentResume{resume}, nil)
+	}
+	if err != nil {
+		// cleanup storage on DB failure
+		_ = s.storage.Remove(context.WithoutCancel(ctx), objectPath)
+		return "", err
+	}
+
+	if replaced.ID != 0 {
+		s.discardResume(ctx, replaced)
+	}
+	return resumeURL, nil
+}
+
+// UpdateResume renames a resume or makes it the default. A default can
+// only be replaced, not unset.
+func (s *Service) UpdateResume(ctx context.Context, auth *authorization.AuthContext, id uint, req UpdateResumeRequest) (models.StudentResume, error) {
+	resumes, err := s.repo.Resumes(ctx, auth.UserID)
+	if err != nil {
+		return models.StudentResume{}, err
+	}
+	resume := findResume(resumes, id)
+	if resume == nil {
+		return models.StudentResume{}, ErrResumeNotFound
+	}
+	save := []*models.StudentResume{resume}
+
+	if req.Name != nil {
+		name, err := text("name", *req.Name, maxResumeNameLength, true)
+		if err != nil {
+			return models.StudentResume{}, err
+		}
+		if nameTaken(resumes, name, id) {
+			return models.StudentResume{}, ErrResumeNameTaken
+		}
+		resume.Name = name
+	}
+
+	var profile *models.StudentProfile
+	if req.IsDefault != nil {
+		if !*req.IsDefault {
+			return models.StudentResume{}, invalid("make another resume the default instead")
+		}
+		if !resume.IsDefault {
+			if previous := defaultResume(resumes); previous != nil {
+				previous.IsDefault = false
+				save = append(save, previous)
+			}
+			resume.IsDefault = true
+			if profile, err = s.profileWithResume(ctx, auth, &resume.URL); err != nil {
+				return models.StudentResume{}, err
+			}
+		}
+	}
+
+	if err := s.repo.SaveResumes(ctx, auth.UserID, profile, save, nil); err != nil {
+		return models.StudentResume{}, err
+	}
+	return *resume, nil
+}
+
+// DeleteResume drops a resume. Deleting the default promotes the newest
+// remaining one; the file stays while an application still uses it.
+func (s *Service) DeleteResume(ctx context.Context, auth *authorization.AuthContext, id uint) error {
+	resumes, err := s.repo.Resumes(ctx, auth.UserID)
+	if err != nil {
+		return err
+	}
+	resume := findResume(resumes, id)
+	if resume == nil {
+		return ErrResumeNotFound
+	}
+
+	var save []*models.StudentResume
+	var profile *models.StudentProfile
+	if resume.IsDefault {
+		var next *string
+		for i := len(resumes) - 1; i >= 0; i-- {
+			if resumes[i].ID != id {
+				resumes[i].IsDefault = true
+				save = append(save, &resumes[i])
+				next = &resumes[i].URL
+				break
+			}
+		}
+		if profile, err = s.profileWithResume(ctx, auth, next); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.SaveResumes(ctx, auth.UserID, profile, save, []uint{id}); err != nil {
+		return err
+	}
+	s.discardResume(ctx, *resume)
+	return nil
+}
+
+// storeResume validates, scans and stores a PDF under a fresh name and
+// returns its object path and URL.
+func (s *Service) storeResume(ctx context.Context, auth *authorization.AuthContext, file io.ReadSeeker, size int64) (string, string, error) {
+	if auth.CollegeID == nil {
+		return "", "", ErrNoCollegeContext
+	}
+	if size > s.maxResumeSize {
+		return "", "", ErrResumeTooLarge
+	}
+
+	// detect MIME
+	header := make([]byte, 512)
+	n, _ := io.ReadFull(file, header)
+	if http.DetectContentType(header[:n]) != "application/pdf" {
+		return "", "", ErrResumeNotPDF
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", "", err
+	}
+	if err := s.scanner.Scan(file); err != nil {
+		return "", "", ErrVirusDetected
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", "", err
+	}
+
+	// every version gets its own object, so applications keep the file
+	// they were submitted with
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return "", "", err
+	}
+	objectPath := fmt.Sprintf(
+		"resumes/%d/%d/%s.pdf",
+		*auth.CollegeID,
+		auth.UserID,
+		hex.EncodeToString(token),
+	)
+
+	resumeURL, err := s.storage.Put(ctx, objectPath, file, size, "application/pdf")
+	if err != nil {
+		return "", "", ErrStorageFailed
+	}
+	return objectPath, resumeURL, nil
+}
+
+// profileWithResume loads the profile, points it at url, the new
+// default, and re-evaluates its completeness.
+func (s *Service) profileWithResume(ctx context.Context, auth *authorization.AuthContext, url *string) (*models.StudentProfile, error) {
+	profile, err := s.loadOrNew(ctx, auth.UserID)
+	if err != nil {
+		return nil, err
+	}
+	profile.ResumeURL = url
+	if profile.ProfileComplete, err = s.isComplete(ctx, auth, *profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// discardResume removes a dropped resume's file unless an application
+// was submitted with it. Failures only leave an orphaned object.
+func (s *Service) discardResume(ctx context.Context, resume models.StudentResume) {
+	ctx = context.WithoutCancel(ctx)
+
+	inUse, err := s.repo.ResumeInUse(ctx, resume.URL)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check resume usage", "resume_id", resume.ID, "error", err)
+		return
+	}
+	if inUse {
+		return
+	}
+	if err := s.storage.Remove(ctx, resume.ObjectPath); err != nil {
+		slog.ErrorContext(ctx, "failed to remove resume file", "path", resume.ObjectPath, "error", err)
+	}
+}
+
+func defaultResume(resumes []models.StudentResume) *models.StudentResume {
+	for i := range resumes {
+		if resumes[i].IsDefault {
+			return &resumes[i]
+		}
+	}
+	return nil
+}
+
+func findResume(resumes []models.StudentResume, id uint) *models.StudentResume {
+	for i := range resumes {
+		if resumes[i].ID == id {
+			return &resumes[i]
+		}
+	}
+	return nil
+}
+
+// nameTaken compares case-insensitively, ignoring the resume except.
+func nameTaken(resumes []models.StudentResume, name string, except uint) bool {
+	for _, r := range resumes {
+		if r.ID != except && strings.EqualFold(r.Name, name) {
+			return true
+		}
+	}
+	return false
+}
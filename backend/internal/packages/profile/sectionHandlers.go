@@ -0,0 +1,242 @@
+package profile
+
+import (
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AddProject(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req ProjectRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		project, err := svc.AddProject(c.Request.Context(), auth, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to add project")
+			return
+		}
+
+		c.JSON(http.StatusCreated, project)
+	}
+}
+
+func UpdateProject(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := entryID(c)
+		if !ok {
+			return
+		}
+
+		var req ProjectRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		project, err := svc.UpdateProject(c.Request.Context(), auth, id, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to update project")
+			return
+		}
+
+		c.JSON(http.StatusOK, project)
+	}
+}
+
+func DeleteProject(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := entryID(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.DeleteProject(c.Request.Context(), auth, id); err != nil {
+			writeServiceError(c, err, "failed to delete project")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "project deleted"})
+	}
+}
+
+func AddInternship(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req InternshipRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		internship, err := svc.AddInternship(c.Request.Context(), auth, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to add internship")
+			return
+		}
+
+		c.JSON(http.StatusCreated, internship)
+	}
+}
+
+func UpdateInternship(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := entryID(c)
+		if !ok {
+			return
+		}
+
+		var req InternshipRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		internship, err := svc.UpdateInternship(c.Request.Context(), auth, id, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to update internship")
+			return
+		}
+
+		c.JSON(http.StatusOK, internship)
+	}
+}
+
+func DeleteInternship(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := entryID(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.DeleteInternship(c.Request.Context(), auth, id); err != nil {
+			writeServiceError(c, err, "failed to delete internship")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "internship deleted"})
+	}
+}
+
+func AddCertification(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req CertificationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		cert, err := svc.AddCertification(c.Request.Context(), auth, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to add certification")
+			return
+		}
+
+		c.JSON(http.StatusCreated, cert)
+	}
+}
+
+func UpdateCertification(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := entryID(c)
+		if !ok {
+			return
+		}
+
+		var req CertificationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		cert, err := svc.UpdateCertification(c.Request.Context(), auth, id, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to update certification")
+			return
+		}
+
+		c.JSON(http.StatusOK, cert)
+	}
+}
+
+func DeleteCertification(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := entryID(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.DeleteCertification(c.Request.Context(), auth, id); err != nil {
+			writeServiceError(c, err, "failed to delete certification")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "certification deleted"})
+	}
+}
+
+func GetRequirements(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		resp, err := svc.Requirements(c.Request.Context(), auth)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch requirements")
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func SetRequirements(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req RequirementsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		resp, err := svc.SetRequirements(audit.Context(c), auth, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to update requirements")
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func entryID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,355 @@
+package profile
+
+import (
+	"context"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// at most this many entries per section
+const maxSectionEntries = 20
+
+type ProjectRequest struct {
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description"`
+	URL         string     `json:"url"`
+	StartDate   *time.Time `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+}
+
+type InternshipRequest struct {
+	Company     string     `json:"company" binding:"required"`
+	Role        string     `json:"role" binding:"required"`
+	Description string     `json:"description"`
+	StartDate   *time.Time `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+}
+
+type CertificationRequest struct {
+	Name          string     `json:"name" binding:"required"`
+	Issuer        string     `json:"issuer"`
+	IssuedOn      *time.Time `json:"issued_on"`
+	CredentialURL string     `json:"credential_url"`
+}
+
+type ProjectResponse struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	URL         string     `json:"url"`
+	StartDate   *time.Time `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+}
+
+type InternshipResponse struct {
+	ID          uint       `json:"id"`
+	Company     string     `json:"company"`
+	Role        string     `json:"role"`
+	Description string     `json:"description"`
+	StartDate   *time.Time `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+}
+
+type CertificationResponse struct {
+	ID            uint       `json:"id"`
+	Name          string     `json:"name"`
+	Issuer        string     `json:"issuer"`
+	IssuedOn      *time.Time `json:"issued_on"`
+	CredentialURL string     `json:"credential_url"`
+}
+
+// Sections are a student's list-valued profile parts.
+type Sections struct {
+	Projects       []models.StudentProject
+	Internships    []models.StudentInternship
+	Certifications []models.StudentCertification
+}
+
+func (s Sections) counts() SectionCounts {
+	return SectionCounts{
+		Projects:       len(s.Projects),
+		Internships:    len(s.Internships),
+		Certifications: len(s.Certifications),
+	}
+}
+
+func projectResponse(p models.StudentProject) ProjectResponse {
+	return ProjectResponse{
+		ID:          p.ID,
+		Title:       p.Title,
+		Description: p.Description,
+		URL:         p.URL,
+		StartDate:   p.StartDate,
+		EndDate:     p.EndDate,
+	}
+}
+
+func internshipResponse(i models.StudentInternship) InternshipResponse {
+	return InternshipResponse{
+		ID:          i.ID,
+		Company:     i.Company,
+		Role:        i.Role,
+		Description: i.Description,
+		StartDate:   i.StartDate,
+		EndDate:     i.EndDate,
+	}
+}
+
+func certificationResponse(c models.StudentCertification) CertificationResponse {
+	return CertificationResponse{
+		ID:            c.ID,
+		Name:          c.Name,
+		Issuer:        c.Issuer,
+		IssuedOn:      c.IssuedOn,
+		CredentialURL: c.CredentialURL,
+	}
+}
+
+// -------- projects --------
+
+func (s *Service) AddProject(ctx context.Context, auth *authorization.AuthContext, req ProjectRequest) (ProjectResponse, error) {
+	project, err := projectOf(auth, req)
+	if err != nil {
+		return ProjectResponse{}, err
+	}
+	if err := s.checkRoom(ctx, auth, func(c SectionCounts) int { return c.Projects }); err != nil {
+		return ProjectResponse{}, err
+	}
+	if err := s.repo.CreateProject(ctx, &project); err != nil {
+		return ProjectResponse{}, err
+	}
+	return projectResponse(project), s.refreshComplete(ctx, auth)
+}
+
+func (s *Service) UpdateProject(ctx context.Context, auth *authorization.AuthContext, id uint, req ProjectRequest) (ProjectResponse, error) {
+	project, err := projectOf(auth, req)
+	if err != nil {
+		return ProjectResponse{}, err
+	}
+	project.ID = id
+	if err := s.repo.UpdateProject(ctx, project); err != nil {
+		return ProjectResponse{}, err
+	}
+	return projectResponse(project), nil
+}
+
+func (s *Service) DeleteProject(ctx context.Context, auth *authorization.AuthContext, id uint) error {
+	if auth.Role != string(models.Student) {
+		return ErrNotStudent
+	}
+	if err := s.repo.DeleteProject(ctx, id, auth.UserID); err != nil {
+		return err
+	}
+	return s.refreshComplete(ctx, auth)
+}
+
+func projectOf(auth *authorization.AuthContext, req ProjectRequest) (models.StudentProject, error) {
+	if auth.Role != string(models.Student) {
+		return models.StudentProject{}, ErrNotStudent
+	}
+	title, err := text("title", req.Title, 200, true)
+	if err != nil {
+		return models.StudentProject{}, err
+	}
+	description, err := text("description", req.Description, 2000, false)
+	if err != nil {
+		return models.StudentProject{}, err
+	}
+	link, err := link("url", req.URL)
+	if err != nil {
+		return models.StudentProject{}, err
+	}
+	if err := checkPeriod(req.StartDate, req.EndDate); err != nil {
+		return models.StudentProject{}, err
+	}
+
+	return models.StudentProject{
+		UserID:      auth.UserID,
+		Title:       title,
+		Description: description,
+		URL:         link,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+	}, nil
+}
+
+// -------- internships --------
+
+func (s *Service) AddInternship(ctx context.Context, auth *authorization.AuthContext, req InternshipRequest) (InternshipResponse, error) {
+	internship, err := internshipOf(auth, req)
+	if err != nil {
+		return InternshipResponse{}, err
+	}
+	if err := s.checkRoom(ctx, auth, func(c SectionCounts) int { return c.Internships }); err != nil {
+		return InternshipResponse{}, err
+	}
+	if err := s.repo.CreateInternship(ctx, &internship); err != nil {
+		return InternshipResponse{}, err
+	}
+	return internshipResponse(internship), s.refreshComplete(ctx, auth)
+}
+
+func (s *Service) UpdateInternship(ctx context.Context, auth *authorization.AuthContext, id uint, req InternshipRequest) (InternshipResponse, error) {
+	internship, err := internshipOf(auth, req)
+	if err != nil {
+		return InternshipResponse{}, err
+	}
+	internship.ID = id
+	if err := s.repo.UpdateInternship(ctx, internship); err != nil {
+		return InternshipResponse{}, err
+	}
+	return internshipResponse(internship), nil
+}
+
+func (s *Service) DeleteInternship(ctx context.Context, auth *authorization.AuthContext, id uint) error {
+	if auth.Role != string(models.Student) {
+		return ErrNotStudent
+	}
+	if err := s.repo.DeleteInternship(ctx, id, auth.UserID); err != nil {
+		return err
+	}
+	return s.refreshComplete(ctx, auth)
+}
+
+func internshipOf(auth *authorization.AuthContext, req InternshipRequest) (models.StudentInternship, error) {
+	if auth.Role != string(models.Student) {
+		return models.StudentInternship{}, ErrNotStudent
+	}
+	company, err := text("company", req.Company, 200, true)
+	if err != nil {
+		return models.StudentInternship{}, err
+	}
+	role, err := text("role", req.Role, 200, true)
+	if err != nil {
+		return models.StudentInternship{}, err
+	}
+	description, err := text("description", req.Description, 2000, false)
+	if err != nil {
+		return models.StudentInternship{}, err
+	}
+	if err := checkPeriod(req.StartDate, req.EndDate); err != nil {
+		return models.StudentInternship{}, err
+	}
+
+	return models.StudentInternship{
+		UserID:      auth.UserID,
+		Company:     company,
+		Role:        role,
+		Description: description,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+	}, nil
+}
+
+// -------- certifications --------
+
+func (s *Service) AddCertification(ctx context.Context, auth *authorization.AuthContext, req CertificationRequest) (CertificationResponse, error) {
+	cert, err := certificationOf(auth, req)
+	if err != nil {
+		return CertificationResponse{}, err
+	}
+	if err := s.checkRoom(ctx, auth, func(c SectionCounts) int { return c.Certifications }); err != nil {
+		return CertificationResponse{}, err
+	}
+	if err := s.repo.CreateCertification(ctx, &cert); err != nil {
+		return CertificationResponse{}, err
+	}
+	return certificationResponse(cert), s.refreshComplete(ctx, auth)
+}
+
+func (s *Service) UpdateCertification(ctx context.Context, auth *authorization.AuthContext, id uint, req CertificationRequest) (CertificationResponse, error) {
+	cert, err := certificationOf(auth, req)
+	if err != nil {
+		return CertificationResponse{}, err
+	}
+	cert.ID = id
+	if err := s.repo.UpdateCertification(ctx, cert); err != nil {
+		return CertificationResponse{}, err
+	}
+	return certificationResponse(cert), nil
+}
+
+func (s *Service) DeleteCertification(ctx context.Context, auth *authorization.AuthContext, id uint) error {
+	if auth.Role != string(models.Student) {
+		return ErrNotStudent
+	}
+	if err := s.repo.DeleteCertification(ctx, id, auth.UserID); err != nil {
+		return err
+	}
+	return s.refreshComplete(ctx, auth)
+}
+
+func certificationOf(auth *authorization.AuthContext, req CertificationRequest) (models.StudentCertification, error) {
+	if auth.Role != string(models.Student) {
+		return models.StudentCertification{}, ErrNotStudent
+	}
+	name, err := text("name", req.Name, 200, true)
+	if err != nil {
+		return models.StudentCertification{}, err
+	}
+	issuer, err := text("issuer", req.Issuer, 200, false)
+	if err != nil {
+		return models.StudentCertification{}, err
+	}
+	credential, err := link("credential_url", req.CredentialURL)
+	if err != nil {
+		return models.StudentCertification{}, err
+	}
+
+	return models.StudentCertification{
+		UserID:        auth.UserID,
+		Name:          name,
+		Issuer:        issuer,
+		IssuedOn:      req.IssuedOn,
+		CredentialURL: credential,
+	}, nil
+}
+
+// -------- shared rules --------
+
+// checkRoom refuses a new entry once the section holds maxSectionEntries.
+func (s *Service) checkRoom(ctx context.Context, auth *authorization.AuthContext, section func(SectionCounts) int) error {
+	counts, err := s.repo.SectionCounts(ctx, []uint{auth.UserID})
+	if err != nil {
+		return err
+	}
+	if section(counts[auth.UserID]) >= maxSectionEntries {
+		return ErrTooManyEntries
+	}
+	return nil
+}
+
+// text trims the value and enforces its length.
+func text(field, value string, max int, required bool) (string, error) {
+	value = strings.TrimSpace(value)
+	if required && value == "" {
+		return "", invalid(field + " is required")
+	}
+	if len(value) > max {
+		return "", invalid(field + " is too long")
+	}
+	return value, nil
+}
+
+// link accepts an empty value or an absolute http(s) URL.
+func link(field, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") || len(value) > 500 {
+		return "", invalid(field + " must be an http(s) URL")
+	}
+	return value, nil
+}
+
+func checkPeriod(start, end *time.Time) error {
+	if start != nil && end != nil && end.Before(*start) {
+		return invalid("end_date is before start_date")
+	}
+	return nil
+}
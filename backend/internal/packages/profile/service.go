@@ -0,0 +1,473 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"io"
+	"strings"
+)
+
+const (
+	defaultMaxResumeSize = 2 * 1024 * 1024
+	defaultMaxResumes    = 5
+)
+
+// Rule violations. Their messages are what the API returns.
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrNotStudent       = errors.New("profile not applicable for this role")
+	ErrInvalidCGPA      = errors.New("invalid cgpa")
+	ErrInvalidBatch     = errors.New("invalid batch")
+	ErrResumeTooLarge   = errors.New("resume too large")
+	ErrResumeNotPDF     = errors.New("only PDF resumes allowed")
+	ErrVirusDetected    = errors.New("virus detected")
+	ErrStorageFailed    = errors.New("upload failed")
+	ErrNoCollegeContext = errors.New("college context required")
+	ErrInvalidScore     = errors.New("invalid percentage")
+	ErrRollNumberTaken  = errors.New("roll number already registered")
+	ErrSectionNotFound  = errors.New("entry not found")
+	ErrTooManyEntries   = errors.New("too many entries in this section")
+	ErrResumeNotFound   = errors.New("resume not found")
+	ErrResumeNameTaken  = errors.New("a resume with this name already exists")
+	ErrTooManyResumes   = errors.New("resume limit reached")
+)
+
+// ValidationError is a bad request; the message is returned as is.
+type ValidationError struct {
+	msg string
+}
+
+func (e *ValidationError) Error() string { return e.msg }
+
+func invalid(msg string) error { return &ValidationError{msg: msg} }
+
+// limits of the free-form profile lists
+const (
+	maxSkills      = 50
+	maxSkillLength = 50
+)
+
+// CodingPlatforms are the keys coding_profiles accepts.
+var CodingPlatforms = []string{
+	"github", "gitlab", "leetcode", "codeforces", "codechef",
+	"hackerrank", "kaggle", "geeksforgeeks",
+}
+
+type Repository interface {
+	// FindUser returns ErrUserNotFound.
+	FindUser(ctx context.Context, userID uint) (models.User, error)
+	// FindProfile returns nil, nil when the student has none yet.
+	FindProfile(ctx context.Context, userID uint) (*models.StudentProfile, error)
+	// SaveProfile upserts the profile, and renames the user when name is
+	// set, in one transaction.
+	SaveProfile(ctx context.Context, profile *models.StudentProfile, name *string) error
+	// RollNumberTaken reports whether another student of the college
+	// uses the roll number, compared case-insensitively.
+	RollNumberTaken(ctx context.Context, collegeID uint, rollNumber string, userID uint) (bool, error)
+
+	Sections(ctx context.Context, userID uint) (Sections, error)
+	SectionCounts(ctx context.Context, userIDs []uint) (map[uint]SectionCounts, error)
+	// Update and Delete of an entry are scoped to its student and return
+	// ErrSectionNotFound.
+	CreateProject(ctx context.Context, project *models.StudentProject) error
+	UpdateProject(ctx context.Context, project models.StudentProject) error
+	DeleteProject(ctx context.Context, id, userID uint) error
+	CreateInternship(ctx context.Context, internship *models.StudentInternship) error
+	UpdateInternship(ctx context.Context, internship models.StudentInternship) error
+	DeleteInternship(ctx context.Context, id, userID uint) error
+	CreateCertification(ctx context.Context, cert *models.StudentCertification) error
+	UpdateCertification(ctx context.Context, cert models.StudentCertification) error
+	DeleteCertification(ctx context.Context, id, userID uint) error
+
+	// RequiredFields returns the college's stored requirements, nil when
+	// it uses the default.
+	RequiredFields(ctx context.Context, collegeID uint) ([]byte, error)
+	// SetRequiredFields stores the requirements and records the audit
+	// entry in the same transaction.
+	SetRequiredFields(ctx context.Context, collegeID uint, before, after []string) error
+	// CollegeProfiles lists the profiles of the college's students.
+	CollegeProfiles(ctx context.Context, collegeID uint) ([]models.StudentProfile, error)
+	SetComplete(ctx context.Context, userIDs []uint, complete bool) error
+
+	// Resumes lists the student's resumes, oldest first.
+	Resumes(ctx context.Context, userID uint) ([]models.StudentResume, error)
+	// SaveResumes removes and stores the student's resumes, and saves the
+	// profile when set, in one transaction; returns ErrResumeNameTaken.
+	SaveResumes(ctx context.Context, userID uint, profile *models.StudentProfile, save []*models.StudentResume, remove []uint) error
+	// ResumeInUse reports whether an application still points at the file.
+	ResumeInUse(ctx context.Context, url string) (bool, error)
+}
+
+// Storage holds uploaded files; Put returns the public URL.
+type Storage interface {
+	Put(ctx context.Context, path string, r io.Reader, size int64, contentType string) (string, error)
+	Remove(ctx context.Context, path string) error
+}
+
+type Scanner interface {
+	Scan(r io.Reader) error
+}
+
+type Service struct {
+	repo          Repository
+	storage       Storage
+	scanner       Scanner
+	maxResumeSize int64
+	maxResumes    int
+}
+
+type Option func(*Service)
+
+// WithMaxResumes overrides how many resumes a student may keep; n <= 0
+// keeps 5.
+func WithMaxResumes(n int) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.maxResumes = n
+		}
+	}
+}
+
+// WithMaxResumeSize overrides the 2MB resume limit; n <= 0 keeps it.
+func WithMaxResumeSize(n int64) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.maxResumeSize = n
+		}
+	}
+}
+
+func NewService(repo Repository, storage Storage, scanner Scanner, opts ...Option) *Service {
+	s := &Service{
+		repo:          repo,
+		storage:       storage,
+		scanner:       scanner,
+		maxResumeSize: defaultMaxResumeSize,
+		maxResumes:    defaultMaxResumes,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// MaxResumeSize is the largest resume UploadResume accepts, in bytes.
+func (s *Service) MaxResumeSize() int64 {
+	return s.maxResumeSize
+}
+
+// ProfileView is everything GET /profile shows. Profile is nil until the
+// student saves something.
+type ProfileView struct {
+	User     models.User
+	Profile  *models.StudentProfile
+	Sections Sections
+	Resumes  []models.StudentResume
+
+	RequiredFields []string
+	MissingFields  []string
+}
+
+func (s *Service) Get(ctx context.Context, auth *authorization.AuthContext) (ProfileView, error) {
+	user, err := s.repo.FindUser(ctx, auth.UserID)
+	if err != nil {
+		return ProfileView{}, err
+	}
+
+	profile, err := s.repo.FindProfile(ctx, auth.UserID)
+	if err != nil {
+		return ProfileView{}, err
+	}
+	sections, err := s.repo.Sections(ctx, auth.UserID)
+	if err != nil {
+		return ProfileView{}, err
+	}
+	resumes, err := s.repo.Resumes(ctx, auth.UserID)
+	if err != nil {
+		return ProfileView{}, err
+	}
+	required, err := s.requiredFields(ctx, auth)
+	if err != nil {
+		return ProfileView{}, err
+	}
+
+	view := ProfileView{User: user, Profile: profile, Sections: sections, Resumes: resumes, RequiredFields: required}
+	current := models.StudentProfile{}
+	if profile != nil {
+		current = *profile
+	}
+	view.MissingFields = missingFields(current, sections.counts(), required)
+	return view, nil
+}
+
+func (s *Service) Update(ctx context.Context, auth *authorization.AuthContext, req UpdateProfileRequest) error {
+	// Only students can update student profile
+	if auth.Role != string(models.Student) {
+		return ErrNotStudent
+	}
+
+	if req.CGPA != nil && (*req.CGPA < 0 || *req.CGPA > 10) {
+		return ErrInvalidCGPA
+	}
+	if req.Batch != nil && *req.Batch < 2000 {
+		return ErrInvalidBatch
+	}
+	for _, score := range []*float32{req.TenthPercentage, req.TwelfthPercentage} {
+		if score != nil && (*score < 0 || *score > 100) {
+			return ErrInvalidScore
+		}
+	}
+
+	var skills, codingProfiles []byte
+	var err error
+	if req.Skills != nil {
+		if skills, err = normalizeSkills(*req.Skills); err != nil {
+			return err
+		}
+	}
+	if req.CodingProfiles != nil {
+		if codingProfiles, err = normalizeCodingProfiles(*req.CodingProfiles); err != nil {
+			return err
+		}
+	}
+	var branch, rollNumber string
+	if req.Branch != nil {
+		if branch, err = text("branch", *req.Branch, 100, false); err != nil {
+			return err
+		}
+	}
+	if req.RollNumber != nil {
+		if rollNumber, err = text("roll_number", *req.RollNumber, 32, false); err != nil {
+			return err
+		}
+		if rollNumber != "" && auth.CollegeID != nil {
+			taken, err := s.repo.RollNumberTaken(ctx, *auth.CollegeID, rollNumber, auth.UserID)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return ErrRollNumberTaken
+			}
+		}
+	}
+
+	profile, err := s.loadOrNew(ctx, auth.UserID)
+	if err != nil {
+		return err
+	}
+
+	if req.Batch != nil {
+		profile.Batch = *req.Batch
+	}
+	if req.CGPA != nil {
+		profile.CGPA = req.CGPA
+	}
+	if req.LinkedinID != nil {
+		profile.LinkedinID = *req.LinkedinID
+	}
+	if req.ShareContactWithRecruiters != nil {
+		profile.ShareContactWithRecruiters = *req.ShareContactWithRecruiters
+	}
+	if req.HideCGPAFromRecruiters != nil {
+		profile.HideCGPAFromRecruiters = *req.HideCGPAFromRecruiters
+	}
+	if req.Branch != nil {
+		profile.Branch = branch
+	}
+	if req.RollNumber != nil {
+		profile.RollNumber = rollNumber
+	}
+	if req.TenthPercentage != nil {
+		profile.TenthPercentage = req.TenthPercentage
+	}
+	if req.TwelfthPercentage != nil {
+		profile.TwelfthPercentage = req.TwelfthPercentage
+	}
+	if req.Skills != nil {
+		profile.Skills = skills
+	}
+	if req.CodingProfiles != nil {
+		profile.CodingProfiles = codingProfiles
+	}
+
+	if profile.ProfileComplete, err = s.isComplete(ctx, auth, *profile); err != nil {
+		return err
+	}
+
+	return s.repo.SaveProfile(ctx, profile, req.Name)
+}
+
+func (s *Service) loadOrNew(ctx context.Context, userID uint) (*models.StudentProfile, error) {
+	profile, err := s.repo.FindProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		profile = &models.StudentProfile{UserID: userID}
+	}
+	return profile, nil
+}
+
+// isComplete checks the profile against the requirements of the
+// student's college.
+func (s *Service) isComplete(ctx context.Context, auth *authorization.AuthContext, p models.StudentProfile) (bool, error) {
+	required, err := s.requiredFields(ctx, auth)
+	if err != nil {
+		return false, err
+	}
+	counts, err := s.repo.SectionCounts(ctx, []uint{auth.UserID})
+	if err != nil {
+		return false, err
+	}
+	return len(missingFields(p, counts[auth.UserID], required)) == 0, nil
+}
+
+// refreshComplete re-evaluates ProfileComplete after a section changed.
+func (s *Service) refreshComplete(ctx context.Context, auth *authorization.AuthContext) error {
+	profile, err := s.loadOrNew(ctx, auth.UserID)
+	if err != nil {
+		return err
+	}
+	complete, err := s.isComplete(ctx, auth, *profile)
+	if err != nil || complete == profile.ProfileComplete {
+		return err
+	}
+	profile.ProfileComplete = complete
+	return s.repo.SaveProfile(ctx, profile, nil)
+}
+
+func (s *Service) requiredFields(ctx context.Context, auth *authorization.AuthContext) ([]string, error) {
+	if auth.CollegeID == nil {
+		return requiredFieldsOf(nil)
+	}
+	raw, err := s.repo.RequiredFields(ctx, *auth.CollegeID)
+	if err != nil {
+		return nil, err
+	}
+	return requiredFieldsOf(raw)
+}
+
+// Requirements returns the profile fields the caller's college requires.
+func (s *Service) Requirements(ctx context.Context, auth *authorization.AuthContext) (RequirementsResponse, error) {
+	if auth.CollegeID == nil {
+		return RequirementsResponse{}, ErrNoCollegeContext
+	}
+	required, err := s.requiredFields(ctx, auth)
+	if err != nil {
+		return RequirementsResponse{}, err
+	}
+	return RequirementsResponse{RequiredFields: required, AvailableFields: RequirableFields}, nil
+}
+
+// SetRequirements replaces the college's required fields and re-evaluates
+// every profile of the college against them.
+func (s *Service) SetRequirements(ctx context.Context, auth *authorization.AuthContext, req RequirementsRequest) (RequirementsUpdate, error) {
+	if auth.CollegeID == nil {
+		return RequirementsUpdate{}, ErrNoCollegeContext
+	}
+	required, err := normalizeRequired(req.RequiredFields)
+	if err != nil {
+		return RequirementsUpdate{}, err
+	}
+	before, err := s.requiredFields(ctx, auth)
+	if err != nil {
+		return RequirementsUpdate{}, err
+	}
+	if err := s.repo.SetRequiredFields(ctx, *auth.CollegeID, before, required); err != nil {
+		return RequirementsUpdate{}, err
+	}
+
+	profiles, err := s.repo.CollegeProfiles(ctx, *auth.CollegeID)
+	if err != nil {
+		return RequirementsUpdate{}, err
+	}
+	ids := make([]uint, len(profiles))
+	for i, p := range profiles {
+		ids[i] = p.UserID
+	}
+	counts, err := s.repo.SectionCounts(ctx, ids)
+	if err != nil {
+		return RequirementsUpdate{}, err
+	}
+
+	var complete, incomplete []uint
+	for _, p := range profiles {
+		if len(missingFields(p, counts[p.UserID], required)) == 0 {
+			complete = append(complete, p.UserID)
+		} else {
+			incomplete = append(incomplete, p.UserID)
+		}
+	}
+	if err := s.repo.SetComplete(ctx, complete, true); err != nil {
+		return RequirementsUpdate{}, err
+	}
+	if err := s.repo.SetComplete(ctx, incomplete, false); err != nil {
+		return RequirementsUpdate{}, err
+	}
+
+	return RequirementsUpdate{
+		RequiredFields:     required,
+		CompleteProfiles:   len(complete),
+		IncompleteProfiles: len(incomplete),
+	}, nil
+}
+
+// normalizeSkills trims and de-duplicates skills, case-insensitively,
+// keeping the first spelling.
+func normalizeSkills(skills []string) ([]byte, error) {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, skill := range skills {
+		skill = strings.TrimSpace(skill)
+		if skill == "" || seen[strings.ToLower(skill)] {
+			continue
+		}
+		if len(skill) > maxSkillLength {
+			return nil, invalid("skill too long: " + skill)
+		}
+		seen[strings.ToLower(skill)] = true
+		out = append(out, skill)
+	}
+	if len(out) > maxSkills {
+		return nil, invalid(fmt.Sprintf("at most %d skills", maxSkills))
+	}
+	return json.Marshal(out)
+}
+
+// normalizeCodingProfiles accepts known platforms only; an empty URL
+// removes the platform.
+func normalizeCodingProfiles(links map[string]string) ([]byte, error) {
+	out := map[string]string{}
+	for platform, raw := range links {
+		platform = strings.ToLower(strings.TrimSpace(platform))
+		if !isCodingPlatform(platform) {
+			return nil, invalid("unknown coding platform " + platform)
+		}
+		value, err := link("coding_profiles."+platform, raw)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			out[platform] = value
+		}
+	}
+	return json.Marshal(out)
+}
+
+func isCodingPlatform(platform string) bool {
+	for _, p := range CodingPlatforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// scannerFunc adapts a plain function to Scanner.
+type scannerFunc func(io.Reader) error
+
+func (f scannerFunc) Scan(r io.Reader) error { return f(r) }
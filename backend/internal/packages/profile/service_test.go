@@ -0,0 +1,648 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeRepo struct {
+	users    map[uint]models.User
+	profiles map[uint]models.StudentProfile
+	renamed  map[uint]string
+	saveErr  error
+
+	projects       []models.StudentProject
+	internships    []models.StudentInternship
+	certifications []models.StudentCertification
+
+	required map[uint][]byte // college -> required fields
+	colleges map[uint]uint   // user -> college
+
+	resumes []models.StudentResume
+	inUse   map[string]bool // resume URLs applications point at
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		users:    map[uint]models.User{},
+		profiles: map[uint]models.StudentProfile{},
+		renamed:  map[uint]string{},
+		required: map[uint][]byte{},
+		colleges: map[uint]uint{},
+		inUse:    map[string]bool{},
+	}
+}
+
+func (r *fakeRepo) FindUser(_ context.Context, id uint) (models.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return u, ErrUserNotFound
+	}
+	return u, nil
+}
+
+func (r *fakeRepo) FindProfile(_ context.Context, id uint) (*models.StudentProfile, error) {
+	p, ok := r.profiles[id]
+	if !ok {
+		return nil, nil
+	}
+	return &p, nil
+}
+
+func (r *fakeRepo) SaveProfile(_ context.Context, p *models.StudentProfile, name *string) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.profiles[p.UserID] = *p
+	if name != nil {
+		r.renamed[p.UserID] = *name
+	}
+	return nil
+}
+
+func (r *fakeRepo) RollNumberTaken(_ context.Context, collegeID uint, roll string, userID uint) (bool, error) {
+	for id, p := range r.profiles {
+		if id != userID && r.colleges[id] == collegeID && strings.EqualFold(p.RollNumber, roll) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeRepo) Sections(_ context.Context, userID uint) (Sections, error) {
+	var out Sections
+	for _, p := range r.projects {
+		if p.UserID == userID {
+			out.Projects = append(out.Projects, p)
+		}
+	}
+	for _, i := range r.internships {
+		if i.UserID == userID {
+			out.Internships = append(out.Internships, i)
+		}
+	}
+	for _, c := range r.certifications {
+		if c.UserID == userID {
+			out.Certifications = append(out.Certifications, c)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) SectionCounts(ctx context.Context, userIDs []uint) (map[uint]SectionCounts, error) {
+	out := map[uint]SectionCounts{}
+	for _, id := range userIDs {
+		s, _ := r.Sections(ctx, id)
+		out[id] = s.counts()
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) CreateProject(_ context.Context, p *models.StudentProject) error {
+	p.ID = uint(len(r.projects) + 1)
+	r.projects = append(r.projects, *p)
+	return nil
+}
+
+func (r *fakeRepo) UpdateProject(_ context.Context, p models.StudentProject) error {
+	for i, old := range r.projects {
+		if old.ID == p.ID && old.UserID == p.UserID {
+			r.projects[i] = p
+			return nil
+		}
+	}
+	return ErrSectionNotFound
+}
+
+func (r *fakeRepo) DeleteProject(_ context.Context, id, userID uint) error {
+	for i, p := range r.projects {
+		if p.ID == id && p.UserID == userID {
+			r.projects = append(r.projects[:i], r.projects[i+1:]...)
+			return nil
+		}
+	}
+	return ErrSectionNotFound
+}
+
+func (r *fakeRepo) CreateInternship(_ context.Context, in *models.StudentInternship) error {
+	in.ID = uint(len(r.internships) + 1)
+	r.internships = append(r.internships, *in)
+	return nil
+}
+
+func (r *fakeRepo) UpdateInternship(context.Context, models.StudentInternship) error {
+	return ErrSectionNotFound
+}
+
+func (r *fakeRepo) DeleteInternship(context.Context, uint, uint) error {
+	return ErrSectionNotFound
+}
+
+func (r *fakeRepo) CreateCertification(_ context.Context, c *models.StudentCertification) error {
+	c.ID = uint(len(r.certifications) + 1)
+	r.certifications = append(r.certifications, *c)
+	return nil
+}
+
+func (r *fakeRepo) UpdateCertification(context.Context, models.StudentCertification) error {
+	return ErrSectionNotFound
+}
+
+func (r *fakeRepo) DeleteCertification(context.Context, uint, uint) error {
+	return ErrSectionNotFound
+}
+
+func (r *fakeRepo) RequiredFields(_ context.Context, collegeID uint) ([]byte, error) {
+	return r.required[collegeID], nil
+}
+
+func (r *fakeRepo) SetRequiredFields(_ context.Context, collegeID uint, _, after []string) error {
+	r.required[collegeID], _ = json.Marshal(after)
+	return nil
+}
+
+func (r *fakeRepo) CollegeProfiles(_ context.Context, collegeID uint) ([]models.StudentProfile, error) {
+	var out []models.StudentProfile
+	for id, p := range r.profiles {
+		if r.colleges[id] == collegeID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) SetComplete(_ context.Context, userIDs []uint, complete bool) error {
+	for _, id := range userIDs {
+		p := r.profiles[id]
+		p.ProfileComplete = complete
+		r.profiles[id] = p
+	}
+	return nil
+}
+
+func (r *fakeRepo) Resumes(_ context.Context, userID uint) ([]models.StudentResume, error) {
+	var out []models.StudentResume
+	for _, resume := range r.resumes {
+		if resume.UserID == userID {
+			out = append(out, resume)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) SaveResumes(_ context.Context, userID uint, p *models.StudentProfile, save []*models.StudentResume, remove []uint) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	kept := r.resumes[:0]
+	for _, resume := range r.resumes {
+		if !(resume.UserID == userID && containsID(remove, resume.ID)) {
+			kept = append(kept, resume)
+		}
+	}
+	r.resumes = kept
+
+	for _, resume := range save {
+		if resume.ID == 0 {
+			resume.ID = uint(len(r.resumes) + 100)
+			r.resumes = append(r.resumes, *resume)
+			continue
+		}
+		for i := range r.resumes {
+			if r.resumes[i].ID == resume.ID {
+				r.resumes[i] = *resume
+			}
+		}
+	}
+	if p != nil {
+		r.profiles[p.UserID] = *p
+	}
+	return nil
+}
+
+func (r *fakeRepo) ResumeInUse(_ context.Context, url string) (bool, error) {
+	return r.inUse[url], nil
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+type fakeStorage struct {
+	objects map[string][]byte
+	removed []string
+	putErr  error
+}
+
+func (s *fakeStorage) Put(_ context.Context, path string, r io.Reader, _ int64, _ string) (string, error) {
+	if s.putErr != nil {
+		return "", s.putErr
+	}
+	b, _ := io.ReadAll(r)
+	s.objects[path] = b
+	return "https://files.test/" + path, nil
+}
+
+func (s *fakeStorage) Remove(_ context.Context, path string) error {
+	s.removed = append(s.removed, path)
+	delete(s.objects, path)
+	return nil
+}
+
+func newTestService(scan func(io.Reader) error) (*Service, *fakeRepo, *fakeStorage) {
+	repo := newFakeRepo()
+	storage := &fakeStorage{objects: map[string][]byte{}}
+	if scan == nil {
+		scan = func(io.Reader) error { return nil }
+	}
+	return NewService(repo, storage, scannerFunc(scan)), repo, storage
+}
+
+func studentAuth(id uint) *authorization.AuthContext {
+	college := uint(10)
+	return &authorization.AuthContext{UserID: id, Role: string(models.Student), CollegeID: &college}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+var pdf = append([]byte("%PDF-1.7\n"), bytes.Repeat([]byte("x"), 1024)...)
+
+func TestUpdateValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		auth *authorization.AuthContext
+		req  UpdateProfileRequest
+		want error
+	}{
+		{"valid", studentAuth(5), UpdateProfileRequest{Batch: ptr(2026), CGPA: ptr(float32(8.5))}, nil},
+		{"college admin", &authorization.AuthContext{UserID: 1, Role: string(models.CollegeAdmin)}, UpdateProfileRequest{}, ErrNotStudent},
+		{"negative cgpa", studentAuth(5), UpdateProfileRequest{CGPA: ptr(float32(-1))}, ErrInvalidCGPA},
+		{"cgpa above 10", studentAuth(5), UpdateProfileRequest{CGPA: ptr(float32(10.5))}, ErrInvalidCGPA},
+		{"ancient batch", studentAuth(5), UpdateProfileRequest{Batch: ptr(1999)}, ErrInvalidBatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestService(nil)
+
+			err := svc.Update(context.Background(), tt.auth, tt.req)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil && len(repo.profiles) != 0 {
+				t.Fatal("invalid update was saved")
+			}
+		})
+	}
+}
+
+func TestProfileCompleteness(t *testing.T) {
+	svc, repo, _ := newTestService(nil)
+	ctx := context.Background()
+
+	// batch alone is not enough
+	if err := svc.Update(ctx, studentAuth(5), UpdateProfileRequest{Batch: ptr(2026), Name: ptr("Asha")}); err != nil {
+		t.Fatal(err)
+	}
+	if repo.profiles[5].ProfileComplete {
+		t.Fatal("profile without resume marked complete")
+	}
+	if repo.renamed[5] != "Asha" {
+		t.Fatal("name not updated")
+	}
+
+	// resume completes it
+	if _, err := svc.UploadResume(ctx, studentAuth(5), bytes.NewReader(pdf), int64(len(pdf))); err != nil {
+		t.Fatal(err)
+	}
+	if !repo.profiles[5].ProfileComplete {
+		t.Fatal("profile with batch and resume not complete")
+	}
+
+	// a resume without a batch is not complete either
+	if _, err := svc.UploadResume(ctx, studentAuth(6), bytes.NewReader(pdf), int64(len(pdf))); err != nil {
+		t.Fatal(err)
+	}
+	if p := repo.profiles[6]; p.ResumeURL == nil || p.ProfileComplete {
+		t.Fatalf("profile = %+v", p)
+	}
+}
+
+func TestUploadResumeRejections(t *testing.T) {
+	infected := errors.New("FOUND Eicar")
+
+	tests := []struct {
+		name string
+		body []byte
+		size int64
+		scan func(io.Reader) error
+		want error
+	}{
+		{"too large", pdf, defaultMaxResumeSize + 1, nil, ErrResumeTooLarge},
+		{"not a pdf", []byte("<html>hello</html>"), 18, nil, ErrResumeNotPDF},
+		{"infected", pdf, int64(len(pdf)), func(io.Reader) error { return infected }, ErrVirusDetected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, storage := newTestService(tt.scan)
+
+			_, err := svc.UploadResume(context.Background(), studentAuth(5), bytes.NewReader(tt.body), tt.size)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if len(storage.objects) != 0 || len(repo.profiles) != 0 {
+				t.Fatal("rejected resume was stored")
+			}
+		})
+	}
+}
+
+func TestUploadResumeScansWholeFileAndStoresIt(t *testing.T) {
+	var scanned []byte
+	svc, _, storage := newTestService(func(r io.Reader) error {
+		scanned, _ = io.ReadAll(r)
+		return nil
+	})
+
+	url, err := svc.UploadResume(context.Background(), studentAuth(5), bytes.NewReader(pdf), int64(len(pdf)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(scanned, pdf) {
+		t.Fatal("scanner did not see the whole file")
+	}
+	path := strings.TrimPrefix(url, "https://files.test/")
+	if !strings.HasPrefix(path, "resumes/10/5/") || !strings.HasSuffix(path, ".pdf") {
+		t.Fatalf("url = %s", url)
+	}
+	if !bytes.Equal(storage.objects[path], pdf) {
+		t.Fatal("stored object differs from upload")
+	}
+}
+
+func TestUploadResumeCleansUpOnSaveFailure(t *testing.T) {
+	svc, repo, storage := newTestService(nil)
+	repo.saveErr = errors.New("db down")
+
+	if _, err := svc.UploadResume(context.Background(), studentAuth(5), bytes.NewReader(pdf), int64(len(pdf))); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(storage.removed) != 1 || len(storage.objects) != 0 {
+		t.Fatalf("object not cleaned up: removed=%v", storage.removed)
+	}
+}
+
+func TestUpdateStructuredFields(t *testing.T) {
+	svc, repo, _ := newTestService(nil)
+	ctx := context.Background()
+
+	err := svc.Update(ctx, studentAuth(5), UpdateProfileRequest{
+		Branch:          ptr(" CSE "),
+		RollNumber:      ptr("BT22CSE001"),
+		TenthPercentage: ptr(float32(92.4)),
+		Skills:          ptr([]string{"Go", " go ", "React", ""}),
+		CodingProfiles:  ptr(map[string]string{"GitHub": "https://github.com/asha", "leetcode": ""}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := repo.profiles[5]
+	if p.Branch != "CSE" || p.RollNumber != "BT22CSE001" || *p.TenthPercentage != 92.4 {
+		t.Fatalf("profile = %+v", p)
+	}
+	if got := skillsOf(p); len(got) != 2 || got[0] != "Go" || got[1] != "React" {
+		t.Fatalf("skills = %v", got)
+	}
+	if got := codingProfilesOf(p); len(got) != 1 || got["github"] != "https://github.com/asha" {
+		t.Fatalf("coding profiles = %v", got)
+	}
+
+	repo.colleges[5], repo.colleges[6], repo.colleges[7] = 10, 10, 11
+	other := studentAuth(7)
+	*other.CollegeID = 11
+
+	many := make([]string, maxSkills+1)
+	for i := range many {
+		many[i] = fmt.Sprintf("skill %d", i)
+	}
+
+	tests := []struct {
+		name string
+		auth *authorization.AuthContext
+		req  UpdateProfileRequest
+		want string
+	}{
+		{"score above 100", studentAuth(6), UpdateProfileRequest{TwelfthPercentage: ptr(float32(101))}, ErrInvalidScore.Error()},
+		{"unknown platform", studentAuth(6), UpdateProfileRequest{CodingProfiles: ptr(map[string]string{"myspace": "https://myspace.com/a"})}, "unknown coding platform"},
+		{"profile link not a URL", studentAuth(6), UpdateProfileRequest{CodingProfiles: ptr(map[string]string{"github": "asha"})}, "http(s) URL"},
+		{"roll number of a classmate", studentAuth(6), UpdateProfileRequest{RollNumber: ptr("bt22cse001")}, ErrRollNumberTaken.Error()},
+		{"too many skills", studentAuth(6), UpdateProfileRequest{Skills: &many}, "at most"},
+		{"same roll number elsewhere", other, UpdateProfileRequest{RollNumber: ptr("BT22CSE001")}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.Update(ctx, tt.auth, tt.req)
+			if tt.want == "" && err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollegeRequirementsDriveCompleteness(t *testing.T) {
+	svc, repo, _ := newTestService(nil)
+	ctx := context.Background()
+	admin := &authorization.AuthContext{UserID: 1, Role: string(models.CollegeAdmin), CollegeID: ptr(uint(10))}
+	repo.colleges[5], repo.colleges[6] = 10, 10
+
+	repo.users[6] = models.User{ID: 6}
+
+	// both complete under the default rule; only 6 has a roll number
+	resume := "https://files.test/r.pdf"
+	repo.profiles[5] = models.StudentProfile{UserID: 5, Batch: 2026, ResumeURL: &resume, ProfileComplete: true}
+	repo.profiles[6] = models.StudentProfile{UserID: 6, Batch: 2026, ResumeURL: &resume, ProfileComplete: true, RollNumber: "R6"}
+
+	if _, err := svc.SetRequirements(ctx, admin, RequirementsRequest{RequiredFields: []string{"shoe_size"}}); err == nil {
+		t.Fatal("unknown field accepted")
+	}
+	update, err := svc.SetRequirements(ctx, admin, RequirementsRequest{
+		RequiredFields: []string{FieldProjects, FieldRollNumber, FieldBatch, FieldRollNumber},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := update.RequiredFields; len(got) != 3 || got[0] != FieldBatch || got[1] != FieldRollNumber || got[2] != FieldProjects {
+		t.Fatalf("required = %v", got)
+	}
+	if update.CompleteProfiles != 0 || update.IncompleteProfiles != 2 || repo.profiles[5].ProfileComplete {
+		t.Fatalf("update = %+v", update)
+	}
+
+	view, err := svc.Get(ctx, studentAuth(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(view.MissingFields) != 1 || view.MissingFields[0] != FieldProjects {
+		t.Fatalf("missing = %v", view.MissingFields)
+	}
+
+	// adding the project completes the profile, removing it undoes that
+	project, err := svc.AddProject(ctx, studentAuth(6), ProjectRequest{Title: "Compiler", URL: "https://github.com/x/cc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !repo.profiles[6].ProfileComplete {
+		t.Fatal("profile with every required field not complete")
+	}
+	if err := svc.DeleteProject(ctx, studentAuth(5), project.ID); !errors.Is(err, ErrSectionNotFound) {
+		t.Fatalf("deleting a classmate's project: err = %v", err)
+	}
+	if err := svc.DeleteProject(ctx, studentAuth(6), project.ID); err != nil {
+		t.Fatal(err)
+	}
+	if repo.profiles[6].ProfileComplete {
+		t.Fatal("profile still complete without a project")
+	}
+}
+
+func TestSectionRules(t *testing.T) {
+	svc, repo, _ := newTestService(nil)
+	ctx := context.Background()
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := start.AddDate(0, -1, 0)
+
+	if _, err := svc.AddInternship(ctx, studentAuth(5), InternshipRequest{Company: "Acme", Role: "SDE", StartDate: &start, EndDate: &before}); err == nil {
+		t.Fatal("internship ending before it starts accepted")
+	}
+	if _, err := svc.AddCertification(ctx, studentAuth(5), CertificationRequest{Name: "  "}); err == nil {
+		t.Fatal("blank certification accepted")
+	}
+	if _, err := svc.AddProject(ctx, studentAuth(5), ProjectRequest{Title: "x", URL: "javascript:alert(1)"}); err == nil {
+		t.Fatal("non-http project url accepted")
+	}
+	if _, err := svc.AddProject(ctx, &authorization.AuthContext{UserID: 1, Role: string(models.CollegeAdmin)}, ProjectRequest{Title: "x"}); !errors.Is(err, ErrNotStudent) {
+		t.Fatalf("admin adding a project: err = %v", err)
+	}
+
+	for i := 0; i < maxSectionEntries; i++ {
+		if _, err := svc.AddCertification(ctx, studentAuth(5), CertificationRequest{Name: "AWS"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := svc.AddCertification(ctx, studentAuth(5), CertificationRequest{Name: "AWS"}); !errors.Is(err, ErrTooManyEntries) {
+		t.Fatalf("err = %v, want ErrTooManyEntries", err)
+	}
+	if len(repo.certifications) != maxSectionEntries {
+		t.Fatalf("%d certifications stored", len(repo.certifications))
+	}
+}
+
+func TestNamedResumes(t *testing.T) {
+	repo := newFakeRepo()
+	storage := &fakeStorage{objects: map[string][]byte{}}
+	svc := NewService(repo, storage, scannerFunc(func(io.Reader) error { return nil }), WithMaxResumes(2))
+	ctx := context.Background()
+	auth := studentAuth(5)
+	repo.profiles[5] = models.StudentProfile{UserID: 5, Batch: 2026}
+
+	add := func(name string, makeDefault bool) (models.StudentResume, error) {
+		return svc.AddResume(ctx, auth, ResumeUpload{Name: name, Default: makeDefault}, bytes.NewReader(pdf), int64(len(pdf)))
+	}
+	profileURL := func() string {
+		if u := repo.profiles[5].ResumeURL; u != nil {
+			return *u
+		}
+		return ""
+	}
+
+	frontend, err := add("Frontend", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !frontend.IsDefault || profileURL() != frontend.URL || !repo.profiles[5].ProfileComplete {
+		t.Fatalf("first resume must become the default: %+v, profile = %+v", frontend, repo.profiles[5])
+	}
+
+	ml, err := add("ML", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ml.IsDefault || profileURL() != frontend.URL {
+		t.Fatal("second resume must not replace the default")
+	}
+	if _, err := add(" frontend ", false); !errors.Is(err, ErrResumeNameTaken) {
+		t.Fatalf("duplicate name: err = %v", err)
+	}
+	objects := len(storage.objects)
+	if _, err := add("Backend", false); !errors.Is(err, ErrTooManyResumes) || len(storage.objects) != objects {
+		t.Fatalf("over the limit: err = %v, objects %d -> %d", err, objects, len(storage.objects))
+	}
+
+	// switch the default
+	var verr *ValidationError
+	if _, err := svc.UpdateResume(ctx, auth, ml.ID, UpdateResumeRequest{IsDefault: ptr(false)}); !errors.As(err, &verr) {
+		t.Fatalf("unsetting a default: err = %v", err)
+	}
+	if _, err := svc.UpdateResume(ctx, auth, ml.ID, UpdateResumeRequest{Name: ptr("Machine learning"), IsDefault: ptr(true)}); err != nil {
+		t.Fatal(err)
+	}
+	resumes, _ := svc.Resumes(ctx, auth)
+	if resumes[0].IsDefault || !resumes[1].IsDefault || resumes[1].Name != "Machine learning" || profileURL() != ml.URL {
+		t.Fatalf("resumes = %+v", resumes)
+	}
+	if _, err := svc.UpdateResume(ctx, studentAuth(6), ml.ID, UpdateResumeRequest{Name: ptr("x")}); !errors.Is(err, ErrResumeNotFound) {
+		t.Fatalf("another student's resume: err = %v", err)
+	}
+
+	// the single-resume endpoint replaces the default's file
+	url, err := svc.UploadResume(ctx, auth, bytes.NewReader(pdf), int64(len(pdf)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumes, _ = svc.Resumes(ctx, auth)
+	if len(resumes) != 2 || resumes[1].URL != url || profileURL() != url {
+		t.Fatalf("resumes = %+v", resumes)
+	}
+	if _, ok := storage.objects[ml.ObjectPath]; ok {
+		t.Fatal("replaced file not removed")
+	}
+
+	// deleting the default promotes the other one
+	if err := svc.DeleteResume(ctx, auth, ml.ID); err != nil {
+		t.Fatal(err)
+	}
+	if resumes, _ = svc.Resumes(ctx, auth); len(resumes) != 1 || !resumes[0].IsDefault || profileURL() != frontend.URL {
+		t.Fatalf("resumes = %+v", resumes)
+	}
+
+	// a file an application was submitted with is kept
+	repo.inUse[frontend.URL] = true
+	if err := svc.DeleteResume(ctx, auth, frontend.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := storage.objects[frontend.ObjectPath]; !ok {
+		t.Fatal("file of a submitted resume removed")
+	}
+	if p := repo.profiles[5]; p.ResumeURL != nil || p.ProfileComplete {
+		t.Fatalf("profile without resumes = %+v", p)
+	}
+}
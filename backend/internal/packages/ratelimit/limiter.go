@@ -0,0 +1,221 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"iiitn-career-portal/internal/config"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Rule allows Limit requests per sliding Window.
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ParseRule reads "<limit>/<window>", e.g. "10/1m" or "5/15m".
+func ParseRule(s string) (Rule, error) {
+	limit, window, err := config.ParseRateLimit(s)
+	if err != nil {
+		return Rule{}, err
+	}
+	return Rule{Limit: limit, Window: window}, nil
+}
+
+type result struct {
+	Allowed   bool
+	Remaining int
+	// time until the oldest counted request leaves the window
+	Reset time.Duration
+	// gives back the request counted by an allowed call
+	refund func(ctx context.Context)
+}
+
+// sliding log in a sorted set: score = request time in ms
+var slidingWindowScript = redis.NewScript(`
+local key    = KEYS[1]
+local now    = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit  = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, 0, now - window)
+local count = redis.call('ZCARD', key)
+
+local allowed = 0
+if count < limit then
+  redis.call('ZADD', key, now, ARGV[4])
+  redis.call('PEXPIRE', key, window)
+  count = count + 1
+  allowed = 1
+end
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+local oldestScore = now
+if oldest[2] then
+  oldestScore = tonumber(oldest[2])
+end
+
+return {allowed, count, oldestScore}
+`)
+
+// Limiter counts requests in Redis and falls back to process memory when
+// Redis is missing or failing, so limits degrade to per-instance instead
+// of disappearing.
+type Limiter struct {
+	rdb    *redis.Client
+	memory *memoryStore
+}
+
+func New(rdb *redis.Client) *Limiter {
+	return &Limiter{
+		rdb:    rdb,
+		memory: newMemoryStore(),
+	}
+}
+
+func (l *Limiter) allow(ctx context.Context, key string, rule Rule) result {
+	now := time.Now()
+
+	if l.rdb != nil {
+		res, err := l.allowRedis(ctx, key, rule, now)
+		if err == nil {
+			return res
+		}
+	}
+
+	return l.memory.allow(key, rule, now)
+}
+
+func (l *Limiter) allowRedis(ctx context.Context, key string, rule Rule, now time.Time) (result, error) {
+	ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+
+	nowMs := now.UnixMilli()
+	windowMs := rule.Window.Milliseconds()
+	redisKey := "ratelimit:" + key
+	member := strconv.FormatInt(nowMs, 10) + "-" + randomSuffix()
+
+	vals, err := slidingWindowScript.Run(
+		ctx,
+		l.rdb,
+		[]string{redisKey},
+		nowMs,
+		windowMs,
+		rule.Limit,
+		member,
+	).Int64Slice()
+	if err != nil {
+		return result{}, err
+	}
+	if len(vals) != 3 {
+		return result{}, errors.New("unexpected rate limit script reply")
+	}
+
+	reset := time.Duration(vals[2]+windowMs-nowMs) * time.Millisecond
+
+	res := result{
+		Allowed:   vals[0] == 1,
+		Remaining: max(rule.Limit-int(vals[1]), 0),
+		Reset:     max(reset, 0),
+	}
+	if res.Allowed {
+		res.refund = func(ctx context.Context) {
+			ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+			defer cancel()
+			_ = l.rdb.ZRem(ctx, redisKey, member).Err()
+		}
+	}
+	return res, nil
+}
+
+func randomSuffix() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// -------- in-memory fallback --------
+
+type memoryStore struct {
+	mu    sync.Mutex
+	logs  map[string][]time.Time
+	calls int
+
+	// longest window seen, bounds how long an idle key must be kept
+	maxWindow time.Duration
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{logs: map[string][]time.Time{}}
+}
+
+func (m *memoryStore) allow(key string, rule Rule, now time.Time) result {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.maxWindow = max(m.maxWindow, rule.Window)
+
+	m.calls++
+	if m.calls%1000 == 0 {
+		m.sweep(now)
+	}
+
+	cutoff := now.Add(-rule.Window)
+	log := m.logs[key]
+
+	i := 0
+	for i < len(log) && !log[i].After(cutoff) {
+		i++
+	}
+	log = log[i:]
+
+	allowed := len(log) < rule.Limit
+	if allowed {
+		log = append(log, now)
+	}
+	m.logs[key] = log
+
+	reset := rule.Window
+	if len(log) > 0 {
+		reset = log[0].Add(rule.Window).Sub(now)
+	}
+
+	res := result{
+		Allowed:   allowed,
+		Remaining: max(rule.Limit-len(log), 0),
+		Reset:     reset,
+	}
+	if allowed {
+		res.refund = func(context.Context) { m.release(key, now) }
+	}
+	return res
+}
+
+// release drops the entry an allowed call added at now.
+func (m *memoryStore) release(key string, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	log := m.logs[key]
+	for i := len(log) - 1; i >= 0; i-- {
+		if log[i].Equal(now) {
+			m.logs[key] = append(log[:i], log[i+1:]...)
+			return
+		}
+	}
+}
+
+// sweep drops keys that no window can still count.
+func (m *memoryStore) sweep(now time.Time) {
+	for k, log := range m.logs {
+		if len(log) == 0 || now.Sub(log[len(log)-1]) > m.maxWindow {
+			delete(m.logs, k)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newRedisLimiter(t *testing.T) (*Limiter, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	return New(rdb), mr
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule(" 10/15m ")
+	if err != nil || rule.Limit != 10 || rule.Window != 15*time.Minute {
+		t.Fatalf("ParseRule = %+v, %v", rule, err)
+	}
+
+	for _, bad := range []string{"", "10", "0/1m", "-1/1m", "x/1m", "10/0s", "10/soon"} {
+		if _, err := ParseRule(bad); err == nil {
+			t.Errorf("ParseRule(%q) accepted", bad)
+		}
+	}
+}
+
+func TestRedisSlidingWindow(t *testing.T) {
+	l, mr := newRedisLimiter(t)
+	ctx := context.Background()
+	rule := Rule{Limit: 2, Window: time.Minute}
+	start := time.Now()
+
+	for i, want := range []int{1, 0} {
+		res, err := l.allowRedis(ctx, "k", rule, start.Add(time.Duration(i)*10*time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Allowed || res.Remaining != want {
+			t.Fatalf("request %d = %+v, want allowed with %d left", i, res, want)
+		}
+	}
+
+	res, err := l.allowRedis(ctx, "k", rule, start.Add(30*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Allowed || res.Remaining != 0 {
+		t.Fatalf("over limit = %+v, want denied", res)
+	}
+	// the first request leaves the window 60s after start
+	if res.Reset != 30*time.Second {
+		t.Fatalf("reset = %v, want 30s", res.Reset)
+	}
+	if n, _ := mr.ZMembers("ratelimit:k"); len(n) != 2 {
+		t.Fatalf("denied request was counted: %v", n)
+	}
+
+	// the window slides: the first request has expired, the second has not
+	res, err = l.allowRedis(ctx, "k", rule, start.Add(61*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("after slide = %+v, want allowed with 0 left", res)
+	}
+
+	res.refund(ctx)
+	if n, _ := mr.ZMembers("ratelimit:k"); len(n) != 1 {
+		t.Fatalf("refund left %v", n)
+	}
+}
+
+func TestMemoryFallback(t *testing.T) {
+	ctx := context.Background()
+	rule := Rule{Limit: 1, Window: time.Minute}
+
+	l, mr := newRedisLimiter(t)
+	if res := l.allow(ctx, "k", rule); !res.Allowed {
+		t.Fatalf("first = %+v", res)
+	}
+
+	// with Redis gone the process keeps its own window
+	mr.Close()
+	if res := l.allow(ctx, "k", rule); !res.Allowed {
+		t.Fatalf("fallback first = %+v", res)
+	}
+	res := l.allow(ctx, "k", rule)
+	if res.Allowed || res.Reset <= 0 || res.Reset > time.Minute {
+		t.Fatalf("fallback second = %+v, want denied", res)
+	}
+
+	m := newMemoryStore()
+	now := time.Now()
+	first := m.allow("k", rule, now)
+	if !first.Allowed {
+		t.Fatalf("memory first = %+v", first)
+	}
+	if res := m.allow("k", rule, now.Add(30*time.Second)); res.Allowed || res.Reset != 30*time.Second {
+		t.Fatalf("memory second = %+v, want denied for 30s", res)
+	}
+	first.refund(ctx)
+	if res := m.allow("k", rule, now.Add(30*time.Second)); !res.Allowed {
+		t.Fatalf("after refund = %+v, want allowed", res)
+	}
+	if res := m.allow("k", rule, now.Add(91*time.Second)); !res.Allowed {
+		t.Fatalf("after window = %+v, want allowed", res)
+	}
+}
@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/packages/authorization"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KeyFunc extracts the identity a policy counts against. An empty key
+// skips the policy for that request.
+type KeyFunc func(c *gin.Context) string
+
+type Policy struct {
+	Name string
+	Rule Rule
+	Key  KeyFunc
+}
+
+// PolicyFromConfig builds a policy whose rule can be overridden through
+// config (RATE_LIMITS="login_ip=20/1m,..."); def is used otherwise.
+func PolicyFromConfig(cfg config.Config, name, def string, key KeyFunc) Policy {
+	spec := def
+	if override, ok := cfg.RateLimits[name]; ok {
+		spec = override
+	}
+
+	rule, err := ParseRule(spec)
+	if err != nil {
+		slog.Warn("invalid rate limit override, using default", "policy", name, "error", err, "default", def)
+		rule, _ = ParseRule(def)
+	}
+
+	return Policy{Name: name, Rule: rule, Key: key}
+}
+
+// Middleware enforces every policy; the request is rejected with 429 if
+// one of them is exhausted. A rejected request gives back what the other
+// policies counted, so a tight per-email limit cannot burn the per-IP one.
+func (l *Limiter) Middleware(policies ...Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			tightest *result
+			limit    int
+			denied   *result
+			counted  []result
+		)
+
+		for _, p := range policies {
+			id := p.Key(c)
+			if id == "" {
+				continue
+			}
+
+			res := l.allow(c.Request.Context(), p.Name+":"+id, p.Rule)
+			if res.Allowed {
+				counted = append(counted, res)
+			}
+
+			if !res.Allowed && (denied == nil || res.Reset > denied.Reset) {
+				denied = &res
+				limit = p.Rule.Limit
+			}
+			if denied == nil && (tightest == nil || res.Remaining < tightest.Remaining) {
+				tightest = &res
+				limit = p.Rule.Limit
+			}
+		}
+
+		if denied != nil {
+			for _, res := range counted {
+				res.refund(c.Request.Context())
+			}
+			writeHeaders(c, limit, *denied)
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(denied.Reset)))
+			c.AbortWithStatusJSON(
+				http.StatusTooManyRequests,
+				gin.H{"error": "too many requests"},
+			)
+			return
+		}
+
+		if tightest != nil {
+			writeHeaders(c, limit, *tightest)
+		}
+
+		c.Next()
+	}
+}
+
+func writeHeaders(c *gin.Context, limit int, res result) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// -------- key functions --------
+
+func ByIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// ByUser needs RequireAuth to have run.
+func ByUser(c *gin.Context) string {
+	v, ok := c.Get("auth")
+	if !ok {
+		return ""
+	}
+	auth, ok := v.(*authorization.AuthContext)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatUint(uint64(auth.UserID), 10)
+}
+
+// at most this much of a body is read to find a keyed field
+const maxKeyedBody = 1 << 20
+
+// ByJSONField keys on a field of the JSON body (e.g. "email"). The body
+// is restored so the handler can still bind it, all of it even past
+// maxKeyedBody; values are hashed so Redis never holds raw emails.
+func ByJSONField(field string) KeyFunc {
+	return func(c *gin.Context) string {
+		if c.Request.Body == nil {
+			return ""
+		}
+
+		original := c.Request.Body
+		body, err := io.ReadAll(io.LimitReader(original, maxKeyedBody))
+		c.Request.Body = replayedBody{io.MultiReader(bytes.NewReader(body), original), original}
+		if err != nil {
+			return ""
+		}
+
+		var fields map[string]interface{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return ""
+		}
+
+		value, _ := fields[field].(string)
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			return ""
+		}
+
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:16])
+	}
+}
+
+// replayedBody reads back what a key function consumed, then the rest of
+// the original body, which it also closes.
+type replayedBody struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func limitedRouter(l *Limiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	byHeader := func(c *gin.Context) string { return c.GetHeader("X-Email") }
+
+	r := gin.New()
+	r.POST("/login",
+		l.Middleware(
+			Policy{Name: "ip", Rule: Rule{Limit: 3, Window: time.Minute}, Key: ByIP},
+			Policy{Name: "email", Rule: Rule{Limit: 1, Window: time.Minute}, Key: byHeader},
+		),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+	return r
+}
+
+func login(r *gin.Engine, email string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/login", nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	if email != "" {
+		req.Header.Set("X-Email", email)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	redisLimiter, _ := newRedisLimiter(t)
+
+	for name, l := range map[string]*Limiter{
+		"redis":  redisLimiter,
+		"memory": New(nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := limitedRouter(l)
+
+			// headers follow the policy with the least left (email: 1/1m)
+			w := login(r, "a@x.test")
+			if w.Code != http.StatusOK {
+				t.Fatalf("first: status %d", w.Code)
+			}
+			if got := w.Header().Get("X-RateLimit-Limit"); got != "1" {
+				t.Errorf("limit = %q, want 1", got)
+			}
+			if got := w.Header().Get("X-RateLimit-Remaining"); got != "0" {
+				t.Errorf("remaining = %q, want 0", got)
+			}
+			if got := w.Header().Get("X-RateLimit-Reset"); got != "60" {
+				t.Errorf("reset = %q, want 60", got)
+			}
+
+			// the email policy denies; the ip count it took is given back
+			for range 3 {
+				w = login(r, "a@x.test")
+				if w.Code != http.StatusTooManyRequests {
+					t.Fatalf("repeat: status %d, want 429", w.Code)
+				}
+				if w.Header().Get("Retry-After") == "" || w.Header().Get("X-RateLimit-Limit") != "1" {
+					t.Fatalf("429 headers = %v", w.Header())
+				}
+			}
+
+			// so the ip still has two of its three requests left
+			for _, email := range []string{"b@x.test", "c@x.test"} {
+				if w = login(r, email); w.Code != http.StatusOK {
+					t.Fatalf("%s: status %d, want 200", email, w.Code)
+				}
+			}
+			w = login(r, "d@x.test")
+			if w.Code != http.StatusTooManyRequests {
+				t.Fatalf("ip exhausted: status %d, want 429", w.Code)
+			}
+			if got := w.Header().Get("X-RateLimit-Limit"); got != "3" {
+				t.Errorf("limit = %q, want 3", got)
+			}
+			if got := w.Header().Get("Retry-After"); got != "60" {
+				t.Errorf("retry-after = %q, want 60", got)
+			}
+
+			// policies whose key is empty are skipped
+			if w = login(r, ""); w.Code != http.StatusTooManyRequests {
+				t.Fatalf("no email: status %d, want 429 from ip", w.Code)
+			}
+		})
+	}
+}
+
+func TestByJSONFieldKeepsTheBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// a body past what the key function reads reaches the handler whole
+	body := `{"email":"A@x.test","bio":"` + strings.Repeat("x", maxKeyedBody) + `"}`
+
+	var key string
+	var got []byte
+	r := gin.New()
+	r.POST("/signup", func(c *gin.Context) {
+		key = ByJSONField("email")(c)
+		got, _ = io.ReadAll(c.Request.Body)
+		c.Status(http.StatusOK)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body)))
+
+	if string(got) != body {
+		t.Fatalf("handler read %d bytes of %d", len(got), len(body))
+	}
+	// the field could not be read within the limit, so nothing is keyed
+	if key != "" {
+		t.Fatalf("key = %q for a body past the limit", key)
+	}
+
+	small := `{"email":" A@x.test "}`
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(small)))
+	if string(got) != small || key == "" {
+		t.Fatalf("small body: read %q, key %q", got, key)
+	}
+}
@@ -0,0 +1,41 @@
+package recruiters
+
+import "time"
+
+type InviteRequest struct {
+	Email   string `json:"email" binding:"required"`
+	Name    string `json:"name"`
+	Company string `json:"company" binding:"required"`
+	JobIDs  []uint `json:"job_ids" binding:"required"`
+}
+
+// InviteResponse is "granted" for an existing recruiter and "invited"
+// with a link to forward otherwise.
+type InviteResponse struct {
+	Status      string     `json:"status"`
+	RecruiterID *uint      `json:"recruiter_id,omitempty"`
+	InviteURL   string     `json:"invite_url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+type AcceptInviteRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+	Name     string `json:"name"`
+}
+
+type RecruiterResponse struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Company string `json:"company"`
+	JobIDs  []uint `json:"job_ids" gorm:"-"`
+}
+
+type RecruiterJobResponse struct {
+	JobID      uint   `json:"job_id"`
+	Title      string `json:"title"`
+	Company    string `json:"company"`
+	CollegeID  uint   `json:"college_id"`
+	Applicants int64  `json:"applicants"`
+}
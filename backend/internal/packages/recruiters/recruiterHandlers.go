@@ -0,0 +1,130 @@
+package recruiters
+
+import (
+	"errors"
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InviteRecruiter(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req InviteRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		resp, err := svc.Invite(audit.Context(c), auth, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to invite recruiter")
+			return
+		}
+
+		status := http.StatusCreated
+		if resp.Status == "granted" {
+			status = http.StatusOK
+		}
+		c.JSON(status, resp)
+	}
+}
+
+func AcceptInvite(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req AcceptInviteRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := svc.Accept(c.Request.Context(), req); err != nil {
+			writeServiceError(c, err, "failed to accept invitation")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Account created. Please login.",
+		})
+	}
+}
+
+func ListRecruiters(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		recruiters, err := svc.List(c.Request.Context(), auth)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch recruiters")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": recruiters})
+	}
+}
+
+func RevokeRecruiterJob(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		recruiterID, err1 := strconv.ParseUint(c.Param("id"), 10, 64)
+		jobID, err2 := strconv.ParseUint(c.Param("job_id"), 10, 64)
+		if err1 != nil || err2 != nil || recruiterID == 0 || jobID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+			return
+		}
+
+		if err := svc.Revoke(audit.Context(c), auth, uint(recruiterID), uint(jobID)); err != nil {
+			writeServiceError(c, err, "failed to revoke access")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "access revoked"})
+	}
+}
+
+func GetMyJobs(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		jobs, err := svc.Jobs(c.Request.Context(), auth)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch jobs")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": jobs})
+	}
+}
+
+var serviceErrorStatus = map[error]int{
+	ErrForbidden:       http.StatusForbidden,
+	ErrJobNotFound:     http.StatusNotFound,
+	ErrEmailTaken:      http.StatusConflict,
+	ErrCompanyMismatch: http.StatusConflict,
+	ErrInviteInvalid:   http.StatusBadRequest,
+	ErrGrantNotFound:   http.StatusNotFound,
+}
+
+// writeServiceError maps rule violations to their status; anything else
+// is a 500 with the fallback message.
+func writeServiceError(c *gin.Context, err error, fallback string) {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": verr.Error()})
+		return
+	}
+
+	for target, status := range serviceErrorStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": target.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
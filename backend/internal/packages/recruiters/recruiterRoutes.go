@@ -0,0 +1,57 @@
+package recruiters
+
+import (
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/packages/keycloak"
+	"iiitn-career-portal/internal/packages/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func newService(db *gorm.DB, cfg config.Config, kc *keycloak.Client) *Service {
+	return NewService(NewGormRepository(db), kc, cfg.FrontendURL)
+}
+
+// RegisterPublicRoutes mounts invitation acceptance, which the invitee
+// calls before having an account.
+func RegisterPublicRoutes(rg *gin.RouterGroup, db *gorm.DB, cfg config.Config, kc *keycloak.Client, limiter *ratelimit.Limiter) {
+	acceptLimit := limiter.Middleware(
+		ratelimit.PolicyFromConfig(cfg, "recruiter_accept_ip", "10/1h", ratelimit.ByIP),
+	)
+
+	rg.POST("/recruiters/invitations/accept", acceptLimit, AcceptInvite(newService(db, cfg, kc)))
+}
+
+func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB, cfg config.Config, kc *keycloak.Client) {
+	svc := newService(db, cfg, kc)
+
+	recruiters := rg.Group("/recruiters")
+	{
+		// College admin only
+		recruiters.POST(
+			"/invitations",
+			authorization.RequireRole(string(models.CollegeAdmin)),
+			InviteRecruiter(svc),
+		)
+		recruiters.GET(
+			"",
+			authorization.RequireRole(string(models.CollegeAdmin)),
+			ListRecruiters(svc),
+		)
+		recruiters.DELETE(
+			"/:id/jobs/:job_id",
+			authorization.RequireRole(string(models.CollegeAdmin)),
+			RevokeRecruiterJob(svc),
+		)
+
+		// Recruiter only; applicants are served by /applications
+		recruiters.GET(
+			"/me/jobs",
+			authorization.RequireRole(string(models.Recruiter)),
+			GetMyJobs(svc),
+		)
+	}
+}
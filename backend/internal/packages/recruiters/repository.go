@@ -0,0 +1,241 @@
+package recruiters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/audit"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) CollegeJobs(ctx context.Context, collegeID uint, ids []uint) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Where(`(college_id = ? OR EXISTS (
+			SELECT 1 FROM job_colleges
+			WHERE job_colleges.job_id = jobs.id AND job_colleges.college_id = ?
+		))`, collegeID, collegeID).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *gormRepository) FindOrCreateCompany(ctx context.Context, name string) (models.Company, error) {
+	db := r.db.WithContext(ctx)
+
+	var company models.Company
+	err := db.Where("LOWER(name) = ?", strings.ToLower(name)).Take(&company).Error
+	if err == nil {
+		return company, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return company, err
+	}
+
+	company = models.Company{Name: name}
+	if err := db.Create(&company).Error; err != nil {
+		return models.Company{}, err
+	}
+	return company, nil
+}
+
+func (r *gormRepository) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("LOWER(email) = ?", email).Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *gormRepository) Grant(ctx context.Context, grants []models.RecruiterJob) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&grants)
+		if res.Error != nil {
+			return res.Error
+		}
+		// every grant already existed: nothing changed
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		jobIDs := make([]uint, len(grants))
+		for i, g := range grants {
+			jobIDs[i] = g.JobID
+		}
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "recruiter.grant",
+			EntityType: "user",
+			EntityID:   grants[0].RecruiterID,
+			CollegeID:  &grants[0].CollegeID,
+			Diff:       audit.Created(map[string]interface{}{"job_ids": jobIDs}),
+		})
+	})
+}
+
+func (r *gormRepository) Revoke(ctx context.Context, recruiterID, jobID, collegeID uint) (bool, error) {
+	revoked := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Where("recruiter_id = ? AND job_id = ? AND college_id = ?", recruiterID, jobID, collegeID).
+			Delete(&models.RecruiterJob{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		revoked = true
+
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "recruiter.revoke",
+			EntityType: "user",
+			EntityID:   recruiterID,
+			CollegeID:  &collegeID,
+			Diff: audit.Diff(
+				map[string]interface{}{"job_id": jobID},
+				map[string]interface{}{"job_id": nil},
+			),
+		})
+	})
+	return revoked, err
+}
+
+func (r *gormRepository) CreateInvite(ctx context.Context, invite *models.RecruiterInvite) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(invite).Error; err != nil {
+			return err
+		}
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "recruiter.invite",
+			EntityType: "recruiter_invite",
+			EntityID:   invite.ID,
+			CollegeID:  &invite.CollegeID,
+			Diff: audit.Created(map[string]interface{}{
+				"email":      invite.Email,
+				"company_id": invite.CompanyID,
+				"job_ids":    invite.JobIDs,
+				"expires_at": invite.ExpiresAt,
+			}),
+		})
+	})
+}
+
+func (r *gormRepository) FindInvite(ctx context.Context, tokenHash string) (*models.RecruiterInvite, error) {
+	var invite models.RecruiterInvite
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ? AND accepted_at IS NULL", tokenHash).
+		Take(&invite).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+func (r *gormRepository) AcceptInvite(ctx context.Context, invite models.RecruiterInvite, user *models.User, at time.Time) error {
+	var jobIDs []uint
+	if err := json.Unmarshal(invite.JobIDs, &jobIDs); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// a concurrent accept of the same link loses here
+		res := tx.Model(&models.RecruiterInvite{}).
+			Where("id = ? AND accepted_at IS NULL", invite.ID).
+			Update("accepted_at", at)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrInviteInvalid
+		}
+
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		grants := grantsFor(user.ID, invite.CollegeID, invite.InvitedBy, jobIDs)
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&grants).Error
+	})
+}
+
+func (r *gormRepository) CollegeRecruiters(ctx context.Context, collegeID uint) ([]RecruiterResponse, error) {
+	db := r.db.WithContext(ctx)
+
+	var grants []struct {
+		RecruiterID uint
+		JobID       uint
+	}
+	if err := db.Model(&models.RecruiterJob{}).
+		Select("recruiter_id, job_id").
+		Where("college_id = ?", collegeID).
+		Order("recruiter_id, job_id").
+		Scan(&grants).Error; err != nil {
+		return nil, err
+	}
+	if len(grants) == 0 {
+		return []RecruiterResponse{}, nil
+	}
+
+	jobsOf := map[uint][]uint{}
+	ids := []uint{}
+	for _, g := range grants {
+		if _, ok := jobsOf[g.RecruiterID]; !ok {
+			ids = append(ids, g.RecruiterID)
+		}
+		jobsOf[g.RecruiterID] = append(jobsOf[g.RecruiterID], g.JobID)
+	}
+
+	var recruiters []RecruiterResponse
+	if err := db.Table("users").
+		Select("users.id, users.name, users.email, companies.name AS company").
+		Joins("LEFT JOIN companies ON companies.id = users.company_id").
+		Where("users.id IN ?", ids).
+		Order("users.id").
+		Scan(&recruiters).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range recruiters {
+		recruiters[i].JobIDs = jobsOf[recruiters[i].ID]
+	}
+	return recruiters, nil
+}
+
+func (r *gormRepository) RecruiterJobs(ctx context.Context, recruiterID uint) ([]RecruiterJobResponse, error) {
+	jobs := []RecruiterJobResponse{}
+	err := r.db.WithContext(ctx).
+		Table("recruiter_jobs").
+		Select(`
+			jobs.id AS job_id,
+			jobs.title,
+			jobs.company,
+			recruiter_jobs.college_id,
+			(
+				SELECT COUNT(*) FROM applications
+				WHERE applications.job_id = recruiter_jobs.job_id
+					AND applications.college_id = recruiter_jobs.college_id
+			) AS applicants
+		`).
+		Joins("JOIN jobs ON jobs.id = recruiter_jobs.job_id").
+		Where("recruiter_jobs.recruiter_id = ?", recruiterID).
+		Order("jobs.id, recruiter_jobs.college_id").
+		Scan(&jobs).Error
+	return jobs, err
+}
@@ -0,0 +1,297 @@
+package recruiters
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// how long an invitation link stays valid
+const inviteTTL = 7 * 24 * time.Hour
+
+// at most this many jobs per invitation
+const maxInviteJobs = 50
+
+// Rule violations. Their messages are what the API returns.
+var (
+	ErrForbidden       = errors.New("access denied")
+	ErrJobNotFound     = errors.New("job not found")
+	ErrEmailTaken      = errors.New("email belongs to a non-recruiter account")
+	ErrCompanyMismatch = errors.New("recruiter belongs to another company")
+	ErrInviteInvalid   = errors.New("invitation is invalid or has expired")
+	ErrGrantNotFound   = errors.New("recruiter has no access to this job")
+)
+
+// ValidationError is a bad request; the message is returned as is.
+type ValidationError struct {
+	msg string
+}
+
+func (e *ValidationError) Error() string { return e.msg }
+
+func invalid(msg string) error { return &ValidationError{msg: msg} }
+
+// Repository is the persistence the recruiter rules need.
+type Repository interface {
+	// CollegeJobs returns the jobs among ids the college may share: its
+	// own and the pooled drives it takes part in.
+	CollegeJobs(ctx context.Context, collegeID uint, ids []uint) ([]models.Job, error)
+	// FindOrCreateCompany matches names case-insensitively.
+	FindOrCreateCompany(ctx context.Context, name string) (models.Company, error)
+	// FindUserByEmail returns nil when there is none.
+	FindUserByEmail(ctx context.Context, email string) (*models.User, error)
+
+	// Grant is idempotent.
+	Grant(ctx context.Context, grants []models.RecruiterJob) error
+	Revoke(ctx context.Context, recruiterID, jobID, collegeID uint) (bool, error)
+
+	CreateInvite(ctx context.Context, invite *models.RecruiterInvite) error
+	// FindInvite looks an unaccepted invitation up by token hash; nil when
+	// there is none.
+	FindInvite(ctx context.Context, tokenHash string) (*models.RecruiterInvite, error)
+	// AcceptInvite creates the recruiter, its grants and marks the
+	// invitation accepted, atomically.
+	AcceptInvite(ctx context.Context, invite models.RecruiterInvite, user *models.User, at time.Time) error
+
+	// CollegeRecruiters lists the recruiters holding grants of the college.
+	CollegeRecruiters(ctx context.Context, collegeID uint) ([]RecruiterResponse, error)
+	// RecruiterJobs lists the recruiter's jobs with their applicant counts.
+	RecruiterJobs(ctx context.Context, recruiterID uint) ([]RecruiterJobResponse, error)
+}
+
+// Identity is the account store recruiters sign in with (Keycloak).
+type Identity interface {
+	CreateUser(ctx context.Context, email, password, name string) (string, error)
+	AssignRealmRole(ctx context.Context, userID, roleName string) error
+	DeleteUser(ctx context.Context, userID string)
+}
+
+type Service struct {
+	repo        Repository
+	identity    Identity
+	frontendURL string
+	now         func() time.Time
+}
+
+func NewService(repo Repository, identity Identity, frontendURL string) *Service {
+	return &Service{repo: repo, identity: identity, frontendURL: frontendURL, now: time.Now}
+}
+
+// Invite gives a recruiter access to the admin's applicants for the
+// given jobs. Existing recruiters are granted access directly; anyone
+// else gets an invitation link to create their account with.
+func (s *Service) Invite(ctx context.Context, auth *authorization.AuthContext, req InviteRequest) (InviteResponse, error) {
+	if auth.CollegeID == nil {
+		return InviteResponse{}, ErrForbidden
+	}
+	collegeID := *auth.CollegeID
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if _, err := mail.ParseAddress(email); err != nil {
+		return InviteResponse{}, invalid("invalid email")
+	}
+	company := strings.TrimSpace(req.Company)
+	if company == "" {
+		return InviteResponse{}, invalid("company is required")
+	}
+	jobIDs := uniqueIDs(req.JobIDs)
+	if len(jobIDs) == 0 {
+		return InviteResponse{}, invalid("job_ids required")
+	}
+	if len(jobIDs) > maxInviteJobs {
+		return InviteResponse{}, invalid("too many jobs in one invitation")
+	}
+
+	jobs, err := s.repo.CollegeJobs(ctx, collegeID, jobIDs)
+	if err != nil {
+		return InviteResponse{}, err
+	}
+	if len(jobs) != len(jobIDs) {
+		return InviteResponse{}, ErrJobNotFound
+	}
+
+	existing, err := s.repo.FindUserByEmail(ctx, email)
+	if err != nil {
+		return InviteResponse{}, err
+	}
+	if existing != nil && existing.Role != string(models.Recruiter) {
+		return InviteResponse{}, ErrEmailTaken
+	}
+
+	comp, err := s.repo.FindOrCreateCompany(ctx, company)
+	if err != nil {
+		return InviteResponse{}, err
+	}
+
+	if existing != nil {
+		if existing.CompanyID == nil || *existing.CompanyID != comp.ID {
+			return InviteResponse{}, ErrCompanyMismatch
+		}
+		if err := s.repo.Grant(ctx, grantsFor(existing.ID, collegeID, auth.UserID, jobIDs)); err != nil {
+			return InviteResponse{}, err
+		}
+		return InviteResponse{Status: "granted", RecruiterID: &existing.ID}, nil
+	}
+
+	token, hash, err := newToken()
+	if err != nil {
+		return InviteResponse{}, err
+	}
+	jobsJSON, err := json.Marshal(jobIDs)
+	if err != nil {
+		return InviteResponse{}, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = email
+	}
+
+	invite := models.RecruiterInvite{
+		Email:     email,
+		Name:      name,
+		CompanyID: comp.ID,
+		CollegeID: collegeID,
+		InvitedBy: auth.UserID,
+		JobIDs:    jobsJSON,
+		TokenHash: hash,
+		ExpiresAt: s.now().Add(inviteTTL),
+	}
+	if err := s.repo.CreateInvite(ctx, &invite); err != nil {
+		return InviteResponse{}, err
+	}
+
+	// there is no mailer: the admin forwards the link
+	return InviteResponse{
+		Status:    "invited",
+		InviteURL: s.frontendURL + "/recruiter/accept?token=" + url.QueryEscape(token),
+		ExpiresAt: &invite.ExpiresAt,
+	}, nil
+}
+
+// Accept creates the recruiter account of an invitation. The identity
+// account is removed again if anything after it fails.
+func (s *Service) Accept(ctx context.Context, req AcceptInviteRequest) error {
+	invite, err := s.repo.FindInvite(ctx, hashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	if invite == nil || !s.now().Before(invite.ExpiresAt) {
+		return ErrInviteInvalid
+	}
+
+	existing, err := s.repo.FindUserByEmail(ctx, invite.Email)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrEmailTaken
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = invite.Name
+	}
+
+	kcUserID, err := s.identity.CreateUser(ctx, invite.Email, req.Password, name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			s.identity.DeleteUser(context.WithoutCancel(ctx), kcUserID)
+		}
+	}()
+
+	if err = s.identity.AssignRealmRole(ctx, kcUserID, string(models.Recruiter)); err != nil {
+		return err
+	}
+
+	user := models.User{
+		KeycloakID: kcUserID,
+		Email:      invite.Email,
+		Name:       name,
+		CompanyID:  &invite.CompanyID,
+		Role:       string(models.Recruiter),
+	}
+	err = s.repo.AcceptInvite(ctx, *invite, &user, s.now())
+	return err
+}
+
+// List returns the recruiters with access to the admin's college.
+func (s *Service) List(ctx context.Context, auth *authorization.AuthContext) ([]RecruiterResponse, error) {
+	if auth.CollegeID == nil {
+		return nil, ErrForbidden
+	}
+	return s.repo.CollegeRecruiters(ctx, *auth.CollegeID)
+}
+
+// Revoke removes a recruiter's access to the admin's applicants for a job.
+func (s *Service) Revoke(ctx context.Context, auth *authorization.AuthContext, recruiterID, jobID uint) error {
+	if auth.CollegeID == nil {
+		return ErrForbidden
+	}
+	ok, err := s.repo.Revoke(ctx, recruiterID, jobID, *auth.CollegeID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrGrantNotFound
+	}
+	return nil
+}
+
+// Jobs lists the recruiter's own jobs.
+func (s *Service) Jobs(ctx context.Context, auth *authorization.AuthContext) ([]RecruiterJobResponse, error) {
+	return s.repo.RecruiterJobs(ctx, auth.UserID)
+}
+
+func grantsFor(recruiterID, collegeID, invitedBy uint, jobIDs []uint) []models.RecruiterJob {
+	grants := make([]models.RecruiterJob, len(jobIDs))
+	for i, jobID := range jobIDs {
+		grants[i] = models.RecruiterJob{
+			RecruiterID: recruiterID,
+			JobID:       jobID,
+			CollegeID:   collegeID,
+			InvitedBy:   invitedBy,
+		}
+	}
+	return grants
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	out := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// newToken returns an invitation token and the hash stored for it.
+func newToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,338 @@
+package recruiters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeRepo struct {
+	jobs      map[uint]models.Job
+	pools     map[uint][]uint // job -> participating colleges
+	users     map[string]*models.User
+	companies []models.Company
+	grants    map[models.RecruiterJob]bool
+	invites   []*models.RecruiterInvite
+
+	acceptErr error
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		jobs:   map[uint]models.Job{},
+		pools:  map[uint][]uint{},
+		users:  map[string]*models.User{},
+		grants: map[models.RecruiterJob]bool{},
+	}
+}
+
+func (r *fakeRepo) CollegeJobs(_ context.Context, collegeID uint, ids []uint) ([]models.Job, error) {
+	var out []models.Job
+	for _, id := range ids {
+		job, ok := r.jobs[id]
+		if !ok {
+			continue
+		}
+		shared := job.CollegeID == collegeID
+		for _, c := range r.pools[id] {
+			shared = shared || c == collegeID
+		}
+		if shared {
+			out = append(out, job)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) FindOrCreateCompany(_ context.Context, name string) (models.Company, error) {
+	for _, c := range r.companies {
+		if strings.EqualFold(c.Name, name) {
+			return c, nil
+		}
+	}
+	c := models.Company{ID: uint(len(r.companies) + 1), Name: name}
+	r.companies = append(r.companies, c)
+	return c, nil
+}
+
+func (r *fakeRepo) FindUserByEmail(_ context.Context, email string) (*models.User, error) {
+	return r.users[email], nil
+}
+
+func (r *fakeRepo) Grant(_ context.Context, grants []models.RecruiterJob) error {
+	for _, g := range grants {
+		g.InvitedBy = 0
+		r.grants[g] = true
+	}
+	return nil
+}
+
+func (r *fakeRepo) Revoke(_ context.Context, recruiterID, jobID, collegeID uint) (bool, error) {
+	key := models.RecruiterJob{RecruiterID: recruiterID, JobID: jobID, CollegeID: collegeID}
+	ok := r.grants[key]
+	delete(r.grants, key)
+	return ok, nil
+}
+
+func (r *fakeRepo) CreateInvite(_ context.Context, invite *models.RecruiterInvite) error {
+	invite.ID = uint(len(r.invites) + 1)
+	r.invites = append(r.invites, invite)
+	return nil
+}
+
+func (r *fakeRepo) FindInvite(_ context.Context, hash string) (*models.RecruiterInvite, error) {
+	for _, inv := range r.invites {
+		if inv.TokenHash == hash && inv.AcceptedAt == nil {
+			return inv, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepo) AcceptInvite(_ context.Context, invite models.RecruiterInvite, user *models.User, at time.Time) error {
+	if r.acceptErr != nil {
+		return r.acceptErr
+	}
+	var jobIDs []uint
+	if err := json.Unmarshal(invite.JobIDs, &jobIDs); err != nil {
+		return err
+	}
+
+	user.ID = uint(100 + len(r.users))
+	r.users[user.Email] = user
+	for _, id := range jobIDs {
+		r.grants[models.RecruiterJob{RecruiterID: user.ID, JobID: id, CollegeID: invite.CollegeID}] = true
+	}
+	for _, inv := range r.invites {
+		if inv.ID == invite.ID {
+			inv.AcceptedAt = &at
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) CollegeRecruiters(context.Context, uint) ([]RecruiterResponse, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) RecruiterJobs(context.Context, uint) ([]RecruiterJobResponse, error) {
+	return nil, nil
+}
+
+type fakeIdentity struct {
+	created []string
+	roles   map[string]string
+	deleted []string
+	roleErr error
+}
+
+func (f *fakeIdentity) CreateUser(_ context.Context, email, _, _ string) (string, error) {
+	f.created = append(f.created, email)
+	return "kc-" + email, nil
+}
+
+func (f *fakeIdentity) AssignRealmRole(_ context.Context, userID, role string) error {
+	if f.roleErr != nil {
+		return f.roleErr
+	}
+	f.roles[userID] = role
+	return nil
+}
+
+func (f *fakeIdentity) DeleteUser(_ context.Context, userID string) {
+	f.deleted = append(f.deleted, userID)
+}
+
+func newTestService() (*Service, *fakeRepo, *fakeIdentity) {
+	repo := newFakeRepo()
+	repo.jobs[1] = models.Job{ID: 1, CollegeID: 10}
+	repo.jobs[2] = models.Job{ID: 2, CollegeID: 11}
+	repo.jobs[3] = models.Job{ID: 3, CollegeID: 11}
+	repo.pools[3] = []uint{10}
+
+	identity := &fakeIdentity{roles: map[string]string{}}
+	svc := NewService(repo, identity, "https://portal.test")
+	svc.now = func() time.Time { return now }
+	return svc, repo, identity
+}
+
+func collegeAdmin(userID, college uint) *authorization.AuthContext {
+	return &authorization.AuthContext{UserID: userID, Role: string(models.CollegeAdmin), CollegeID: &college}
+}
+
+func tokenOf(t *testing.T, resp InviteResponse) string {
+	t.Helper()
+	u, err := url.Parse(resp.InviteURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Query().Get("token")
+}
+
+func TestInviteValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  InviteRequest
+		want error
+	}{
+		{"own job", InviteRequest{Email: "hr@acme.test", Company: "Acme", JobIDs: []uint{1}}, nil},
+		{"pooled drive", InviteRequest{Email: "hr@acme.test", Company: "Acme", JobIDs: []uint{3}}, nil},
+		{"other college's job", InviteRequest{Email: "hr@acme.test", Company: "Acme", JobIDs: []uint{1, 2}}, ErrJobNotFound},
+		{"unknown job", InviteRequest{Email: "hr@acme.test", Company: "Acme", JobIDs: []uint{9}}, ErrJobNotFound},
+		{"bad email", InviteRequest{Email: "not an email", Company: "Acme", JobIDs: []uint{1}}, &ValidationError{}},
+		{"no company", InviteRequest{Email: "hr@acme.test", Company: " ", JobIDs: []uint{1}}, &ValidationError{}},
+		{"no jobs", InviteRequest{Email: "hr@acme.test", Company: "Acme", JobIDs: []uint{0}}, &ValidationError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+
+			resp, err := svc.Invite(context.Background(), collegeAdmin(1, 10), tt.req)
+
+			var verr *ValidationError
+			switch {
+			case tt.want == nil:
+				if err != nil || resp.Status != "invited" || len(repo.invites) != 1 {
+					t.Fatalf("resp = %+v, err = %v", resp, err)
+				}
+			case errors.As(tt.want, &verr):
+				if !errors.As(err, &verr) {
+					t.Fatalf("err = %v, want a validation error", err)
+				}
+			case !errors.Is(err, tt.want):
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInviteStoresOnlyTheTokenHash(t *testing.T) {
+	svc, repo, _ := newTestService()
+
+	resp, err := svc.Invite(context.Background(), collegeAdmin(1, 10), InviteRequest{
+		Email: "HR@Acme.test", Company: "Acme", JobIDs: []uint{1, 1, 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := tokenOf(t, resp)
+	invite := repo.invites[0]
+	if token == "" || invite.TokenHash == token || invite.TokenHash != hashToken(token) {
+		t.Fatalf("token %q stored as %q", token, invite.TokenHash)
+	}
+	if invite.Email != "hr@acme.test" || string(invite.JobIDs) != "[1,3]" || !invite.ExpiresAt.Equal(now.Add(inviteTTL)) {
+		t.Fatalf("invite = %+v", invite)
+	}
+}
+
+func TestInviteExistingRecruiter(t *testing.T) {
+	ctx := context.Background()
+	acme := uint(1)
+
+	svc, repo, identity := newTestService()
+	repo.companies = []models.Company{{ID: acme, Name: "Acme"}}
+	repo.users["hr@acme.test"] = &models.User{ID: 50, Email: "hr@acme.test", Role: string(models.Recruiter), CompanyID: &acme}
+	repo.users["student@iiitn.test"] = &models.User{ID: 51, Email: "student@iiitn.test", Role: string(models.Student)}
+
+	resp, err := svc.Invite(ctx, collegeAdmin(1, 10), InviteRequest{Email: "hr@acme.test", Company: "ACME", JobIDs: []uint{1}})
+	if err != nil || resp.Status != "granted" || *resp.RecruiterID != 50 {
+		t.Fatalf("resp = %+v, err = %v", resp, err)
+	}
+	if !repo.grants[models.RecruiterJob{RecruiterID: 50, JobID: 1, CollegeID: 10}] || len(repo.invites) != 0 || len(identity.created) != 0 {
+		t.Fatalf("grants = %v, invites = %d", repo.grants, len(repo.invites))
+	}
+
+	if _, err := svc.Invite(ctx, collegeAdmin(1, 10), InviteRequest{Email: "hr@acme.test", Company: "Globex", JobIDs: []uint{1}}); !errors.Is(err, ErrCompanyMismatch) {
+		t.Fatalf("other company: err = %v, want ErrCompanyMismatch", err)
+	}
+	if _, err := svc.Invite(ctx, collegeAdmin(1, 10), InviteRequest{Email: "student@iiitn.test", Company: "Acme", JobIDs: []uint{1}}); !errors.Is(err, ErrEmailTaken) {
+		t.Fatalf("student email: err = %v, want ErrEmailTaken", err)
+	}
+}
+
+func TestAccept(t *testing.T) {
+	ctx := context.Background()
+
+	svc, repo, identity := newTestService()
+	resp, err := svc.Invite(ctx, collegeAdmin(1, 10), InviteRequest{Email: "hr@acme.test", Company: "Acme", JobIDs: []uint{1, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := tokenOf(t, resp)
+
+	if err := svc.Accept(ctx, AcceptInviteRequest{Token: "forged", Password: "password1"}); !errors.Is(err, ErrInviteInvalid) {
+		t.Fatalf("forged token: err = %v, want ErrInviteInvalid", err)
+	}
+
+	if err := svc.Accept(ctx, AcceptInviteRequest{Token: token, Password: "password1"}); err != nil {
+		t.Fatal(err)
+	}
+	user := repo.users["hr@acme.test"]
+	if user == nil || user.Role != string(models.Recruiter) || user.CollegeID != nil || *user.CompanyID != 1 {
+		t.Fatalf("user = %+v", user)
+	}
+	if identity.roles["kc-hr@acme.test"] != "recruiter" {
+		t.Fatalf("realm roles = %v", identity.roles)
+	}
+	for _, job := range []uint{1, 3} {
+		if !repo.grants[models.RecruiterJob{RecruiterID: user.ID, JobID: job, CollegeID: 10}] {
+			t.Fatalf("missing grant for job %d: %v", job, repo.grants)
+		}
+	}
+
+	if err := svc.Accept(ctx, AcceptInviteRequest{Token: token, Password: "password1"}); !errors.Is(err, ErrInviteInvalid) {
+		t.Fatalf("second accept: err = %v, want ErrInviteInvalid", err)
+	}
+}
+
+func TestAcceptExpiredOrFailing(t *testing.T) {
+	ctx := context.Background()
+
+	svc, repo, identity := newTestService()
+	resp, err := svc.Invite(ctx, collegeAdmin(1, 10), InviteRequest{Email: "hr@acme.test", Company: "Acme", JobIDs: []uint{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := tokenOf(t, resp)
+
+	repo.acceptErr = errors.New("db down")
+	if err := svc.Accept(ctx, AcceptInviteRequest{Token: token, Password: "password1"}); err == nil {
+		t.Fatal("accept should fail")
+	}
+	if len(identity.deleted) != 1 || identity.deleted[0] != "kc-hr@acme.test" {
+		t.Fatalf("identity account not cleaned up: %v", identity.deleted)
+	}
+
+	svc.now = func() time.Time { return now.Add(inviteTTL) }
+	repo.acceptErr = nil
+	if err := svc.Accept(ctx, AcceptInviteRequest{Token: token, Password: "password1"}); !errors.Is(err, ErrInviteInvalid) {
+		t.Fatalf("expired: err = %v, want ErrInviteInvalid", err)
+	}
+}
+
+func TestRevokeIsScopedToTheCollege(t *testing.T) {
+	ctx := context.Background()
+
+	svc, repo, _ := newTestService()
+	repo.grants[models.RecruiterJob{RecruiterID: 50, JobID: 3, CollegeID: 11}] = true
+
+	if err := svc.Revoke(ctx, collegeAdmin(1, 10), 50, 3); !errors.Is(err, ErrGrantNotFound) {
+		t.Fatalf("other college's grant: err = %v, want ErrGrantNotFound", err)
+	}
+	if err := svc.Revoke(ctx, collegeAdmin(2, 11), 50, 3); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.grants) != 0 {
+		t.Fatalf("grants = %v", repo.grants)
+	}
+}
@@ -0,0 +1,91 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/pagination"
+	"time"
+)
+
+type CreateEndpointRequest struct {
+	URL         string                `json:"url" binding:"required"`
+	Events      []models.WebhookEvent `json:"events" binding:"required,min=1"`
+	Description string                `json:"description"`
+}
+
+type UpdateEndpointRequest struct {
+	URL         *string                `json:"url"`
+	Events      *[]models.WebhookEvent `json:"events"`
+	Description *string                `json:"description"`
+	IsActive    *bool                  `json:"is_active"`
+}
+
+// EndpointResponse carries the secret only when it was just created or
+// rotated.
+type EndpointResponse struct {
+	ID          uint                  `json:"id"`
+	URL         string                `json:"url"`
+	Events      []models.WebhookEvent `json:"events"`
+	Description string                `json:"description"`
+	IsActive    bool                  `json:"is_active"`
+	Secret      string                `json:"secret,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+type DeliveryListQuery struct {
+	pagination.Params
+
+	Status models.WebhookDeliveryStatus `form:"status" binding:"omitempty,oneof=PENDING SUCCEEDED FAILED"`
+
+	// decoded Params.Cursor; nil on the first page
+	After *pagination.Cursor `form:"-"`
+}
+
+type DeliveryResponse struct {
+	ID             uint                         `json:"id"`
+	EndpointID     uint                         `json:"endpoint_id"`
+	Event          models.WebhookEvent          `json:"event"`
+	Payload        json.RawMessage              `json:"payload"`
+	Status         models.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	NextAttemptAt  *time.Time                   `json:"next_attempt_at"`
+	LastStatusCode *int                         `json:"last_status_code"`
+	LastError      string                       `json:"last_error"`
+	DeliveredAt    *time.Time                   `json:"delivered_at"`
+	CreatedAt      time.Time                    `json:"created_at"`
+}
+
+// DeliveryPage is one page of the delivery log. Total is nil when not
+// counted; NextCursor is empty on the last keyset page.
+type DeliveryPage struct {
+	Items      []DeliveryResponse
+	Total      *int64
+	NextCursor string
+}
+
+func deliveryOf(d models.WebhookDelivery) DeliveryResponse {
+	return DeliveryResponse{
+		ID:             d.ID,
+		EndpointID:     d.EndpointID,
+		Event:          d.Event,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+// envelope is the body every delivery posts. id identifies the event and
+// stays the same across retries and redeliveries.
+type envelope struct {
+	ID        string              `json:"id"`
+	Event     models.WebhookEvent `json:"event"`
+	CollegeID uint                `json:"college_id"`
+	CreatedAt time.Time           `json:"created_at"`
+	Data      any                 `json:"data"`
+}
@@ -0,0 +1,271 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/pagination"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// the only order of the delivery log: newest first
+const listSort = "latest"
+
+var listKeyset = pagination.Keyset{
+	Column:   "webhook_deliveries.created_at",
+	IDColumn: "webhook_deliveries.id",
+	Desc:     true,
+}
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+// Enqueue publishes the event inside the caller's transaction, so the
+// deliveries exist exactly when the change they describe commits.
+func Enqueue(ctx context.Context, tx *gorm.DB, collegeID uint, event models.WebhookEvent, data any) error {
+	return NewService(NewGormRepository(tx), URLPolicy{}).Publish(ctx, collegeID, event, data)
+}
+
+func (r *gormRepository) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(endpoint).Error; err != nil {
+			return err
+		}
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "webhook.create",
+			EntityType: "webhook",
+			EntityID:   endpoint.ID,
+			CollegeID:  &endpoint.CollegeID,
+			Diff: audit.Created(map[string]interface{}{
+				"url":       endpoint.URL,
+				"events":    endpoint.Events,
+				"is_active": endpoint.IsActive,
+			}),
+		})
+	})
+}
+
+func (r *gormRepository) CountEndpoints(ctx context.Context, collegeID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.WebhookEndpoint{}).
+		Where("college_id = ?", collegeID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *gormRepository) FindEndpoint(ctx context.Context, id, collegeID uint) (models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND college_id = ?", id, collegeID).
+		Take(&endpoint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return endpoint, ErrEndpointNotFound
+	}
+	return endpoint, err
+}
+
+func (r *gormRepository) ListEndpoints(ctx context.Context, collegeID uint) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	err := r.db.WithContext(ctx).
+		Where("college_id = ?", collegeID).
+		Order("id").
+		Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *gormRepository) UpdateEndpoint(ctx context.Context, endpoint models.WebhookEndpoint, updates map[string]interface{}) error {
+	before := map[string]interface{}{
+		"url":         endpoint.URL,
+		"events":      endpoint.Events,
+		"description": endpoint.Description,
+		"is_active":   endpoint.IsActive,
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&models.WebhookEndpoint{}).
+			Where("id = ?", endpoint.ID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+
+		// never write secrets into the audit log
+		diff := audit.Diff(before, updates)
+		action := "webhook.update"
+		if _, ok := updates["secret"]; ok {
+			delete(diff, "secret")
+			action = "webhook.rotate_secret"
+		}
+
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     action,
+			EntityType: "webhook",
+			EntityID:   endpoint.ID,
+			CollegeID:  &endpoint.CollegeID,
+			Diff:       diff,
+		})
+	})
+}
+
+func (r *gormRepository) DeleteEndpoint(ctx context.Context, endpoint models.WebhookEndpoint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("endpoint_id = ?", endpoint.ID).
+			Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&endpoint).Error; err != nil {
+			return err
+		}
+		return audit.RecordContext(ctx, tx, audit.Entry{
+			Action:     "webhook.delete",
+			EntityType: "webhook",
+			EntityID:   endpoint.ID,
+			CollegeID:  &endpoint.CollegeID,
+			Diff: audit.Diff(
+				map[string]interface{}{"url": endpoint.URL},
+				map[string]interface{}{"url": nil},
+			),
+		})
+	})
+}
+
+func (r *gormRepository) ActiveEndpoints(ctx context.Context, collegeID uint) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	err := r.db.WithContext(ctx).
+		Where("college_id = ? AND is_active", collegeID).
+		Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *gormRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Create(&deliveries).Error
+}
+
+func (r *gormRepository) ListDeliveries(ctx context.Context, endpointID uint, q DeliveryListQuery) (DeliveryPage, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Where("endpoint_id = ?", endpointID)
+	if q.Status != "" {
+		query = query.Where("status = ?", q.Status)
+	}
+
+	var page DeliveryPage
+	if q.WithTotal() {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return DeliveryPage{}, err
+		}
+		page.Total = &total
+	}
+
+	query = query.Order(listKeyset.OrderBy())
+
+	if !q.Keyset() {
+		query = query.Limit(q.Limit).Offset(q.Offset())
+	} else {
+		if q.After != nil {
+			var after time.Time
+			if err := q.After.Scan(&after); err != nil {
+				return DeliveryPage{}, err
+			}
+			cond, args := listKeyset.After(after, q.After.ID)
+			query = query.Where(cond, args...)
+		}
+		// one extra row tells whether there is a next page
+		query = query.Limit(q.Limit + 1)
+	}
+
+	var rows []models.WebhookDelivery
+	if err := query.Find(&rows).Error; err != nil {
+		return DeliveryPage{}, err
+	}
+
+	if q.Keyset() && len(rows) > q.Limit {
+		rows = rows[:q.Limit]
+		last := rows[len(rows)-1]
+		page.NextCursor = pagination.NewCursor(listSort, last.CreatedAt, last.ID).Encode()
+	}
+
+	page.Items = make([]DeliveryResponse, len(rows))
+	for i, d := range rows {
+		page.Items[i] = deliveryOf(d)
+	}
+	return page, nil
+}
+
+func (r *gormRepository) FindDelivery(ctx context.Context, id, endpointID uint) (models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND endpoint_id = ?", id, endpointID).
+		Take(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return delivery, ErrDeliveryNotFound
+	}
+	return delivery, err
+}
+
+func (r *gormRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var due []models.WebhookDelivery
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+			Order("next_attempt_at, id").
+			Limit(limit).
+			Find(&due).Error; err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(due))
+		for i, d := range due {
+			ids[i] = d.ID
+		}
+		return tx.
+			Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil || len(due) == 0 {
+		return nil, err
+	}
+
+	endpointIDs := make([]uint, len(due))
+	for i, d := range due {
+		endpointIDs[i] = d.EndpointID
+	}
+	var endpoints []models.WebhookEndpoint
+	if err := r.db.WithContext(ctx).Where("id IN ?", endpointIDs).Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.WebhookEndpoint, len(endpoints))
+	for _, e := range endpoints {
+		byID[e.ID] = e
+	}
+	for i := range due {
+		due[i].Endpoint = byID[due[i].EndpointID]
+	}
+
+	return due, nil
+}
+
+func (r *gormRepository) RecordAttempt(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+}
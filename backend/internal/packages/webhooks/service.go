@@ -0,0 +1,428 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/pagination"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// at most this many endpoints per college
+const maxEndpoints = 10
+
+// Rule violations. Their messages are what the API returns.
+var (
+	ErrForbidden        = errors.New("access denied")
+	ErrEndpointNotFound = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("delivery not found")
+	ErrTooManyEndpoints = errors.New("too many webhooks for this college")
+)
+
+// ValidationError is a bad request; the message is returned as is.
+type ValidationError struct {
+	msg string
+}
+
+func (e *ValidationError) Error() string { return e.msg }
+
+func invalid(msg string) error { return &ValidationError{msg: msg} }
+
+// Events is every event endpoints can subscribe to.
+var Events = []models.WebhookEvent{
+	models.WebhookJobCreated,
+	models.WebhookJobUpdated,
+	models.WebhookJobDeleted,
+	models.WebhookApplicationConfirmed,
+	models.WebhookApplicationStatusChanged,
+}
+
+// Repository is the persistence of endpoints and their delivery log.
+// Endpoint changes record their audit entry in the same transaction.
+type Repository interface {
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	CountEndpoints(ctx context.Context, collegeID uint) (int64, error)
+	// FindEndpoint is college-scoped; returns ErrEndpointNotFound.
+	FindEndpoint(ctx context.Context, id, collegeID uint) (models.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, collegeID uint) ([]models.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint models.WebhookEndpoint, updates map[string]interface{}) error
+	DeleteEndpoint(ctx context.Context, endpoint models.WebhookEndpoint) error
+	// ActiveEndpoints lists the college's active endpoints, whatever they
+	// subscribed to.
+	ActiveEndpoints(ctx context.Context, collegeID uint) ([]models.WebhookEndpoint, error)
+
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	// ListDeliveries returns the endpoint's deliveries, newest first.
+	ListDeliveries(ctx context.Context, endpointID uint, q DeliveryListQuery) (DeliveryPage, error)
+	// FindDelivery returns ErrDeliveryNotFound.
+	FindDelivery(ctx context.Context, id, endpointID uint) (models.WebhookDelivery, error)
+
+	// ClaimDue leases up to limit pending deliveries that are due, so
+	// other workers skip them until the attempt is recorded.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+	// RecordAttempt stores the outcome of an attempt.
+	RecordAttempt(ctx context.Context, id uint, updates map[string]interface{}) error
+}
+
+// URLPolicy restricts where endpoints may point.
+type URLPolicy struct {
+	RequireHTTPS bool
+	// loopback, private and link-local addresses
+	AllowPrivate bool
+}
+
+func PolicyFromConfig(cfg config.Config) URLPolicy {
+	return URLPolicy{
+		RequireHTTPS: cfg.IsProduction(),
+		AllowPrivate: cfg.Webhooks.AllowPrivate,
+	}
+}
+
+type Service struct {
+	repo   Repository
+	policy URLPolicy
+	now    func() time.Time
+}
+
+func NewService(repo Repository, policy URLPolicy) *Service {
+	return &Service{repo: repo, policy: policy, now: time.Now}
+}
+
+// Publish queues a delivery of the event for every active endpoint of
+// the college subscribed to it.
+func (s *Service) Publish(ctx context.Context, collegeID uint, event models.WebhookEvent, data any) error {
+	endpoints, err := s.repo.ActiveEndpoints(ctx, collegeID)
+	if err != nil {
+		return err
+	}
+
+	var subscribed []models.WebhookEndpoint
+	for _, e := range endpoints {
+		if subscribes(e, event) {
+			subscribed = append(subscribed, e)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	id, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	payload, err := json.Marshal(envelope{
+		ID:        "evt_" + id,
+		Event:     event,
+		CollegeID: collegeID,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]models.WebhookDelivery, len(subscribed))
+	for i, e := range subscribed {
+		deliveries[i] = models.WebhookDelivery{
+			EndpointID:    e.ID,
+			Event:         event,
+			Payload:       payload,
+			Status:        models.DeliveryPending,
+			NextAttemptAt: &now,
+		}
+	}
+	return s.repo.CreateDeliveries(ctx, deliveries)
+}
+
+func (s *Service) Create(ctx context.Context, auth *authorization.AuthContext, req CreateEndpointRequest) (EndpointResponse, error) {
+	if auth.CollegeID == nil {
+		return EndpointResponse{}, ErrForbidden
+	}
+
+	target, err := s.checkURL(req.URL)
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+	eventsJSON, err := eventsOf(req.Events)
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+
+	count, err := s.repo.CountEndpoints(ctx, *auth.CollegeID)
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+	if count >= maxEndpoints {
+		return EndpointResponse{}, ErrTooManyEndpoints
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+
+	endpoint := models.WebhookEndpoint{
+		CollegeID:   *auth.CollegeID,
+		URL:         target,
+		Secret:      secret,
+		Events:      eventsJSON,
+		Description: strings.TrimSpace(req.Description),
+		IsActive:    true,
+		CreatedBy:   auth.UserID,
+	}
+	if err := s.repo.CreateEndpoint(ctx, &endpoint); err != nil {
+		return EndpointResponse{}, err
+	}
+
+	resp, err := endpointResponse(endpoint)
+	resp.Secret = secret
+	return resp, err
+}
+
+func (s *Service) List(ctx context.Context, auth *authorization.AuthContext) ([]EndpointResponse, error) {
+	if auth.CollegeID == nil {
+		return nil, ErrForbidden
+	}
+
+	endpoints, err := s.repo.ListEndpoints(ctx, *auth.CollegeID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]EndpointResponse, len(endpoints))
+	for i, e := range endpoints {
+		if out[i], err = endpointResponse(e); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (s *Service) Update(ctx context.Context, auth *authorization.AuthContext, id uint, req UpdateEndpointRequest) (EndpointResponse, error) {
+	endpoint, err := s.endpoint(ctx, auth, id)
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+
+	updates := map[string]interface{}{}
+	if req.URL != nil {
+		if endpoint.URL, err = s.checkURL(*req.URL); err != nil {
+			return EndpointResponse{}, err
+		}
+		updates["url"] = endpoint.URL
+	}
+	if req.Events != nil {
+		if endpoint.Events, err = eventsOf(*req.Events); err != nil {
+			return EndpointResponse{}, err
+		}
+		updates["events"] = endpoint.Events
+	}
+	if req.Description != nil {
+		endpoint.Description = strings.TrimSpace(*req.Description)
+		updates["description"] = endpoint.Description
+	}
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+		updates["is_active"] = endpoint.IsActive
+	}
+	if len(updates) == 0 {
+		return EndpointResponse{}, invalid("no fields to update")
+	}
+
+	if err := s.repo.UpdateEndpoint(ctx, endpoint, updates); err != nil {
+		return EndpointResponse{}, err
+	}
+	endpoint.UpdatedAt = s.now()
+	return endpointResponse(endpoint)
+}
+
+// RotateSecret replaces the signing secret; deliveries still pending are
+// signed with the new one.
+func (s *Service) RotateSecret(ctx context.Context, auth *authorization.AuthContext, id uint) (EndpointResponse, error) {
+	endpoint, err := s.endpoint(ctx, auth, id)
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+	if err := s.repo.UpdateEndpoint(ctx, endpoint, map[string]interface{}{"secret": secret}); err != nil {
+		return EndpointResponse{}, err
+	}
+
+	endpoint.UpdatedAt = s.now()
+	resp, err := endpointResponse(endpoint)
+	resp.Secret = secret
+	return resp, err
+}
+
+// Delete removes the endpoint together with its delivery log.
+func (s *Service) Delete(ctx context.Context, auth *authorization.AuthContext, id uint) error {
+	endpoint, err := s.endpoint(ctx, auth, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteEndpoint(ctx, endpoint)
+}
+
+func (s *Service) Deliveries(ctx context.Context, auth *authorization.AuthContext, id uint, q DeliveryListQuery) (DeliveryPage, DeliveryListQuery, error) {
+	q.Normalize(20, 100)
+
+	endpoint, err := s.endpoint(ctx, auth, id)
+	if err != nil {
+		return DeliveryPage{}, q, err
+	}
+
+	if q.Keyset() {
+		after, err := pagination.Decode(*q.Cursor, listSort)
+		if err == nil && after != nil {
+			err = after.Scan(new(time.Time))
+		}
+		if err != nil {
+			return DeliveryPage{}, q, pagination.ErrInvalidCursor
+		}
+		q.After = after
+	}
+
+	page, err := s.repo.ListDeliveries(ctx, endpoint.ID, q)
+	return page, q, err
+}
+
+// Redeliver queues the delivery's payload again as a new delivery, with
+// the same event id so receivers can tell it is a repeat.
+func (s *Service) Redeliver(ctx context.Context, auth *authorization.AuthContext, id, deliveryID uint) (DeliveryResponse, error) {
+	endpoint, err := s.endpoint(ctx, auth, id)
+	if err != nil {
+		return DeliveryResponse{}, err
+	}
+	original, err := s.repo.FindDelivery(ctx, deliveryID, endpoint.ID)
+	if err != nil {
+		return DeliveryResponse{}, err
+	}
+
+	now := s.now()
+	delivery := models.WebhookDelivery{
+		EndpointID:    endpoint.ID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        models.DeliveryPending,
+		NextAttemptAt: &now,
+	}
+	deliveries := []models.WebhookDelivery{delivery}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return DeliveryResponse{}, err
+	}
+	return deliveryOf(deliveries[0]), nil
+}
+
+func (s *Service) endpoint(ctx context.Context, auth *authorization.AuthContext, id uint) (models.WebhookEndpoint, error) {
+	if auth.CollegeID == nil {
+		return models.WebhookEndpoint{}, ErrForbidden
+	}
+	return s.repo.FindEndpoint(ctx, id, *auth.CollegeID)
+}
+
+// checkURL returns the normalized target or why it is refused. Hosts
+// resolving to private addresses are refused again when delivering.
+func (s *Service) checkURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", invalid("url must be an absolute http(s) URL")
+	}
+	if u.Scheme != "https" && s.policy.RequireHTTPS {
+		return "", invalid("url must use https")
+	}
+	if u.User != nil {
+		return "", invalid("url must not contain credentials")
+	}
+	if !s.policy.AllowPrivate {
+		host := u.Hostname()
+		if ip := net.ParseIP(host); (ip != nil && isPrivate(ip)) || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+			return "", invalid("url must not point at a private address")
+		}
+	}
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+func eventsOf(events []models.WebhookEvent) ([]byte, error) {
+	seen := map[models.WebhookEvent]bool{}
+	out := []models.WebhookEvent{}
+	for _, e := range events {
+		if !isEvent(e) {
+			return nil, invalid(fmt.Sprintf("unknown event %q", e))
+		}
+		if !seen[e] {
+			seen[e] = true
+			out = append(out, e)
+		}
+	}
+	if len(out) == 0 {
+		return nil, invalid("events required")
+	}
+	return json.Marshal(out)
+}
+
+func isEvent(e models.WebhookEvent) bool {
+	for _, known := range Events {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
+
+func subscribes(endpoint models.WebhookEndpoint, event models.WebhookEvent) bool {
+	var events []models.WebhookEvent
+	if err := json.Unmarshal(endpoint.Events, &events); err != nil {
+		return false
+	}
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func endpointResponse(e models.WebhookEndpoint) (EndpointResponse, error) {
+	var events []models.WebhookEvent
+	if err := json.Unmarshal(e.Events, &events); err != nil {
+		return EndpointResponse{}, err
+	}
+	return EndpointResponse{
+		ID:          e.ID,
+		URL:         e.URL,
+		Events:      events,
+		Description: e.Description,
+		IsActive:    e.IsActive,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}, nil
+}
+
+func newSecret() (string, error) {
+	s, err := randomHex(32)
+	return "whsec_" + s, err
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
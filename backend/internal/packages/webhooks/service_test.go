@@ -0,0 +1,385 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeRepo struct {
+	endpoints  map[uint]*models.WebhookEndpoint
+	deliveries []*models.WebhookDelivery
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{endpoints: map[uint]*models.WebhookEndpoint{}}
+}
+
+func (r *fakeRepo) CreateEndpoint(_ context.Context, endpoint *models.WebhookEndpoint) error {
+	endpoint.ID = uint(len(r.endpoints) + 1)
+	e := *endpoint
+	r.endpoints[e.ID] = &e
+	return nil
+}
+
+func (r *fakeRepo) CountEndpoints(_ context.Context, collegeID uint) (int64, error) {
+	var n int64
+	for _, e := range r.endpoints {
+		if e.CollegeID == collegeID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeRepo) FindEndpoint(_ context.Context, id, collegeID uint) (models.WebhookEndpoint, error) {
+	e, ok := r.endpoints[id]
+	if !ok || e.CollegeID != collegeID {
+		return models.WebhookEndpoint{}, ErrEndpointNotFound
+	}
+	return *e, nil
+}
+
+func (r *fakeRepo) ListEndpoints(_ context.Context, collegeID uint) ([]models.WebhookEndpoint, error) {
+	var out []models.WebhookEndpoint
+	for _, e := range r.endpoints {
+		if e.CollegeID == collegeID {
+			out = append(out, *e)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) UpdateEndpoint(_ context.Context, endpoint models.WebhookEndpoint, updates map[string]interface{}) error {
+	e := r.endpoints[endpoint.ID]
+	if v, ok := updates["secret"]; ok {
+		e.Secret = v.(string)
+	}
+	if v, ok := updates["is_active"]; ok {
+		e.IsActive = v.(bool)
+	}
+	if v, ok := updates["url"]; ok {
+		e.URL = v.(string)
+	}
+	return nil
+}
+
+func (r *fakeRepo) DeleteEndpoint(_ context.Context, endpoint models.WebhookEndpoint) error {
+	delete(r.endpoints, endpoint.ID)
+	return nil
+}
+
+func (r *fakeRepo) ActiveEndpoints(_ context.Context, collegeID uint) ([]models.WebhookEndpoint, error) {
+	var out []models.WebhookEndpoint
+	for _, e := range r.endpoints {
+		if e.CollegeID == collegeID && e.IsActive {
+			out = append(out, *e)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) CreateDeliveries(_ context.Context, deliveries []models.WebhookDelivery) error {
+	for i := range deliveries {
+		deliveries[i].ID = uint(len(r.deliveries) + 1)
+		d := deliveries[i]
+		r.deliveries = append(r.deliveries, &d)
+	}
+	return nil
+}
+
+func (r *fakeRepo) ListDeliveries(context.Context, uint, DeliveryListQuery) (DeliveryPage, error) {
+	return DeliveryPage{}, nil
+}
+
+func (r *fakeRepo) FindDelivery(_ context.Context, id, endpointID uint) (models.WebhookDelivery, error) {
+	for _, d := range r.deliveries {
+		if d.ID == id && d.EndpointID == endpointID {
+			return *d, nil
+		}
+	}
+	return models.WebhookDelivery{}, ErrDeliveryNotFound
+}
+
+func (r *fakeRepo) ClaimDue(_ context.Context, at time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var out []models.WebhookDelivery
+	for _, d := range r.deliveries {
+		if len(out) == limit {
+			break
+		}
+		if d.Status != models.DeliveryPending || d.NextAttemptAt == nil || d.NextAttemptAt.After(at) {
+			continue
+		}
+		leased := at.Add(lease)
+		d.NextAttemptAt = &leased
+
+		claimed := *d
+		claimed.Endpoint = *r.endpoints[d.EndpointID]
+		out = append(out, claimed)
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) RecordAttempt(_ context.Context, id uint, updates map[string]interface{}) error {
+	d := r.deliveries[id-1]
+	d.Attempts = updates["attempts"].(int)
+	if v, ok := updates["status"]; ok {
+		d.Status = v.(models.WebhookDeliveryStatus)
+	}
+	if v, ok := updates["next_attempt_at"]; ok {
+		if t, ok := v.(time.Time); ok {
+			d.NextAttemptAt = &t
+		} else {
+			d.NextAttemptAt = nil
+		}
+	}
+	if v, ok := updates["last_status_code"]; ok {
+		code := v.(int)
+		d.LastStatusCode = &code
+	}
+	if v, ok := updates["last_error"]; ok {
+		d.LastError = v.(string)
+	}
+	return nil
+}
+
+func collegeAdmin(collegeID uint) *authorization.AuthContext {
+	return &authorization.AuthContext{UserID: 1, Role: string(models.CollegeAdmin), CollegeID: &collegeID}
+}
+
+func newTestService(repo Repository, policy URLPolicy) *Service {
+	s := NewService(repo, policy)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestCreateValidatesURL(t *testing.T) {
+	prod := newTestService(newFakeRepo(), URLPolicy{RequireHTTPS: true})
+	events := []models.WebhookEvent{models.WebhookJobCreated}
+
+	refused := map[string]string{
+		"ftp://hooks.example.com":           "absolute http(s)",
+		"/relative":                         "absolute http(s)",
+		"http://hooks.example.com":          "https",
+		"https://user:pw@hooks.example.com": "credentials",
+		"https://127.0.0.1/hook":            "private",
+		"https://10.1.2.3/hook":             "private",
+		"https://169.254.169.254/latest":    "private",
+		"https://localhost:8443/hook":       "private",
+	}
+	for raw, want := range refused {
+		_, err := prod.Create(context.Background(), collegeAdmin(1), CreateEndpointRequest{URL: raw, Events: events})
+		var verr *ValidationError
+		if !errors.As(err, &verr) || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", raw, err, want)
+		}
+	}
+
+	_, err := prod.Create(context.Background(), collegeAdmin(1), CreateEndpointRequest{
+		URL: "https://hooks.example.com/x", Events: []models.WebhookEvent{"job.exploded"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unknown event") {
+		t.Fatalf("unknown event: err = %v", err)
+	}
+
+	// outside production plain http and private targets may be allowed
+	dev := newTestService(newFakeRepo(), URLPolicy{AllowPrivate: true})
+	if _, err := dev.Create(context.Background(), collegeAdmin(1), CreateEndpointRequest{URL: "http://127.0.0.1:9000/hook", Events: events}); err != nil {
+		t.Fatalf("dev create: %v", err)
+	}
+}
+
+func TestCreateLimitsEndpointsPerCollege(t *testing.T) {
+	svc := newTestService(newFakeRepo(), URLPolicy{})
+	req := CreateEndpointRequest{URL: "https://hooks.example.com", Events: []models.WebhookEvent{models.WebhookJobCreated}}
+
+	for i := 0; i < maxEndpoints; i++ {
+		resp, err := svc.Create(context.Background(), collegeAdmin(1), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(resp.Secret, "whsec_") {
+			t.Fatalf("secret = %q", resp.Secret)
+		}
+	}
+	if _, err := svc.Create(context.Background(), collegeAdmin(1), req); !errors.Is(err, ErrTooManyEndpoints) {
+		t.Fatalf("err = %v, want ErrTooManyEndpoints", err)
+	}
+	// the limit is per college
+	if _, err := svc.Create(context.Background(), collegeAdmin(2), req); err != nil {
+		t.Fatalf("other college: %v", err)
+	}
+}
+
+func TestPublishOnlyToSubscribedActiveEndpoints(t *testing.T) {
+	repo := newFakeRepo()
+	svc := newTestService(repo, URLPolicy{})
+
+	add := func(collegeID uint, active bool, events ...models.WebhookEvent) uint {
+		raw, _ := json.Marshal(events)
+		e := models.WebhookEndpoint{CollegeID: collegeID, URL: "https://hooks.example.com", Events: raw, IsActive: active}
+		_ = repo.CreateEndpoint(context.Background(), &e)
+		return e.ID
+	}
+	jobs := add(1, true, models.WebhookJobCreated, models.WebhookJobUpdated)
+	add(1, true, models.WebhookApplicationConfirmed)
+	add(1, false, models.WebhookJobCreated)
+	add(2, true, models.WebhookJobCreated)
+
+	if err := svc.Publish(context.Background(), 1, models.WebhookJobCreated, map[string]uint{"id": 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.deliveries) != 1 || repo.deliveries[0].EndpointID != jobs {
+		t.Fatalf("deliveries = %+v", repo.deliveries)
+	}
+	d := repo.deliveries[0]
+	if d.Status != models.DeliveryPending || d.NextAttemptAt == nil || !d.NextAttemptAt.Equal(now) {
+		t.Fatalf("delivery = %+v", d)
+	}
+
+	var body envelope
+	if err := json.Unmarshal(d.Payload, &body); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(body.ID, "evt_") || body.Event != models.WebhookJobCreated || body.CollegeID != 1 {
+		t.Fatalf("envelope = %+v", body)
+	}
+}
+
+func TestRedeliverKeepsPayloadAndScope(t *testing.T) {
+	repo := newFakeRepo()
+	svc := newTestService(repo, URLPolicy{})
+
+	resp, err := svc.Create(context.Background(), collegeAdmin(1), CreateEndpointRequest{
+		URL: "https://hooks.example.com", Events: []models.WebhookEvent{models.WebhookJobDeleted},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Publish(context.Background(), 1, models.WebhookJobDeleted, map[string]uint{"id": 3}); err != nil {
+		t.Fatal(err)
+	}
+	repo.deliveries[0].Status = models.DeliveryFailed
+
+	if _, err := svc.Redeliver(context.Background(), collegeAdmin(2), resp.ID, 1); !errors.Is(err, ErrEndpointNotFound) {
+		t.Fatalf("other college: err = %v", err)
+	}
+
+	again, err := svc.Redeliver(context.Background(), collegeAdmin(1), resp.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID == 1 || again.Status != models.DeliveryPending || string(again.Payload) != string(repo.deliveries[0].Payload) {
+		t.Fatalf("redelivery = %+v", again)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, want := range map[int]time.Duration{
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		5:  16 * time.Minute,
+		9:  256 * time.Minute,
+		10: 6 * time.Hour,
+		30: 6 * time.Hour,
+	} {
+		if got := backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestWorkerRetriesThenGivesUp(t *testing.T) {
+	var signatures []string
+	// only the second event is accepted
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signatures = append(signatures, r.Header.Get("X-Webhook-Signature"))
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"n":1`) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	repo := newFakeRepo()
+	svc := newTestService(repo, URLPolicy{AllowPrivate: true})
+	resp, err := svc.Create(context.Background(), collegeAdmin(1), CreateEndpointRequest{
+		URL: srv.URL, Events: []models.WebhookEvent{models.WebhookJobCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := svc.Publish(context.Background(), 1, models.WebhookJobCreated, map[string]int{"n": i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clock := now
+	w := NewWorker(repo, config.WebhookConfig{Timeout: time.Second, MaxAttempts: 2, AllowPrivate: true})
+	w.now = func() time.Time { return clock }
+
+	if n, err := w.RunOnce(context.Background()); err != nil || n != 2 {
+		t.Fatalf("first run: n=%d err=%v", n, err)
+	}
+	failing, accepted := repo.deliveries[0], repo.deliveries[1]
+	if failing.Status != models.DeliveryPending || failing.Attempts != 1 || *failing.LastStatusCode != 500 ||
+		!failing.NextAttemptAt.Equal(clock.Add(time.Minute)) {
+		t.Fatalf("after a failure: %+v", failing)
+	}
+	if accepted.Status != models.DeliverySucceeded || accepted.Attempts != 1 || accepted.NextAttemptAt != nil {
+		t.Fatalf("after success: %+v", accepted)
+	}
+	ts := strings.TrimPrefix(strings.Split(signatures[0], ",")[0], "t=")
+	if signatures[0] != Sign(resp.Secret, ts, failing.Payload) {
+		t.Fatalf("signature %q does not verify", signatures[0])
+	}
+
+	// nothing is due before the backoff elapses
+	if n, _ := w.RunOnce(context.Background()); n != 0 {
+		t.Fatalf("ran %d deliveries early", n)
+	}
+
+	clock = clock.Add(time.Minute)
+	if n, err := w.RunOnce(context.Background()); err != nil || n != 1 {
+		t.Fatalf("retry: n=%d err=%v", n, err)
+	}
+	if failing.Status != models.DeliveryFailed || failing.Attempts != 2 || failing.NextAttemptAt != nil {
+		t.Fatalf("after the last attempt: %+v", failing)
+	}
+}
+
+func TestWorkerRefusesPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("private address was reached")
+	}))
+	defer srv.Close()
+
+	repo := newFakeRepo()
+	endpoint := models.WebhookEndpoint{CollegeID: 1, URL: srv.URL, Events: []byte(`["job.created"]`), IsActive: true}
+	_ = repo.CreateEndpoint(context.Background(), &endpoint)
+	_ = repo.CreateDeliveries(context.Background(), []models.WebhookDelivery{
+		{EndpointID: endpoint.ID, Event: models.WebhookJobCreated, Payload: []byte(`{}`), Status: models.DeliveryPending, NextAttemptAt: &now},
+	})
+
+	w := NewWorker(repo, config.WebhookConfig{Timeout: time.Second, MaxAttempts: 3})
+	w.now = func() time.Time { return now }
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if d := repo.deliveries[0]; d.Status != models.DeliveryPending || !strings.Contains(d.LastError, errPrivateAddress.Error()) {
+		t.Fatalf("delivery = %+v", d)
+	}
+}
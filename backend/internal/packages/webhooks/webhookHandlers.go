@@ -0,0 +1,195 @@
+package webhooks
+
+import (
+	"errors"
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/pagination"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateWebhook(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		var req CreateEndpointRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		endpoint, err := svc.Create(audit.Context(c), auth, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to create webhook")
+			return
+		}
+
+		c.JSON(http.StatusCreated, endpoint)
+	}
+}
+
+func ListWebhooks(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		endpoints, err := svc.List(c.Request.Context(), auth)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch webhooks")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": endpoints})
+	}
+}
+
+func UpdateWebhook(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := paramID(c, "id")
+		if !ok {
+			return
+		}
+
+		var req UpdateEndpointRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		endpoint, err := svc.Update(audit.Context(c), auth, id, req)
+		if err != nil {
+			writeServiceError(c, err, "failed to update webhook")
+			return
+		}
+
+		c.JSON(http.StatusOK, endpoint)
+	}
+}
+
+func DeleteWebhook(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := paramID(c, "id")
+		if !ok {
+			return
+		}
+
+		if err := svc.Delete(audit.Context(c), auth, id); err != nil {
+			writeServiceError(c, err, "failed to delete webhook")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "webhook deleted"})
+	}
+}
+
+func RotateWebhookSecret(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := paramID(c, "id")
+		if !ok {
+			return
+		}
+
+		endpoint, err := svc.RotateSecret(audit.Context(c), auth, id)
+		if err != nil {
+			writeServiceError(c, err, "failed to rotate secret")
+			return
+		}
+
+		c.JSON(http.StatusOK, endpoint)
+	}
+}
+
+func ListWebhookDeliveries(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := paramID(c, "id")
+		if !ok {
+			return
+		}
+
+		var q DeliveryListQuery
+		if err := c.ShouldBindQuery(&q); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+			return
+		}
+
+		page, q, err := svc.Deliveries(c.Request.Context(), auth, id, q)
+		if err != nil {
+			writeServiceError(c, err, "failed to fetch deliveries")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": page.Items,
+			"meta": pagination.Meta(q.Params, page.Total, page.NextCursor),
+		})
+	}
+}
+
+func RedeliverWebhook(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := c.MustGet("auth").(*authorization.AuthContext)
+
+		id, ok := paramID(c, "id")
+		if !ok {
+			return
+		}
+		deliveryID, ok := paramID(c, "delivery_id")
+		if !ok {
+			return
+		}
+
+		delivery, err := svc.Redeliver(c.Request.Context(), auth, id, deliveryID)
+		if err != nil {
+			writeServiceError(c, err, "failed to queue redelivery")
+			return
+		}
+
+		c.JSON(http.StatusAccepted, delivery)
+	}
+}
+
+func paramID(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+var serviceErrorStatus = map[error]int{
+	ErrForbidden:                http.StatusForbidden,
+	ErrEndpointNotFound:         http.StatusNotFound,
+	ErrDeliveryNotFound:         http.StatusNotFound,
+	ErrTooManyEndpoints:         http.StatusConflict,
+	pagination.ErrInvalidCursor: http.StatusBadRequest,
+}
+
+// writeServiceError maps rule violations to their status; anything else
+// is a 500 with the fallback message.
+func writeServiceError(c *gin.Context, err error, fallback string) {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": verr.Error()})
+		return
+	}
+
+	for target, status := range serviceErrorStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": target.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
@@ -0,0 +1,26 @@
+package webhooks
+
+import (
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/packages/authorization"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, db *gorm.DB, cfg config.Config) {
+	svc := NewService(NewGormRepository(db), PolicyFromConfig(cfg))
+
+	// College admin only; endpoints are scoped to the admin's college
+	webhooks := rg.Group("/webhooks", authorization.RequireRole(string(models.CollegeAdmin)))
+	{
+		webhooks.POST("", CreateWebhook(svc))
+		webhooks.GET("", ListWebhooks(svc))
+		webhooks.PATCH("/:id", UpdateWebhook(svc))
+		webhooks.DELETE("/:id", DeleteWebhook(svc))
+		webhooks.POST("/:id/rotate-secret", RotateWebhookSecret(svc))
+		webhooks.GET("/:id/deliveries", ListWebhookDeliveries(svc))
+		webhooks.POST("/:id/deliveries/:delivery_id/redeliver", RedeliverWebhook(svc))
+	}
+}
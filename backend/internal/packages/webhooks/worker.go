@@ -0,0 +1,221 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/models"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	deliveryPollInterval = 5 * time.Second
+	deliveryBatchSize    = 20
+	// a claimed delivery is retried by any worker after this long, should
+	// the one holding it die mid-attempt
+	deliveryLease = 2 * time.Minute
+
+	backoffBase = time.Minute
+	backoffMax  = 6 * time.Hour
+
+	// response bodies are only kept as error context
+	maxErrorBody = 512
+)
+
+var errPrivateAddress = errors.New("webhook target resolves to a private address")
+
+// Worker posts pending deliveries and schedules retries with
+// exponential backoff.
+type Worker struct {
+	repo        Repository
+	client      *http.Client
+	maxAttempts int
+	now         func() time.Time
+}
+
+func NewWorker(repo Repository, cfg config.WebhookConfig) *Worker {
+	return &Worker{
+		repo:        repo,
+		client:      newClient(cfg.Timeout, cfg.AllowPrivate),
+		maxAttempts: cfg.MaxAttempts,
+		now:         time.Now,
+	}
+}
+
+// StartDeliveryWorker delivers webhooks until ctx is cancelled. Several
+// instances may run side by side: deliveries are leased, not shared.
+func StartDeliveryWorker(ctx context.Context, db *gorm.DB, cfg config.WebhookConfig) {
+	w := NewWorker(NewGormRepository(db), cfg)
+
+	ticker := time.NewTicker(deliveryPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// drain the backlog before sleeping again
+		for {
+			n, err := w.RunOnce(ctx)
+			if err != nil {
+				slog.Error("webhook deliveries failed", "error", err)
+			}
+			if err != nil || n < deliveryBatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce attempts one batch of due deliveries and returns its size.
+func (w *Worker) RunOnce(ctx context.Context) (int, error) {
+	due, err := w.repo.ClaimDue(ctx, w.now(), deliveryLease, deliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range due {
+		// an attempt in flight is finished even during shutdown
+		if err := w.attempt(context.WithoutCancel(ctx), d); err != nil {
+			return len(due), err
+		}
+	}
+	return len(due), nil
+}
+
+func (w *Worker) attempt(ctx context.Context, d models.WebhookDelivery) error {
+	attempts := d.Attempts + 1
+	updates := map[string]interface{}{"attempts": attempts}
+
+	if !d.Endpoint.IsActive {
+		updates["status"] = models.DeliveryFailed
+		updates["next_attempt_at"] = nil
+		updates["last_error"] = "endpoint disabled"
+		return w.repo.RecordAttempt(ctx, d.ID, updates)
+	}
+
+	status, err := w.post(ctx, d)
+	if status != 0 {
+		updates["last_status_code"] = status
+	}
+
+	now := w.now()
+	switch {
+	case err == nil:
+		updates["status"] = models.DeliverySucceeded
+		updates["next_attempt_at"] = nil
+		updates["delivered_at"] = now
+		updates["last_error"] = ""
+	case attempts >= w.maxAttempts:
+		updates["status"] = models.DeliveryFailed
+		updates["next_attempt_at"] = nil
+		updates["last_error"] = err.Error()
+	default:
+		updates["next_attempt_at"] = now.Add(backoff(attempts))
+		updates["last_error"] = err.Error()
+	}
+
+	return w.repo.RecordAttempt(ctx, d.ID, updates)
+}
+
+// post sends the delivery; any non-2xx answer is an error.
+func (w *Worker) post(ctx context.Context, d models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Endpoint.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(w.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "iiitn-career-portal-webhooks/1")
+	req.Header.Set("X-Webhook-Event", string(d.Event))
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(d.ID), 10))
+	req.Header.Set("X-Webhook-Signature", Sign(d.Endpoint.Secret, timestamp, d.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint answered %d: %s", resp.StatusCode, body)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign is the X-Webhook-Signature header: the timestamp and an
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the endpoint's secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff is the wait after the given failed attempt: 1m, 2m, 4m, ...
+// capped at 6h.
+func backoff(attempt int) time.Duration {
+	d := backoffBase
+	for i := 1; i < attempt && d < backoffMax; i++ {
+		d *= 2
+	}
+	if d > backoffMax {
+		d = backoffMax
+	}
+	return d
+}
+
+// newClient does not follow redirects, ignores proxies and, unless
+// allowPrivate, refuses to connect to private addresses, whatever the
+// host name resolved to.
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivate(ip) {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func isPrivate(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast()
+}
@@ -0,0 +1,49 @@
+package pagination
+
+import "fmt"
+
+// Keyset is an ORDER BY on one column with the id as tiebreaker, which
+// makes the order total and therefore stable across pages. Nullable
+// columns sort NULLs last in both directions.
+type Keyset struct {
+	Column   string // qualified, e.g. "jobs.ctc"
+	IDColumn string // e.g. "jobs.id"
+	Desc     bool
+	Nullable bool
+}
+
+func (k Keyset) OrderBy() string {
+	dir := "ASC"
+	if k.Desc {
+		dir = "DESC"
+	}
+
+	nulls := ""
+	if k.Nullable {
+		nulls = " NULLS LAST"
+	}
+
+	return fmt.Sprintf("%s %s%s, %s %s", k.Column, dir, nulls, k.IDColumn, dir)
+}
+
+// After returns the WHERE condition selecting the rows that follow the
+// row (value, id) in this order. value is nil for a NULL sort value.
+func (k Keyset) After(value any, id uint) (string, []any) {
+	cmp := ">"
+	if k.Desc {
+		cmp = "<"
+	}
+
+	if value == nil {
+		// only other NULLs follow a NULL
+		return fmt.Sprintf("(%s IS NULL AND %s %s ?)", k.Column, k.IDColumn, cmp), []any{id}
+	}
+
+	cond := fmt.Sprintf("(%[1]s %[3]s ? OR (%[1]s = ? AND %[2]s %[3]s ?))", k.Column, k.IDColumn, cmp)
+	args := []any{value, value, id}
+
+	if k.Nullable {
+		cond = fmt.Sprintf("(%s IS NULL OR %s)", k.Column, cond)
+	}
+	return cond, args
+}
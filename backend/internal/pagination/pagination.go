@@ -0,0 +1,138 @@
+// Package pagination holds what list endpoints share: the paging query
+// parameters, opaque keyset cursors and the keyset SQL.
+//
+// Two modes:
+//
+//	offset  ?page=3&limit=20            total included unless count=false
+//	keyset  ?cursor=&limit=20           first page; follow meta.next_cursor
+//	        ?cursor=<next_cursor>       total only with count=true
+//
+// Keyset pages don't shift when rows are inserted while a client pages
+// through, and skip the COUNT and OFFSET scans.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Params are the paging query parameters. Cursor is nil in offset mode
+// and points at "" for the first keyset page.
+type Params struct {
+	Page   int     `form:"page" json:"page,omitempty"`
+	Limit  int     `form:"limit" json:"limit,omitempty"`
+	Cursor *string `form:"cursor" json:"cursor,omitempty"`
+	Count  *bool   `form:"count" json:"count,omitempty"`
+}
+
+func (p Params) Keyset() bool {
+	return p.Cursor != nil
+}
+
+// WithTotal reports whether the total should be counted: by default in
+// offset mode only.
+func (p Params) WithTotal() bool {
+	if p.Count != nil {
+		return *p.Count
+	}
+	return !p.Keyset()
+}
+
+// Normalize clamps page and limit; def and max bound the limit.
+func (p *Params) Normalize(def, max int) {
+	if p.Page < 1 || p.Keyset() {
+		p.Page = 1
+	}
+	if p.Limit < 1 || p.Limit > max {
+		p.Limit = def
+	}
+}
+
+// Offset of the page in offset mode.
+func (p Params) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// Cursor is the position after the last row of a page: that row's sort
+// value and id, tagged with the sort it was produced under so it can't
+// be replayed against another ordering.
+type Cursor struct {
+	Sort  string          `json:"s"`
+	Value json.RawMessage `json:"v"`
+	ID    uint            `json:"id"`
+}
+
+// NewCursor captures value (nil for SQL NULL) and id.
+func NewCursor(sort string, value any, id uint) Cursor {
+	raw, _ := json.Marshal(value)
+	return Cursor{Sort: sort, Value: raw, ID: id}
+}
+
+// Encode returns the opaque form handed to clients.
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Decode parses an opaque cursor produced under sort. "" is the first
+// page and returns nil.
+func Decode(s, sort string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == 0 || len(c.Value) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != sort {
+		return nil, fmt.Errorf("%w: it belongs to sort %q", ErrInvalidCursor, c.Sort)
+	}
+	return &c, nil
+}
+
+// IsNull reports whether the row the cursor points after had a NULL sort
+// value.
+func (c Cursor) IsNull() bool {
+	return string(c.Value) == "null"
+}
+
+// Scan decodes the sort value into dst (*time.Time, *float64, *string).
+func (c Cursor) Scan(dst any) error {
+	if err := json.Unmarshal(c.Value, dst); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// Meta is the "meta" object of a list response. next_cursor is null on
+// the last keyset page; total is left out when not counted.
+func Meta(p Params, total *int64, next string) gin.H {
+	meta := gin.H{"limit": p.Limit}
+
+	if p.Keyset() {
+		if next == "" {
+			meta["next_cursor"] = nil
+		} else {
+			meta["next_cursor"] = next
+		}
+	} else {
+		meta["page"] = p.Page
+	}
+
+	if total != nil {
+		meta["total"] = *total
+	}
+	return meta
+}
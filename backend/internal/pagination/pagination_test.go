@@ -0,0 +1,79 @@
+package pagination
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 30, 0, 123456000, time.UTC)
+
+	c, err := Decode(NewCursor("latest", at, 42).Encode(), "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got time.Time
+	if err := c.Scan(&got); err != nil || !got.Equal(at) || c.ID != 42 {
+		t.Fatalf("cursor = %+v (%v), err %v", c, got, err)
+	}
+
+	c, err = Decode(NewCursor("ctc_desc", nil, 7).Encode(), "ctc_desc")
+	if err != nil || !c.IsNull() {
+		t.Fatalf("null cursor = %+v, err %v", c, err)
+	}
+
+	if c, err := Decode("", "latest"); c != nil || err != nil {
+		t.Fatalf("empty cursor = %+v, %v", c, err)
+	}
+
+	for _, bad := range []string{"%%%", "bm90IGpzb24", NewCursor("latest", at, 0).Encode()} {
+		if _, err := Decode(bad, "latest"); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Decode(%q) err = %v", bad, err)
+		}
+	}
+	if _, err := Decode(NewCursor("latest", at, 1).Encode(), "ctc_asc"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("cursor accepted under another sort: %v", err)
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	k := Keyset{Column: "jobs.ctc", IDColumn: "jobs.id", Desc: true, Nullable: true}
+
+	if got := k.OrderBy(); got != "jobs.ctc DESC NULLS LAST, jobs.id DESC" {
+		t.Fatalf("OrderBy = %q", got)
+	}
+
+	cond, args := k.After(12.5, 3)
+	if cond != "(jobs.ctc IS NULL OR (jobs.ctc < ? OR (jobs.ctc = ? AND jobs.id < ?)))" ||
+		!reflect.DeepEqual(args, []any{12.5, 12.5, uint(3)}) {
+		t.Fatalf("After = %q %v", cond, args)
+	}
+
+	cond, args = k.After(nil, 3)
+	if cond != "(jobs.ctc IS NULL AND jobs.id < ?)" || !reflect.DeepEqual(args, []any{uint(3)}) {
+		t.Fatalf("After(nil) = %q %v", cond, args)
+	}
+}
+
+func TestParams(t *testing.T) {
+	empty := ""
+	off := false
+
+	p := Params{Page: 4, Limit: 500}
+	p.Normalize(20, 100)
+	if p.Page != 4 || p.Limit != 20 || p.Offset() != 60 || !p.WithTotal() {
+		t.Fatalf("offset params = %+v", p)
+	}
+
+	p = Params{Page: 4, Cursor: &empty}
+	p.Normalize(20, 100)
+	if p.Page != 1 || !p.Keyset() || p.WithTotal() {
+		t.Fatalf("keyset params = %+v", p)
+	}
+
+	if (Params{Count: &off}).WithTotal() {
+		t.Fatal("count=false ignored")
+	}
+}
@@ -0,0 +1,771 @@
+package server
+
+import (
+	"iiitn-career-portal/internal/health"
+	"iiitn-career-portal/internal/models"
+	"iiitn-career-portal/internal/openapi"
+	"iiitn-career-portal/internal/packages/admin"
+	"iiitn-career-portal/internal/packages/alumni"
+	"iiitn-career-portal/internal/packages/applications"
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/packages/auth"
+	"iiitn-career-portal/internal/packages/experiences"
+	"iiitn-career-portal/internal/packages/interviews"
+	"iiitn-career-portal/internal/packages/jobs"
+	"iiitn-career-portal/internal/packages/notifications"
+	"iiitn-career-portal/internal/packages/profile"
+	"iiitn-career-portal/internal/packages/recruiters"
+	"iiitn-career-portal/internal/packages/webhooks"
+	"iiitn-career-portal/internal/pagination"
+	"net/http"
+)
+
+const specPath = "/api/openapi.json"
+
+// jobListQuery is what GetJobs reads from the query string by hand.
+type jobListQuery struct {
+	pagination.Params
+	Sort string `form:"sort" binding:"omitempty,oneof=latest ctc_asc ctc_desc stipend_asc stipend_desc"`
+	jobs.JobFilter
+}
+
+type pageQuery struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type feedQuery struct {
+	Rotate bool `form:"rotate"`
+}
+
+type ssoCallbackQuery struct {
+	Code string `form:"code" binding:"required"`
+}
+
+var (
+	message = openapi.Object{"message": ""}
+	created = openapi.Object{"id": uint(0), "message": ""}
+)
+
+func page(items any) openapi.Object {
+	return openapi.Object{
+		"data": items,
+		"meta": openapi.Object{"page": 0, "limit": 0, "total": int64(0)},
+	}
+}
+
+// cursorPage is a list that also supports keyset paging: meta carries
+// page in offset mode, next_cursor in cursor mode, total when counted.
+func cursorPage(items any) openapi.Object {
+	return openapi.Object{
+		"data": items,
+		"meta": openapi.Object{
+			"page":        0,
+			"limit":       0,
+			"total":       int64(0),
+			"next_cursor": (*string)(nil),
+		},
+	}
+}
+
+// cursorHelp is appended to the description of cursor-capable lists.
+const cursorHelp = "Offset paging with page/limit by default. Pass cursor= (empty) " +
+	"for keyset paging and follow meta.next_cursor until it is null. " +
+	"count=true/false overrides whether meta.total is computed " +
+	"(default: offset mode only)."
+
+func roles(rs ...models.Role) []string {
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = string(r)
+	}
+	return out
+}
+
+// apiSpec documents every route NewRouter registers. TestSpecMatchesRoutes
+// fails when the two drift apart.
+func apiSpec() *openapi.Spec {
+	s := openapi.New(openapi.Info{
+		Title:   "IIITN Career Portal API",
+		Version: "1.0.0",
+		Description: "Generated from the registered routes. Errors are " +
+			"{\"error\": \"...\", \"request_id\": \"...\"}; sessions use the portal_token cookie.",
+	})
+
+	s.Enum(models.Admin, models.CollegeAdmin, models.Student, models.Alumni, models.Recruiter)
+	s.Enum(models.JobIntern, models.JobFTE, models.JobInternPPO)
+	s.Enum(
+		models.DomainFrontend, models.DomainBackend, models.DomainFullstack,
+		models.DomainSDE, models.DomainECE, models.DomainAIML, models.DomainOther,
+	)
+	s.Enum(models.Applied, models.Shortlisted, models.Interview, models.Offered, models.Rejected, models.Withdrawn)
+	s.Enum(models.DifficultyEasy, models.DifficultyMedium, models.DifficultyHard)
+	s.Enum(models.VerdictSelected, models.VerdictRejected, models.VerdictPending)
+	s.Enum(models.ContactNone, models.ContactEmail, models.ContactLinkedin)
+	s.Enum(
+		models.WebhookJobCreated, models.WebhookJobUpdated, models.WebhookJobDeleted,
+		models.WebhookApplicationConfirmed, models.WebhookApplicationStatusChanged,
+	)
+	s.Enum(models.DeliveryPending, models.DeliverySucceeded, models.DeliveryFailed)
+	s.Enum(models.RegistrationVerified, models.RegistrationMissing)
+	s.Enum(models.AlertInstant, models.AlertDaily)
+
+	var (
+		adminOnly    = roles(models.Admin)
+		collegeAdmin = roles(models.CollegeAdmin)
+		student      = roles(models.Student)
+		alumniOnly   = roles(models.Alumni)
+		studentOrCA  = roles(models.Student, models.CollegeAdmin)
+		recruiter    = roles(models.Recruiter)
+		applicants   = roles(models.Student, models.CollegeAdmin, models.Recruiter)
+		statusMovers = roles(models.CollegeAdmin, models.Recruiter)
+		auditReaders = roles(models.Admin, models.CollegeAdmin)
+		discussion   = roles(models.Student, models.Alumni, models.CollegeAdmin)
+		authors      = roles(models.Student, models.Alumni)
+	)
+
+	// -------- meta --------
+
+	s.Route(http.MethodGet, "/health", openapi.Route{
+		Summary:     "Liveness probe",
+		Description: "Alias of /health/live.",
+		Public:      true,
+		Response:    openapi.Object{"status": ""},
+	})
+	s.Route(http.MethodGet, "/health/live", openapi.Route{
+		Summary:     "Liveness probe",
+		Description: "Always 200 while the process serves HTTP; dependencies are not checked.",
+		Public:      true,
+		Response:    openapi.Object{"status": ""},
+	})
+	s.Route(http.MethodGet, "/health/ready", openapi.Route{
+		Summary: "Readiness probe",
+		Description: "Probes postgres, redis, minio and keycloak with a timeout each. " +
+			"503 when draining or a critical dependency (postgres) is down; " +
+			"200 with status \"degraded\" when only optional ones are.",
+		Public: true,
+		Response: openapi.Object{
+			"status": "",
+			"checks": map[string]health.Result{},
+		},
+	})
+	s.Route(http.MethodGet, "/metrics", openapi.Route{
+		Summary:     "Prometheus metrics",
+		Description: "Needs \"Authorization: Bearer <METRICS_TOKEN>\" when METRICS_TOKEN is set.",
+		Public:      true,
+		ContentType: "text/plain",
+	})
+	s.Route(http.MethodGet, specPath, openapi.Route{
+		Summary:  "This document",
+		Public:   true,
+		Response: openapi.Object{},
+	})
+
+	// -------- auth --------
+
+	s.Route(http.MethodPost, "/api/auth/signup", openapi.Route{
+		Summary:  "Create a student account",
+		Public:   true,
+		Body:     auth.SignupRequest{},
+		Status:   http.StatusCreated,
+		Response: message,
+	})
+	s.Route(http.MethodPost, "/api/auth/login", openapi.Route{
+		Summary:     "Sign in with email and password",
+		Description: "Sets the portal_token cookie.",
+		Public:      true,
+		Body:        auth.LoginRequest{},
+		Response:    message,
+	})
+	s.Route(http.MethodGet, "/api/auth/sso/login", openapi.Route{
+		Summary: "Redirect to the Keycloak login page",
+		Public:  true,
+		Status:  http.StatusFound,
+	})
+	s.Route(http.MethodGet, "/api/auth/sso/callback", openapi.Route{
+		Summary:     "Keycloak redirect target",
+		Description: "Sets the portal_token cookie and redirects to the frontend.",
+		Public:      true,
+		Query:       ssoCallbackQuery{},
+		Status:      http.StatusFound,
+	})
+	s.Route(http.MethodGet, "/api/auth/me", openapi.Route{
+		Summary: "Current user",
+		Response: openapi.Object{
+			"id":               uint(0),
+			"name":             "",
+			"email":            "",
+			"role":             models.Role(""),
+			"college_id":       (*uint)(nil),
+			"company_id":       (*uint)(nil),
+			"profile_complete": false,
+		},
+	})
+
+	// -------- colleges --------
+
+	s.Route(http.MethodGet, "/api/colleges", openapi.Route{
+		Summary:  "List colleges",
+		Public:   true,
+		Response: openapi.Object{"data": []models.College{}},
+	})
+	s.Route(http.MethodPost, "/api/colleges", openapi.Route{
+		Summary:  "Create a college",
+		Roles:    adminOnly,
+		Body:     admin.CreateCollegeRequest{},
+		Status:   http.StatusCreated,
+		Response: models.College{},
+	})
+
+	// -------- audit --------
+
+	s.Route(http.MethodGet, "/api/audit-logs", openapi.Route{
+		Summary:  "Search the audit log",
+		Roles:    auditReaders,
+		Query:    audit.ListQuery{},
+		Response: page([]models.AuditLog{}),
+	})
+	s.Route(http.MethodGet, "/api/audit-logs/verify", openapi.Route{
+		Summary: "Verify the audit hash chain",
+		Roles:   auditReaders,
+		Query:   audit.ListQuery{},
+		Response: openapi.Object{
+			"chain_key":     uint(0),
+			"valid":         false,
+			"checked_count": 0,
+			"broken_at_id":  (*uint)(nil),
+			"head_hash":     "",
+		},
+	})
+
+	// -------- profile --------
+
+	s.Route(http.MethodGet, "/api/profile", openapi.Route{
+		Summary: "Own student profile",
+		Roles:   student,
+		Response: openapi.Object{
+			"id":         uint(0),
+			"name":       "",
+			"email":      "",
+			"role":       models.Role(""),
+			"college_id": (*uint)(nil),
+			"profile": openapi.Object{
+				"batch":            (*int)(nil),
+				"cgpa":             (*float32)(nil),
+				"resume_url":       (*string)(nil),
+				"linkedin_id":      (*string)(nil),
+				"profile_complete": false,
+
+				"branch":             (*string)(nil),
+				"roll_number":        (*string)(nil),
+				"tenth_percentage":   (*float32)(nil),
+				"twelfth_percentage": (*float32)(nil),
+				"skills":             []string{},
+				"coding_profiles":    map[string]string{},
+				"projects":           []profile.ProjectResponse{},
+				"internships":        []profile.InternshipResponse{},
+				"certifications":     []profile.CertificationResponse{},
+				"resumes":            []profile.ResumeResponse{},
+				"required_fields":    []string{},
+				"missing_fields":     []string{},
+
+				"share_contact_with_recruiters": false,
+				"hide_cgpa_from_recruiters":     false,
+			},
+		},
+	})
+	s.Route(http.MethodPatch, "/api/profile", openapi.Route{
+		Summary:  "Update own profile",
+		Roles:    student,
+		Body:     profile.UpdateProfileRequest{},
+		Response: message,
+	})
+	s.Route(http.MethodPost, "/api/profile/resume", openapi.Route{
+		Summary:     "Upload a PDF resume",
+		Description: "Replaces the file of the default resume, creating one named \"Resume\" if there is none.",
+		Roles:       student,
+		FileField:   "resume",
+		Response:    openapi.Object{"message": "", "resume_url": ""},
+	})
+	s.Route(http.MethodGet, "/api/profile/resumes", openapi.Route{
+		Summary:  "Own named resumes, oldest first",
+		Roles:    student,
+		Response: openapi.Object{"data": []profile.ResumeResponse{}},
+	})
+	s.Route(http.MethodPost, "/api/profile/resumes", openapi.Route{
+		Summary: "Upload a named resume",
+		Description: "Multipart with a name field and an optional default=true. " +
+			"The first resume becomes the default; UPLOAD_MAX_RESUMES caps how many a student keeps.",
+		Roles:     student,
+		FileField: "resume",
+		Status:    http.StatusCreated,
+		Response:  profile.ResumeResponse{},
+	})
+	s.Route(http.MethodPatch, "/api/profile/resumes/:id", openapi.Route{
+		Summary:     "Rename a resume or make it the default",
+		Description: "is_default only accepts true; the previous default is unset.",
+		Roles:       student,
+		Body:        profile.UpdateResumeRequest{},
+		Response:    profile.ResumeResponse{},
+	})
+	s.Route(http.MethodDelete, "/api/profile/resumes/:id", openapi.Route{
+		Summary:     "Delete a resume",
+		Description: "Deleting the default promotes the newest remaining resume.",
+		Roles:       student,
+		Response:    message,
+	})
+	sections := []struct {
+		path, name string
+		body, item any
+	}{
+		{"projects", "project", profile.ProjectRequest{}, profile.ProjectResponse{}},
+		{"internships", "internship", profile.InternshipRequest{}, profile.InternshipResponse{}},
+		{"certifications", "certification", profile.CertificationRequest{}, profile.CertificationResponse{}},
+	}
+	for _, sec := range sections {
+		s.Route(http.MethodPost, "/api/profile/"+sec.path, openapi.Route{
+			Summary:     "Add a " + sec.name + " to own profile",
+			Description: "At most 20 entries per section.",
+			Roles:       student,
+			Body:        sec.body,
+			Status:      http.StatusCreated,
+			Response:    sec.item,
+		})
+		s.Route(http.MethodPut, "/api/profile/"+sec.path+"/:id", openapi.Route{
+			Summary:  "Replace a " + sec.name + " of own profile",
+			Roles:    student,
+			Body:     sec.body,
+			Response: sec.item,
+		})
+		s.Route(http.MethodDelete, "/api/profile/"+sec.path+"/:id", openapi.Route{
+			Summary:  "Remove a " + sec.name + " from own profile",
+			Roles:    student,
+			Response: message,
+		})
+	}
+	s.Route(http.MethodGet, "/api/profile/requirements", openapi.Route{
+		Summary:  "Profile fields the caller's college requires",
+		Roles:    studentOrCA,
+		Response: profile.RequirementsResponse{},
+	})
+	s.Route(http.MethodPut, "/api/profile/requirements", openapi.Route{
+		Summary:     "Set the college's required profile fields",
+		Description: "Every profile of the college is re-evaluated against the new fields.",
+		Roles:       collegeAdmin,
+		Body:        profile.RequirementsRequest{},
+		Response:    profile.RequirementsUpdate{},
+	})
+
+	// -------- jobs --------
+
+	s.Route(http.MethodGet, "/api/jobs", openapi.Route{
+		Summary:     "List active jobs of the caller's college, pooled drives included",
+		Description: cursorHelp,
+		Query:       jobListQuery{},
+		Response:    cursorPage([]jobs.JobListItem{}),
+	})
+	s.Route(http.MethodPost, "/api/jobs", openapi.Route{
+		Summary:  "Post a job",
+		Roles:    collegeAdmin,
+		Body:     jobs.CreateJobRequest{},
+		Status:   http.StatusCreated,
+		Response: created,
+	})
+	s.Route(http.MethodGet, "/api/jobs/bookmarked", openapi.Route{
+		Summary:     "Bookmarked jobs, most recently bookmarked first",
+		Description: cursorHelp,
+		Roles:       student,
+		Query:       pagination.Params{},
+		Response:    cursorPage([]jobs.BookmarkedJob{}),
+	})
+	s.Route(http.MethodGet, "/api/jobs/:id", openapi.Route{
+		Summary:  "Job details",
+		Response: jobs.JobDetailResponse{},
+	})
+	s.Route(http.MethodPatch, "/api/jobs/:id", openapi.Route{
+		Summary:  "Update a job",
+		Roles:    collegeAdmin,
+		Body:     jobs.UpdateJobRequest{},
+		Response: message,
+	})
+	s.Route(http.MethodDelete, "/api/jobs/:id", openapi.Route{
+		Summary:  "Deactivate a job",
+		Roles:    collegeAdmin,
+		Response: message,
+	})
+	s.Route(http.MethodGet, "/api/jobs/:id/pool", openapi.Route{
+		Summary:     "Colleges a pooled drive is shared with",
+		Description: "The host college sees every entry, a participating college only its own.",
+		Roles:       collegeAdmin,
+		Response:    openapi.Object{"data": []jobs.PoolEntryResponse{}},
+	})
+	s.Route(http.MethodPut, "/api/jobs/:id/pool", openapi.Route{
+		Summary:  "Replace the colleges a drive is pooled with",
+		Roles:    collegeAdmin,
+		Body:     jobs.SetPoolRequest{},
+		Response: openapi.Object{"data": []jobs.PoolEntryResponse{}},
+	})
+	s.Route(http.MethodPatch, "/api/jobs/:id/pool/:college_id", openapi.Route{
+		Summary:  "Update a college's batches or visibility for a pooled drive",
+		Roles:    collegeAdmin,
+		Body:     jobs.UpdatePoolEntryRequest{},
+		Response: jobs.PoolEntryResponse{},
+	})
+	s.Route(http.MethodPost, "/api/jobs/:id/apply", openapi.Route{
+		Summary: "Start an application",
+		Description: "Records an intent and returns the registration form; confirm it via /applications/{id}/confirm with the intent id. " +
+			"resume_id picks the resume to submit; without it the default at confirmation is used.",
+		Roles:        student,
+		Body:         jobs.ApplyRequest{},
+		OptionalBody: true,
+		Response:     openapi.Object{"redirect_url": (*string)(nil), "message": ""},
+	})
+	s.Route(http.MethodPost, "/api/jobs/:id/bookmark", openapi.Route{
+		Summary:  "Bookmark a job",
+		Roles:    student,
+		Response: message,
+	})
+	s.Route(http.MethodDelete, "/api/jobs/:id/bookmark", openapi.Route{
+		Summary:  "Remove a bookmark",
+		Roles:    student,
+		Response: message,
+	})
+	s.Route(http.MethodGet, "/api/jobs/saved-searches", openapi.Route{
+		Summary:  "Saved searches",
+		Roles:    student,
+		Response: openapi.Object{"data": []jobs.SavedSearchResponse{}},
+	})
+	s.Route(http.MethodPost, "/api/jobs/saved-searches", openapi.Route{
+		Summary:     "Save a search",
+		Description: "Matching jobs are alerted by a worker: frequency INSTANT (default) per job, DAILY as one digest.",
+		Roles:       student,
+		Body:        jobs.SavedSearchRequest{},
+		Status:      http.StatusCreated,
+		Response:    created,
+	})
+	s.Route(http.MethodPatch, "/api/jobs/saved-searches/:search_id", openapi.Route{
+		Summary:  "Update a saved search",
+		Roles:    student,
+		Body:     jobs.UpdateSavedSearchRequest{},
+		Response: message,
+	})
+	s.Route(http.MethodDelete, "/api/jobs/saved-searches/:search_id", openapi.Route{
+		Summary:  "Delete a saved search",
+		Roles:    student,
+		Response: message,
+	})
+
+	// -------- applications --------
+
+	s.Route(http.MethodPost, "/api/applications/:id/confirm", openapi.Route{
+		Summary: "Confirm an application intent",
+		Description: "id is the intent's. resume_id replaces the resume chosen when applying. " +
+			"The resume is snapshotted into the application.",
+		Roles:        student,
+		Body:         applications.ConfirmRequest{},
+		OptionalBody: true,
+		Response:     message,
+	})
+	s.Route(http.MethodGet, "/api/applications/intents", openapi.Route{
+		Summary: "Pending application intents",
+		Description: "The student's unexpired intents, soonest to expire first, each with its confirm_url. " +
+			"Intents expire 24h after applying; a reminder is sent 4h before.",
+		Roles:    student,
+		Response: openapi.Object{"data": []applications.IntentResponse{}},
+	})
+	s.Route(http.MethodGet, "/api/applications/intents/metrics", openapi.Route{
+		Summary: "Intent-to-confirm conversion per job",
+		Description: "Over the college's own students. conversion_rate is confirmed / (confirmed + expired), " +
+			"null while every intent is pending.",
+		Roles:    collegeAdmin,
+		Query:    applications.IntentMetricsQuery{},
+		Response: openapi.Object{"data": []applications.IntentMetrics{}},
+	})
+	s.Route(http.MethodPatch, "/api/applications/status/bulk", openapi.Route{
+		Summary:     "Move applications to a new status",
+		Description: "Recruiters may move the applications of the jobs they were granted.",
+		Roles:       statusMovers,
+		Body:        applications.BulkStatusUpdateRequest{},
+		Response:    openapi.Object{"updated_count": 0, "new_status": models.ApplicationStatus("")},
+	})
+	s.Route(http.MethodGet, "/api/applications", openapi.Route{
+		Summary: "List applications",
+		Description: cursorHelp + " Recruiters get applications.CandidateResponse " +
+			"items, filtered by each student's privacy settings.",
+		Roles:    applicants,
+		Query:    applications.ApplicationListQuery{},
+		Response: cursorPage([]models.Application{}),
+	})
+	s.Route(http.MethodGet, "/api/applications/:id", openapi.Route{
+		Summary:     "Application details",
+		Description: "Recruiters get a CandidateResponse.",
+		Roles:       applicants,
+		Response:    models.Application{},
+	})
+	s.Route(http.MethodGet, "/api/applications/:id/resume", openapi.Route{
+		Summary:     "Download the resume submitted with an application",
+		Description: "Redirects to the resume snapshot taken when the application was confirmed.",
+		Roles:       applicants,
+		Status:      http.StatusFound,
+	})
+	s.Route(http.MethodPost, "/api/applications/:id/withdraw", openapi.Route{
+		Summary: "Withdraw an application",
+		Description: "Only from APPLIED or SHORTLISTED, before the college's cutoff and within its per-season limit (409 otherwise). " +
+			"The college admins are notified; withdrawing is final.",
+		Roles:        student,
+		Body:         applications.WithdrawRequest{},
+		OptionalBody: true,
+		Response:     applications.WithdrawResponse{},
+	})
+	s.Route(http.MethodGet, "/api/applications/withdrawal-policy", openapi.Route{
+		Summary:     "The college's withdrawal policy",
+		Description: "Students also get withdrawals_used, their withdrawals since season_start.",
+		Roles:       studentOrCA,
+		Response:    applications.WithdrawalPolicyResponse{},
+	})
+	s.Route(http.MethodPut, "/api/applications/withdrawal-policy", openapi.Route{
+		Summary:  "Set the college's withdrawal policy",
+		Roles:    collegeAdmin,
+		Body:     applications.WithdrawalPolicyRequest{},
+		Response: applications.WithdrawalPolicy{},
+	})
+	s.Route(http.MethodPost, "/api/applications/reconciliations", openapi.Route{
+		Summary: "Reconcile a job's registration form responses",
+		Description: "Multipart CSV export of up to 5 MB with a header row. Responses match the college's students " +
+			"by email, then roll number; the columns are detected from their headers unless named in the query. " +
+			"Pending intents of students who responded are confirmed, and applications are marked " +
+			"registration_check VERIFIED or MISSING.",
+		Roles:     collegeAdmin,
+		Query:     applications.ReconcileQuery{},
+		FileField: "responses",
+		Status:    http.StatusCreated,
+		Response:  applications.ReconciliationReport{},
+	})
+	s.Route(http.MethodGet, "/api/applications/reconciliations", openapi.Route{
+		Summary:  "Past reconciliations, newest first",
+		Roles:    collegeAdmin,
+		Query:    applications.ReconciliationListQuery{},
+		Response: openapi.Object{"data": []applications.ReconciliationSummary{}},
+	})
+	s.Route(http.MethodGet, "/api/applications/reconciliations/:id", openapi.Route{
+		Summary:  "A reconciliation report",
+		Roles:    collegeAdmin,
+		Response: applications.ReconciliationReport{},
+	})
+
+	// -------- recruiters --------
+
+	s.Route(http.MethodPost, "/api/recruiters/invitations", openapi.Route{
+		Summary: "Give a recruiter access to applicants of some jobs",
+		Description: "Existing recruiters of the company are granted access directly (200); " +
+			"anyone else gets an invitation link to forward (201).",
+		Roles:    collegeAdmin,
+		Body:     recruiters.InviteRequest{},
+		Status:   http.StatusCreated,
+		Response: recruiters.InviteResponse{},
+	})
+	s.Route(http.MethodPost, "/api/recruiters/invitations/accept", openapi.Route{
+		Summary:  "Create a recruiter account from an invitation",
+		Public:   true,
+		Body:     recruiters.AcceptInviteRequest{},
+		Status:   http.StatusCreated,
+		Response: message,
+	})
+	s.Route(http.MethodGet, "/api/recruiters", openapi.Route{
+		Summary:  "Recruiters with access to the college's applicants",
+		Roles:    collegeAdmin,
+		Response: openapi.Object{"data": []recruiters.RecruiterResponse{}},
+	})
+	s.Route(http.MethodDelete, "/api/recruiters/:id/jobs/:job_id", openapi.Route{
+		Summary:  "Revoke a recruiter's access to a job",
+		Roles:    collegeAdmin,
+		Response: message,
+	})
+	s.Route(http.MethodGet, "/api/recruiters/me/jobs", openapi.Route{
+		Summary:  "The recruiter's jobs with applicant counts",
+		Roles:    recruiter,
+		Response: openapi.Object{"data": []recruiters.RecruiterJobResponse{}},
+	})
+
+	// -------- webhooks --------
+
+	s.Route(http.MethodPost, "/api/webhooks", openapi.Route{
+		Summary: "Register a webhook endpoint for the college",
+		Description: "The response carries the signing secret; it is not shown again. " +
+			"At most 10 endpoints per college.",
+		Roles:    collegeAdmin,
+		Body:     webhooks.CreateEndpointRequest{},
+		Status:   http.StatusCreated,
+		Response: webhooks.EndpointResponse{},
+	})
+	s.Route(http.MethodGet, "/api/webhooks", openapi.Route{
+		Summary:  "The college's webhook endpoints",
+		Roles:    collegeAdmin,
+		Response: openapi.Object{"data": []webhooks.EndpointResponse{}},
+	})
+	s.Route(http.MethodPatch, "/api/webhooks/:id", openapi.Route{
+		Summary:  "Change a webhook's URL, events, description or active flag",
+		Roles:    collegeAdmin,
+		Body:     webhooks.UpdateEndpointRequest{},
+		Response: webhooks.EndpointResponse{},
+	})
+	s.Route(http.MethodDelete, "/api/webhooks/:id", openapi.Route{
+		Summary:  "Delete a webhook and its delivery log",
+		Roles:    collegeAdmin,
+		Response: message,
+	})
+	s.Route(http.MethodPost, "/api/webhooks/:id/rotate-secret", openapi.Route{
+		Summary:  "Replace a webhook's signing secret",
+		Roles:    collegeAdmin,
+		Response: webhooks.EndpointResponse{},
+	})
+	s.Route(http.MethodGet, "/api/webhooks/:id/deliveries", openapi.Route{
+		Summary:     "A webhook's delivery log, newest first",
+		Description: cursorHelp,
+		Roles:       collegeAdmin,
+		Query:       webhooks.DeliveryListQuery{},
+		Response:    cursorPage([]webhooks.DeliveryResponse{}),
+	})
+	s.Route(http.MethodPost, "/api/webhooks/:id/deliveries/:delivery_id/redeliver", openapi.Route{
+		Summary:     "Queue a delivery's payload again",
+		Description: "Creates a new delivery with the same payload and event id.",
+		Roles:       collegeAdmin,
+		Status:      http.StatusAccepted,
+		Response:    webhooks.DeliveryResponse{},
+	})
+
+	// -------- notifications --------
+
+	s.Route(http.MethodGet, "/api/notifications", openapi.Route{
+		Summary:     "List the caller's notifications, newest first",
+		Description: cursorHelp,
+		Query:       notifications.ListQuery{},
+		Response:    cursorPage([]notifications.Item{}),
+	})
+
+	// -------- interviews --------
+
+	s.Route(http.MethodPost, "/api/interviews/jobs/:job_id/slots", openapi.Route{
+		Summary:  "Create interview slots",
+		Roles:    collegeAdmin,
+		Body:     interviews.CreateSlotsRequest{},
+		Status:   http.StatusCreated,
+		Response: openapi.Object{"ids": []uint{}, "message": ""},
+	})
+	s.Route(http.MethodGet, "/api/interviews/jobs/:job_id/slots", openapi.Route{
+		Summary:  "Slots of a job",
+		Roles:    collegeAdmin,
+		Response: openapi.Object{"data": []interviews.SlotResponse{}},
+	})
+	s.Route(http.MethodPost, "/api/interviews/jobs/:job_id/slots/auto-assign", openapi.Route{
+		Summary:  "Assign free slots to unscheduled interview-stage applicants",
+		Roles:    collegeAdmin,
+		Response: openapi.Object{"assigned_count": 0, "unassigned_count": 0},
+	})
+	s.Route(http.MethodPut, "/api/interviews/slots/:slot_id/assign", openapi.Route{
+		Summary:  "Assign or unassign a slot",
+		Roles:    collegeAdmin,
+		Body:     interviews.AssignSlotRequest{},
+		Response: message,
+	})
+	s.Route(http.MethodDelete, "/api/interviews/slots/:slot_id", openapi.Route{
+		Summary:  "Delete a slot",
+		Roles:    collegeAdmin,
+		Response: message,
+	})
+	s.Route(http.MethodGet, "/api/interviews/me", openapi.Route{
+		Summary:  "Own interview slots",
+		Roles:    student,
+		Response: openapi.Object{"data": []interviews.SlotResponse{}},
+	})
+	s.Route(http.MethodPost, "/api/interviews/slots/:slot_id/reschedule", openapi.Route{
+		Summary:  "Ask for a different slot",
+		Roles:    student,
+		Body:     interviews.RescheduleRequest{},
+		Response: message,
+	})
+	s.Route(http.MethodGet, "/api/interviews/feed", openapi.Route{
+		Summary:  "Personal calendar feed URL",
+		Roles:    student,
+		Query:    feedQuery{},
+		Response: openapi.Object{"feed_url": ""},
+	})
+	s.Route(http.MethodGet, "/api/interviews/slots/:slot_id/ics", openapi.Route{
+		Summary:     "Download one slot as iCalendar",
+		Roles:       studentOrCA,
+		ContentType: "text/calendar",
+	})
+	s.Route(http.MethodGet, "/api/calendar/:token", openapi.Route{
+		Summary:     "Calendar feed",
+		Description: "The unguessable token (optionally suffixed .ics) is the credential.",
+		Public:      true,
+		ContentType: "text/calendar",
+	})
+
+	// -------- experiences --------
+
+	s.Route(http.MethodGet, "/api/experiences", openapi.Route{
+		Summary:  "List interview experiences",
+		Roles:    discussion,
+		Query:    experiences.ExperienceListQuery{},
+		Response: page([]experiences.ExperienceResponse{}),
+	})
+	s.Route(http.MethodPost, "/api/experiences", openapi.Route{
+		Summary:  "Share an interview experience",
+		Roles:    authors,
+		Body:     experiences.CreateExperienceRequest{},
+		Status:   http.StatusCreated,
+		Response: created,
+	})
+	s.Route(http.MethodGet, "/api/experiences/:id", openapi.Route{
+		Summary:  "Experience details",
+		Roles:    discussion,
+		Response: experiences.ExperienceResponse{},
+	})
+	s.Route(http.MethodDelete, "/api/experiences/:id", openapi.Route{
+		Summary:  "Delete an experience",
+		Roles:    discussion,
+		Response: message,
+	})
+	s.Route(http.MethodGet, "/api/experiences/:id/comments", openapi.Route{
+		Summary:  "Comments on an experience",
+		Roles:    discussion,
+		Query:    pageQuery{},
+		Response: page([]experiences.CommentResponse{}),
+	})
+	s.Route(http.MethodPost, "/api/experiences/:id/comments", openapi.Route{
+		Summary:  "Comment or reply",
+		Roles:    discussion,
+		Body:     experiences.CreateCommentRequest{},
+		Status:   http.StatusCreated,
+		Response: created,
+	})
+
+	// -------- alumni --------
+
+	s.Route(http.MethodGet, "/api/alumni", openapi.Route{
+		Summary:  "Alumni directory",
+		Roles:    discussion,
+		Query:    alumni.DirectoryQuery{},
+		Response: page([]alumni.DirectoryEntry{}),
+	})
+	s.Route(http.MethodGet, "/api/alumni/me", openapi.Route{
+		Summary:  "Own alumni profile",
+		Roles:    alumniOnly,
+		Response: alumni.AlumniProfileResponse{},
+	})
+	s.Route(http.MethodPatch, "/api/alumni/me", openapi.Route{
+		Summary:  "Update own alumni profile",
+		Roles:    alumniOnly,
+		Body:     alumni.UpdateAlumniProfileRequest{},
+		Response: message,
+	})
+	s.Route(http.MethodPost, "/api/alumni/graduate", openapi.Route{
+		Summary:  "Graduate a batch or specific students",
+		Roles:    collegeAdmin,
+		Body:     alumni.GraduateRequest{},
+		Response: openapi.Object{"graduated_count": 0},
+	})
+
+	return s
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"iiitn-career-portal/internal/config"
+	"iiitn-career-portal/internal/health"
+	"iiitn-career-portal/internal/logging"
+	"iiitn-career-portal/internal/metrics"
+	"iiitn-career-portal/internal/packages/admin"
+	"iiitn-career-portal/internal/packages/alumni"
+	"iiitn-career-portal/internal/packages/applications"
+	"iiitn-career-portal/internal/packages/audit"
+	"iiitn-career-portal/internal/packages/auth"
+	"iiitn-career-portal/internal/packages/authorization"
+	"iiitn-career-portal/internal/packages/colleges"
+	"iiitn-career-portal/internal/packages/experiences"
+	"iiitn-career-portal/internal/packages/interviews"
+	"iiitn-career-portal/internal/packages/jobs"
+	"iiitn-career-portal/internal/packages/keycloak"
+	"iiitn-career-portal/internal/packages/notifications"
+	"iiitn-career-portal/internal/packages/profile"
+	"iiitn-career-portal/internal/packages/ratelimit"
+	"iiitn-career-portal/internal/packages/recruiters"
+	"iiitn-career-portal/internal/packages/webhooks"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Deps are the long-lived clients the routes are built on.
+type Deps struct {
+	Config   config.Config
+	DB       *gorm.DB
+	Redis    *redis.Client
+	Keycloak *keycloak.Client
+	Limiter  *ratelimit.Limiter
+
+	// readiness checks; nil serves a readiness probe with no checks
+	Health *health.Checker
+}
+
+// NewRouter registers every route. Registration does not touch the
+// database or Redis, so tests can build it with zero-value clients.
+func NewRouter(d Deps) *gin.Engine {
+	cfg, db, redisClient := d.Config, d.DB, d.Redis
+
+	router := gin.New()
+	router.Use(
+		logging.RequestID(),
+		logging.AccessLog(),
+		metrics.Middleware(),
+		logging.Recovery(),
+	)
+
+	// no origins (zero-value test configs) means same-origin only
+	if len(cfg.CORS.AllowedOrigins) > 0 {
+		router.Use(corsMiddleware(cfg.CORS))
+	}
+
+	spec := apiSpec()
+	if cfg.OpenAPIValidate {
+		router.Use(spec.Validator())
+	}
+
+	checker := d.Health
+	if checker == nil {
+		checker = health.New()
+	}
+	router.GET("/health", health.Live())
+	router.GET("/health/live", health.Live())
+	router.GET("/health/ready", checker.Ready())
+	router.GET("/metrics", metrics.Handler(cfg.MetricsToken))
+
+	api := router.Group("/api")
+	{
+		api.GET("/openapi.json", spec.Handler(router))
+		auth.RegisterRoutes(api, cfg, db, d.Keycloak, d.Limiter)
+		colleges.RegisterRoutes(api, db)
+		interviews.RegisterPublicRoutes(api, db)
+		recruiters.RegisterPublicRoutes(api, db, cfg, d.Keycloak, d.Limiter)
+		protected := api.Group("/")
+		protected.Use(authorization.RequireAuth(cfg))
+		{
+			admin.RegisterRoutes(protected, db)
+			audit.RegisterRoutes(protected, db)
+			profile.RegisterRoutes(protected, db, cfg)
+			jobs.RegisterRoutes(protected, db, redisClient, cfg, d.Limiter)
+			applications.RegisterRoutes(protected, db, redisClient)
+			notifications.RegisterRoutes(protected, db, redisClient)
+			interviews.RegisterRoutes(protected, db, redisClient, cfg)
+			experiences.RegisterRoutes(protected, db, redisClient)
+			alumni.RegisterRoutes(protected, db)
+			recruiters.RegisterRoutes(protected, db, cfg, d.Keycloak)
+			webhooks.RegisterRoutes(protected, db, cfg)
+		}
+	}
+
+	return router
+}
+
+func corsMiddleware(c config.CORSConfig) gin.HandlerFunc {
+	return cors.New(cors.Config{
+		AllowOrigins: c.AllowedOrigins,
+		AllowMethods: []string{
+			"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS",
+		},
+		AllowHeaders: []string{
+			"Origin", "Content-Type", "Authorization", logging.RequestIDHeader,
+		},
+		ExposeHeaders: []string{
+			"Content-Length",
+			"Retry-After",
+			"X-RateLimit-Limit",
+			"X-RateLimit-Remaining",
+			"X-RateLimit-Reset",
+			logging.RequestIDHeader,
+		},
+		AllowCredentials: true,
+		MaxAge:           c.MaxAge,
+	})
+}
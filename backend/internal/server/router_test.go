@@ -0,0 +1,154 @@
+package server
+
+import (
+	"encoding/json"
+	"iiitn-career-portal/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// Route registration never touches the clients, so zero deps are enough.
+func newTestRouter(cfg config.Config) *gin.Engine {
+	return NewRouter(Deps{Config: cfg})
+}
+
+func TestSpecMatchesRoutes(t *testing.T) {
+	router := newTestRouter(config.Config{})
+
+	undocumented, stale := apiSpec().Diff(router.Routes())
+	for _, key := range undocumented {
+		t.Errorf("route %s is not in the OpenAPI spec (internal/server/openapi.go)", key)
+	}
+	for _, key := range stale {
+		t.Errorf("spec documents %s but no such route is registered", key)
+	}
+}
+
+func TestServesSpec(t *testing.T) {
+	router := newTestRouter(config.Config{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, specPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+
+	var doc struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Fatalf("openapi = %q", doc.OpenAPI)
+	}
+	if _, ok := doc.Paths["/api/jobs/{id}"]["patch"]; !ok {
+		t.Fatal("PATCH /api/jobs/{id} missing")
+	}
+	for _, name := range []string{"CreateJobRequest", "JobDetailResponse", "Round"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("schema %s missing", name)
+		}
+	}
+
+	// every $ref must resolve
+	for _, ref := range strings.Split(w.Body.String(), `"$ref":"#/components/schemas/`)[1:] {
+		name := ref[:strings.IndexByte(ref, '"')]
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("dangling $ref %s", name)
+		}
+	}
+}
+
+func TestValidatorRejectsBeforeAuth(t *testing.T) {
+	router := newTestRouter(config.Config{OpenAPIValidate: true})
+
+	cases := []struct {
+		name, method, target, body string
+		want                       int
+	}{
+		{"bad enum in query", http.MethodGet, "/api/applications?status=MAYBE", "", http.StatusBadRequest},
+		{"bad path id", http.MethodGet, "/api/jobs/abc", "", http.StatusBadRequest},
+		{"missing required field", http.MethodPost, "/api/auth/login", `{"email":"a@b.co"}`, http.StatusBadRequest},
+		{"valid body reaches auth", http.MethodPatch, "/api/jobs/3", `{"title":"SDE"}`, http.StatusUnauthorized},
+		{"public route", http.MethodGet, "/health", "", http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tc.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	router := newTestRouter(config.Config{MetricsToken: "scrape"})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("without token: status = %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer scrape")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `http_request_duration_seconds_count{method="GET",route="/health",status="200"}`) {
+		t.Fatalf("no /health latency series in:\n%s", w.Body)
+	}
+}
+
+func TestCORSFromConfig(t *testing.T) {
+	cfg := config.Config{CORS: config.CORSConfig{
+		AllowedOrigins: []string{"https://careers.example.org"},
+		MaxAge:         time.Hour,
+	}}
+	router := newTestRouter(cfg)
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/api/jobs", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := preflight("https://careers.example.org")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://careers.example.org" {
+		t.Fatalf("allowed origin: Access-Control-Allow-Origin = %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "3600" {
+		t.Fatalf("Access-Control-Max-Age = %q", got)
+	}
+
+	w = preflight("http://localhost:5173")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("unlisted origin was allowed: %q", got)
+	}
+}
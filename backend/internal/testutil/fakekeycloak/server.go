@@ -0,0 +1,474 @@
+// Package fakekeycloak is an in-process stand-in for the Keycloak realm
+// endpoints the backend talks to: discovery, JWKS, token (password,
+// client_credentials, authorization_code), userinfo and the admin
+// users / realm role-mapping API. Tokens are RS256 JWTs signed with a
+// key generated per server.
+package fakekeycloak
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	Realm        = "portal"
+	ClientID     = "portal-backend"
+	ClientSecret = "test-secret"
+
+	keyID = "fake-kc-key"
+)
+
+// realm roles the fake knows about; assigning anything else is a 404
+var knownRoles = []string{"student", "college_admin", "admin", "alumni", "recruiter"}
+
+type User struct {
+	ID       string
+	Email    string
+	Name     string
+	Password string
+	Roles    []string
+}
+
+type Server struct {
+	URL string
+
+	srv *httptest.Server
+	key *rsa.PrivateKey
+
+	mu          sync.Mutex
+	users       map[string]*User
+	codes       map[string]string // auth code -> user id
+	adminTokens map[string]bool
+	hits        map[string]int
+
+	failRoleAssignment bool
+	tokenTTL           time.Duration
+}
+
+// Start runs a fake realm until the test ends.
+func Start(t testing.TB) *Server {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("fakekeycloak: generate key: %v", err)
+	}
+
+	s := &Server{
+		key:         key,
+		users:       map[string]*User{},
+		codes:       map[string]string{},
+		adminTokens: map[string]bool{},
+		hits:        map[string]int{},
+		tokenTTL:    5 * time.Minute,
+	}
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.route))
+	s.URL = s.srv.URL
+	t.Cleanup(s.srv.Close)
+
+	return s
+}
+
+// Client is an http.Client wired to the test server.
+func (s *Server) Client() *http.Client {
+	return s.srv.Client()
+}
+
+func (s *Server) Issuer() string {
+	return s.URL + "/realms/" + Realm
+}
+
+// AddUser creates a user directly, as if provisioned in the admin console.
+func (s *Server) AddUser(email, password, name string, roles ...string) *User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := &User{
+		ID:       randomID(),
+		Email:    strings.ToLower(email),
+		Name:     name,
+		Password: password,
+		Roles:    append([]string(nil), roles...),
+	}
+	s.users[u.ID] = u
+	return u
+}
+
+func (s *Server) UserByEmail(email string) (User, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.users {
+		if u.Email == strings.ToLower(email) {
+			return *u, true
+		}
+	}
+	return User{}, false
+}
+
+func (s *Server) UserCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.users)
+}
+
+// IssueCode returns an authorization code for userID, as if the user had
+// just signed in on the Keycloak login page.
+func (s *Server) IssueCode(userID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := randomID()
+	s.codes[code] = userID
+	return code
+}
+
+// Hits counts requests per endpoint name: "discovery", "certs",
+// "token:<grant_type>", "userinfo", "admin".
+func (s *Server) Hits(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits[endpoint]
+}
+
+// RevokeAdminTokens invalidates every issued admin token, so the next
+// admin call gets a 401.
+func (s *Server) RevokeAdminTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.adminTokens = map[string]bool{}
+}
+
+// FailRoleAssignment makes role-mapping calls return 500.
+func (s *Server) FailRoleAssignment(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failRoleAssignment = fail
+}
+
+// SignToken signs arbitrary claims with the realm key, for tests that need
+// expired or otherwise malformed tokens.
+func (s *Server) SignToken(claims jwt.MapClaims) string {
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = keyID
+	signed, err := tok.SignedString(s.key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+// -------- routing --------
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	realmPrefix := "/realms/" + Realm
+	adminPrefix := "/admin/realms/" + Realm
+
+	switch {
+	case r.URL.Path == realmPrefix+"/.well-known/openid-configuration":
+		s.hit("discovery")
+		s.discovery(w)
+	case r.URL.Path == realmPrefix+"/protocol/openid-connect/certs":
+		s.hit("certs")
+		s.certs(w)
+	case r.URL.Path == realmPrefix+"/protocol/openid-connect/token" && r.Method == http.MethodPost:
+		s.token(w, r)
+	case r.URL.Path == realmPrefix+"/protocol/openid-connect/userinfo":
+		s.hit("userinfo")
+		s.userinfo(w, r)
+	case strings.HasPrefix(r.URL.Path, adminPrefix+"/"):
+		s.hit("admin")
+		if !s.adminAuthorized(r) {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "HTTP 401 Unauthorized"})
+			return
+		}
+		s.admin(w, r, strings.TrimPrefix(r.URL.Path, adminPrefix))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) hit(endpoint string) {
+	s.mu.Lock()
+	s.hits[endpoint]++
+	s.mu.Unlock()
+}
+
+func (s *Server) discovery(w http.ResponseWriter) {
+	base := s.Issuer() + "/protocol/openid-connect"
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.Issuer(),
+		"authorization_endpoint":                base + "/auth",
+		"token_endpoint":                        base + "/token",
+		"userinfo_endpoint":                     base + "/userinfo",
+		"jwks_uri":                              base + "/certs",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (s *Server) certs(w http.ResponseWriter) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": keyID,
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		oauthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	grant := r.PostForm.Get("grant_type")
+	s.hit("token:" + grant)
+
+	if r.PostForm.Get("client_id") != ClientID || r.PostForm.Get("client_secret") != ClientSecret {
+		oauthError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	switch grant {
+	case "client_credentials":
+		token := randomID()
+		s.mu.Lock()
+		s.adminTokens[token] = true
+		s.mu.Unlock()
+		s.writeToken(w, token)
+
+	case "password":
+		u, ok := s.UserByEmail(r.PostForm.Get("username"))
+		if !ok || u.Password != r.PostForm.Get("password") {
+			oauthError(w, http.StatusUnauthorized, "invalid_grant")
+			return
+		}
+		s.writeToken(w, s.accessToken(u))
+
+	case "authorization_code":
+		s.mu.Lock()
+		userID, ok := s.codes[r.PostForm.Get("code")]
+		delete(s.codes, r.PostForm.Get("code"))
+		u := s.users[userID]
+		s.mu.Unlock()
+
+		if !ok || u == nil {
+			oauthError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		s.writeToken(w, s.accessToken(*u))
+
+	default:
+		oauthError(w, http.StatusBadRequest, "unsupported_grant_type")
+	}
+}
+
+func (s *Server) accessToken(u User) string {
+	now := time.Now()
+	return s.SignToken(jwt.MapClaims{
+		"iss":   s.Issuer(),
+		"aud":   []string{ClientID, "account"},
+		"azp":   ClientID,
+		"sub":   u.ID,
+		"email": u.Email,
+		"name":  u.Name,
+		"iat":   now.Unix(),
+		"exp":   now.Add(s.tokenTTL).Unix(),
+		"realm_access": map[string]interface{}{
+			"roles": u.Roles,
+		},
+	})
+}
+
+func (s *Server) writeToken(w http.ResponseWriter, token string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"expires_in":   int(s.tokenTTL.Seconds()),
+		"token_type":   "Bearer",
+	})
+}
+
+func (s *Server) userinfo(w http.ResponseWriter, r *http.Request) {
+	raw := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(*jwt.Token) (interface{}, error) {
+		return &s.key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sub":   claims["sub"],
+		"email": claims["email"],
+		"name":  claims["name"],
+	})
+}
+
+// -------- admin API --------
+
+func (s *Server) adminAuthorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.adminTokens[token]
+}
+
+func (s *Server) admin(w http.ResponseWriter, r *http.Request, path string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	// POST /users
+	case len(parts) == 1 && parts[0] == "users" && r.Method == http.MethodPost:
+		s.createUser(w, r)
+
+	// DELETE /users/{id}
+	case len(parts) == 2 && parts[0] == "users" && r.Method == http.MethodDelete:
+		s.mu.Lock()
+		_, ok := s.users[parts[1]]
+		delete(s.users, parts[1])
+		s.mu.Unlock()
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "User not found"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	// GET /roles/{name}
+	case len(parts) == 2 && parts[0] == "roles" && r.Method == http.MethodGet:
+		name, _ := url.PathUnescape(parts[1])
+		if !isKnownRole(name) {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Could not find role"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":        "role-" + name,
+			"name":      name,
+			"composite": false,
+		})
+
+	// POST /users/{id}/role-mappings/realm
+	case len(parts) == 4 && parts[0] == "users" && parts[2] == "role-mappings" && parts[3] == "realm" &&
+		r.Method == http.MethodPost:
+		s.assignRoles(w, r, parts[1])
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username    string `json:"username"`
+		Email       string `json:"email"`
+		FirstName   string `json:"firstName"`
+		Credentials []struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"credentials"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid json"})
+		return
+	}
+
+	if _, exists := s.UserByEmail(req.Email); exists {
+		writeJSON(w, http.StatusConflict, map[string]string{"errorMessage": "User exists with same email"})
+		return
+	}
+
+	password := ""
+	for _, c := range req.Credentials {
+		if c.Type == "password" {
+			password = c.Value
+		}
+	}
+
+	u := s.AddUser(req.Email, password, req.FirstName)
+
+	w.Header().Set("Location", s.URL+"/admin/realms/"+Realm+"/users/"+u.ID)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) assignRoles(w http.ResponseWriter, r *http.Request, userID string) {
+	var roles []struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&roles); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid json"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failRoleAssignment {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "unknown_error"})
+		return
+	}
+
+	u, ok := s.users[userID]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "User not found"})
+		return
+	}
+
+	for _, role := range roles {
+		if !isKnownRole(role.Name) {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Role not found"})
+			return
+		}
+		u.Roles = append(u.Roles, role.Name)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// -------- helpers --------
+
+func isKnownRole(name string) bool {
+	for _, r := range knownRoles {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+func oauthError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
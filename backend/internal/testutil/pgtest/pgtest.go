@@ -0,0 +1,83 @@
+// Package pgtest hands tests a Postgres database of their own when
+// TEST_DATABASE_URL points at a server. Each test gets a fresh schema,
+// dropped again when it ends, so tests and packages can share a server.
+package pgtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// EnvURL names the variable holding the server's URL or DSN.
+const EnvURL = "TEST_DATABASE_URL"
+
+// Enabled reports whether a Postgres server is configured.
+func Enabled() bool {
+	return strings.TrimSpace(os.Getenv(EnvURL)) != ""
+}
+
+// Open returns a connection whose search_path is a new, empty schema.
+// Without TEST_DATABASE_URL the test is skipped.
+func Open(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	if !Enabled() {
+		t.Skip(EnvURL + " is not set")
+	}
+	dsn := strings.TrimSpace(os.Getenv(EnvURL))
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("connect to %s: %v", EnvURL, err)
+	}
+	adminDB, _ := admin.DB()
+	t.Cleanup(func() { _ = adminDB.Close() })
+
+	schema := "test_" + randomSuffix(t)
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := admin.Exec("DROP SCHEMA " + schema + " CASCADE").Error; err != nil {
+			t.Errorf("drop schema %s: %v", schema, err)
+		}
+	})
+
+	conf, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("parse %s: %v", EnvURL, err)
+	}
+	conf.RuntimeParams["search_path"] = schema
+
+	sqlDB := stdlib.OpenDB(*conf)
+	// registered after the schema's cleanup, so it runs before it
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		TranslateError: true,
+		Logger:         logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open schema %s: %v", schema, err)
+	}
+	return db
+}
+
+func randomSuffix(t *testing.T) string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(b)
+}
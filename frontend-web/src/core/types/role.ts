@@ -2,6 +2,7 @@ export const Roles = {
 	admin: "admin",
 	college_admin: "college_admin",
 	student: "student",
+	alumni: "alumni",
 } as const
 
 export type Role = typeof Roles[keyof typeof Roles]